@@ -43,10 +43,64 @@ func main() {
 		log.Fatalf("Failed to setup auth: %v", err)
 	}
 
+	// Auth config with the OAuth redirect allowlist
+	authConfig := auth.DefaultConfig()
+	authConfig.Redirect.Clients = map[string]auth.RedirectClientConfig{
+		"web": {
+			URIs:          cfg.Auth.WebRedirectURIs,
+			TokenDelivery: auth.TokenDeliveryFragment,
+		},
+		"mobile": {
+			URIs:          cfg.Auth.MobileRedirectURIs,
+			TokenDelivery: auth.TokenDeliveryCode,
+		},
+	}
+	authConfig.Anonymous.TTL = cfg.Auth.AnonymousTTL
+	authConfig.Anonymous.MaxPerIP = cfg.Auth.AnonymousMaxPerIP
+	authConfig.Anonymous.MaxPerDevice = cfg.Auth.AnonymousMaxPerDevice
+	authConfig.Anonymous.TrustForwardedFor = cfg.Auth.TrustForwardedFor
+	authConfig.Email.RequireVerification = cfg.Auth.EmailRequireVerification
+	authConfig.Email.VerificationURL = cfg.Auth.EmailVerificationURL
+	authConfig.Email.PasswordResetURL = cfg.Auth.PasswordResetURL
+	authConfig.Email.PasswordHashAlgorithm = cfg.Auth.PasswordHashAlgorithm
+	authConfig.Lockout.MaxAccountFailures = cfg.Auth.LockoutMaxAccountFailures
+	authConfig.Lockout.MaxIPFailures = cfg.Auth.LockoutMaxIPFailures
+	authConfig.Lockout.LockoutDuration = cfg.Auth.LockoutDuration
+	authConfig.MFA.Issuer = cfg.Auth.MFAIssuer
+	authConfig.Passkey.RPID = cfg.Auth.PasskeyRPID
+	authConfig.Passkey.Origins = cfg.Auth.PasskeyOrigins
+	authConfig.APIKeys.MaxPerUser = cfg.Auth.APIKeyMaxPerUser
+	authConfig.APIKeys.MaxTTL = cfg.Auth.APIKeyMaxTTL
+	authConfig.Deletion.GracePeriod = cfg.Auth.AccountDeletionGracePeriod
+	authConfig.Deletion.ContentPolicy = cfg.Auth.AccountDeletionContentPolicy
+	authConfig.SMTP.Host = cfg.Auth.SMTPHost
+	authConfig.SMTP.Port = cfg.Auth.SMTPPort
+	authConfig.SMTP.Username = cfg.Auth.SMTPUsername
+	authConfig.SMTP.Password = cfg.Auth.SMTPPassword
+	authConfig.SMTP.From = cfg.Auth.EmailFrom
+
+	// Data export download links are signed; a generated key only lasts until restart
+	exportSigningKey, err := base64.StdEncoding.DecodeString(cfg.Services.ExportSigningKey)
+	if err != nil {
+		log.Fatalf("Invalid EXPORT_SIGNING_KEY: %v", err)
+	}
+	if len(exportSigningKey) == 0 {
+		exportSigningKey, err = auth.GenerateEncryptionKey()
+		if err != nil {
+			log.Fatalf("Failed to generate export signing key: %v", err)
+		}
+		log.Println("Generated export signing key for development")
+	}
+
+	if cfg.Services.VAPIDPrivateKey == "" {
+		log.Println("VAPID_PRIVATE_KEY is not set; Web Push notifications are disabled")
+	}
+
 	// Create server config
 	serverConfig := &server.Config{
 		// Server
 		Addr:         cfg.Server.Host + ":" + cfg.Server.Port,
+		Development:  cfg.Server.IsDevelopment(),
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  120 * time.Second,
@@ -59,12 +113,31 @@ func main() {
 		TokenManager:   tokenManager,
 		StateManager:   stateManager,
 		SessionManager: sessionManager,
-		AuthConfig:     nil, // Will be updated to match new auth config
+		AuthConfig:     authConfig,
 
 		// OAuth Providers
 		GoogleClientID:     cfg.Auth.GoogleClientID,
 		GoogleClientSecret: cfg.Auth.GoogleClientSecret,
 		GoogleRedirectURL:  cfg.Auth.GoogleRedirectURL,
+		AppleClientID:      cfg.Auth.AppleClientID,
+		AppleTeamID:        cfg.Auth.AppleTeamID,
+		AppleKeyID:         cfg.Auth.AppleKeyID,
+		ApplePrivateKey:    cfg.Auth.ApplePrivateKey,
+		AppleRedirectURL:   cfg.Auth.AppleRedirectURL,
+		AppleBundleIDs:     cfg.Auth.AppleBundleIDs,
+
+		// Uploaded media
+		MediaPath: cfg.Services.MediaPath,
+		MediaURL:  cfg.Services.MediaURL,
+
+		// Data exports
+		ExportPath:       cfg.Services.ExportPath,
+		ExportURL:        cfg.Services.ExportURL,
+		ExportSigningKey: exportSigningKey,
+
+		// Web Push
+		VAPIDPrivateKey: cfg.Services.VAPIDPrivateKey,
+		VAPIDSubject:    cfg.Services.VAPIDSubject,
 
 		// Future: Add more dependencies here
 		// S3Client:    s3Client,
@@ -95,7 +168,7 @@ func main() {
 	go func() {
 		log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 		log.Printf("🚀 Server ready at http://localhost:%s", cfg.Server.Port)
-		log.Printf("📋 Environment: Development")
+		log.Printf("📋 Environment: %s", cfg.Server.Environment)
 		log.Printf("🗄️  Database: Connected (%s@%s:%s/%s)", cfg.DB.User, cfg.DB.Host, cfg.DB.Port, cfg.DB.Name)
 		log.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 		if err := srv.Start(); err != nil {
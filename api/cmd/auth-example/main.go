@@ -8,6 +8,7 @@ import (
 	"os"
 	"time"
 	
+	"github.com/radjathaher/alunalun/api/internal/middleware"
 	authService "github.com/radjathaher/alunalun/api/internal/services/auth"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
 	"github.com/radjathaher/alunalun/api/internal/utils/oauth"
@@ -175,13 +176,17 @@ func main() {
 		log.Fatal("Failed to create auth service:", err)
 	}
 	
+	// Validate tokens and refuse those of disabled users
+	interceptor := middleware.NewAuthInterceptor(tokenManager)
+	interceptor.SetUserStore(userStore)
+	
 	// Create router
 	router := authService.NewRouter(
 		service,
 		stateManager,
 		registry,
 		tokenManager,
-		sessionManager,
+		interceptor,
 	)
 	
 	// Start server
@@ -5,6 +5,7 @@ go 1.24
 require (
 	connectrpc.com/connect v1.18.1
 	github.com/golang-jwt/jwt/v5 v5.3.0
+	github.com/google/uuid v1.6.0
 	github.com/jackc/pgx/v5 v5.7.5
 	github.com/lib/pq v1.10.9
 	github.com/mmcloughlin/geohash v0.10.0
@@ -14,12 +15,12 @@ require (
 	golang.org/x/crypto v0.41.0
 	golang.org/x/net v0.42.0
 	golang.org/x/oauth2 v0.30.0
+	golang.org/x/text v0.28.0
 	google.golang.org/protobuf v1.36.8
 )
 
 require (
 	cloud.google.com/go/compute/metadata v0.3.0 // indirect
-	github.com/google/uuid v1.6.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
 	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/jackc/puddle/v2 v2.2.2 // indirect
@@ -28,5 +29,5 @@ require (
 	github.com/sethvargo/go-retry v0.3.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	golang.org/x/sync v0.16.0 // indirect
-	golang.org/x/text v0.28.0 // indirect
+	golang.org/x/sys v0.35.0 // indirect
 )
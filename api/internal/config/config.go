@@ -5,6 +5,7 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +17,7 @@ type Config struct {
 }
 
 type ServerConfig struct {
+	Environment     string // Only an explicit "development" allows development-only fallbacks such as logged emails
 	Host            string
 	Port            string
 	AllowedOrigins  []string
@@ -41,6 +43,11 @@ func (s *ServerConfig) FindAvailablePort() (string, error) {
 	return "", fmt.Errorf("no available ports found starting from %d", startPort)
 }
 
+// IsDevelopment reports whether the server runs in development
+func (s *ServerConfig) IsDevelopment() bool {
+	return s.Environment == "development"
+}
+
 // Address returns the full address (host:port)
 func (s *ServerConfig) Address() string {
 	return s.Host + ":" + s.Port
@@ -81,16 +88,76 @@ type AuthConfig struct {
 	GoogleClientID     string
 	GoogleClientSecret string
 	GoogleRedirectURL  string
+	AppleClientID      string
+	AppleTeamID        string
+	AppleKeyID         string
+	ApplePrivateKey    string
+	AppleRedirectURL   string
+	AppleBundleIDs     []string // Native apps whose ID tokens are accepted
+
+	// Allowed client redirect URIs for the OAuth redirect flow
+	WebRedirectURIs    []string
+	MobileRedirectURIs []string
+
+	// Anonymous account lifecycle
+	AnonymousTTL          time.Duration
+	AnonymousMaxPerIP     int
+	AnonymousMaxPerDevice int
+	TrustForwardedFor     bool
+
+	// Email verification
+	EmailRequireVerification bool
+	EmailVerificationURL     string
+	PasswordResetURL         string
+	PasswordHashAlgorithm    string
+
+	// Password brute-force protection
+	LockoutMaxAccountFailures int
+	LockoutMaxIPFailures      int
+	LockoutDuration           time.Duration
+
+	// Two-factor authentication
+	MFAIssuer string
+
+	// Passkeys (WebAuthn relying party)
+	PasskeyRPID    string
+	PasskeyOrigins []string
+
+	// Personal API keys
+	APIKeyMaxPerUser int
+	APIKeyMaxTTL     time.Duration
+
+	// Account deletion
+	AccountDeletionGracePeriod   time.Duration
+	AccountDeletionContentPolicy string
+
+	// Outgoing email; without SMTP_HOST emails are only logged, with APP_ENV=development
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	EmailFrom    string
 }
 
 type ServicesConfig struct {
 	MapboxToken string
 	MediaPath   string
+	MediaURL    string // Public URL the media directory is served at
+
+	// Data exports, only served through signed links
+	ExportPath       string
+	ExportURL        string
+	ExportSigningKey string // base64, at least 32 bytes
+
+	// Web Push; an empty key disables it
+	VAPIDPrivateKey string // base64url P-256 private key
+	VAPIDSubject    string // mailto: or https: contact for push services
 }
 
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
+			Environment:     getEnv("APP_ENV", "production"),
 			Host:            getEnv("SERVER_HOST", "0.0.0.0"),
 			Port:            getEnv("PORT", "8080"),
 			AllowedOrigins:  []string{getEnv("ALLOWED_ORIGINS", "http://localhost:3000")},
@@ -116,10 +183,57 @@ func Load() *Config {
 			GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 			GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 			GoogleRedirectURL:  getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/auth/oauth/google/callback"),
+			AppleClientID:      getEnv("APPLE_CLIENT_ID", ""),
+			AppleTeamID:        getEnv("APPLE_TEAM_ID", ""),
+			AppleKeyID:         getEnv("APPLE_KEY_ID", ""),
+			ApplePrivateKey:    getFileEnv("APPLE_PRIVATE_KEY", "APPLE_PRIVATE_KEY_PATH"),
+			AppleRedirectURL:   getEnv("APPLE_REDIRECT_URL", "http://localhost:8080/auth/oauth/apple/callback"),
+			AppleBundleIDs:     getListEnv("APPLE_BUNDLE_IDS", nil),
+			WebRedirectURIs:    getListEnv("OAUTH_WEB_REDIRECT_URIS", []string{"http://localhost:3000/auth/callback"}),
+			MobileRedirectURIs: getListEnv("OAUTH_MOBILE_REDIRECT_URIS", []string{"alunalun://auth/callback"}),
+
+			AnonymousTTL:          getDurationEnv("ANONYMOUS_TTL", 30*24*time.Hour),
+			AnonymousMaxPerIP:     getIntEnv("ANONYMOUS_MAX_PER_IP", 20),
+			AnonymousMaxPerDevice: getIntEnv("ANONYMOUS_MAX_PER_DEVICE", 3),
+			TrustForwardedFor:     getBoolEnv("TRUST_FORWARDED_FOR", false),
+
+			EmailRequireVerification: getBoolEnv("EMAIL_REQUIRE_VERIFICATION", true),
+			EmailVerificationURL:     getEnv("EMAIL_VERIFICATION_URL", "http://localhost:3000/auth/verify-email"),
+			PasswordResetURL:         getEnv("PASSWORD_RESET_URL", "http://localhost:3000/auth/reset-password"),
+			PasswordHashAlgorithm:    getEnv("PASSWORD_HASH_ALGORITHM", "bcrypt"),
+
+			LockoutMaxAccountFailures: getIntEnv("LOCKOUT_MAX_ACCOUNT_FAILURES", 5),
+			LockoutMaxIPFailures:      getIntEnv("LOCKOUT_MAX_IP_FAILURES", 50),
+			LockoutDuration:           getDurationEnv("LOCKOUT_DURATION", 15*time.Minute),
+
+			MFAIssuer: getEnv("MFA_ISSUER", "Alunalun"),
+
+			PasskeyRPID:    getEnv("PASSKEY_RP_ID", "localhost"),
+			PasskeyOrigins: getListEnv("PASSKEY_ORIGINS", []string{"http://localhost:3000"}),
+
+			APIKeyMaxPerUser: getIntEnv("API_KEY_MAX_PER_USER", 10),
+			APIKeyMaxTTL:     getDurationEnv("API_KEY_MAX_TTL", 0),
+
+			AccountDeletionGracePeriod:   getDurationEnv("ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+			AccountDeletionContentPolicy: getEnv("ACCOUNT_DELETION_CONTENT_POLICY", "anonymize"),
+
+			SMTPHost:     getEnv("SMTP_HOST", ""),
+			SMTPPort:     getIntEnv("SMTP_PORT", 587),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			EmailFrom:    getEnv("EMAIL_FROM", "Alunalun <no-reply@localhost>"),
 		},
 		Services: ServicesConfig{
 			MapboxToken: getEnv("MAPBOX_TOKEN", ""),
 			MediaPath:   getEnv("MEDIA_PATH", "./uploads"),
+			MediaURL:    getEnv("MEDIA_URL", "http://localhost:8080/media"),
+
+			ExportPath:       getEnv("EXPORT_PATH", "./exports"),
+			ExportURL:        getEnv("EXPORT_URL", "http://localhost:8080/exports"),
+			ExportSigningKey: getEnv("EXPORT_SIGNING_KEY", ""),
+
+			VAPIDPrivateKey: getEnv("VAPID_PRIVATE_KEY", ""),
+			VAPIDSubject:    getEnv("VAPID_SUBJECT", "mailto:admin@localhost"),
 		},
 	}
 }
@@ -131,6 +245,36 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getFileEnv reads a value from an environment variable, falling back to the
+// contents of the file named by pathKey
+func getFileEnv(key, pathKey string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	if path := os.Getenv(pathKey); path != "" {
+		if data, err := os.ReadFile(path); err == nil {
+			return string(data)
+		}
+	}
+	return ""
+}
+
+// getListEnv reads a comma-separated list from an environment variable
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -140,6 +284,15 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
 func getBoolEnv(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if b, err := strconv.ParseBool(value); err == nil {
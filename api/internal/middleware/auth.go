@@ -2,26 +2,42 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
 )
 
-// Context keys for auth values
-type contextKey string
+// RenewedTokenHeader carries a replacement token when an anonymous token is renewed
+const RenewedTokenHeader = "X-Renewed-Token"
 
-const (
-	claimsKey   contextKey = "claims"
-	userIDKey   contextKey = "user_id"
-	usernameKey contextKey = "username"
-	emailKey    contextKey = "email"
-)
+// apiKeyScheme is the Authorization scheme for personal API keys
+const apiKeyScheme = "ApiKey "
+
+// userStatusTTL bounds how long tokens of a newly disabled or signed-out user keep working
+const userStatusTTL = 30 * time.Second
 
 // AuthInterceptor handles JWT authentication for ConnectRPC
 type AuthInterceptor struct {
 	tokenManager *auth.TokenManager
+	anonymous    *auth.AnonymousManager // nil disables anonymous renewal
+	roles        auth.RoleStore         // nil trusts the roles in the token
+	apiKeys      *auth.APIKeyManager    // nil rejects API keys
+	users        auth.UserStore         // nil skips account status checks
+
+	statusMu sync.Mutex
+	statuses map[string]cachedStatus
+}
+
+// cachedStatus is a user's account as of a recent lookup
+type cachedStatus struct {
+	user    *auth.User
+	expires time.Time
 }
 
 // NewAuthInterceptor creates a new auth interceptor
@@ -31,51 +47,236 @@ func NewAuthInterceptor(tokenManager *auth.TokenManager) *AuthInterceptor {
 	}
 }
 
+// SetAnonymousManager enables sliding renewal of anonymous tokens on activity
+func (a *AuthInterceptor) SetAnonymousManager(manager *auth.AnonymousManager) {
+	a.anonymous = manager
+}
+
+// SetRoleStore re-checks elevated roles against the store, so a revoked role
+// stops working before the caller's token expires
+func (a *AuthInterceptor) SetRoleStore(store auth.RoleStore) {
+	a.roles = store
+}
+
+// SetAPIKeyManager accepts "Authorization: ApiKey <key>" alongside JWTs
+func (a *AuthInterceptor) SetAPIKeyManager(manager *auth.APIKeyManager) {
+	a.apiKeys = manager
+}
+
+// SetUserStore rejects tokens of disabled users and tokens revoked by signing
+// out everywhere, within userStatusTTL instead of when the token expires
+func (a *AuthInterceptor) SetUserStore(store auth.UserStore) {
+	a.users = store
+}
+
 // WrapUnary creates a unary interceptor for authentication
 func (a *AuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-		// Check if endpoint requires authentication
-		if !isPublicEndpoint(req.Spec().Procedure) {
-			// Extract and validate token
-			token := extractBearerToken(req.Header())
-			if token == "" {
-				// No token on protected endpoint
-				return nil, connect.NewError(
-					connect.CodeUnauthenticated,
-					nil, // Don't leak info about why auth failed
-				)
-			}
+		ctx, renewedToken, err := a.authorize(ctx, req.Spec().Procedure, req.Header())
+		if err != nil {
+			return nil, err
+		}
 
-			// Validate token
-			claims, err := a.tokenManager.ValidateToken(token)
-			if err != nil {
-				return nil, connect.NewError(
-					connect.CodeUnauthenticated,
-					nil, // Don't leak token validation errors
-				)
-			}
+		resp, err := next(ctx, req)
+		if resp != nil && renewedToken != "" {
+			resp.Header().Set(RenewedTokenHeader, renewedToken)
+		}
+		return resp, err
+	}
+}
+
+// authorize applies a procedure's policy to the request's credentials. It
+// returns a context carrying the caller's claims, and a replacement token when
+// an anonymous token was renewed.
+func (a *AuthInterceptor) authorize(ctx context.Context, procedure string, headers http.Header) (context.Context, string, error) {
+	// Check if endpoint requires authentication
+	policy := PolicyFor(procedure)
+	if policy.Access == AccessPublic {
+		ctx, renewed := a.authorizePublic(ctx, policy, headers)
+		return ctx, renewed, nil
+	}
 
-			// Add claims to context
-			ctx = context.WithValue(ctx, claimsKey, claims)
-			ctx = context.WithValue(ctx, userIDKey, claims.UserID)
-			ctx = context.WithValue(ctx, usernameKey, claims.Username)
-			ctx = context.WithValue(ctx, emailKey, claims.Email)
-		} else {
-			// Public endpoint - optionally extract token if provided
-			token := extractBearerToken(req.Header())
-			if token != "" {
-				// Best effort - validate but don't fail if invalid
-				if claims, err := a.tokenManager.ValidateToken(token); err == nil {
-					ctx = context.WithValue(ctx, claimsKey, claims)
-					ctx = context.WithValue(ctx, userIDKey, claims.UserID)
-					ctx = context.WithValue(ctx, usernameKey, claims.Username)
-					ctx = context.WithValue(ctx, emailKey, claims.Email)
-				}
+	// Validate the token or API key
+	claims, renewed, err := a.authenticate(ctx, headers)
+	if isUserDisabled(err) {
+		return nil, "", connect.NewError(connect.CodePermissionDenied, errors.New("user account is disabled"))
+	}
+	if err != nil || claims == nil {
+		return nil, "", connect.NewError(
+			connect.CodeUnauthenticated,
+			nil, // Don't leak why auth failed
+		)
+	}
+
+	// API keys only reach procedures that accept one of their scopes
+	if !scopeAllowed(claims, policy) {
+		return nil, "", connect.NewError(
+			connect.CodePermissionDenied,
+			errors.New("API key scope does not allow this procedure"),
+		)
+	}
+
+	// Enforce the procedure's minimum role
+	a.refreshRoles(ctx, claims, policy.Role != "")
+	if policy.Role != "" && !claims.HasRole(policy.Role) {
+		return nil, "", connect.NewError(
+			connect.CodePermissionDenied,
+			errors.New("insufficient role"),
+		)
+	}
+
+	// Add claims to context; handlers read identity only from here
+	return auth.ContextWithClaims(ctx, claims), renewed, nil
+}
+
+// authorizePublic identifies the caller of a public endpoint, best effort: valid
+// credentials are used, invalid ones ignored
+func (a *AuthInterceptor) authorizePublic(ctx context.Context, policy Policy, headers http.Header) (context.Context, string) {
+	claims, renewed, err := a.authenticate(ctx, headers)
+	if err == nil && claims != nil && scopeAllowed(claims, policy) {
+		a.refreshRoles(ctx, claims, false)
+		return auth.ContextWithClaims(ctx, claims), renewed
+	}
+	return ctx, ""
+}
+
+// authenticate resolves the request's credentials: "ApiKey <key>", or a JWT
+// as "Bearer <token>" or bare. It returns nil claims when none are sent, and
+// a replacement token when an anonymous token was renewed.
+func (a *AuthInterceptor) authenticate(ctx context.Context, headers http.Header) (*auth.Claims, string, error) {
+	if header := headers.Get("Authorization"); strings.HasPrefix(header, apiKeyScheme) {
+		if a.apiKeys == nil {
+			return nil, "", errors.New("API keys are not enabled")
+		}
+		claims, err := a.apiKeys.Authenticate(ctx, strings.TrimSpace(header[len(apiKeyScheme):]))
+		return claims, "", err
+	}
+
+	token := extractBearerToken(headers)
+	if token == "" {
+		return nil, "", nil
+	}
+
+	claims, err := a.tokenManager.ValidateToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := a.checkUserStatus(ctx, claims); err != nil {
+		return nil, "", err
+	}
+
+	// Slide the expiry of active anonymous users
+	renewedToken, err := a.renewAnonymous(ctx, claims)
+	if err != nil {
+		return nil, "", err
+	}
+	return claims, renewedToken, nil
+}
+
+// scopeAllowed reports whether the caller may use a procedure under its
+// policy. Only API keys are limited; procedures without a scope refuse them.
+func scopeAllowed(claims *auth.Claims, policy Policy) bool {
+	if claims.Provider != auth.ProviderAPIKey {
+		return true
+	}
+	return policy.Scope != "" && claims.HasScope(policy.Scope)
+}
+
+// renewAnonymous renews an anonymous token when due. Only a purged account or
+// revoked session is an error; storage failures are logged and let through.
+func (a *AuthInterceptor) renewAnonymous(ctx context.Context, claims *auth.Claims) (string, error) {
+	if a.anonymous == nil || !claims.IsAnonymous {
+		return "", nil
+	}
+
+	token, err := a.anonymous.Renew(ctx, claims)
+	if err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			return "", err
+		}
+		log.Printf("failed to renew anonymous token: %v", err)
+		return "", nil
+	}
+	return token, nil
+}
+
+// refreshRoles replaces the token's elevated roles with the stored ones, so
+// revoked roles take effect immediately. Tokens without elevated roles are only
+// looked up when the procedure requires a role. On storage errors elevated
+// roles are dropped rather than trusted.
+func (a *AuthInterceptor) refreshRoles(ctx context.Context, claims *auth.Claims, required bool) {
+	if a.roles == nil || claims.IsAnonymous || claims.Provider == auth.ProviderAPIKey || (len(claims.Roles) == 0 && !required) {
+		return
+	}
+
+	roles, err := a.roles.ListUserRoles(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("failed to load roles for user %s: %v", claims.UserID, err)
+		roles = nil
+	}
+	claims.Roles = roles
+}
+
+// checkUserStatus rejects disabled and deleted users, and tokens signed in
+// before the user signed out everywhere. API keys check this themselves.
+// Storage failures are logged and let through.
+func (a *AuthInterceptor) checkUserStatus(ctx context.Context, claims *auth.Claims) error {
+	if a.users == nil || claims.UserID == "" {
+		return nil
+	}
+
+	now := time.Now()
+	a.statusMu.Lock()
+	cached, ok := a.statuses[claims.UserID]
+	a.statusMu.Unlock()
+
+	if !ok || now.After(cached.expires) {
+		user, err := a.users.GetUserByID(ctx, claims.UserID)
+		if err != nil {
+			var authErr *auth.AuthError
+			if errors.As(err, &authErr) {
+				return err
 			}
+			log.Printf("failed to check status of user %s: %v", claims.UserID, err)
+			return nil
 		}
+		cached = a.cacheUserStatus(claims.UserID, user, now)
+	}
+
+	if cached.user.Status == "disabled" {
+		return &auth.AuthError{Code: auth.ErrUserDisabled, Message: "user account is disabled"}
+	}
+	if cached.user.TokenRevoked(claims) {
+		return &auth.AuthError{Code: auth.ErrTokenInvalid, Message: "token has been revoked"}
+	}
+	return nil
+}
+
+// cacheUserStatus remembers a looked-up status, dropping expired entries as the cache grows
+func (a *AuthInterceptor) cacheUserStatus(userID string, user *auth.User, now time.Time) cachedStatus {
+	a.statusMu.Lock()
+	defer a.statusMu.Unlock()
 
-		return next(ctx, req)
+	if a.statuses == nil {
+		a.statuses = make(map[string]cachedStatus)
 	}
+	if len(a.statuses) >= 10000 {
+		for id, entry := range a.statuses {
+			if now.After(entry.expires) {
+				delete(a.statuses, id)
+			}
+		}
+	}
+	entry := cachedStatus{user: user, expires: now.Add(userStatusTTL)}
+	a.statuses[userID] = entry
+	return entry
+}
+
+// isUserDisabled reports whether authentication failed because the account is disabled
+func isUserDisabled(err error) bool {
+	var authErr *auth.AuthError
+	return errors.As(err, &authErr) && authErr.Code == auth.ErrUserDisabled
 }
 
 // WrapStreamingClient creates a streaming client interceptor
@@ -86,14 +287,36 @@ func (a *AuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc)
 	}
 }
 
-// WrapStreamingHandler creates a streaming handler interceptor
+// WrapStreamingHandler creates a streaming handler interceptor that applies
+// the same policies as WrapUnary
 func (a *AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
 	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
-		// Streaming not used in MVP, but structure is here
+		ctx, renewedToken, err := a.authorize(ctx, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+
+		// Headers go out with the first message, so the renewed token must be set before
+		if renewedToken != "" {
+			conn.ResponseHeader().Set(RenewedTokenHeader, renewedToken)
+		}
 		return next(ctx, conn)
 	}
 }
 
+// WrapHTTP identifies callers of plain HTTP handlers the way public procedures
+// do: valid credentials put their claims in the request context, and missing or
+// rejected ones leave it without. API keys are not accepted.
+func (a *AuthInterceptor) WrapHTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, renewedToken := a.authorizePublic(r.Context(), Policy{Access: AccessPublic}, r.Header)
+		if renewedToken != "" {
+			w.Header().Set(RenewedTokenHeader, renewedToken)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // extractBearerToken extracts token from Authorization header
 func extractBearerToken(headers http.Header) string {
 	auth := headers.Get("Authorization")
@@ -108,54 +331,33 @@ func extractBearerToken(headers http.Header) string {
 	return auth
 }
 
-// isPublicEndpoint checks if an endpoint allows public access
-func isPublicEndpoint(procedure string) bool {
-	// Public endpoints that don't require auth
-	publicEndpoints := []string{
-		// Auth endpoints
-		"/api.v1.service.auth.AuthService/CheckUsername",
-		"/api.v1.service.auth.AuthService/InitAnonymous", // For future use
-		"/api.v1.service.auth.AuthService/Authenticate",
-		"/api.v1.service.auth.AuthService/RefreshToken",
-		
-		// Public read endpoints for pins
-		"/api.v1.service.PinService/ListPins",  // Public map viewing
-		"/api.v1.service.PinService/GetPin",     // Public pin details
-		
-		// Public user info
-		"/api.v1.service.UserService/GetUser",   // Public user profile
-	}
-	
-	for _, endpoint := range publicEndpoints {
-		if procedure == endpoint {
-			return true
-		}
-	}
-	return false
-}
-
 // GetClaims retrieves JWT claims from context
 func GetClaims(ctx context.Context) *auth.Claims {
-	claims, _ := ctx.Value(claimsKey).(*auth.Claims)
+	claims, _ := auth.ClaimsFromContext(ctx)
 	return claims
 }
 
 // GetUserID gets user ID from context
 func GetUserID(ctx context.Context) (string, bool) {
-	userID, ok := ctx.Value(userIDKey).(string)
-	return userID, ok
+	return auth.UserIDFromContext(ctx)
 }
 
 // GetUsername gets username from context
 func GetUsername(ctx context.Context) (string, bool) {
-	username, ok := ctx.Value(usernameKey).(string)
-	return username, ok
+	claims := GetClaims(ctx)
+	if claims == nil {
+		return "", false
+	}
+	return claims.Username, true
 }
 
 // GetEmail gets email from context
 func GetEmail(ctx context.Context) (string, bool) {
-	email, ok := ctx.Value(emailKey).(string)
-	return email, ok
+	claims := GetClaims(ctx)
+	if claims == nil {
+		return "", false
+	}
+	return claims.Email, true
 }
 
 // IsAuthenticated checks if the request is authenticated
@@ -0,0 +1,262 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	testIssuer   = "alunalun-test"
+	testAudience = "alunalun-test-api"
+
+	// A procedure with the default policy: valid token, any role, no API keys
+	protectedProcedure = servicev1connect.UserServiceUpdateProfileProcedure
+	streamProcedure    = servicev1connect.NotificationServiceWatchNotificationsProcedure
+)
+
+// testUserStore is an auth.UserStore over a fixed set of users
+type testUserStore struct {
+	users map[string]*auth.User
+}
+
+func (s *testUserStore) CreateUser(ctx context.Context, user *auth.User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *testUserStore) GetUserByEmail(ctx context.Context, email string) (*auth.User, error) {
+	for _, user := range s.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
+}
+
+func (s *testUserStore) GetUserByID(ctx context.Context, userID string) (*auth.User, error) {
+	if user, ok := s.users[userID]; ok {
+		return user, nil
+	}
+	return nil, &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
+}
+
+func (s *testUserStore) UpdateUser(ctx context.Context, user *auth.User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *testUserStore) CheckUsernameAvailable(ctx context.Context, username string) (bool, error) {
+	return true, nil
+}
+
+// interceptorTest serves a unary and a streaming procedure behind the interceptor
+// and counts how often their handlers run
+type interceptorTest struct {
+	tokens  *auth.TokenManager
+	users   *testUserStore
+	apiKeys *auth.APIKeyManager
+	server  *httptest.Server
+
+	unaryCalls  int
+	streamCalls int
+}
+
+func newInterceptorTest(t *testing.T) *interceptorTest {
+	t.Helper()
+	it := &interceptorTest{
+		tokens: newTestTokenManager(t),
+		users: &testUserStore{users: map[string]*auth.User{
+			"active-user":   {ID: "active-user", Email: "active@alunalun.test", Username: "active", Status: "active"},
+			"disabled-user": {ID: "disabled-user", Email: "disabled@alunalun.test", Username: "disabled", Status: "disabled"},
+		}},
+	}
+
+	apiKeys, err := auth.NewAPIKeyManager(auth.NewInMemoryAPIKeyStore(), it.users, auth.APIKeyConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	it.apiKeys = apiKeys
+
+	interceptor := NewAuthInterceptor(it.tokens)
+	interceptor.SetUserStore(it.users)
+	interceptor.SetAPIKeyManager(apiKeys)
+	options := connect.WithInterceptors(interceptor)
+
+	mux := http.NewServeMux()
+	mux.Handle(protectedProcedure, connect.NewUnaryHandler(protectedProcedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[emptypb.Empty], error) {
+			it.unaryCalls++
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		}, options))
+	mux.Handle(streamProcedure, connect.NewServerStreamHandler(streamProcedure,
+		func(ctx context.Context, req *connect.Request[emptypb.Empty], stream *connect.ServerStream[emptypb.Empty]) error {
+			it.streamCalls++
+			return stream.Send(&emptypb.Empty{})
+		}, options))
+
+	it.server = httptest.NewServer(mux)
+	t.Cleanup(it.server.Close)
+	return it
+}
+
+func newTestTokenManager(t *testing.T) *auth.TokenManager {
+	t.Helper()
+	privateKey, publicKey, err := auth.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := auth.NewTokenManager(privateKey, publicKey, testIssuer, testAudience)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokens
+}
+
+// token signs a token for a user with a manager
+func token(t *testing.T, tokens *auth.TokenManager, userID string, expiresAt time.Time) string {
+	t.Helper()
+	claims := &auth.Claims{UserID: userID, SessionID: "session", Provider: "email"}
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	signed, err := tokens.GenerateToken(claims, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+// callUnary calls the protected procedure with an Authorization header, if any
+func (it *interceptorTest) callUnary(authorization string) error {
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](http.DefaultClient, it.server.URL+protectedProcedure)
+	req := connect.NewRequest(&emptypb.Empty{})
+	if authorization != "" {
+		req.Header().Set("Authorization", authorization)
+	}
+	_, err := client.CallUnary(context.Background(), req)
+	return err
+}
+
+// callStream opens the streaming procedure and reads it to the end
+func (it *interceptorTest) callStream(authorization string) error {
+	client := connect.NewClient[emptypb.Empty, emptypb.Empty](http.DefaultClient, it.server.URL+streamProcedure)
+	req := connect.NewRequest(&emptypb.Empty{})
+	if authorization != "" {
+		req.Header().Set("Authorization", authorization)
+	}
+	stream, err := client.CallServerStream(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	for stream.Receive() {
+	}
+	return stream.Err()
+}
+
+func requireCode(t *testing.T, err error, code connect.Code) {
+	t.Helper()
+	if connect.CodeOf(err) != code {
+		t.Fatalf("expected %v, got %v", code, err)
+	}
+}
+
+func TestAuthInterceptorAllowsValidToken(t *testing.T) {
+	it := newInterceptorTest(t)
+	bearer := "Bearer " + token(t, it.tokens, "active-user", time.Now().Add(time.Hour))
+
+	if err := it.callUnary(bearer); err != nil {
+		t.Fatalf("unary call failed: %v", err)
+	}
+	if err := it.callStream(bearer); err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	if it.unaryCalls != 1 || it.streamCalls != 1 {
+		t.Fatalf("handlers ran %d and %d times, expected once each", it.unaryCalls, it.streamCalls)
+	}
+}
+
+func TestAuthInterceptorRejectsBadTokens(t *testing.T) {
+	it := newInterceptorTest(t)
+	forger := newTestTokenManager(t)
+	revokedAt := time.Now().Add(time.Minute)
+	it.users.users["signed-out-user"] = &auth.User{ID: "signed-out-user", Status: "active", TokensRevokedAt: &revokedAt}
+
+	tests := []struct {
+		name          string
+		authorization string
+		code          connect.Code
+	}{
+		{"missing", "", connect.CodeUnauthenticated},
+		{"malformed", "Bearer not-a-jwt", connect.CodeUnauthenticated},
+		{"expired", "Bearer " + token(t, it.tokens, "active-user", time.Now().Add(-time.Minute)), connect.CodeUnauthenticated},
+		{"forged", "Bearer " + token(t, forger, "active-user", time.Now().Add(time.Hour)), connect.CodeUnauthenticated},
+		{"revoked", "Bearer " + token(t, it.tokens, "signed-out-user", time.Now().Add(time.Hour)), connect.CodeUnauthenticated},
+		{"unknown user", "Bearer " + token(t, it.tokens, "deleted-user", time.Now().Add(time.Hour)), connect.CodeUnauthenticated},
+		{"disabled user", "Bearer " + token(t, it.tokens, "disabled-user", time.Now().Add(time.Hour)), connect.CodePermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requireCode(t, it.callUnary(tt.authorization), tt.code)
+			requireCode(t, it.callStream(tt.authorization), tt.code)
+		})
+	}
+
+	if it.unaryCalls != 0 || it.streamCalls != 0 {
+		t.Fatalf("handlers ran %d and %d times for rejected calls", it.unaryCalls, it.streamCalls)
+	}
+}
+
+func TestAuthInterceptorRejectsAPIKeyWithoutScope(t *testing.T) {
+	it := newInterceptorTest(t)
+	key, _, err := it.apiKeys.Create(context.Background(), it.users.users["active-user"], "script", []string{auth.ScopePinsRead, auth.ScopePinsWrite}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The procedure accepts no API key scope, so even a key with every scope is refused
+	requireCode(t, it.callUnary("ApiKey "+key), connect.CodePermissionDenied)
+	requireCode(t, it.callStream("ApiKey "+key), connect.CodePermissionDenied)
+	if it.unaryCalls != 0 || it.streamCalls != 0 {
+		t.Fatalf("handlers ran %d and %d times for rejected calls", it.unaryCalls, it.streamCalls)
+	}
+}
+
+func TestAuthInterceptorRejectsAPIKeyOfDisabledUser(t *testing.T) {
+	it := newInterceptorTest(t)
+	user := it.users.users["active-user"]
+	key, _, err := it.apiKeys.Create(context.Background(), user, "script", []string{auth.ScopePinsRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.Status = "disabled"
+
+	requireCode(t, it.callUnary("ApiKey "+key), connect.CodePermissionDenied)
+	if it.unaryCalls != 0 {
+		t.Fatalf("handler ran %d times for a disabled user's key", it.unaryCalls)
+	}
+}
+
+func TestAuthInterceptorRejectsAPIKeyOfAccountScheduledForDeletion(t *testing.T) {
+	it := newInterceptorTest(t)
+	user := it.users.users["active-user"]
+	key, _, err := it.apiKeys.Create(context.Background(), user, "script", []string{auth.ScopePinsRead}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduledAt := time.Now().Add(30 * 24 * time.Hour)
+	user.DeletionScheduledAt = &scheduledAt
+
+	requireCode(t, it.callUnary("ApiKey "+key), connect.CodeUnauthenticated)
+	if it.unaryCalls != 0 {
+		t.Fatalf("handler ran %d times for a key of an account scheduled for deletion", it.unaryCalls)
+	}
+}
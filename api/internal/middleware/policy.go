@@ -0,0 +1,73 @@
+package middleware
+
+import "github.com/radjathaher/alunalun/api/internal/utils/auth"
+
+// Access says whether a procedure needs a token
+type Access int
+
+const (
+	// AccessAuthenticated requires a valid token. This is the default.
+	AccessAuthenticated Access = iota
+
+	// AccessPublic allows callers without a token. A valid token is still
+	// read so handlers can tailor the response to the caller.
+	AccessPublic
+)
+
+// Policy is the access rule for a procedure
+type Policy struct {
+	Access Access
+	Role   string // Minimum role; empty allows any caller, including anonymous users
+	Scope  string // API key scope that grants access; empty refuses API keys
+}
+
+// procedurePolicies lists the procedures whose rule differs from the default
+// (valid token, any role, no API keys). Keep it in sync when adding RPCs.
+var procedurePolicies = map[string]Policy{
+	// Auth endpoints
+	"/api.v1.service.auth.AuthService/CheckUsername":         {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/InitAnonymous":         {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/Authenticate":          {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/RefreshToken":          {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/SendVerificationEmail": {Access: AccessPublic}, // Uses the caller's email when authenticated
+	"/api.v1.service.auth.AuthService/VerifyEmail":           {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/RequestPasswordReset":  {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/ResetPassword":         {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/CompleteMFA":           {Access: AccessPublic},
+	"/api.v1.service.auth.AuthService/BeginPasskeyLogin":     {Access: AccessPublic},
+
+	// Role administration
+	"/api.v1.service.auth.AuthService/GrantRole":  {Role: auth.RoleAdmin},
+	"/api.v1.service.auth.AuthService/RevokeRole": {Role: auth.RoleAdmin},
+
+	// Public read endpoints for pins
+	"/api.v1.service.PinService/ListPins": {Access: AccessPublic, Scope: auth.ScopePinsRead}, // Public map viewing
+	"/api.v1.service.PinService/GetPin":   {Access: AccessPublic, Scope: auth.ScopePinsRead}, // Public pin details
+
+	// Pin writes, also open to API keys
+	"/api.v1.service.PinService/CreatePin":  {Scope: auth.ScopePinsWrite},
+	"/api.v1.service.PinService/AddComment": {Scope: auth.ScopePinsWrite},
+	"/api.v1.service.PinService/DeletePin":  {Scope: auth.ScopePinsWrite},
+
+	// The caller's feed, also open to API keys
+	"/api.v1.service.PinService/ListFeed": {Scope: auth.ScopePinsRead},
+
+	// Public user info
+	"/api.v1.service.UserService/GetUser":       {Access: AccessPublic}, // Public user profile
+	"/api.v1.service.UserService/RegisterUser":  {Access: AccessPublic}, // Password sign-up only
+	"/api.v1.service.UserService/ListFollowers": {Access: AccessPublic},
+	"/api.v1.service.UserService/ListFollowing": {Access: AccessPublic},
+	"/api.v1.service.UserService/ListUserPins":  {Access: AccessPublic, Scope: auth.ScopePinsRead}, // Profile page
+	"/api.v1.service.UserService/GetUserStats":  {Access: AccessPublic},
+
+	// Push subscriptions
+	"/api.v1.service.PushSubscriptionService/GetVapidPublicKey": {Access: AccessPublic},
+}
+
+// PolicyFor returns the access rule for a procedure
+func PolicyFor(procedure string) Policy {
+	if policy, ok := procedurePolicies[procedure]; ok {
+		return policy
+	}
+	return Policy{Access: AccessAuthenticated}
+}
@@ -23,6 +23,57 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// UsernameResolution picks the username kept when an anonymous account
+// is merged into an existing registered account
+type UsernameResolution int32
+
+const (
+	UsernameResolution_USERNAME_RESOLUTION_UNSPECIFIED     UsernameResolution = 0 // Fail if the usernames differ
+	UsernameResolution_USERNAME_RESOLUTION_KEEP_REGISTERED UsernameResolution = 1 // Keep the registered account's username
+	UsernameResolution_USERNAME_RESOLUTION_KEEP_ANONYMOUS  UsernameResolution = 2 // Move the anonymous username to the registered account
+)
+
+// Enum value maps for UsernameResolution.
+var (
+	UsernameResolution_name = map[int32]string{
+		0: "USERNAME_RESOLUTION_UNSPECIFIED",
+		1: "USERNAME_RESOLUTION_KEEP_REGISTERED",
+		2: "USERNAME_RESOLUTION_KEEP_ANONYMOUS",
+	}
+	UsernameResolution_value = map[string]int32{
+		"USERNAME_RESOLUTION_UNSPECIFIED":     0,
+		"USERNAME_RESOLUTION_KEEP_REGISTERED": 1,
+		"USERNAME_RESOLUTION_KEEP_ANONYMOUS":  2,
+	}
+)
+
+func (x UsernameResolution) Enum() *UsernameResolution {
+	p := new(UsernameResolution)
+	*p = x
+	return p
+}
+
+func (x UsernameResolution) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UsernameResolution) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_service_auth_proto_enumTypes[0].Descriptor()
+}
+
+func (UsernameResolution) Type() protoreflect.EnumType {
+	return &file_v1_service_auth_proto_enumTypes[0]
+}
+
+func (x UsernameResolution) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UsernameResolution.Descriptor instead.
+func (UsernameResolution) EnumDescriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{0}
+}
+
 // CheckUsernameRequest checks availability
 type CheckUsernameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -123,10 +174,11 @@ func (x *CheckUsernameResponse) GetMessage() string {
 
 // InitAnonymousRequest creates anonymous session
 type InitAnonymousRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Username          string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	DeviceFingerprint *string                `protobuf:"bytes,2,opt,name=device_fingerprint,json=deviceFingerprint,proto3,oneof" json:"device_fingerprint,omitempty"` // Stable device identifier, used to cap anonymous accounts per device
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *InitAnonymousRequest) Reset() {
@@ -166,10 +218,17 @@ func (x *InitAnonymousRequest) GetUsername() string {
 	return ""
 }
 
+func (x *InitAnonymousRequest) GetDeviceFingerprint() string {
+	if x != nil && x.DeviceFingerprint != nil {
+		return *x.DeviceFingerprint
+	}
+	return ""
+}
+
 // InitAnonymousResponse returns anonymous token
 type InitAnonymousResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // JWT with no expiration
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                          // JWT renewed on activity (see x-renewed-token)
 	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Server-generated UUID
 	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`                    // Confirmed username
 	unknownFields protoimpl.UnknownFields
@@ -229,12 +288,13 @@ func (x *InitAnonymousResponse) GetUsername() string {
 
 // AuthenticateRequest for provider-based auth
 type AuthenticateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                          // "google", "magic_link", etc
-	Credential    string                 `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"`                      // ID token, magic token, etc
-	SessionId     *string                `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3,oneof" json:"session_id,omitempty"` // For migration from anonymous
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Provider           string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                                                                                            // "google", "magic_link", "passkey", etc
+	Credential         string                 `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"`                                                                                        // ID token, magic token, passkey assertion JSON, etc
+	SessionId          *string                `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3,oneof" json:"session_id,omitempty"`                                                                   // For migration from anonymous
+	UsernameResolution UsernameResolution     `protobuf:"varint,4,opt,name=username_resolution,json=usernameResolution,proto3,enum=api.v1.service.auth.UsernameResolution" json:"username_resolution,omitempty"` // Required when merging into an account with a different username
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *AuthenticateRequest) Reset() {
@@ -288,12 +348,21 @@ func (x *AuthenticateRequest) GetSessionId() string {
 	return ""
 }
 
+func (x *AuthenticateRequest) GetUsernameResolution() UsernameResolution {
+	if x != nil {
+		return x.UsernameResolution
+	}
+	return UsernameResolution_USERNAME_RESOLUTION_UNSPECIFIED
+}
+
 // AuthenticateResponse returns auth token
 type AuthenticateResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Token           string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // JWT with 1hr expiration
 	User            *entities.User         `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
 	SessionMigrated bool                   `protobuf:"varint,3,opt,name=session_migrated,json=sessionMigrated,proto3" json:"session_migrated,omitempty"` // If session_id was provided and migrated
+	MfaRequired     bool                   `protobuf:"varint,4,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`             // Token and user are empty; call CompleteMFA
+	MfaChallenge    string                 `protobuf:"bytes,5,opt,name=mfa_challenge,json=mfaChallenge,proto3" json:"mfa_challenge,omitempty"`           // Short-lived challenge for CompleteMFA
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -349,6 +418,20 @@ func (x *AuthenticateResponse) GetSessionMigrated() bool {
 	return false
 }
 
+func (x *AuthenticateResponse) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *AuthenticateResponse) GetMfaChallenge() string {
+	if x != nil {
+		return x.MfaChallenge
+	}
+	return ""
+}
+
 // RefreshTokenRequest refreshes expired JWT
 type RefreshTokenRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -439,44 +522,1941 @@ func (x *RefreshTokenResponse) GetToken() string {
 	return ""
 }
 
-var File_v1_service_auth_proto protoreflect.FileDescriptor
+// LinkedProvider is a login method attached to a user
+type LinkedProvider struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Provider       string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                                     // "google", "apple", "email", etc
+	ProviderUserId string                 `protobuf:"bytes,2,opt,name=provider_user_id,json=providerUserId,proto3" json:"provider_user_id,omitempty"` // Subject at the provider
+	Email          *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`                                     // Email reported by the provider
+	LinkedAt       int64                  `protobuf:"varint,4,opt,name=linked_at,json=linkedAt,proto3" json:"linked_at,omitempty"`                    // Unix timestamp
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
 
-const file_v1_service_auth_proto_rawDesc = "" +
-	"\n" +
-	"\x15v1/service/auth.proto\x12\x13api.v1.service.auth\x1a\x16v1/entities/user.proto\x1a google/protobuf/field_mask.proto\"2\n" +
-	"\x14CheckUsernameRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"O\n" +
-	"\x15CheckUsernameResponse\x12\x1c\n" +
-	"\tavailable\x18\x01 \x01(\bR\tavailable\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"2\n" +
-	"\x14InitAnonymousRequest\x12\x1a\n" +
-	"\busername\x18\x01 \x01(\tR\busername\"h\n" +
-	"\x15InitAnonymousResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1a\n" +
-	"\busername\x18\x03 \x01(\tR\busername\"\x84\x01\n" +
-	"\x13AuthenticateRequest\x12\x1a\n" +
-	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1e\n" +
-	"\n" +
-	"credential\x18\x02 \x01(\tR\n" +
-	"credential\x12\"\n" +
-	"\n" +
-	"session_id\x18\x03 \x01(\tH\x00R\tsessionId\x88\x01\x01B\r\n" +
-	"\v_session_id\"\x82\x01\n" +
-	"\x14AuthenticateResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\x12)\n" +
-	"\x04user\x18\x02 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12)\n" +
-	"\x10session_migrated\x18\x03 \x01(\bR\x0fsessionMigrated\":\n" +
-	"\x13RefreshTokenRequest\x12#\n" +
-	"\rexpired_token\x18\x01 \x01(\tR\fexpiredToken\",\n" +
-	"\x14RefreshTokenResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token2\xa7\x03\n" +
-	"\vAuthService\x12f\n" +
-	"\rCheckUsername\x12).api.v1.service.auth.CheckUsernameRequest\x1a*.api.v1.service.auth.CheckUsernameResponse\x12f\n" +
-	"\rInitAnonymous\x12).api.v1.service.auth.InitAnonymousRequest\x1a*.api.v1.service.auth.InitAnonymousResponse\x12c\n" +
-	"\fAuthenticate\x12(.api.v1.service.auth.AuthenticateRequest\x1a).api.v1.service.auth.AuthenticateResponse\x12c\n" +
-	"\fRefreshToken\x12(.api.v1.service.auth.RefreshTokenRequest\x1a).api.v1.service.auth.RefreshTokenResponseBWZUgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service;auth_servicev1b\x06proto3"
+func (x *LinkedProvider) Reset() {
+	*x = LinkedProvider{}
+	mi := &file_v1_service_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkedProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkedProvider) ProtoMessage() {}
+
+func (x *LinkedProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkedProvider.ProtoReflect.Descriptor instead.
+func (*LinkedProvider) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LinkedProvider) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkedProvider) GetProviderUserId() string {
+	if x != nil {
+		return x.ProviderUserId
+	}
+	return ""
+}
+
+func (x *LinkedProvider) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *LinkedProvider) GetLinkedAt() int64 {
+	if x != nil {
+		return x.LinkedAt
+	}
+	return 0
+}
+
+// LinkProviderRequest links a provider using a fresh authentication
+type LinkProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`     // Provider to link
+	Credential    string                 `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"` // Fresh credential for that provider (ID token, code, etc)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkProviderRequest) Reset() {
+	*x = LinkProviderRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkProviderRequest) ProtoMessage() {}
+
+func (x *LinkProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkProviderRequest.ProtoReflect.Descriptor instead.
+func (*LinkProviderRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LinkProviderRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *LinkProviderRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+// LinkProviderResponse returns the new link
+type LinkProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      *LinkedProvider        `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkProviderResponse) Reset() {
+	*x = LinkProviderResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkProviderResponse) ProtoMessage() {}
+
+func (x *LinkProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkProviderResponse.ProtoReflect.Descriptor instead.
+func (*LinkProviderResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LinkProviderResponse) GetProvider() *LinkedProvider {
+	if x != nil {
+		return x.Provider
+	}
+	return nil
+}
+
+// UnlinkProviderRequest removes a provider
+type UnlinkProviderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkProviderRequest) Reset() {
+	*x = UnlinkProviderRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkProviderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkProviderRequest) ProtoMessage() {}
+
+func (x *UnlinkProviderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkProviderRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkProviderRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnlinkProviderRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+// UnlinkProviderResponse is empty on success
+type UnlinkProviderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkProviderResponse) Reset() {
+	*x = UnlinkProviderResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkProviderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkProviderResponse) ProtoMessage() {}
+
+func (x *UnlinkProviderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkProviderResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkProviderResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{12}
+}
+
+// ListLinkedProvidersRequest lists the caller's providers
+type ListLinkedProvidersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinkedProvidersRequest) Reset() {
+	*x = ListLinkedProvidersRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinkedProvidersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinkedProvidersRequest) ProtoMessage() {}
+
+func (x *ListLinkedProvidersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinkedProvidersRequest.ProtoReflect.Descriptor instead.
+func (*ListLinkedProvidersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{13}
+}
+
+// ListLinkedProvidersResponse returns linked providers
+type ListLinkedProvidersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Providers     []*LinkedProvider      `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLinkedProvidersResponse) Reset() {
+	*x = ListLinkedProvidersResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLinkedProvidersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLinkedProvidersResponse) ProtoMessage() {}
+
+func (x *ListLinkedProvidersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLinkedProvidersResponse.ProtoReflect.Descriptor instead.
+func (*ListLinkedProvidersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListLinkedProvidersResponse) GetProviders() []*LinkedProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// SendVerificationEmailRequest requests a verification link
+type SendVerificationEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // Optional when authenticated; defaults to the caller's email
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendVerificationEmailRequest) Reset() {
+	*x = SendVerificationEmailRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendVerificationEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendVerificationEmailRequest) ProtoMessage() {}
+
+func (x *SendVerificationEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendVerificationEmailRequest.ProtoReflect.Descriptor instead.
+func (*SendVerificationEmailRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SendVerificationEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// SendVerificationEmailResponse is empty on success
+type SendVerificationEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendVerificationEmailResponse) Reset() {
+	*x = SendVerificationEmailResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendVerificationEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendVerificationEmailResponse) ProtoMessage() {}
+
+func (x *SendVerificationEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendVerificationEmailResponse.ProtoReflect.Descriptor instead.
+func (*SendVerificationEmailResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{16}
+}
+
+// VerifyEmailRequest verifies an email address
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Token from the verification link
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VerifyEmailRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// VerifyEmailResponse returns the verified user
+type VerifyEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *entities.User         `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailResponse) Reset() {
+	*x = VerifyEmailResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailResponse) ProtoMessage() {}
+
+func (x *VerifyEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *VerifyEmailResponse) GetUser() *entities.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// RequestPasswordResetRequest requests a password reset link
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RequestPasswordResetRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// RequestPasswordResetResponse is empty; it is the same whether or not the email exists
+type RequestPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetResponse) Reset() {
+	*x = RequestPasswordResetResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetResponse) ProtoMessage() {}
+
+func (x *RequestPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{20}
+}
+
+// ResetPasswordRequest sets a new password
+type ResetPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Token from the reset link
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// ResetPasswordResponse is empty on success
+type ResetPasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordResponse) ProtoMessage() {}
+
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{22}
+}
+
+// EnrollTOTPRequest starts TOTP enrollment for the caller
+type EnrollTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPRequest) Reset() {
+	*x = EnrollTOTPRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPRequest) ProtoMessage() {}
+
+func (x *EnrollTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{23}
+}
+
+// EnrollTOTPResponse returns the new secret
+type EnrollTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`                           // Base32 secret for manual entry
+	OtpauthUri    string                 `protobuf:"bytes,2,opt,name=otpauth_uri,json=otpauthUri,proto3" json:"otpauth_uri,omitempty"` // otpauth:// URI for QR codes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTOTPResponse) Reset() {
+	*x = EnrollTOTPResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTOTPResponse) ProtoMessage() {}
+
+func (x *EnrollTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTOTPResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *EnrollTOTPResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTOTPResponse) GetOtpauthUri() string {
+	if x != nil {
+		return x.OtpauthUri
+	}
+	return ""
+}
+
+// ConfirmTOTPRequest confirms enrollment
+type ConfirmTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // Current code from the authenticator app
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPRequest) Reset() {
+	*x = ConfirmTOTPRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPRequest) ProtoMessage() {}
+
+func (x *ConfirmTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ConfirmTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// ConfirmTOTPResponse returns recovery codes; they are shown only once
+type ConfirmTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTOTPResponse) Reset() {
+	*x = ConfirmTOTPResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTOTPResponse) ProtoMessage() {}
+
+func (x *ConfirmTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTOTPResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConfirmTOTPResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+// DisableTOTPRequest turns off TOTP
+type DisableTOTPRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"` // Current TOTP code or a recovery code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPRequest) Reset() {
+	*x = DisableTOTPRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPRequest) ProtoMessage() {}
+
+func (x *DisableTOTPRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPRequest.ProtoReflect.Descriptor instead.
+func (*DisableTOTPRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DisableTOTPRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// DisableTOTPResponse is empty on success
+type DisableTOTPResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableTOTPResponse) Reset() {
+	*x = DisableTOTPResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableTOTPResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableTOTPResponse) ProtoMessage() {}
+
+func (x *DisableTOTPResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableTOTPResponse.ProtoReflect.Descriptor instead.
+func (*DisableTOTPResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{28}
+}
+
+// CompleteMFARequest finishes a two-factor sign-in
+type CompleteMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MfaChallenge  string                 `protobuf:"bytes,1,opt,name=mfa_challenge,json=mfaChallenge,proto3" json:"mfa_challenge,omitempty"` // From AuthenticateResponse
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`                                     // TOTP code or a recovery code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteMFARequest) Reset() {
+	*x = CompleteMFARequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMFARequest) ProtoMessage() {}
+
+func (x *CompleteMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMFARequest.ProtoReflect.Descriptor instead.
+func (*CompleteMFARequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CompleteMFARequest) GetMfaChallenge() string {
+	if x != nil {
+		return x.MfaChallenge
+	}
+	return ""
+}
+
+func (x *CompleteMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// CompleteMFAResponse returns the auth token
+type CompleteMFAResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Token           string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	User            *entities.User         `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	SessionMigrated bool                   `protobuf:"varint,3,opt,name=session_migrated,json=sessionMigrated,proto3" json:"session_migrated,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CompleteMFAResponse) Reset() {
+	*x = CompleteMFAResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteMFAResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteMFAResponse) ProtoMessage() {}
+
+func (x *CompleteMFAResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteMFAResponse.ProtoReflect.Descriptor instead.
+func (*CompleteMFAResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CompleteMFAResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CompleteMFAResponse) GetUser() *entities.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *CompleteMFAResponse) GetSessionMigrated() bool {
+	if x != nil {
+		return x.SessionMigrated
+	}
+	return false
+}
+
+// Passkey is a WebAuthn credential registered to a user
+type Passkey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // base64url credential ID
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Transports    []string               `protobuf:"bytes,3,rep,name=transports,proto3" json:"transports,omitempty"`                            // "internal", "hybrid", "usb", etc
+	CreatedAt     int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`            // Unix timestamp
+	LastUsedAt    *int64                 `protobuf:"varint,5,opt,name=last_used_at,json=lastUsedAt,proto3,oneof" json:"last_used_at,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Passkey) Reset() {
+	*x = Passkey{}
+	mi := &file_v1_service_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Passkey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Passkey) ProtoMessage() {}
+
+func (x *Passkey) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Passkey.ProtoReflect.Descriptor instead.
+func (*Passkey) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Passkey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Passkey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Passkey) GetTransports() []string {
+	if x != nil {
+		return x.Transports
+	}
+	return nil
+}
+
+func (x *Passkey) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Passkey) GetLastUsedAt() int64 {
+	if x != nil && x.LastUsedAt != nil {
+		return *x.LastUsedAt
+	}
+	return 0
+}
+
+// BeginPasskeyRegistrationRequest starts passkey registration
+type BeginPasskeyRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyRegistrationRequest) Reset() {
+	*x = BeginPasskeyRegistrationRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyRegistrationRequest) ProtoMessage() {}
+
+func (x *BeginPasskeyRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{32}
+}
+
+// BeginPasskeyRegistrationResponse returns creation options
+type BeginPasskeyRegistrationResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OptionsJson    string                 `protobuf:"bytes,1,opt,name=options_json,json=optionsJson,proto3" json:"options_json,omitempty"`          // PublicKeyCredentialCreationOptionsJSON for navigator.credentials.create
+	ChallengeToken string                 `protobuf:"bytes,2,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"` // Pass back to FinishPasskeyRegistration
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyRegistrationResponse) Reset() {
+	*x = BeginPasskeyRegistrationResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyRegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyRegistrationResponse) ProtoMessage() {}
+
+func (x *BeginPasskeyRegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyRegistrationResponse.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyRegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *BeginPasskeyRegistrationResponse) GetOptionsJson() string {
+	if x != nil {
+		return x.OptionsJson
+	}
+	return ""
+}
+
+func (x *BeginPasskeyRegistrationResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+// FinishPasskeyRegistrationRequest completes passkey registration
+type FinishPasskeyRegistrationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeToken string                 `protobuf:"bytes,1,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	CredentialJson string                 `protobuf:"bytes,2,opt,name=credential_json,json=credentialJson,proto3" json:"credential_json,omitempty"` // PublicKeyCredential.toJSON() of the new credential
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                           // Optional label, e.g. "iPhone"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FinishPasskeyRegistrationRequest) Reset() {
+	*x = FinishPasskeyRegistrationRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishPasskeyRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishPasskeyRegistrationRequest) ProtoMessage() {}
+
+func (x *FinishPasskeyRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishPasskeyRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*FinishPasskeyRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FinishPasskeyRegistrationRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+func (x *FinishPasskeyRegistrationRequest) GetCredentialJson() string {
+	if x != nil {
+		return x.CredentialJson
+	}
+	return ""
+}
+
+func (x *FinishPasskeyRegistrationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// FinishPasskeyRegistrationResponse returns the stored passkey
+type FinishPasskeyRegistrationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passkey       *Passkey               `protobuf:"bytes,1,opt,name=passkey,proto3" json:"passkey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishPasskeyRegistrationResponse) Reset() {
+	*x = FinishPasskeyRegistrationResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishPasskeyRegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishPasskeyRegistrationResponse) ProtoMessage() {}
+
+func (x *FinishPasskeyRegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishPasskeyRegistrationResponse.ProtoReflect.Descriptor instead.
+func (*FinishPasskeyRegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *FinishPasskeyRegistrationResponse) GetPasskey() *Passkey {
+	if x != nil {
+		return x.Passkey
+	}
+	return nil
+}
+
+// BeginPasskeyLoginRequest starts a passkey sign-in
+type BeginPasskeyLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyLoginRequest) Reset() {
+	*x = BeginPasskeyLoginRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyLoginRequest) ProtoMessage() {}
+
+func (x *BeginPasskeyLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyLoginRequest.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyLoginRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{36}
+}
+
+// BeginPasskeyLoginResponse returns request options. Authenticate with provider
+// "passkey" and credential {"challenge_token": ..., "credential": PublicKeyCredential.toJSON()}
+type BeginPasskeyLoginResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OptionsJson    string                 `protobuf:"bytes,1,opt,name=options_json,json=optionsJson,proto3" json:"options_json,omitempty"` // PublicKeyCredentialRequestOptionsJSON for navigator.credentials.get
+	ChallengeToken string                 `protobuf:"bytes,2,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BeginPasskeyLoginResponse) Reset() {
+	*x = BeginPasskeyLoginResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginPasskeyLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginPasskeyLoginResponse) ProtoMessage() {}
+
+func (x *BeginPasskeyLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginPasskeyLoginResponse.ProtoReflect.Descriptor instead.
+func (*BeginPasskeyLoginResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BeginPasskeyLoginResponse) GetOptionsJson() string {
+	if x != nil {
+		return x.OptionsJson
+	}
+	return ""
+}
+
+func (x *BeginPasskeyLoginResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+// ListPasskeysRequest lists the caller's passkeys
+type ListPasskeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPasskeysRequest) Reset() {
+	*x = ListPasskeysRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPasskeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPasskeysRequest) ProtoMessage() {}
+
+func (x *ListPasskeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPasskeysRequest.ProtoReflect.Descriptor instead.
+func (*ListPasskeysRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{38}
+}
+
+// ListPasskeysResponse returns passkeys, newest first
+type ListPasskeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Passkeys      []*Passkey             `protobuf:"bytes,1,rep,name=passkeys,proto3" json:"passkeys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPasskeysResponse) Reset() {
+	*x = ListPasskeysResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPasskeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPasskeysResponse) ProtoMessage() {}
+
+func (x *ListPasskeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPasskeysResponse.ProtoReflect.Descriptor instead.
+func (*ListPasskeysResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListPasskeysResponse) GetPasskeys() []*Passkey {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
+// DeletePasskeyRequest removes a passkey
+type DeletePasskeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePasskeyRequest) Reset() {
+	*x = DeletePasskeyRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePasskeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePasskeyRequest) ProtoMessage() {}
+
+func (x *DeletePasskeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePasskeyRequest.ProtoReflect.Descriptor instead.
+func (*DeletePasskeyRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeletePasskeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeletePasskeyResponse is empty on success
+type DeletePasskeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePasskeyResponse) Reset() {
+	*x = DeletePasskeyResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePasskeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePasskeyResponse) ProtoMessage() {}
+
+func (x *DeletePasskeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePasskeyResponse.ProtoReflect.Descriptor instead.
+func (*DeletePasskeyResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{41}
+}
+
+// GrantRoleRequest grants an elevated role
+type GrantRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // "moderator" or "admin"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRoleRequest) Reset() {
+	*x = GrantRoleRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRoleRequest) ProtoMessage() {}
+
+func (x *GrantRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRoleRequest.ProtoReflect.Descriptor instead.
+func (*GrantRoleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GrantRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GrantRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// GrantRoleResponse returns the user's elevated roles
+type GrantRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []string               `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantRoleResponse) Reset() {
+	*x = GrantRoleResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantRoleResponse) ProtoMessage() {}
+
+func (x *GrantRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantRoleResponse.ProtoReflect.Descriptor instead.
+func (*GrantRoleResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GrantRoleResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+// RevokeRoleRequest revokes an elevated role
+type RevokeRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRoleRequest) Reset() {
+	*x = RevokeRoleRequest{}
+	mi := &file_v1_service_auth_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRoleRequest) ProtoMessage() {}
+
+func (x *RevokeRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRoleRequest.ProtoReflect.Descriptor instead.
+func (*RevokeRoleRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RevokeRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+// RevokeRoleResponse returns the user's remaining elevated roles
+type RevokeRoleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []string               `protobuf:"bytes,1,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeRoleResponse) Reset() {
+	*x = RevokeRoleResponse{}
+	mi := &file_v1_service_auth_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeRoleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeRoleResponse) ProtoMessage() {}
+
+func (x *RevokeRoleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_auth_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeRoleResponse.ProtoReflect.Descriptor instead.
+func (*RevokeRoleResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_auth_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RevokeRoleResponse) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+var File_v1_service_auth_proto protoreflect.FileDescriptor
+
+const file_v1_service_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x15v1/service/auth.proto\x12\x13api.v1.service.auth\x1a\x16v1/entities/user.proto\x1a google/protobuf/field_mask.proto\"2\n" +
+	"\x14CheckUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"O\n" +
+	"\x15CheckUsernameResponse\x12\x1c\n" +
+	"\tavailable\x18\x01 \x01(\bR\tavailable\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"}\n" +
+	"\x14InitAnonymousRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x122\n" +
+	"\x12device_fingerprint\x18\x02 \x01(\tH\x00R\x11deviceFingerprint\x88\x01\x01B\x15\n" +
+	"\x13_device_fingerprint\"h\n" +
+	"\x15InitAnonymousResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\"\xde\x01\n" +
+	"\x13AuthenticateRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\x12\"\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tH\x00R\tsessionId\x88\x01\x01\x12X\n" +
+	"\x13username_resolution\x18\x04 \x01(\x0e2'.api.v1.service.auth.UsernameResolutionR\x12usernameResolutionB\r\n" +
+	"\v_session_id\"\xca\x01\n" +
+	"\x14AuthenticateResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12)\n" +
+	"\x04user\x18\x02 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12)\n" +
+	"\x10session_migrated\x18\x03 \x01(\bR\x0fsessionMigrated\x12!\n" +
+	"\fmfa_required\x18\x04 \x01(\bR\vmfaRequired\x12#\n" +
+	"\rmfa_challenge\x18\x05 \x01(\tR\fmfaChallenge\":\n" +
+	"\x13RefreshTokenRequest\x12#\n" +
+	"\rexpired_token\x18\x01 \x01(\tR\fexpiredToken\",\n" +
+	"\x14RefreshTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x98\x01\n" +
+	"\x0eLinkedProvider\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12(\n" +
+	"\x10provider_user_id\x18\x02 \x01(\tR\x0eproviderUserId\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x00R\x05email\x88\x01\x01\x12\x1b\n" +
+	"\tlinked_at\x18\x04 \x01(\x03R\blinkedAtB\b\n" +
+	"\x06_email\"Q\n" +
+	"\x13LinkProviderRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\"W\n" +
+	"\x14LinkProviderResponse\x12?\n" +
+	"\bprovider\x18\x01 \x01(\v2#.api.v1.service.auth.LinkedProviderR\bprovider\"3\n" +
+	"\x15UnlinkProviderRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\"\x18\n" +
+	"\x16UnlinkProviderResponse\"\x1c\n" +
+	"\x1aListLinkedProvidersRequest\"`\n" +
+	"\x1bListLinkedProvidersResponse\x12A\n" +
+	"\tproviders\x18\x01 \x03(\v2#.api.v1.service.auth.LinkedProviderR\tproviders\"4\n" +
+	"\x1cSendVerificationEmailRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x1f\n" +
+	"\x1dSendVerificationEmailResponse\"*\n" +
+	"\x12VerifyEmailRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"@\n" +
+	"\x13VerifyEmailResponse\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\"3\n" +
+	"\x1bRequestPasswordResetRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"\x1e\n" +
+	"\x1cRequestPasswordResetResponse\"O\n" +
+	"\x14ResetPasswordRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fnew_password\x18\x02 \x01(\tR\vnewPassword\"\x17\n" +
+	"\x15ResetPasswordResponse\"\x13\n" +
+	"\x11EnrollTOTPRequest\"M\n" +
+	"\x12EnrollTOTPResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x1f\n" +
+	"\votpauth_uri\x18\x02 \x01(\tR\n" +
+	"otpauthUri\"(\n" +
+	"\x12ConfirmTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"<\n" +
+	"\x13ConfirmTOTPResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"(\n" +
+	"\x12DisableTOTPRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x15\n" +
+	"\x13DisableTOTPResponse\"M\n" +
+	"\x12CompleteMFARequest\x12#\n" +
+	"\rmfa_challenge\x18\x01 \x01(\tR\fmfaChallenge\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"\x81\x01\n" +
+	"\x13CompleteMFAResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12)\n" +
+	"\x04user\x18\x02 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12)\n" +
+	"\x10session_migrated\x18\x03 \x01(\bR\x0fsessionMigrated\"\xa4\x01\n" +
+	"\aPasskey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"transports\x18\x03 \x03(\tR\n" +
+	"transports\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12%\n" +
+	"\flast_used_at\x18\x05 \x01(\x03H\x00R\n" +
+	"lastUsedAt\x88\x01\x01B\x0f\n" +
+	"\r_last_used_at\"!\n" +
+	"\x1fBeginPasskeyRegistrationRequest\"n\n" +
+	" BeginPasskeyRegistrationResponse\x12!\n" +
+	"\foptions_json\x18\x01 \x01(\tR\voptionsJson\x12'\n" +
+	"\x0fchallenge_token\x18\x02 \x01(\tR\x0echallengeToken\"\x88\x01\n" +
+	" FinishPasskeyRegistrationRequest\x12'\n" +
+	"\x0fchallenge_token\x18\x01 \x01(\tR\x0echallengeToken\x12'\n" +
+	"\x0fcredential_json\x18\x02 \x01(\tR\x0ecredentialJson\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"[\n" +
+	"!FinishPasskeyRegistrationResponse\x126\n" +
+	"\apasskey\x18\x01 \x01(\v2\x1c.api.v1.service.auth.PasskeyR\apasskey\"\x1a\n" +
+	"\x18BeginPasskeyLoginRequest\"g\n" +
+	"\x19BeginPasskeyLoginResponse\x12!\n" +
+	"\foptions_json\x18\x01 \x01(\tR\voptionsJson\x12'\n" +
+	"\x0fchallenge_token\x18\x02 \x01(\tR\x0echallengeToken\"\x15\n" +
+	"\x13ListPasskeysRequest\"P\n" +
+	"\x14ListPasskeysResponse\x128\n" +
+	"\bpasskeys\x18\x01 \x03(\v2\x1c.api.v1.service.auth.PasskeyR\bpasskeys\"&\n" +
+	"\x14DeletePasskeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x17\n" +
+	"\x15DeletePasskeyResponse\"?\n" +
+	"\x10GrantRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\")\n" +
+	"\x11GrantRoleResponse\x12\x14\n" +
+	"\x05roles\x18\x01 \x03(\tR\x05roles\"@\n" +
+	"\x11RevokeRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"*\n" +
+	"\x12RevokeRoleResponse\x12\x14\n" +
+	"\x05roles\x18\x01 \x03(\tR\x05roles*\x8a\x01\n" +
+	"\x12UsernameResolution\x12#\n" +
+	"\x1fUSERNAME_RESOLUTION_UNSPECIFIED\x10\x00\x12'\n" +
+	"#USERNAME_RESOLUTION_KEEP_REGISTERED\x10\x01\x12&\n" +
+	"\"USERNAME_RESOLUTION_KEEP_ANONYMOUS\x10\x022\xd0\x12\n" +
+	"\vAuthService\x12f\n" +
+	"\rCheckUsername\x12).api.v1.service.auth.CheckUsernameRequest\x1a*.api.v1.service.auth.CheckUsernameResponse\x12f\n" +
+	"\rInitAnonymous\x12).api.v1.service.auth.InitAnonymousRequest\x1a*.api.v1.service.auth.InitAnonymousResponse\x12c\n" +
+	"\fAuthenticate\x12(.api.v1.service.auth.AuthenticateRequest\x1a).api.v1.service.auth.AuthenticateResponse\x12c\n" +
+	"\fRefreshToken\x12(.api.v1.service.auth.RefreshTokenRequest\x1a).api.v1.service.auth.RefreshTokenResponse\x12c\n" +
+	"\fLinkProvider\x12(.api.v1.service.auth.LinkProviderRequest\x1a).api.v1.service.auth.LinkProviderResponse\x12i\n" +
+	"\x0eUnlinkProvider\x12*.api.v1.service.auth.UnlinkProviderRequest\x1a+.api.v1.service.auth.UnlinkProviderResponse\x12x\n" +
+	"\x13ListLinkedProviders\x12/.api.v1.service.auth.ListLinkedProvidersRequest\x1a0.api.v1.service.auth.ListLinkedProvidersResponse\x12~\n" +
+	"\x15SendVerificationEmail\x121.api.v1.service.auth.SendVerificationEmailRequest\x1a2.api.v1.service.auth.SendVerificationEmailResponse\x12`\n" +
+	"\vVerifyEmail\x12'.api.v1.service.auth.VerifyEmailRequest\x1a(.api.v1.service.auth.VerifyEmailResponse\x12{\n" +
+	"\x14RequestPasswordReset\x120.api.v1.service.auth.RequestPasswordResetRequest\x1a1.api.v1.service.auth.RequestPasswordResetResponse\x12f\n" +
+	"\rResetPassword\x12).api.v1.service.auth.ResetPasswordRequest\x1a*.api.v1.service.auth.ResetPasswordResponse\x12]\n" +
+	"\n" +
+	"EnrollTOTP\x12&.api.v1.service.auth.EnrollTOTPRequest\x1a'.api.v1.service.auth.EnrollTOTPResponse\x12`\n" +
+	"\vConfirmTOTP\x12'.api.v1.service.auth.ConfirmTOTPRequest\x1a(.api.v1.service.auth.ConfirmTOTPResponse\x12`\n" +
+	"\vDisableTOTP\x12'.api.v1.service.auth.DisableTOTPRequest\x1a(.api.v1.service.auth.DisableTOTPResponse\x12`\n" +
+	"\vCompleteMFA\x12'.api.v1.service.auth.CompleteMFARequest\x1a(.api.v1.service.auth.CompleteMFAResponse\x12\x87\x01\n" +
+	"\x18BeginPasskeyRegistration\x124.api.v1.service.auth.BeginPasskeyRegistrationRequest\x1a5.api.v1.service.auth.BeginPasskeyRegistrationResponse\x12\x8a\x01\n" +
+	"\x19FinishPasskeyRegistration\x125.api.v1.service.auth.FinishPasskeyRegistrationRequest\x1a6.api.v1.service.auth.FinishPasskeyRegistrationResponse\x12r\n" +
+	"\x11BeginPasskeyLogin\x12-.api.v1.service.auth.BeginPasskeyLoginRequest\x1a..api.v1.service.auth.BeginPasskeyLoginResponse\x12c\n" +
+	"\fListPasskeys\x12(.api.v1.service.auth.ListPasskeysRequest\x1a).api.v1.service.auth.ListPasskeysResponse\x12f\n" +
+	"\rDeletePasskey\x12).api.v1.service.auth.DeletePasskeyRequest\x1a*.api.v1.service.auth.DeletePasskeyResponse\x12Z\n" +
+	"\tGrantRole\x12%.api.v1.service.auth.GrantRoleRequest\x1a&.api.v1.service.auth.GrantRoleResponse\x12]\n" +
+	"\n" +
+	"RevokeRole\x12&.api.v1.service.auth.RevokeRoleRequest\x1a'.api.v1.service.auth.RevokeRoleResponseBWZUgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service;auth_servicev1b\x06proto3"
 
 var (
 	file_v1_service_auth_proto_rawDescOnce sync.Once
@@ -490,33 +2470,116 @@ func file_v1_service_auth_proto_rawDescGZIP() []byte {
 	return file_v1_service_auth_proto_rawDescData
 }
 
-var file_v1_service_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_v1_service_auth_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v1_service_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
 var file_v1_service_auth_proto_goTypes = []any{
-	(*CheckUsernameRequest)(nil),  // 0: api.v1.service.auth.CheckUsernameRequest
-	(*CheckUsernameResponse)(nil), // 1: api.v1.service.auth.CheckUsernameResponse
-	(*InitAnonymousRequest)(nil),  // 2: api.v1.service.auth.InitAnonymousRequest
-	(*InitAnonymousResponse)(nil), // 3: api.v1.service.auth.InitAnonymousResponse
-	(*AuthenticateRequest)(nil),   // 4: api.v1.service.auth.AuthenticateRequest
-	(*AuthenticateResponse)(nil),  // 5: api.v1.service.auth.AuthenticateResponse
-	(*RefreshTokenRequest)(nil),   // 6: api.v1.service.auth.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),  // 7: api.v1.service.auth.RefreshTokenResponse
-	(*entities.User)(nil),         // 8: api.v1.entities.User
+	(UsernameResolution)(0),                   // 0: api.v1.service.auth.UsernameResolution
+	(*CheckUsernameRequest)(nil),              // 1: api.v1.service.auth.CheckUsernameRequest
+	(*CheckUsernameResponse)(nil),             // 2: api.v1.service.auth.CheckUsernameResponse
+	(*InitAnonymousRequest)(nil),              // 3: api.v1.service.auth.InitAnonymousRequest
+	(*InitAnonymousResponse)(nil),             // 4: api.v1.service.auth.InitAnonymousResponse
+	(*AuthenticateRequest)(nil),               // 5: api.v1.service.auth.AuthenticateRequest
+	(*AuthenticateResponse)(nil),              // 6: api.v1.service.auth.AuthenticateResponse
+	(*RefreshTokenRequest)(nil),               // 7: api.v1.service.auth.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),              // 8: api.v1.service.auth.RefreshTokenResponse
+	(*LinkedProvider)(nil),                    // 9: api.v1.service.auth.LinkedProvider
+	(*LinkProviderRequest)(nil),               // 10: api.v1.service.auth.LinkProviderRequest
+	(*LinkProviderResponse)(nil),              // 11: api.v1.service.auth.LinkProviderResponse
+	(*UnlinkProviderRequest)(nil),             // 12: api.v1.service.auth.UnlinkProviderRequest
+	(*UnlinkProviderResponse)(nil),            // 13: api.v1.service.auth.UnlinkProviderResponse
+	(*ListLinkedProvidersRequest)(nil),        // 14: api.v1.service.auth.ListLinkedProvidersRequest
+	(*ListLinkedProvidersResponse)(nil),       // 15: api.v1.service.auth.ListLinkedProvidersResponse
+	(*SendVerificationEmailRequest)(nil),      // 16: api.v1.service.auth.SendVerificationEmailRequest
+	(*SendVerificationEmailResponse)(nil),     // 17: api.v1.service.auth.SendVerificationEmailResponse
+	(*VerifyEmailRequest)(nil),                // 18: api.v1.service.auth.VerifyEmailRequest
+	(*VerifyEmailResponse)(nil),               // 19: api.v1.service.auth.VerifyEmailResponse
+	(*RequestPasswordResetRequest)(nil),       // 20: api.v1.service.auth.RequestPasswordResetRequest
+	(*RequestPasswordResetResponse)(nil),      // 21: api.v1.service.auth.RequestPasswordResetResponse
+	(*ResetPasswordRequest)(nil),              // 22: api.v1.service.auth.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),             // 23: api.v1.service.auth.ResetPasswordResponse
+	(*EnrollTOTPRequest)(nil),                 // 24: api.v1.service.auth.EnrollTOTPRequest
+	(*EnrollTOTPResponse)(nil),                // 25: api.v1.service.auth.EnrollTOTPResponse
+	(*ConfirmTOTPRequest)(nil),                // 26: api.v1.service.auth.ConfirmTOTPRequest
+	(*ConfirmTOTPResponse)(nil),               // 27: api.v1.service.auth.ConfirmTOTPResponse
+	(*DisableTOTPRequest)(nil),                // 28: api.v1.service.auth.DisableTOTPRequest
+	(*DisableTOTPResponse)(nil),               // 29: api.v1.service.auth.DisableTOTPResponse
+	(*CompleteMFARequest)(nil),                // 30: api.v1.service.auth.CompleteMFARequest
+	(*CompleteMFAResponse)(nil),               // 31: api.v1.service.auth.CompleteMFAResponse
+	(*Passkey)(nil),                           // 32: api.v1.service.auth.Passkey
+	(*BeginPasskeyRegistrationRequest)(nil),   // 33: api.v1.service.auth.BeginPasskeyRegistrationRequest
+	(*BeginPasskeyRegistrationResponse)(nil),  // 34: api.v1.service.auth.BeginPasskeyRegistrationResponse
+	(*FinishPasskeyRegistrationRequest)(nil),  // 35: api.v1.service.auth.FinishPasskeyRegistrationRequest
+	(*FinishPasskeyRegistrationResponse)(nil), // 36: api.v1.service.auth.FinishPasskeyRegistrationResponse
+	(*BeginPasskeyLoginRequest)(nil),          // 37: api.v1.service.auth.BeginPasskeyLoginRequest
+	(*BeginPasskeyLoginResponse)(nil),         // 38: api.v1.service.auth.BeginPasskeyLoginResponse
+	(*ListPasskeysRequest)(nil),               // 39: api.v1.service.auth.ListPasskeysRequest
+	(*ListPasskeysResponse)(nil),              // 40: api.v1.service.auth.ListPasskeysResponse
+	(*DeletePasskeyRequest)(nil),              // 41: api.v1.service.auth.DeletePasskeyRequest
+	(*DeletePasskeyResponse)(nil),             // 42: api.v1.service.auth.DeletePasskeyResponse
+	(*GrantRoleRequest)(nil),                  // 43: api.v1.service.auth.GrantRoleRequest
+	(*GrantRoleResponse)(nil),                 // 44: api.v1.service.auth.GrantRoleResponse
+	(*RevokeRoleRequest)(nil),                 // 45: api.v1.service.auth.RevokeRoleRequest
+	(*RevokeRoleResponse)(nil),                // 46: api.v1.service.auth.RevokeRoleResponse
+	(*entities.User)(nil),                     // 47: api.v1.entities.User
 }
 var file_v1_service_auth_proto_depIdxs = []int32{
-	8, // 0: api.v1.service.auth.AuthenticateResponse.user:type_name -> api.v1.entities.User
-	0, // 1: api.v1.service.auth.AuthService.CheckUsername:input_type -> api.v1.service.auth.CheckUsernameRequest
-	2, // 2: api.v1.service.auth.AuthService.InitAnonymous:input_type -> api.v1.service.auth.InitAnonymousRequest
-	4, // 3: api.v1.service.auth.AuthService.Authenticate:input_type -> api.v1.service.auth.AuthenticateRequest
-	6, // 4: api.v1.service.auth.AuthService.RefreshToken:input_type -> api.v1.service.auth.RefreshTokenRequest
-	1, // 5: api.v1.service.auth.AuthService.CheckUsername:output_type -> api.v1.service.auth.CheckUsernameResponse
-	3, // 6: api.v1.service.auth.AuthService.InitAnonymous:output_type -> api.v1.service.auth.InitAnonymousResponse
-	5, // 7: api.v1.service.auth.AuthService.Authenticate:output_type -> api.v1.service.auth.AuthenticateResponse
-	7, // 8: api.v1.service.auth.AuthService.RefreshToken:output_type -> api.v1.service.auth.RefreshTokenResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: api.v1.service.auth.AuthenticateRequest.username_resolution:type_name -> api.v1.service.auth.UsernameResolution
+	47, // 1: api.v1.service.auth.AuthenticateResponse.user:type_name -> api.v1.entities.User
+	9,  // 2: api.v1.service.auth.LinkProviderResponse.provider:type_name -> api.v1.service.auth.LinkedProvider
+	9,  // 3: api.v1.service.auth.ListLinkedProvidersResponse.providers:type_name -> api.v1.service.auth.LinkedProvider
+	47, // 4: api.v1.service.auth.VerifyEmailResponse.user:type_name -> api.v1.entities.User
+	47, // 5: api.v1.service.auth.CompleteMFAResponse.user:type_name -> api.v1.entities.User
+	32, // 6: api.v1.service.auth.FinishPasskeyRegistrationResponse.passkey:type_name -> api.v1.service.auth.Passkey
+	32, // 7: api.v1.service.auth.ListPasskeysResponse.passkeys:type_name -> api.v1.service.auth.Passkey
+	1,  // 8: api.v1.service.auth.AuthService.CheckUsername:input_type -> api.v1.service.auth.CheckUsernameRequest
+	3,  // 9: api.v1.service.auth.AuthService.InitAnonymous:input_type -> api.v1.service.auth.InitAnonymousRequest
+	5,  // 10: api.v1.service.auth.AuthService.Authenticate:input_type -> api.v1.service.auth.AuthenticateRequest
+	7,  // 11: api.v1.service.auth.AuthService.RefreshToken:input_type -> api.v1.service.auth.RefreshTokenRequest
+	10, // 12: api.v1.service.auth.AuthService.LinkProvider:input_type -> api.v1.service.auth.LinkProviderRequest
+	12, // 13: api.v1.service.auth.AuthService.UnlinkProvider:input_type -> api.v1.service.auth.UnlinkProviderRequest
+	14, // 14: api.v1.service.auth.AuthService.ListLinkedProviders:input_type -> api.v1.service.auth.ListLinkedProvidersRequest
+	16, // 15: api.v1.service.auth.AuthService.SendVerificationEmail:input_type -> api.v1.service.auth.SendVerificationEmailRequest
+	18, // 16: api.v1.service.auth.AuthService.VerifyEmail:input_type -> api.v1.service.auth.VerifyEmailRequest
+	20, // 17: api.v1.service.auth.AuthService.RequestPasswordReset:input_type -> api.v1.service.auth.RequestPasswordResetRequest
+	22, // 18: api.v1.service.auth.AuthService.ResetPassword:input_type -> api.v1.service.auth.ResetPasswordRequest
+	24, // 19: api.v1.service.auth.AuthService.EnrollTOTP:input_type -> api.v1.service.auth.EnrollTOTPRequest
+	26, // 20: api.v1.service.auth.AuthService.ConfirmTOTP:input_type -> api.v1.service.auth.ConfirmTOTPRequest
+	28, // 21: api.v1.service.auth.AuthService.DisableTOTP:input_type -> api.v1.service.auth.DisableTOTPRequest
+	30, // 22: api.v1.service.auth.AuthService.CompleteMFA:input_type -> api.v1.service.auth.CompleteMFARequest
+	33, // 23: api.v1.service.auth.AuthService.BeginPasskeyRegistration:input_type -> api.v1.service.auth.BeginPasskeyRegistrationRequest
+	35, // 24: api.v1.service.auth.AuthService.FinishPasskeyRegistration:input_type -> api.v1.service.auth.FinishPasskeyRegistrationRequest
+	37, // 25: api.v1.service.auth.AuthService.BeginPasskeyLogin:input_type -> api.v1.service.auth.BeginPasskeyLoginRequest
+	39, // 26: api.v1.service.auth.AuthService.ListPasskeys:input_type -> api.v1.service.auth.ListPasskeysRequest
+	41, // 27: api.v1.service.auth.AuthService.DeletePasskey:input_type -> api.v1.service.auth.DeletePasskeyRequest
+	43, // 28: api.v1.service.auth.AuthService.GrantRole:input_type -> api.v1.service.auth.GrantRoleRequest
+	45, // 29: api.v1.service.auth.AuthService.RevokeRole:input_type -> api.v1.service.auth.RevokeRoleRequest
+	2,  // 30: api.v1.service.auth.AuthService.CheckUsername:output_type -> api.v1.service.auth.CheckUsernameResponse
+	4,  // 31: api.v1.service.auth.AuthService.InitAnonymous:output_type -> api.v1.service.auth.InitAnonymousResponse
+	6,  // 32: api.v1.service.auth.AuthService.Authenticate:output_type -> api.v1.service.auth.AuthenticateResponse
+	8,  // 33: api.v1.service.auth.AuthService.RefreshToken:output_type -> api.v1.service.auth.RefreshTokenResponse
+	11, // 34: api.v1.service.auth.AuthService.LinkProvider:output_type -> api.v1.service.auth.LinkProviderResponse
+	13, // 35: api.v1.service.auth.AuthService.UnlinkProvider:output_type -> api.v1.service.auth.UnlinkProviderResponse
+	15, // 36: api.v1.service.auth.AuthService.ListLinkedProviders:output_type -> api.v1.service.auth.ListLinkedProvidersResponse
+	17, // 37: api.v1.service.auth.AuthService.SendVerificationEmail:output_type -> api.v1.service.auth.SendVerificationEmailResponse
+	19, // 38: api.v1.service.auth.AuthService.VerifyEmail:output_type -> api.v1.service.auth.VerifyEmailResponse
+	21, // 39: api.v1.service.auth.AuthService.RequestPasswordReset:output_type -> api.v1.service.auth.RequestPasswordResetResponse
+	23, // 40: api.v1.service.auth.AuthService.ResetPassword:output_type -> api.v1.service.auth.ResetPasswordResponse
+	25, // 41: api.v1.service.auth.AuthService.EnrollTOTP:output_type -> api.v1.service.auth.EnrollTOTPResponse
+	27, // 42: api.v1.service.auth.AuthService.ConfirmTOTP:output_type -> api.v1.service.auth.ConfirmTOTPResponse
+	29, // 43: api.v1.service.auth.AuthService.DisableTOTP:output_type -> api.v1.service.auth.DisableTOTPResponse
+	31, // 44: api.v1.service.auth.AuthService.CompleteMFA:output_type -> api.v1.service.auth.CompleteMFAResponse
+	34, // 45: api.v1.service.auth.AuthService.BeginPasskeyRegistration:output_type -> api.v1.service.auth.BeginPasskeyRegistrationResponse
+	36, // 46: api.v1.service.auth.AuthService.FinishPasskeyRegistration:output_type -> api.v1.service.auth.FinishPasskeyRegistrationResponse
+	38, // 47: api.v1.service.auth.AuthService.BeginPasskeyLogin:output_type -> api.v1.service.auth.BeginPasskeyLoginResponse
+	40, // 48: api.v1.service.auth.AuthService.ListPasskeys:output_type -> api.v1.service.auth.ListPasskeysResponse
+	42, // 49: api.v1.service.auth.AuthService.DeletePasskey:output_type -> api.v1.service.auth.DeletePasskeyResponse
+	44, // 50: api.v1.service.auth.AuthService.GrantRole:output_type -> api.v1.service.auth.GrantRoleResponse
+	46, // 51: api.v1.service.auth.AuthService.RevokeRole:output_type -> api.v1.service.auth.RevokeRoleResponse
+	30, // [30:52] is the sub-list for method output_type
+	8,  // [8:30] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_v1_service_auth_proto_init() }
@@ -524,19 +2587,23 @@ func file_v1_service_auth_proto_init() {
 	if File_v1_service_auth_proto != nil {
 		return
 	}
+	file_v1_service_auth_proto_msgTypes[2].OneofWrappers = []any{}
 	file_v1_service_auth_proto_msgTypes[4].OneofWrappers = []any{}
+	file_v1_service_auth_proto_msgTypes[8].OneofWrappers = []any{}
+	file_v1_service_auth_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_service_auth_proto_rawDesc), len(file_v1_service_auth_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      1,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_v1_service_auth_proto_goTypes,
 		DependencyIndexes: file_v1_service_auth_proto_depIdxs,
+		EnumInfos:         file_v1_service_auth_proto_enumTypes,
 		MessageInfos:      file_v1_service_auth_proto_msgTypes,
 	}.Build()
 	File_v1_service_auth_proto = out.File
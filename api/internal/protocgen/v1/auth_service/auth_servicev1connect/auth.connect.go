@@ -45,6 +45,53 @@ const (
 	// AuthServiceRefreshTokenProcedure is the fully-qualified name of the AuthService's RefreshToken
 	// RPC.
 	AuthServiceRefreshTokenProcedure = "/api.v1.service.auth.AuthService/RefreshToken"
+	// AuthServiceLinkProviderProcedure is the fully-qualified name of the AuthService's LinkProvider
+	// RPC.
+	AuthServiceLinkProviderProcedure = "/api.v1.service.auth.AuthService/LinkProvider"
+	// AuthServiceUnlinkProviderProcedure is the fully-qualified name of the AuthService's
+	// UnlinkProvider RPC.
+	AuthServiceUnlinkProviderProcedure = "/api.v1.service.auth.AuthService/UnlinkProvider"
+	// AuthServiceListLinkedProvidersProcedure is the fully-qualified name of the AuthService's
+	// ListLinkedProviders RPC.
+	AuthServiceListLinkedProvidersProcedure = "/api.v1.service.auth.AuthService/ListLinkedProviders"
+	// AuthServiceSendVerificationEmailProcedure is the fully-qualified name of the AuthService's
+	// SendVerificationEmail RPC.
+	AuthServiceSendVerificationEmailProcedure = "/api.v1.service.auth.AuthService/SendVerificationEmail"
+	// AuthServiceVerifyEmailProcedure is the fully-qualified name of the AuthService's VerifyEmail RPC.
+	AuthServiceVerifyEmailProcedure = "/api.v1.service.auth.AuthService/VerifyEmail"
+	// AuthServiceRequestPasswordResetProcedure is the fully-qualified name of the AuthService's
+	// RequestPasswordReset RPC.
+	AuthServiceRequestPasswordResetProcedure = "/api.v1.service.auth.AuthService/RequestPasswordReset"
+	// AuthServiceResetPasswordProcedure is the fully-qualified name of the AuthService's ResetPassword
+	// RPC.
+	AuthServiceResetPasswordProcedure = "/api.v1.service.auth.AuthService/ResetPassword"
+	// AuthServiceEnrollTOTPProcedure is the fully-qualified name of the AuthService's EnrollTOTP RPC.
+	AuthServiceEnrollTOTPProcedure = "/api.v1.service.auth.AuthService/EnrollTOTP"
+	// AuthServiceConfirmTOTPProcedure is the fully-qualified name of the AuthService's ConfirmTOTP RPC.
+	AuthServiceConfirmTOTPProcedure = "/api.v1.service.auth.AuthService/ConfirmTOTP"
+	// AuthServiceDisableTOTPProcedure is the fully-qualified name of the AuthService's DisableTOTP RPC.
+	AuthServiceDisableTOTPProcedure = "/api.v1.service.auth.AuthService/DisableTOTP"
+	// AuthServiceCompleteMFAProcedure is the fully-qualified name of the AuthService's CompleteMFA RPC.
+	AuthServiceCompleteMFAProcedure = "/api.v1.service.auth.AuthService/CompleteMFA"
+	// AuthServiceBeginPasskeyRegistrationProcedure is the fully-qualified name of the AuthService's
+	// BeginPasskeyRegistration RPC.
+	AuthServiceBeginPasskeyRegistrationProcedure = "/api.v1.service.auth.AuthService/BeginPasskeyRegistration"
+	// AuthServiceFinishPasskeyRegistrationProcedure is the fully-qualified name of the AuthService's
+	// FinishPasskeyRegistration RPC.
+	AuthServiceFinishPasskeyRegistrationProcedure = "/api.v1.service.auth.AuthService/FinishPasskeyRegistration"
+	// AuthServiceBeginPasskeyLoginProcedure is the fully-qualified name of the AuthService's
+	// BeginPasskeyLogin RPC.
+	AuthServiceBeginPasskeyLoginProcedure = "/api.v1.service.auth.AuthService/BeginPasskeyLogin"
+	// AuthServiceListPasskeysProcedure is the fully-qualified name of the AuthService's ListPasskeys
+	// RPC.
+	AuthServiceListPasskeysProcedure = "/api.v1.service.auth.AuthService/ListPasskeys"
+	// AuthServiceDeletePasskeyProcedure is the fully-qualified name of the AuthService's DeletePasskey
+	// RPC.
+	AuthServiceDeletePasskeyProcedure = "/api.v1.service.auth.AuthService/DeletePasskey"
+	// AuthServiceGrantRoleProcedure is the fully-qualified name of the AuthService's GrantRole RPC.
+	AuthServiceGrantRoleProcedure = "/api.v1.service.auth.AuthService/GrantRole"
+	// AuthServiceRevokeRoleProcedure is the fully-qualified name of the AuthService's RevokeRole RPC.
+	AuthServiceRevokeRoleProcedure = "/api.v1.service.auth.AuthService/RevokeRole"
 )
 
 // AuthServiceClient is a client for the api.v1.service.auth.AuthService service.
@@ -57,6 +104,42 @@ type AuthServiceClient interface {
 	Authenticate(context.Context, *connect.Request[auth_service.AuthenticateRequest]) (*connect.Response[auth_service.AuthenticateResponse], error)
 	// Refresh authenticated tokens (anonymous tokens never expire)
 	RefreshToken(context.Context, *connect.Request[auth_service.RefreshTokenRequest]) (*connect.Response[auth_service.RefreshTokenResponse], error)
+	// Link another login provider to the authenticated user
+	LinkProvider(context.Context, *connect.Request[auth_service.LinkProviderRequest]) (*connect.Response[auth_service.LinkProviderResponse], error)
+	// Unlink a login provider (the last remaining one cannot be removed)
+	UnlinkProvider(context.Context, *connect.Request[auth_service.UnlinkProviderRequest]) (*connect.Response[auth_service.UnlinkProviderResponse], error)
+	// List login providers linked to the authenticated user
+	ListLinkedProviders(context.Context, *connect.Request[auth_service.ListLinkedProvidersRequest]) (*connect.Response[auth_service.ListLinkedProvidersResponse], error)
+	// Send an email verification link (always succeeds, to avoid revealing accounts)
+	SendVerificationEmail(context.Context, *connect.Request[auth_service.SendVerificationEmailRequest]) (*connect.Response[auth_service.SendVerificationEmailResponse], error)
+	// Verify an email address with the token from a verification link
+	VerifyEmail(context.Context, *connect.Request[auth_service.VerifyEmailRequest]) (*connect.Response[auth_service.VerifyEmailResponse], error)
+	// Send a password reset link (always succeeds, to avoid revealing accounts)
+	RequestPasswordReset(context.Context, *connect.Request[auth_service.RequestPasswordResetRequest]) (*connect.Response[auth_service.RequestPasswordResetResponse], error)
+	// Set a new password with the token from a reset link; signs out all sessions
+	ResetPassword(context.Context, *connect.Request[auth_service.ResetPasswordRequest]) (*connect.Response[auth_service.ResetPasswordResponse], error)
+	// Start TOTP enrollment; returns a secret and otpauth URI for an authenticator app
+	EnrollTOTP(context.Context, *connect.Request[auth_service.EnrollTOTPRequest]) (*connect.Response[auth_service.EnrollTOTPResponse], error)
+	// Enable TOTP with the first code from the app; returns one-time recovery codes
+	ConfirmTOTP(context.Context, *connect.Request[auth_service.ConfirmTOTPRequest]) (*connect.Response[auth_service.ConfirmTOTPResponse], error)
+	// Turn off TOTP with a current code or recovery code
+	DisableTOTP(context.Context, *connect.Request[auth_service.DisableTOTPRequest]) (*connect.Response[auth_service.DisableTOTPResponse], error)
+	// Finish a sign-in that returned mfa_required with a TOTP or recovery code
+	CompleteMFA(context.Context, *connect.Request[auth_service.CompleteMFARequest]) (*connect.Response[auth_service.CompleteMFAResponse], error)
+	// Start registering a passkey for the authenticated user
+	BeginPasskeyRegistration(context.Context, *connect.Request[auth_service.BeginPasskeyRegistrationRequest]) (*connect.Response[auth_service.BeginPasskeyRegistrationResponse], error)
+	// Verify the authenticator's response and store the passkey
+	FinishPasskeyRegistration(context.Context, *connect.Request[auth_service.FinishPasskeyRegistrationRequest]) (*connect.Response[auth_service.FinishPasskeyRegistrationResponse], error)
+	// Start a passkey sign-in; finish with Authenticate(provider = "passkey")
+	BeginPasskeyLogin(context.Context, *connect.Request[auth_service.BeginPasskeyLoginRequest]) (*connect.Response[auth_service.BeginPasskeyLoginResponse], error)
+	// List the authenticated user's passkeys
+	ListPasskeys(context.Context, *connect.Request[auth_service.ListPasskeysRequest]) (*connect.Response[auth_service.ListPasskeysResponse], error)
+	// Remove one of the authenticated user's passkeys
+	DeletePasskey(context.Context, *connect.Request[auth_service.DeletePasskeyRequest]) (*connect.Response[auth_service.DeletePasskeyResponse], error)
+	// Give a user an elevated role (admin only; moderators and admins need 2FA)
+	GrantRole(context.Context, *connect.Request[auth_service.GrantRoleRequest]) (*connect.Response[auth_service.GrantRoleResponse], error)
+	// Remove an elevated role from a user (admin only)
+	RevokeRole(context.Context, *connect.Request[auth_service.RevokeRoleRequest]) (*connect.Response[auth_service.RevokeRoleResponse], error)
 }
 
 // NewAuthServiceClient constructs a client for the api.v1.service.auth.AuthService service. By
@@ -94,15 +177,141 @@ func NewAuthServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(authServiceMethods.ByName("RefreshToken")),
 			connect.WithClientOptions(opts...),
 		),
+		linkProvider: connect.NewClient[auth_service.LinkProviderRequest, auth_service.LinkProviderResponse](
+			httpClient,
+			baseURL+AuthServiceLinkProviderProcedure,
+			connect.WithSchema(authServiceMethods.ByName("LinkProvider")),
+			connect.WithClientOptions(opts...),
+		),
+		unlinkProvider: connect.NewClient[auth_service.UnlinkProviderRequest, auth_service.UnlinkProviderResponse](
+			httpClient,
+			baseURL+AuthServiceUnlinkProviderProcedure,
+			connect.WithSchema(authServiceMethods.ByName("UnlinkProvider")),
+			connect.WithClientOptions(opts...),
+		),
+		listLinkedProviders: connect.NewClient[auth_service.ListLinkedProvidersRequest, auth_service.ListLinkedProvidersResponse](
+			httpClient,
+			baseURL+AuthServiceListLinkedProvidersProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListLinkedProviders")),
+			connect.WithClientOptions(opts...),
+		),
+		sendVerificationEmail: connect.NewClient[auth_service.SendVerificationEmailRequest, auth_service.SendVerificationEmailResponse](
+			httpClient,
+			baseURL+AuthServiceSendVerificationEmailProcedure,
+			connect.WithSchema(authServiceMethods.ByName("SendVerificationEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyEmail: connect.NewClient[auth_service.VerifyEmailRequest, auth_service.VerifyEmailResponse](
+			httpClient,
+			baseURL+AuthServiceVerifyEmailProcedure,
+			connect.WithSchema(authServiceMethods.ByName("VerifyEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		requestPasswordReset: connect.NewClient[auth_service.RequestPasswordResetRequest, auth_service.RequestPasswordResetResponse](
+			httpClient,
+			baseURL+AuthServiceRequestPasswordResetProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RequestPasswordReset")),
+			connect.WithClientOptions(opts...),
+		),
+		resetPassword: connect.NewClient[auth_service.ResetPasswordRequest, auth_service.ResetPasswordResponse](
+			httpClient,
+			baseURL+AuthServiceResetPasswordProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ResetPassword")),
+			connect.WithClientOptions(opts...),
+		),
+		enrollTOTP: connect.NewClient[auth_service.EnrollTOTPRequest, auth_service.EnrollTOTPResponse](
+			httpClient,
+			baseURL+AuthServiceEnrollTOTPProcedure,
+			connect.WithSchema(authServiceMethods.ByName("EnrollTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		confirmTOTP: connect.NewClient[auth_service.ConfirmTOTPRequest, auth_service.ConfirmTOTPResponse](
+			httpClient,
+			baseURL+AuthServiceConfirmTOTPProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ConfirmTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		disableTOTP: connect.NewClient[auth_service.DisableTOTPRequest, auth_service.DisableTOTPResponse](
+			httpClient,
+			baseURL+AuthServiceDisableTOTPProcedure,
+			connect.WithSchema(authServiceMethods.ByName("DisableTOTP")),
+			connect.WithClientOptions(opts...),
+		),
+		completeMFA: connect.NewClient[auth_service.CompleteMFARequest, auth_service.CompleteMFAResponse](
+			httpClient,
+			baseURL+AuthServiceCompleteMFAProcedure,
+			connect.WithSchema(authServiceMethods.ByName("CompleteMFA")),
+			connect.WithClientOptions(opts...),
+		),
+		beginPasskeyRegistration: connect.NewClient[auth_service.BeginPasskeyRegistrationRequest, auth_service.BeginPasskeyRegistrationResponse](
+			httpClient,
+			baseURL+AuthServiceBeginPasskeyRegistrationProcedure,
+			connect.WithSchema(authServiceMethods.ByName("BeginPasskeyRegistration")),
+			connect.WithClientOptions(opts...),
+		),
+		finishPasskeyRegistration: connect.NewClient[auth_service.FinishPasskeyRegistrationRequest, auth_service.FinishPasskeyRegistrationResponse](
+			httpClient,
+			baseURL+AuthServiceFinishPasskeyRegistrationProcedure,
+			connect.WithSchema(authServiceMethods.ByName("FinishPasskeyRegistration")),
+			connect.WithClientOptions(opts...),
+		),
+		beginPasskeyLogin: connect.NewClient[auth_service.BeginPasskeyLoginRequest, auth_service.BeginPasskeyLoginResponse](
+			httpClient,
+			baseURL+AuthServiceBeginPasskeyLoginProcedure,
+			connect.WithSchema(authServiceMethods.ByName("BeginPasskeyLogin")),
+			connect.WithClientOptions(opts...),
+		),
+		listPasskeys: connect.NewClient[auth_service.ListPasskeysRequest, auth_service.ListPasskeysResponse](
+			httpClient,
+			baseURL+AuthServiceListPasskeysProcedure,
+			connect.WithSchema(authServiceMethods.ByName("ListPasskeys")),
+			connect.WithClientOptions(opts...),
+		),
+		deletePasskey: connect.NewClient[auth_service.DeletePasskeyRequest, auth_service.DeletePasskeyResponse](
+			httpClient,
+			baseURL+AuthServiceDeletePasskeyProcedure,
+			connect.WithSchema(authServiceMethods.ByName("DeletePasskey")),
+			connect.WithClientOptions(opts...),
+		),
+		grantRole: connect.NewClient[auth_service.GrantRoleRequest, auth_service.GrantRoleResponse](
+			httpClient,
+			baseURL+AuthServiceGrantRoleProcedure,
+			connect.WithSchema(authServiceMethods.ByName("GrantRole")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeRole: connect.NewClient[auth_service.RevokeRoleRequest, auth_service.RevokeRoleResponse](
+			httpClient,
+			baseURL+AuthServiceRevokeRoleProcedure,
+			connect.WithSchema(authServiceMethods.ByName("RevokeRole")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // authServiceClient implements AuthServiceClient.
 type authServiceClient struct {
-	checkUsername *connect.Client[auth_service.CheckUsernameRequest, auth_service.CheckUsernameResponse]
-	initAnonymous *connect.Client[auth_service.InitAnonymousRequest, auth_service.InitAnonymousResponse]
-	authenticate  *connect.Client[auth_service.AuthenticateRequest, auth_service.AuthenticateResponse]
-	refreshToken  *connect.Client[auth_service.RefreshTokenRequest, auth_service.RefreshTokenResponse]
+	checkUsername             *connect.Client[auth_service.CheckUsernameRequest, auth_service.CheckUsernameResponse]
+	initAnonymous             *connect.Client[auth_service.InitAnonymousRequest, auth_service.InitAnonymousResponse]
+	authenticate              *connect.Client[auth_service.AuthenticateRequest, auth_service.AuthenticateResponse]
+	refreshToken              *connect.Client[auth_service.RefreshTokenRequest, auth_service.RefreshTokenResponse]
+	linkProvider              *connect.Client[auth_service.LinkProviderRequest, auth_service.LinkProviderResponse]
+	unlinkProvider            *connect.Client[auth_service.UnlinkProviderRequest, auth_service.UnlinkProviderResponse]
+	listLinkedProviders       *connect.Client[auth_service.ListLinkedProvidersRequest, auth_service.ListLinkedProvidersResponse]
+	sendVerificationEmail     *connect.Client[auth_service.SendVerificationEmailRequest, auth_service.SendVerificationEmailResponse]
+	verifyEmail               *connect.Client[auth_service.VerifyEmailRequest, auth_service.VerifyEmailResponse]
+	requestPasswordReset      *connect.Client[auth_service.RequestPasswordResetRequest, auth_service.RequestPasswordResetResponse]
+	resetPassword             *connect.Client[auth_service.ResetPasswordRequest, auth_service.ResetPasswordResponse]
+	enrollTOTP                *connect.Client[auth_service.EnrollTOTPRequest, auth_service.EnrollTOTPResponse]
+	confirmTOTP               *connect.Client[auth_service.ConfirmTOTPRequest, auth_service.ConfirmTOTPResponse]
+	disableTOTP               *connect.Client[auth_service.DisableTOTPRequest, auth_service.DisableTOTPResponse]
+	completeMFA               *connect.Client[auth_service.CompleteMFARequest, auth_service.CompleteMFAResponse]
+	beginPasskeyRegistration  *connect.Client[auth_service.BeginPasskeyRegistrationRequest, auth_service.BeginPasskeyRegistrationResponse]
+	finishPasskeyRegistration *connect.Client[auth_service.FinishPasskeyRegistrationRequest, auth_service.FinishPasskeyRegistrationResponse]
+	beginPasskeyLogin         *connect.Client[auth_service.BeginPasskeyLoginRequest, auth_service.BeginPasskeyLoginResponse]
+	listPasskeys              *connect.Client[auth_service.ListPasskeysRequest, auth_service.ListPasskeysResponse]
+	deletePasskey             *connect.Client[auth_service.DeletePasskeyRequest, auth_service.DeletePasskeyResponse]
+	grantRole                 *connect.Client[auth_service.GrantRoleRequest, auth_service.GrantRoleResponse]
+	revokeRole                *connect.Client[auth_service.RevokeRoleRequest, auth_service.RevokeRoleResponse]
 }
 
 // CheckUsername calls api.v1.service.auth.AuthService.CheckUsername.
@@ -125,6 +334,96 @@ func (c *authServiceClient) RefreshToken(ctx context.Context, req *connect.Reque
 	return c.refreshToken.CallUnary(ctx, req)
 }
 
+// LinkProvider calls api.v1.service.auth.AuthService.LinkProvider.
+func (c *authServiceClient) LinkProvider(ctx context.Context, req *connect.Request[auth_service.LinkProviderRequest]) (*connect.Response[auth_service.LinkProviderResponse], error) {
+	return c.linkProvider.CallUnary(ctx, req)
+}
+
+// UnlinkProvider calls api.v1.service.auth.AuthService.UnlinkProvider.
+func (c *authServiceClient) UnlinkProvider(ctx context.Context, req *connect.Request[auth_service.UnlinkProviderRequest]) (*connect.Response[auth_service.UnlinkProviderResponse], error) {
+	return c.unlinkProvider.CallUnary(ctx, req)
+}
+
+// ListLinkedProviders calls api.v1.service.auth.AuthService.ListLinkedProviders.
+func (c *authServiceClient) ListLinkedProviders(ctx context.Context, req *connect.Request[auth_service.ListLinkedProvidersRequest]) (*connect.Response[auth_service.ListLinkedProvidersResponse], error) {
+	return c.listLinkedProviders.CallUnary(ctx, req)
+}
+
+// SendVerificationEmail calls api.v1.service.auth.AuthService.SendVerificationEmail.
+func (c *authServiceClient) SendVerificationEmail(ctx context.Context, req *connect.Request[auth_service.SendVerificationEmailRequest]) (*connect.Response[auth_service.SendVerificationEmailResponse], error) {
+	return c.sendVerificationEmail.CallUnary(ctx, req)
+}
+
+// VerifyEmail calls api.v1.service.auth.AuthService.VerifyEmail.
+func (c *authServiceClient) VerifyEmail(ctx context.Context, req *connect.Request[auth_service.VerifyEmailRequest]) (*connect.Response[auth_service.VerifyEmailResponse], error) {
+	return c.verifyEmail.CallUnary(ctx, req)
+}
+
+// RequestPasswordReset calls api.v1.service.auth.AuthService.RequestPasswordReset.
+func (c *authServiceClient) RequestPasswordReset(ctx context.Context, req *connect.Request[auth_service.RequestPasswordResetRequest]) (*connect.Response[auth_service.RequestPasswordResetResponse], error) {
+	return c.requestPasswordReset.CallUnary(ctx, req)
+}
+
+// ResetPassword calls api.v1.service.auth.AuthService.ResetPassword.
+func (c *authServiceClient) ResetPassword(ctx context.Context, req *connect.Request[auth_service.ResetPasswordRequest]) (*connect.Response[auth_service.ResetPasswordResponse], error) {
+	return c.resetPassword.CallUnary(ctx, req)
+}
+
+// EnrollTOTP calls api.v1.service.auth.AuthService.EnrollTOTP.
+func (c *authServiceClient) EnrollTOTP(ctx context.Context, req *connect.Request[auth_service.EnrollTOTPRequest]) (*connect.Response[auth_service.EnrollTOTPResponse], error) {
+	return c.enrollTOTP.CallUnary(ctx, req)
+}
+
+// ConfirmTOTP calls api.v1.service.auth.AuthService.ConfirmTOTP.
+func (c *authServiceClient) ConfirmTOTP(ctx context.Context, req *connect.Request[auth_service.ConfirmTOTPRequest]) (*connect.Response[auth_service.ConfirmTOTPResponse], error) {
+	return c.confirmTOTP.CallUnary(ctx, req)
+}
+
+// DisableTOTP calls api.v1.service.auth.AuthService.DisableTOTP.
+func (c *authServiceClient) DisableTOTP(ctx context.Context, req *connect.Request[auth_service.DisableTOTPRequest]) (*connect.Response[auth_service.DisableTOTPResponse], error) {
+	return c.disableTOTP.CallUnary(ctx, req)
+}
+
+// CompleteMFA calls api.v1.service.auth.AuthService.CompleteMFA.
+func (c *authServiceClient) CompleteMFA(ctx context.Context, req *connect.Request[auth_service.CompleteMFARequest]) (*connect.Response[auth_service.CompleteMFAResponse], error) {
+	return c.completeMFA.CallUnary(ctx, req)
+}
+
+// BeginPasskeyRegistration calls api.v1.service.auth.AuthService.BeginPasskeyRegistration.
+func (c *authServiceClient) BeginPasskeyRegistration(ctx context.Context, req *connect.Request[auth_service.BeginPasskeyRegistrationRequest]) (*connect.Response[auth_service.BeginPasskeyRegistrationResponse], error) {
+	return c.beginPasskeyRegistration.CallUnary(ctx, req)
+}
+
+// FinishPasskeyRegistration calls api.v1.service.auth.AuthService.FinishPasskeyRegistration.
+func (c *authServiceClient) FinishPasskeyRegistration(ctx context.Context, req *connect.Request[auth_service.FinishPasskeyRegistrationRequest]) (*connect.Response[auth_service.FinishPasskeyRegistrationResponse], error) {
+	return c.finishPasskeyRegistration.CallUnary(ctx, req)
+}
+
+// BeginPasskeyLogin calls api.v1.service.auth.AuthService.BeginPasskeyLogin.
+func (c *authServiceClient) BeginPasskeyLogin(ctx context.Context, req *connect.Request[auth_service.BeginPasskeyLoginRequest]) (*connect.Response[auth_service.BeginPasskeyLoginResponse], error) {
+	return c.beginPasskeyLogin.CallUnary(ctx, req)
+}
+
+// ListPasskeys calls api.v1.service.auth.AuthService.ListPasskeys.
+func (c *authServiceClient) ListPasskeys(ctx context.Context, req *connect.Request[auth_service.ListPasskeysRequest]) (*connect.Response[auth_service.ListPasskeysResponse], error) {
+	return c.listPasskeys.CallUnary(ctx, req)
+}
+
+// DeletePasskey calls api.v1.service.auth.AuthService.DeletePasskey.
+func (c *authServiceClient) DeletePasskey(ctx context.Context, req *connect.Request[auth_service.DeletePasskeyRequest]) (*connect.Response[auth_service.DeletePasskeyResponse], error) {
+	return c.deletePasskey.CallUnary(ctx, req)
+}
+
+// GrantRole calls api.v1.service.auth.AuthService.GrantRole.
+func (c *authServiceClient) GrantRole(ctx context.Context, req *connect.Request[auth_service.GrantRoleRequest]) (*connect.Response[auth_service.GrantRoleResponse], error) {
+	return c.grantRole.CallUnary(ctx, req)
+}
+
+// RevokeRole calls api.v1.service.auth.AuthService.RevokeRole.
+func (c *authServiceClient) RevokeRole(ctx context.Context, req *connect.Request[auth_service.RevokeRoleRequest]) (*connect.Response[auth_service.RevokeRoleResponse], error) {
+	return c.revokeRole.CallUnary(ctx, req)
+}
+
 // AuthServiceHandler is an implementation of the api.v1.service.auth.AuthService service.
 type AuthServiceHandler interface {
 	// Check if username is available
@@ -135,6 +434,42 @@ type AuthServiceHandler interface {
 	Authenticate(context.Context, *connect.Request[auth_service.AuthenticateRequest]) (*connect.Response[auth_service.AuthenticateResponse], error)
 	// Refresh authenticated tokens (anonymous tokens never expire)
 	RefreshToken(context.Context, *connect.Request[auth_service.RefreshTokenRequest]) (*connect.Response[auth_service.RefreshTokenResponse], error)
+	// Link another login provider to the authenticated user
+	LinkProvider(context.Context, *connect.Request[auth_service.LinkProviderRequest]) (*connect.Response[auth_service.LinkProviderResponse], error)
+	// Unlink a login provider (the last remaining one cannot be removed)
+	UnlinkProvider(context.Context, *connect.Request[auth_service.UnlinkProviderRequest]) (*connect.Response[auth_service.UnlinkProviderResponse], error)
+	// List login providers linked to the authenticated user
+	ListLinkedProviders(context.Context, *connect.Request[auth_service.ListLinkedProvidersRequest]) (*connect.Response[auth_service.ListLinkedProvidersResponse], error)
+	// Send an email verification link (always succeeds, to avoid revealing accounts)
+	SendVerificationEmail(context.Context, *connect.Request[auth_service.SendVerificationEmailRequest]) (*connect.Response[auth_service.SendVerificationEmailResponse], error)
+	// Verify an email address with the token from a verification link
+	VerifyEmail(context.Context, *connect.Request[auth_service.VerifyEmailRequest]) (*connect.Response[auth_service.VerifyEmailResponse], error)
+	// Send a password reset link (always succeeds, to avoid revealing accounts)
+	RequestPasswordReset(context.Context, *connect.Request[auth_service.RequestPasswordResetRequest]) (*connect.Response[auth_service.RequestPasswordResetResponse], error)
+	// Set a new password with the token from a reset link; signs out all sessions
+	ResetPassword(context.Context, *connect.Request[auth_service.ResetPasswordRequest]) (*connect.Response[auth_service.ResetPasswordResponse], error)
+	// Start TOTP enrollment; returns a secret and otpauth URI for an authenticator app
+	EnrollTOTP(context.Context, *connect.Request[auth_service.EnrollTOTPRequest]) (*connect.Response[auth_service.EnrollTOTPResponse], error)
+	// Enable TOTP with the first code from the app; returns one-time recovery codes
+	ConfirmTOTP(context.Context, *connect.Request[auth_service.ConfirmTOTPRequest]) (*connect.Response[auth_service.ConfirmTOTPResponse], error)
+	// Turn off TOTP with a current code or recovery code
+	DisableTOTP(context.Context, *connect.Request[auth_service.DisableTOTPRequest]) (*connect.Response[auth_service.DisableTOTPResponse], error)
+	// Finish a sign-in that returned mfa_required with a TOTP or recovery code
+	CompleteMFA(context.Context, *connect.Request[auth_service.CompleteMFARequest]) (*connect.Response[auth_service.CompleteMFAResponse], error)
+	// Start registering a passkey for the authenticated user
+	BeginPasskeyRegistration(context.Context, *connect.Request[auth_service.BeginPasskeyRegistrationRequest]) (*connect.Response[auth_service.BeginPasskeyRegistrationResponse], error)
+	// Verify the authenticator's response and store the passkey
+	FinishPasskeyRegistration(context.Context, *connect.Request[auth_service.FinishPasskeyRegistrationRequest]) (*connect.Response[auth_service.FinishPasskeyRegistrationResponse], error)
+	// Start a passkey sign-in; finish with Authenticate(provider = "passkey")
+	BeginPasskeyLogin(context.Context, *connect.Request[auth_service.BeginPasskeyLoginRequest]) (*connect.Response[auth_service.BeginPasskeyLoginResponse], error)
+	// List the authenticated user's passkeys
+	ListPasskeys(context.Context, *connect.Request[auth_service.ListPasskeysRequest]) (*connect.Response[auth_service.ListPasskeysResponse], error)
+	// Remove one of the authenticated user's passkeys
+	DeletePasskey(context.Context, *connect.Request[auth_service.DeletePasskeyRequest]) (*connect.Response[auth_service.DeletePasskeyResponse], error)
+	// Give a user an elevated role (admin only; moderators and admins need 2FA)
+	GrantRole(context.Context, *connect.Request[auth_service.GrantRoleRequest]) (*connect.Response[auth_service.GrantRoleResponse], error)
+	// Remove an elevated role from a user (admin only)
+	RevokeRole(context.Context, *connect.Request[auth_service.RevokeRoleRequest]) (*connect.Response[auth_service.RevokeRoleResponse], error)
 }
 
 // NewAuthServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -168,6 +503,114 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(authServiceMethods.ByName("RefreshToken")),
 		connect.WithHandlerOptions(opts...),
 	)
+	authServiceLinkProviderHandler := connect.NewUnaryHandler(
+		AuthServiceLinkProviderProcedure,
+		svc.LinkProvider,
+		connect.WithSchema(authServiceMethods.ByName("LinkProvider")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceUnlinkProviderHandler := connect.NewUnaryHandler(
+		AuthServiceUnlinkProviderProcedure,
+		svc.UnlinkProvider,
+		connect.WithSchema(authServiceMethods.ByName("UnlinkProvider")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListLinkedProvidersHandler := connect.NewUnaryHandler(
+		AuthServiceListLinkedProvidersProcedure,
+		svc.ListLinkedProviders,
+		connect.WithSchema(authServiceMethods.ByName("ListLinkedProviders")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceSendVerificationEmailHandler := connect.NewUnaryHandler(
+		AuthServiceSendVerificationEmailProcedure,
+		svc.SendVerificationEmail,
+		connect.WithSchema(authServiceMethods.ByName("SendVerificationEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceVerifyEmailHandler := connect.NewUnaryHandler(
+		AuthServiceVerifyEmailProcedure,
+		svc.VerifyEmail,
+		connect.WithSchema(authServiceMethods.ByName("VerifyEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRequestPasswordResetHandler := connect.NewUnaryHandler(
+		AuthServiceRequestPasswordResetProcedure,
+		svc.RequestPasswordReset,
+		connect.WithSchema(authServiceMethods.ByName("RequestPasswordReset")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceResetPasswordHandler := connect.NewUnaryHandler(
+		AuthServiceResetPasswordProcedure,
+		svc.ResetPassword,
+		connect.WithSchema(authServiceMethods.ByName("ResetPassword")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceEnrollTOTPHandler := connect.NewUnaryHandler(
+		AuthServiceEnrollTOTPProcedure,
+		svc.EnrollTOTP,
+		connect.WithSchema(authServiceMethods.ByName("EnrollTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceConfirmTOTPHandler := connect.NewUnaryHandler(
+		AuthServiceConfirmTOTPProcedure,
+		svc.ConfirmTOTP,
+		connect.WithSchema(authServiceMethods.ByName("ConfirmTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceDisableTOTPHandler := connect.NewUnaryHandler(
+		AuthServiceDisableTOTPProcedure,
+		svc.DisableTOTP,
+		connect.WithSchema(authServiceMethods.ByName("DisableTOTP")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceCompleteMFAHandler := connect.NewUnaryHandler(
+		AuthServiceCompleteMFAProcedure,
+		svc.CompleteMFA,
+		connect.WithSchema(authServiceMethods.ByName("CompleteMFA")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceBeginPasskeyRegistrationHandler := connect.NewUnaryHandler(
+		AuthServiceBeginPasskeyRegistrationProcedure,
+		svc.BeginPasskeyRegistration,
+		connect.WithSchema(authServiceMethods.ByName("BeginPasskeyRegistration")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceFinishPasskeyRegistrationHandler := connect.NewUnaryHandler(
+		AuthServiceFinishPasskeyRegistrationProcedure,
+		svc.FinishPasskeyRegistration,
+		connect.WithSchema(authServiceMethods.ByName("FinishPasskeyRegistration")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceBeginPasskeyLoginHandler := connect.NewUnaryHandler(
+		AuthServiceBeginPasskeyLoginProcedure,
+		svc.BeginPasskeyLogin,
+		connect.WithSchema(authServiceMethods.ByName("BeginPasskeyLogin")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceListPasskeysHandler := connect.NewUnaryHandler(
+		AuthServiceListPasskeysProcedure,
+		svc.ListPasskeys,
+		connect.WithSchema(authServiceMethods.ByName("ListPasskeys")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceDeletePasskeyHandler := connect.NewUnaryHandler(
+		AuthServiceDeletePasskeyProcedure,
+		svc.DeletePasskey,
+		connect.WithSchema(authServiceMethods.ByName("DeletePasskey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceGrantRoleHandler := connect.NewUnaryHandler(
+		AuthServiceGrantRoleProcedure,
+		svc.GrantRole,
+		connect.WithSchema(authServiceMethods.ByName("GrantRole")),
+		connect.WithHandlerOptions(opts...),
+	)
+	authServiceRevokeRoleHandler := connect.NewUnaryHandler(
+		AuthServiceRevokeRoleProcedure,
+		svc.RevokeRole,
+		connect.WithSchema(authServiceMethods.ByName("RevokeRole")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.service.auth.AuthService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AuthServiceCheckUsernameProcedure:
@@ -178,6 +621,42 @@ func NewAuthServiceHandler(svc AuthServiceHandler, opts ...connect.HandlerOption
 			authServiceAuthenticateHandler.ServeHTTP(w, r)
 		case AuthServiceRefreshTokenProcedure:
 			authServiceRefreshTokenHandler.ServeHTTP(w, r)
+		case AuthServiceLinkProviderProcedure:
+			authServiceLinkProviderHandler.ServeHTTP(w, r)
+		case AuthServiceUnlinkProviderProcedure:
+			authServiceUnlinkProviderHandler.ServeHTTP(w, r)
+		case AuthServiceListLinkedProvidersProcedure:
+			authServiceListLinkedProvidersHandler.ServeHTTP(w, r)
+		case AuthServiceSendVerificationEmailProcedure:
+			authServiceSendVerificationEmailHandler.ServeHTTP(w, r)
+		case AuthServiceVerifyEmailProcedure:
+			authServiceVerifyEmailHandler.ServeHTTP(w, r)
+		case AuthServiceRequestPasswordResetProcedure:
+			authServiceRequestPasswordResetHandler.ServeHTTP(w, r)
+		case AuthServiceResetPasswordProcedure:
+			authServiceResetPasswordHandler.ServeHTTP(w, r)
+		case AuthServiceEnrollTOTPProcedure:
+			authServiceEnrollTOTPHandler.ServeHTTP(w, r)
+		case AuthServiceConfirmTOTPProcedure:
+			authServiceConfirmTOTPHandler.ServeHTTP(w, r)
+		case AuthServiceDisableTOTPProcedure:
+			authServiceDisableTOTPHandler.ServeHTTP(w, r)
+		case AuthServiceCompleteMFAProcedure:
+			authServiceCompleteMFAHandler.ServeHTTP(w, r)
+		case AuthServiceBeginPasskeyRegistrationProcedure:
+			authServiceBeginPasskeyRegistrationHandler.ServeHTTP(w, r)
+		case AuthServiceFinishPasskeyRegistrationProcedure:
+			authServiceFinishPasskeyRegistrationHandler.ServeHTTP(w, r)
+		case AuthServiceBeginPasskeyLoginProcedure:
+			authServiceBeginPasskeyLoginHandler.ServeHTTP(w, r)
+		case AuthServiceListPasskeysProcedure:
+			authServiceListPasskeysHandler.ServeHTTP(w, r)
+		case AuthServiceDeletePasskeyProcedure:
+			authServiceDeletePasskeyHandler.ServeHTTP(w, r)
+		case AuthServiceGrantRoleProcedure:
+			authServiceGrantRoleHandler.ServeHTTP(w, r)
+		case AuthServiceRevokeRoleProcedure:
+			authServiceRevokeRoleHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -202,3 +681,75 @@ func (UnimplementedAuthServiceHandler) Authenticate(context.Context, *connect.Re
 func (UnimplementedAuthServiceHandler) RefreshToken(context.Context, *connect.Request[auth_service.RefreshTokenRequest]) (*connect.Response[auth_service.RefreshTokenResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.RefreshToken is not implemented"))
 }
+
+func (UnimplementedAuthServiceHandler) LinkProvider(context.Context, *connect.Request[auth_service.LinkProviderRequest]) (*connect.Response[auth_service.LinkProviderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.LinkProvider is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) UnlinkProvider(context.Context, *connect.Request[auth_service.UnlinkProviderRequest]) (*connect.Response[auth_service.UnlinkProviderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.UnlinkProvider is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListLinkedProviders(context.Context, *connect.Request[auth_service.ListLinkedProvidersRequest]) (*connect.Response[auth_service.ListLinkedProvidersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.ListLinkedProviders is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) SendVerificationEmail(context.Context, *connect.Request[auth_service.SendVerificationEmailRequest]) (*connect.Response[auth_service.SendVerificationEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.SendVerificationEmail is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) VerifyEmail(context.Context, *connect.Request[auth_service.VerifyEmailRequest]) (*connect.Response[auth_service.VerifyEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.VerifyEmail is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RequestPasswordReset(context.Context, *connect.Request[auth_service.RequestPasswordResetRequest]) (*connect.Response[auth_service.RequestPasswordResetResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.RequestPasswordReset is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ResetPassword(context.Context, *connect.Request[auth_service.ResetPasswordRequest]) (*connect.Response[auth_service.ResetPasswordResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.ResetPassword is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) EnrollTOTP(context.Context, *connect.Request[auth_service.EnrollTOTPRequest]) (*connect.Response[auth_service.EnrollTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.EnrollTOTP is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ConfirmTOTP(context.Context, *connect.Request[auth_service.ConfirmTOTPRequest]) (*connect.Response[auth_service.ConfirmTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.ConfirmTOTP is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) DisableTOTP(context.Context, *connect.Request[auth_service.DisableTOTPRequest]) (*connect.Response[auth_service.DisableTOTPResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.DisableTOTP is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) CompleteMFA(context.Context, *connect.Request[auth_service.CompleteMFARequest]) (*connect.Response[auth_service.CompleteMFAResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.CompleteMFA is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) BeginPasskeyRegistration(context.Context, *connect.Request[auth_service.BeginPasskeyRegistrationRequest]) (*connect.Response[auth_service.BeginPasskeyRegistrationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.BeginPasskeyRegistration is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) FinishPasskeyRegistration(context.Context, *connect.Request[auth_service.FinishPasskeyRegistrationRequest]) (*connect.Response[auth_service.FinishPasskeyRegistrationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.FinishPasskeyRegistration is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) BeginPasskeyLogin(context.Context, *connect.Request[auth_service.BeginPasskeyLoginRequest]) (*connect.Response[auth_service.BeginPasskeyLoginResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.BeginPasskeyLogin is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) ListPasskeys(context.Context, *connect.Request[auth_service.ListPasskeysRequest]) (*connect.Response[auth_service.ListPasskeysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.ListPasskeys is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) DeletePasskey(context.Context, *connect.Request[auth_service.DeletePasskeyRequest]) (*connect.Response[auth_service.DeletePasskeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.DeletePasskey is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) GrantRole(context.Context, *connect.Request[auth_service.GrantRoleRequest]) (*connect.Response[auth_service.GrantRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.GrantRole is not implemented"))
+}
+
+func (UnimplementedAuthServiceHandler) RevokeRole(context.Context, *connect.Request[auth_service.RevokeRoleRequest]) (*connect.Response[auth_service.RevokeRoleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.auth.AuthService.RevokeRole is not implemented"))
+}
@@ -21,6 +21,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PinVisibility controls who can see a pin
+type PinVisibility int32
+
+const (
+	PinVisibility_PIN_VISIBILITY_UNSPECIFIED PinVisibility = 0 // Treated as public
+	PinVisibility_PIN_VISIBILITY_PUBLIC      PinVisibility = 1
+	PinVisibility_PIN_VISIBILITY_FOLLOWERS   PinVisibility = 2 // Only the author and their followers
+)
+
+// Enum value maps for PinVisibility.
+var (
+	PinVisibility_name = map[int32]string{
+		0: "PIN_VISIBILITY_UNSPECIFIED",
+		1: "PIN_VISIBILITY_PUBLIC",
+		2: "PIN_VISIBILITY_FOLLOWERS",
+	}
+	PinVisibility_value = map[string]int32{
+		"PIN_VISIBILITY_UNSPECIFIED": 0,
+		"PIN_VISIBILITY_PUBLIC":      1,
+		"PIN_VISIBILITY_FOLLOWERS":   2,
+	}
+)
+
+func (x PinVisibility) Enum() *PinVisibility {
+	p := new(PinVisibility)
+	*p = x
+	return p
+}
+
+func (x PinVisibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PinVisibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_entities_pin_proto_enumTypes[0].Descriptor()
+}
+
+func (PinVisibility) Type() protoreflect.EnumType {
+	return &file_v1_entities_pin_proto_enumTypes[0]
+}
+
+func (x PinVisibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PinVisibility.Descriptor instead.
+func (PinVisibility) EnumDescriptor() ([]byte, []int) {
+	return file_v1_entities_pin_proto_rawDescGZIP(), []int{0}
+}
+
 // Pin represents a pin on the map (composed from posts + posts_location)
 type Pin struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
@@ -31,8 +81,9 @@ type Pin struct {
 	CreatedAt int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
 	UpdatedAt int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix timestamp
 	// API-only fields (joined/computed from database)
-	Author        *User `protobuf:"bytes,7,opt,name=author,proto3,oneof" json:"author,omitempty"`                            // Joined from users table
-	CommentCount  int32 `protobuf:"varint,8,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"` // Computed from posts with parent_id
+	Author        *User         `protobuf:"bytes,7,opt,name=author,proto3,oneof" json:"author,omitempty"`                            // Joined from users table
+	CommentCount  int32         `protobuf:"varint,8,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"` // Computed from posts with parent_id
+	Visibility    PinVisibility `protobuf:"varint,9,opt,name=visibility,proto3,enum=api.v1.entities.PinVisibility" json:"visibility,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -123,6 +174,13 @@ func (x *Pin) GetCommentCount() int32 {
 	return 0
 }
 
+func (x *Pin) GetVisibility() PinVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return PinVisibility_PIN_VISIBILITY_UNSPECIFIED
+}
+
 // Location represents geographic coordinates
 type Location struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -281,7 +339,7 @@ var File_v1_entities_pin_proto protoreflect.FileDescriptor
 
 const file_v1_entities_pin_proto_rawDesc = "" +
 	"\n" +
-	"\x15v1/entities/pin.proto\x12\x0fapi.v1.entities\x1a\x16v1/entities/user.proto\"\xa1\x02\n" +
+	"\x15v1/entities/pin.proto\x12\x0fapi.v1.entities\x1a\x16v1/entities/user.proto\"\xe1\x02\n" +
 	"\x03Pin\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
@@ -292,7 +350,10 @@ const file_v1_entities_pin_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x122\n" +
 	"\x06author\x18\a \x01(\v2\x15.api.v1.entities.UserH\x00R\x06author\x88\x01\x01\x12#\n" +
-	"\rcomment_count\x18\b \x01(\x05R\fcommentCountB\t\n" +
+	"\rcomment_count\x18\b \x01(\x05R\fcommentCount\x12>\n" +
+	"\n" +
+	"visibility\x18\t \x01(\x0e2\x1e.api.v1.entities.PinVisibilityR\n" +
+	"visibilityB\t\n" +
 	"\a_author\"\x9d\x01\n" +
 	"\bLocation\x12\x1a\n" +
 	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
@@ -312,7 +373,11 @@ const file_v1_entities_pin_proto_rawDesc = "" +
 	"\x06author\x18\x06 \x01(\v2\x15.api.v1.entities.UserH\x01R\x06author\x88\x01\x01B\f\n" +
 	"\n" +
 	"_parent_idB\t\n" +
-	"\a_authorBOZMgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/entities;entitiesv1b\x06proto3"
+	"\a_author*h\n" +
+	"\rPinVisibility\x12\x1e\n" +
+	"\x1aPIN_VISIBILITY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15PIN_VISIBILITY_PUBLIC\x10\x01\x12\x1c\n" +
+	"\x18PIN_VISIBILITY_FOLLOWERS\x10\x02BOZMgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/entities;entitiesv1b\x06proto3"
 
 var (
 	file_v1_entities_pin_proto_rawDescOnce sync.Once
@@ -326,22 +391,25 @@ func file_v1_entities_pin_proto_rawDescGZIP() []byte {
 	return file_v1_entities_pin_proto_rawDescData
 }
 
+var file_v1_entities_pin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_v1_entities_pin_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_v1_entities_pin_proto_goTypes = []any{
-	(*Pin)(nil),      // 0: api.v1.entities.Pin
-	(*Location)(nil), // 1: api.v1.entities.Location
-	(*Comment)(nil),  // 2: api.v1.entities.Comment
-	(*User)(nil),     // 3: api.v1.entities.User
+	(PinVisibility)(0), // 0: api.v1.entities.PinVisibility
+	(*Pin)(nil),        // 1: api.v1.entities.Pin
+	(*Location)(nil),   // 2: api.v1.entities.Location
+	(*Comment)(nil),    // 3: api.v1.entities.Comment
+	(*User)(nil),       // 4: api.v1.entities.User
 }
 var file_v1_entities_pin_proto_depIdxs = []int32{
-	1, // 0: api.v1.entities.Pin.location:type_name -> api.v1.entities.Location
-	3, // 1: api.v1.entities.Pin.author:type_name -> api.v1.entities.User
-	3, // 2: api.v1.entities.Comment.author:type_name -> api.v1.entities.User
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	2, // 0: api.v1.entities.Pin.location:type_name -> api.v1.entities.Location
+	4, // 1: api.v1.entities.Pin.author:type_name -> api.v1.entities.User
+	0, // 2: api.v1.entities.Pin.visibility:type_name -> api.v1.entities.PinVisibility
+	4, // 3: api.v1.entities.Comment.author:type_name -> api.v1.entities.User
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_v1_entities_pin_proto_init() }
@@ -358,13 +426,14 @@ func file_v1_entities_pin_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_entities_pin_proto_rawDesc), len(file_v1_entities_pin_proto_rawDesc)),
-			NumEnums:      0,
+			NumEnums:      1,
 			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_v1_entities_pin_proto_goTypes,
 		DependencyIndexes: file_v1_entities_pin_proto_depIdxs,
+		EnumInfos:         file_v1_entities_pin_proto_enumTypes,
 		MessageInfos:      file_v1_entities_pin_proto_msgTypes,
 	}.Build()
 	File_v1_entities_pin_proto = out.File
@@ -76,15 +76,19 @@ func (UserStatus) EnumDescriptor() ([]byte, []int) {
 
 // User represents a user in the system (matches database schema)
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Email         *string                `protobuf:"bytes,2,opt,name=email,proto3,oneof" json:"email,omitempty"`                                                                           // NULL for anonymous users
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`                                                                           // "anon-xyz" for anonymous, regular for registered
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Flexible key-value metadata
-	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                                       // Unix timestamp
-	UpdatedAt     int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                                       // Unix timestamp
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Email               *string                `protobuf:"bytes,2,opt,name=email,proto3,oneof" json:"email,omitempty"`                                                                           // NULL for anonymous users
+	Username            string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`                                                                           // "anon-xyz" for anonymous, regular for registered
+	Metadata            map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Flexible key-value metadata
+	CreatedAt           int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                                       // Unix timestamp
+	UpdatedAt           int64                  `protobuf:"varint,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                                                       // Unix timestamp
+	DisplayName         *string                `protobuf:"bytes,7,opt,name=display_name,json=displayName,proto3,oneof" json:"display_name,omitempty"`
+	AvatarUrl           *string                `protobuf:"bytes,8,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
+	Status              UserStatus             `protobuf:"varint,9,opt,name=status,proto3,enum=api.v1.entities.UserStatus" json:"status,omitempty"`
+	DeletionScheduledAt *int64                 `protobuf:"varint,10,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3,oneof" json:"deletion_scheduled_at,omitempty"` // Unix timestamp; set while the account is scheduled for deletion
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -159,11 +163,39 @@ func (x *User) GetUpdatedAt() int64 {
 	return 0
 }
 
+func (x *User) GetDisplayName() string {
+	if x != nil && x.DisplayName != nil {
+		return *x.DisplayName
+	}
+	return ""
+}
+
+func (x *User) GetAvatarUrl() string {
+	if x != nil && x.AvatarUrl != nil {
+		return *x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *User) GetStatus() UserStatus {
+	if x != nil {
+		return x.Status
+	}
+	return UserStatus_USER_STATUS_UNSPECIFIED
+}
+
+func (x *User) GetDeletionScheduledAt() int64 {
+	if x != nil && x.DeletionScheduledAt != nil {
+		return *x.DeletionScheduledAt
+	}
+	return 0
+}
+
 var File_v1_entities_user_proto protoreflect.FileDescriptor
 
 const file_v1_entities_user_proto_rawDesc = "" +
 	"\n" +
-	"\x16v1/entities/user.proto\x12\x0fapi.v1.entities\"\x93\x02\n" +
+	"\x16v1/entities/user.proto\x12\x0fapi.v1.entities\"\x87\x04\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x88\x01\x01\x12\x1a\n" +
@@ -172,11 +204,20 @@ const file_v1_entities_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x1a;\n" +
+	"updated_at\x18\x06 \x01(\x03R\tupdatedAt\x12&\n" +
+	"\fdisplay_name\x18\a \x01(\tH\x01R\vdisplayName\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"avatar_url\x18\b \x01(\tH\x02R\tavatarUrl\x88\x01\x01\x123\n" +
+	"\x06status\x18\t \x01(\x0e2\x1b.api.v1.entities.UserStatusR\x06status\x127\n" +
+	"\x15deletion_scheduled_at\x18\n" +
+	" \x01(\x03H\x03R\x13deletionScheduledAt\x88\x01\x01\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
-	"\x06_email*t\n" +
+	"\x06_emailB\x0f\n" +
+	"\r_display_nameB\r\n" +
+	"\v_avatar_urlB\x18\n" +
+	"\x16_deletion_scheduled_at*t\n" +
 	"\n" +
 	"UserStatus\x12\x1b\n" +
 	"\x17USER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
@@ -205,11 +246,12 @@ var file_v1_entities_user_proto_goTypes = []any{
 }
 var file_v1_entities_user_proto_depIdxs = []int32{
 	2, // 0: api.v1.entities.User.metadata:type_name -> api.v1.entities.User.MetadataEntry
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0, // 1: api.v1.entities.User.status:type_name -> api.v1.entities.UserStatus
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_v1_entities_user_proto_init() }
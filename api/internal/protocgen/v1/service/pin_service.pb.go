@@ -24,8 +24,9 @@ const (
 
 type CreatePinRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`   // Pin title/description
-	Location      *entities.Location     `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"` // Geographic location
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`                                           // Pin title/description
+	Location      *entities.Location     `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`                                         // Geographic location
+	Visibility    entities.PinVisibility `protobuf:"varint,3,opt,name=visibility,proto3,enum=api.v1.entities.PinVisibility" json:"visibility,omitempty"` // Defaults to public
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -74,6 +75,13 @@ func (x *CreatePinRequest) GetLocation() *entities.Location {
 	return nil
 }
 
+func (x *CreatePinRequest) GetVisibility() entities.PinVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return entities.PinVisibility(0)
+}
+
 type CreatePinResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Pin           *entities.Pin          `protobuf:"bytes,1,opt,name=pin,proto3" json:"pin,omitempty"`
@@ -535,14 +543,121 @@ func (x *DeletePinResponse) GetSuccess() bool {
 	return false
 }
 
+type ListFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         *int32                 `protobuf:"varint,1,opt,name=limit,proto3,oneof" json:"limit,omitempty"`  // Defaults to 20, at most 100
+	Cursor        *string                `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor from the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedRequest) Reset() {
+	*x = ListFeedRequest{}
+	mi := &file_v1_service_pin_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedRequest) ProtoMessage() {}
+
+func (x *ListFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_pin_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedRequest.ProtoReflect.Descriptor instead.
+func (*ListFeedRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_pin_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListFeedRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListFeedRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type ListFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*entities.Pin        `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	NextCursor    *string                `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"` // Unset on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeedResponse) Reset() {
+	*x = ListFeedResponse{}
+	mi := &file_v1_service_pin_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeedResponse) ProtoMessage() {}
+
+func (x *ListFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_pin_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeedResponse.ProtoReflect.Descriptor instead.
+func (*ListFeedResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_pin_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListFeedResponse) GetPins() []*entities.Pin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+func (x *ListFeedResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
 var File_v1_service_pin_service_proto protoreflect.FileDescriptor
 
 const file_v1_service_pin_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1cv1/service/pin_service.proto\x12\x0eapi.v1.service\x1a\x15v1/entities/pin.proto\"c\n" +
+	"\x1cv1/service/pin_service.proto\x12\x0eapi.v1.service\x1a\x15v1/entities/pin.proto\"\xa3\x01\n" +
 	"\x10CreatePinRequest\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x125\n" +
-	"\blocation\x18\x02 \x01(\v2\x19.api.v1.entities.LocationR\blocation\";\n" +
+	"\blocation\x18\x02 \x01(\v2\x19.api.v1.entities.LocationR\blocation\x12>\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\x0e2\x1e.api.v1.entities.PinVisibilityR\n" +
+	"visibility\";\n" +
 	"\x11CreatePinResponse\x12&\n" +
 	"\x03pin\x18\x01 \x01(\v2\x14.api.v1.entities.PinR\x03pin\"\xac\x01\n" +
 	"\x0fListPinsRequest\x12\x1a\n" +
@@ -574,7 +689,17 @@ const file_v1_service_pin_service_proto_rawDesc = "" +
 	"\x10DeletePinRequest\x12\x15\n" +
 	"\x06pin_id\x18\x01 \x01(\tR\x05pinId\"-\n" +
 	"\x11DeletePinResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\x9d\x03\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"^\n" +
+	"\x0fListFeedRequest\x12\x19\n" +
+	"\x05limit\x18\x01 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_cursor\"r\n" +
+	"\x10ListFeedResponse\x12(\n" +
+	"\x04pins\x18\x01 \x03(\v2\x14.api.v1.entities.PinR\x04pins\x12$\n" +
+	"\vnext_cursor\x18\x02 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor2\xec\x03\n" +
 	"\n" +
 	"PinService\x12P\n" +
 	"\tCreatePin\x12 .api.v1.service.CreatePinRequest\x1a!.api.v1.service.CreatePinResponse\x12M\n" +
@@ -582,7 +707,8 @@ const file_v1_service_pin_service_proto_rawDesc = "" +
 	"\x06GetPin\x12\x1d.api.v1.service.GetPinRequest\x1a\x1e.api.v1.service.GetPinResponse\x12S\n" +
 	"\n" +
 	"AddComment\x12!.api.v1.service.AddCommentRequest\x1a\".api.v1.service.AddCommentResponse\x12P\n" +
-	"\tDeletePin\x12 .api.v1.service.DeletePinRequest\x1a!.api.v1.service.DeletePinResponseBMZKgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/service;servicev1b\x06proto3"
+	"\tDeletePin\x12 .api.v1.service.DeletePinRequest\x1a!.api.v1.service.DeletePinResponse\x12M\n" +
+	"\bListFeed\x12\x1f.api.v1.service.ListFeedRequest\x1a .api.v1.service.ListFeedResponseBMZKgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/service;servicev1b\x06proto3"
 
 var (
 	file_v1_service_pin_service_proto_rawDescOnce sync.Once
@@ -596,44 +722,51 @@ func file_v1_service_pin_service_proto_rawDescGZIP() []byte {
 	return file_v1_service_pin_service_proto_rawDescData
 }
 
-var file_v1_service_pin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_v1_service_pin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_v1_service_pin_service_proto_goTypes = []any{
-	(*CreatePinRequest)(nil),   // 0: api.v1.service.CreatePinRequest
-	(*CreatePinResponse)(nil),  // 1: api.v1.service.CreatePinResponse
-	(*ListPinsRequest)(nil),    // 2: api.v1.service.ListPinsRequest
-	(*ListPinsResponse)(nil),   // 3: api.v1.service.ListPinsResponse
-	(*GetPinRequest)(nil),      // 4: api.v1.service.GetPinRequest
-	(*GetPinResponse)(nil),     // 5: api.v1.service.GetPinResponse
-	(*AddCommentRequest)(nil),  // 6: api.v1.service.AddCommentRequest
-	(*AddCommentResponse)(nil), // 7: api.v1.service.AddCommentResponse
-	(*DeletePinRequest)(nil),   // 8: api.v1.service.DeletePinRequest
-	(*DeletePinResponse)(nil),  // 9: api.v1.service.DeletePinResponse
-	(*entities.Location)(nil),  // 10: api.v1.entities.Location
-	(*entities.Pin)(nil),       // 11: api.v1.entities.Pin
-	(*entities.Comment)(nil),   // 12: api.v1.entities.Comment
+	(*CreatePinRequest)(nil),    // 0: api.v1.service.CreatePinRequest
+	(*CreatePinResponse)(nil),   // 1: api.v1.service.CreatePinResponse
+	(*ListPinsRequest)(nil),     // 2: api.v1.service.ListPinsRequest
+	(*ListPinsResponse)(nil),    // 3: api.v1.service.ListPinsResponse
+	(*GetPinRequest)(nil),       // 4: api.v1.service.GetPinRequest
+	(*GetPinResponse)(nil),      // 5: api.v1.service.GetPinResponse
+	(*AddCommentRequest)(nil),   // 6: api.v1.service.AddCommentRequest
+	(*AddCommentResponse)(nil),  // 7: api.v1.service.AddCommentResponse
+	(*DeletePinRequest)(nil),    // 8: api.v1.service.DeletePinRequest
+	(*DeletePinResponse)(nil),   // 9: api.v1.service.DeletePinResponse
+	(*ListFeedRequest)(nil),     // 10: api.v1.service.ListFeedRequest
+	(*ListFeedResponse)(nil),    // 11: api.v1.service.ListFeedResponse
+	(*entities.Location)(nil),   // 12: api.v1.entities.Location
+	(entities.PinVisibility)(0), // 13: api.v1.entities.PinVisibility
+	(*entities.Pin)(nil),        // 14: api.v1.entities.Pin
+	(*entities.Comment)(nil),    // 15: api.v1.entities.Comment
 }
 var file_v1_service_pin_service_proto_depIdxs = []int32{
-	10, // 0: api.v1.service.CreatePinRequest.location:type_name -> api.v1.entities.Location
-	11, // 1: api.v1.service.CreatePinResponse.pin:type_name -> api.v1.entities.Pin
-	11, // 2: api.v1.service.ListPinsResponse.pins:type_name -> api.v1.entities.Pin
-	11, // 3: api.v1.service.GetPinResponse.pin:type_name -> api.v1.entities.Pin
-	12, // 4: api.v1.service.GetPinResponse.comments:type_name -> api.v1.entities.Comment
-	12, // 5: api.v1.service.AddCommentResponse.comment:type_name -> api.v1.entities.Comment
-	0,  // 6: api.v1.service.PinService.CreatePin:input_type -> api.v1.service.CreatePinRequest
-	2,  // 7: api.v1.service.PinService.ListPins:input_type -> api.v1.service.ListPinsRequest
-	4,  // 8: api.v1.service.PinService.GetPin:input_type -> api.v1.service.GetPinRequest
-	6,  // 9: api.v1.service.PinService.AddComment:input_type -> api.v1.service.AddCommentRequest
-	8,  // 10: api.v1.service.PinService.DeletePin:input_type -> api.v1.service.DeletePinRequest
-	1,  // 11: api.v1.service.PinService.CreatePin:output_type -> api.v1.service.CreatePinResponse
-	3,  // 12: api.v1.service.PinService.ListPins:output_type -> api.v1.service.ListPinsResponse
-	5,  // 13: api.v1.service.PinService.GetPin:output_type -> api.v1.service.GetPinResponse
-	7,  // 14: api.v1.service.PinService.AddComment:output_type -> api.v1.service.AddCommentResponse
-	9,  // 15: api.v1.service.PinService.DeletePin:output_type -> api.v1.service.DeletePinResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	12, // 0: api.v1.service.CreatePinRequest.location:type_name -> api.v1.entities.Location
+	13, // 1: api.v1.service.CreatePinRequest.visibility:type_name -> api.v1.entities.PinVisibility
+	14, // 2: api.v1.service.CreatePinResponse.pin:type_name -> api.v1.entities.Pin
+	14, // 3: api.v1.service.ListPinsResponse.pins:type_name -> api.v1.entities.Pin
+	14, // 4: api.v1.service.GetPinResponse.pin:type_name -> api.v1.entities.Pin
+	15, // 5: api.v1.service.GetPinResponse.comments:type_name -> api.v1.entities.Comment
+	15, // 6: api.v1.service.AddCommentResponse.comment:type_name -> api.v1.entities.Comment
+	14, // 7: api.v1.service.ListFeedResponse.pins:type_name -> api.v1.entities.Pin
+	0,  // 8: api.v1.service.PinService.CreatePin:input_type -> api.v1.service.CreatePinRequest
+	2,  // 9: api.v1.service.PinService.ListPins:input_type -> api.v1.service.ListPinsRequest
+	4,  // 10: api.v1.service.PinService.GetPin:input_type -> api.v1.service.GetPinRequest
+	6,  // 11: api.v1.service.PinService.AddComment:input_type -> api.v1.service.AddCommentRequest
+	8,  // 12: api.v1.service.PinService.DeletePin:input_type -> api.v1.service.DeletePinRequest
+	10, // 13: api.v1.service.PinService.ListFeed:input_type -> api.v1.service.ListFeedRequest
+	1,  // 14: api.v1.service.PinService.CreatePin:output_type -> api.v1.service.CreatePinResponse
+	3,  // 15: api.v1.service.PinService.ListPins:output_type -> api.v1.service.ListPinsResponse
+	5,  // 16: api.v1.service.PinService.GetPin:output_type -> api.v1.service.GetPinResponse
+	7,  // 17: api.v1.service.PinService.AddComment:output_type -> api.v1.service.AddCommentResponse
+	9,  // 18: api.v1.service.PinService.DeletePin:output_type -> api.v1.service.DeletePinResponse
+	11, // 19: api.v1.service.PinService.ListFeed:output_type -> api.v1.service.ListFeedResponse
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_v1_service_pin_service_proto_init() }
@@ -644,13 +777,15 @@ func file_v1_service_pin_service_proto_init() {
 	file_v1_service_pin_service_proto_msgTypes[2].OneofWrappers = []any{}
 	file_v1_service_pin_service_proto_msgTypes[3].OneofWrappers = []any{}
 	file_v1_service_pin_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_v1_service_pin_service_proto_msgTypes[10].OneofWrappers = []any{}
+	file_v1_service_pin_service_proto_msgTypes[11].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_service_pin_service_proto_rawDesc), len(file_v1_service_pin_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -43,6 +43,8 @@ const (
 	PinServiceAddCommentProcedure = "/api.v1.service.PinService/AddComment"
 	// PinServiceDeletePinProcedure is the fully-qualified name of the PinService's DeletePin RPC.
 	PinServiceDeletePinProcedure = "/api.v1.service.PinService/DeletePin"
+	// PinServiceListFeedProcedure is the fully-qualified name of the PinService's ListFeed RPC.
+	PinServiceListFeedProcedure = "/api.v1.service.PinService/ListFeed"
 )
 
 // PinServiceClient is a client for the api.v1.service.PinService service.
@@ -57,6 +59,8 @@ type PinServiceClient interface {
 	AddComment(context.Context, *connect.Request[service.AddCommentRequest]) (*connect.Response[service.AddCommentResponse], error)
 	// Delete a pin (owner only)
 	DeletePin(context.Context, *connect.Request[service.DeletePinRequest]) (*connect.Response[service.DeletePinResponse], error)
+	// Get recent pins from followed users, anywhere on the map
+	ListFeed(context.Context, *connect.Request[service.ListFeedRequest]) (*connect.Response[service.ListFeedResponse], error)
 }
 
 // NewPinServiceClient constructs a client for the api.v1.service.PinService service. By default, it
@@ -100,6 +104,12 @@ func NewPinServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(pinServiceMethods.ByName("DeletePin")),
 			connect.WithClientOptions(opts...),
 		),
+		listFeed: connect.NewClient[service.ListFeedRequest, service.ListFeedResponse](
+			httpClient,
+			baseURL+PinServiceListFeedProcedure,
+			connect.WithSchema(pinServiceMethods.ByName("ListFeed")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -110,6 +120,7 @@ type pinServiceClient struct {
 	getPin     *connect.Client[service.GetPinRequest, service.GetPinResponse]
 	addComment *connect.Client[service.AddCommentRequest, service.AddCommentResponse]
 	deletePin  *connect.Client[service.DeletePinRequest, service.DeletePinResponse]
+	listFeed   *connect.Client[service.ListFeedRequest, service.ListFeedResponse]
 }
 
 // CreatePin calls api.v1.service.PinService.CreatePin.
@@ -137,6 +148,11 @@ func (c *pinServiceClient) DeletePin(ctx context.Context, req *connect.Request[s
 	return c.deletePin.CallUnary(ctx, req)
 }
 
+// ListFeed calls api.v1.service.PinService.ListFeed.
+func (c *pinServiceClient) ListFeed(ctx context.Context, req *connect.Request[service.ListFeedRequest]) (*connect.Response[service.ListFeedResponse], error) {
+	return c.listFeed.CallUnary(ctx, req)
+}
+
 // PinServiceHandler is an implementation of the api.v1.service.PinService service.
 type PinServiceHandler interface {
 	// Create a new pin on the map
@@ -149,6 +165,8 @@ type PinServiceHandler interface {
 	AddComment(context.Context, *connect.Request[service.AddCommentRequest]) (*connect.Response[service.AddCommentResponse], error)
 	// Delete a pin (owner only)
 	DeletePin(context.Context, *connect.Request[service.DeletePinRequest]) (*connect.Response[service.DeletePinResponse], error)
+	// Get recent pins from followed users, anywhere on the map
+	ListFeed(context.Context, *connect.Request[service.ListFeedRequest]) (*connect.Response[service.ListFeedResponse], error)
 }
 
 // NewPinServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -188,6 +206,12 @@ func NewPinServiceHandler(svc PinServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(pinServiceMethods.ByName("DeletePin")),
 		connect.WithHandlerOptions(opts...),
 	)
+	pinServiceListFeedHandler := connect.NewUnaryHandler(
+		PinServiceListFeedProcedure,
+		svc.ListFeed,
+		connect.WithSchema(pinServiceMethods.ByName("ListFeed")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.service.PinService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case PinServiceCreatePinProcedure:
@@ -200,6 +224,8 @@ func NewPinServiceHandler(svc PinServiceHandler, opts ...connect.HandlerOption)
 			pinServiceAddCommentHandler.ServeHTTP(w, r)
 		case PinServiceDeletePinProcedure:
 			pinServiceDeletePinHandler.ServeHTTP(w, r)
+		case PinServiceListFeedProcedure:
+			pinServiceListFeedHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -228,3 +254,7 @@ func (UnimplementedPinServiceHandler) AddComment(context.Context, *connect.Reque
 func (UnimplementedPinServiceHandler) DeletePin(context.Context, *connect.Request[service.DeletePinRequest]) (*connect.Response[service.DeletePinResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.PinService.DeletePin is not implemented"))
 }
+
+func (UnimplementedPinServiceHandler) ListFeed(context.Context, *connect.Request[service.ListFeedRequest]) (*connect.Response[service.ListFeedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.PinService.ListFeed is not implemented"))
+}
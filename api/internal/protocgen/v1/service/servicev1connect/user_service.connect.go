@@ -41,6 +41,43 @@ const (
 	UserServiceRegisterUserProcedure = "/api.v1.service.UserService/RegisterUser"
 	// UserServiceGetUserProcedure is the fully-qualified name of the UserService's GetUser RPC.
 	UserServiceGetUserProcedure = "/api.v1.service.UserService/GetUser"
+	// UserServiceUpdateProfileProcedure is the fully-qualified name of the UserService's UpdateProfile
+	// RPC.
+	UserServiceUpdateProfileProcedure = "/api.v1.service.UserService/UpdateProfile"
+	// UserServiceUploadAvatarProcedure is the fully-qualified name of the UserService's UploadAvatar
+	// RPC.
+	UserServiceUploadAvatarProcedure = "/api.v1.service.UserService/UploadAvatar"
+	// UserServiceFollowProcedure is the fully-qualified name of the UserService's Follow RPC.
+	UserServiceFollowProcedure = "/api.v1.service.UserService/Follow"
+	// UserServiceUnfollowProcedure is the fully-qualified name of the UserService's Unfollow RPC.
+	UserServiceUnfollowProcedure = "/api.v1.service.UserService/Unfollow"
+	// UserServiceListFollowersProcedure is the fully-qualified name of the UserService's ListFollowers
+	// RPC.
+	UserServiceListFollowersProcedure = "/api.v1.service.UserService/ListFollowers"
+	// UserServiceListFollowingProcedure is the fully-qualified name of the UserService's ListFollowing
+	// RPC.
+	UserServiceListFollowingProcedure = "/api.v1.service.UserService/ListFollowing"
+	// UserServiceListUserPinsProcedure is the fully-qualified name of the UserService's ListUserPins
+	// RPC.
+	UserServiceListUserPinsProcedure = "/api.v1.service.UserService/ListUserPins"
+	// UserServiceGetUserStatsProcedure is the fully-qualified name of the UserService's GetUserStats
+	// RPC.
+	UserServiceGetUserStatsProcedure = "/api.v1.service.UserService/GetUserStats"
+	// UserServiceBlockUserProcedure is the fully-qualified name of the UserService's BlockUser RPC.
+	UserServiceBlockUserProcedure = "/api.v1.service.UserService/BlockUser"
+	// UserServiceUnblockUserProcedure is the fully-qualified name of the UserService's UnblockUser RPC.
+	UserServiceUnblockUserProcedure = "/api.v1.service.UserService/UnblockUser"
+	// UserServiceMuteUserProcedure is the fully-qualified name of the UserService's MuteUser RPC.
+	UserServiceMuteUserProcedure = "/api.v1.service.UserService/MuteUser"
+	// UserServiceDeleteAccountProcedure is the fully-qualified name of the UserService's DeleteAccount
+	// RPC.
+	UserServiceDeleteAccountProcedure = "/api.v1.service.UserService/DeleteAccount"
+	// UserServiceExportMyDataProcedure is the fully-qualified name of the UserService's ExportMyData
+	// RPC.
+	UserServiceExportMyDataProcedure = "/api.v1.service.UserService/ExportMyData"
+	// UserServiceGetExportStatusProcedure is the fully-qualified name of the UserService's
+	// GetExportStatus RPC.
+	UserServiceGetExportStatusProcedure = "/api.v1.service.UserService/GetExportStatus"
 )
 
 // UserServiceClient is a client for the api.v1.service.UserService service.
@@ -51,6 +88,35 @@ type UserServiceClient interface {
 	RegisterUser(context.Context, *connect.Request[service.RegisterUserRequest]) (*connect.Response[service.RegisterUserResponse], error)
 	// Get user by ID
 	GetUser(context.Context, *connect.Request[service.GetUserRequest]) (*connect.Response[service.GetUserResponse], error)
+	// Update the caller's profile; only fields named in update_mask change
+	UpdateProfile(context.Context, *connect.Request[service.UpdateProfileRequest]) (*connect.Response[service.UpdateProfileResponse], error)
+	// Replace the caller's avatar; the image is cropped and resized server-side
+	UploadAvatar(context.Context, *connect.Request[service.UploadAvatarRequest]) (*connect.Response[service.UploadAvatarResponse], error)
+	// Follow a user to see their pins in the feed
+	Follow(context.Context, *connect.Request[service.FollowRequest]) (*connect.Response[service.FollowResponse], error)
+	// Stop following a user
+	Unfollow(context.Context, *connect.Request[service.UnfollowRequest]) (*connect.Response[service.UnfollowResponse], error)
+	// List the users following a user, most recent first
+	ListFollowers(context.Context, *connect.Request[service.ListFollowersRequest]) (*connect.Response[service.ListFollowersResponse], error)
+	// List the users a user follows, most recent first
+	ListFollowing(context.Context, *connect.Request[service.ListFollowingRequest]) (*connect.Response[service.ListFollowingResponse], error)
+	// List a user's pins, newest first, for their profile page
+	ListUserPins(context.Context, *connect.Request[service.ListUserPinsRequest]) (*connect.Response[service.ListUserPinsResponse], error)
+	// Get the counts shown on a user's profile page
+	GetUserStats(context.Context, *connect.Request[service.GetUserStatsRequest]) (*connect.Response[service.GetUserStatsResponse], error)
+	// Block a user: hide their pins and comments, end follows both ways and
+	// stop them following or commenting on the caller's pins
+	BlockUser(context.Context, *connect.Request[service.BlockUserRequest]) (*connect.Response[service.BlockUserResponse], error)
+	// Remove a block or mute
+	UnblockUser(context.Context, *connect.Request[service.UnblockUserRequest]) (*connect.Response[service.UnblockUserResponse], error)
+	// Mute a user: hide their pins and comments from the caller only
+	MuteUser(context.Context, *connect.Request[service.MuteUserRequest]) (*connect.Response[service.MuteUserResponse], error)
+	// Schedule the caller's account for deletion; signing in again before then cancels it
+	DeleteAccount(context.Context, *connect.Request[service.DeleteAccountRequest]) (*connect.Response[service.DeleteAccountResponse], error)
+	// Start building an archive of the caller's data; poll GetExportStatus for the download link
+	ExportMyData(context.Context, *connect.Request[service.ExportMyDataRequest]) (*connect.Response[service.ExportMyDataResponse], error)
+	// Get the progress of a data export, with a short-lived download link once it is ready
+	GetExportStatus(context.Context, *connect.Request[service.GetExportStatusRequest]) (*connect.Response[service.GetExportStatusResponse], error)
 }
 
 // NewUserServiceClient constructs a client for the api.v1.service.UserService service. By default,
@@ -82,14 +148,112 @@ func NewUserServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(userServiceMethods.ByName("GetUser")),
 			connect.WithClientOptions(opts...),
 		),
+		updateProfile: connect.NewClient[service.UpdateProfileRequest, service.UpdateProfileResponse](
+			httpClient,
+			baseURL+UserServiceUpdateProfileProcedure,
+			connect.WithSchema(userServiceMethods.ByName("UpdateProfile")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadAvatar: connect.NewClient[service.UploadAvatarRequest, service.UploadAvatarResponse](
+			httpClient,
+			baseURL+UserServiceUploadAvatarProcedure,
+			connect.WithSchema(userServiceMethods.ByName("UploadAvatar")),
+			connect.WithClientOptions(opts...),
+		),
+		follow: connect.NewClient[service.FollowRequest, service.FollowResponse](
+			httpClient,
+			baseURL+UserServiceFollowProcedure,
+			connect.WithSchema(userServiceMethods.ByName("Follow")),
+			connect.WithClientOptions(opts...),
+		),
+		unfollow: connect.NewClient[service.UnfollowRequest, service.UnfollowResponse](
+			httpClient,
+			baseURL+UserServiceUnfollowProcedure,
+			connect.WithSchema(userServiceMethods.ByName("Unfollow")),
+			connect.WithClientOptions(opts...),
+		),
+		listFollowers: connect.NewClient[service.ListFollowersRequest, service.ListFollowersResponse](
+			httpClient,
+			baseURL+UserServiceListFollowersProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListFollowers")),
+			connect.WithClientOptions(opts...),
+		),
+		listFollowing: connect.NewClient[service.ListFollowingRequest, service.ListFollowingResponse](
+			httpClient,
+			baseURL+UserServiceListFollowingProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListFollowing")),
+			connect.WithClientOptions(opts...),
+		),
+		listUserPins: connect.NewClient[service.ListUserPinsRequest, service.ListUserPinsResponse](
+			httpClient,
+			baseURL+UserServiceListUserPinsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ListUserPins")),
+			connect.WithClientOptions(opts...),
+		),
+		getUserStats: connect.NewClient[service.GetUserStatsRequest, service.GetUserStatsResponse](
+			httpClient,
+			baseURL+UserServiceGetUserStatsProcedure,
+			connect.WithSchema(userServiceMethods.ByName("GetUserStats")),
+			connect.WithClientOptions(opts...),
+		),
+		blockUser: connect.NewClient[service.BlockUserRequest, service.BlockUserResponse](
+			httpClient,
+			baseURL+UserServiceBlockUserProcedure,
+			connect.WithSchema(userServiceMethods.ByName("BlockUser")),
+			connect.WithClientOptions(opts...),
+		),
+		unblockUser: connect.NewClient[service.UnblockUserRequest, service.UnblockUserResponse](
+			httpClient,
+			baseURL+UserServiceUnblockUserProcedure,
+			connect.WithSchema(userServiceMethods.ByName("UnblockUser")),
+			connect.WithClientOptions(opts...),
+		),
+		muteUser: connect.NewClient[service.MuteUserRequest, service.MuteUserResponse](
+			httpClient,
+			baseURL+UserServiceMuteUserProcedure,
+			connect.WithSchema(userServiceMethods.ByName("MuteUser")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAccount: connect.NewClient[service.DeleteAccountRequest, service.DeleteAccountResponse](
+			httpClient,
+			baseURL+UserServiceDeleteAccountProcedure,
+			connect.WithSchema(userServiceMethods.ByName("DeleteAccount")),
+			connect.WithClientOptions(opts...),
+		),
+		exportMyData: connect.NewClient[service.ExportMyDataRequest, service.ExportMyDataResponse](
+			httpClient,
+			baseURL+UserServiceExportMyDataProcedure,
+			connect.WithSchema(userServiceMethods.ByName("ExportMyData")),
+			connect.WithClientOptions(opts...),
+		),
+		getExportStatus: connect.NewClient[service.GetExportStatusRequest, service.GetExportStatusResponse](
+			httpClient,
+			baseURL+UserServiceGetExportStatusProcedure,
+			connect.WithSchema(userServiceMethods.ByName("GetExportStatus")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // userServiceClient implements UserServiceClient.
 type userServiceClient struct {
-	getCurrentUser *connect.Client[service.GetCurrentUserRequest, service.GetCurrentUserResponse]
-	registerUser   *connect.Client[service.RegisterUserRequest, service.RegisterUserResponse]
-	getUser        *connect.Client[service.GetUserRequest, service.GetUserResponse]
+	getCurrentUser  *connect.Client[service.GetCurrentUserRequest, service.GetCurrentUserResponse]
+	registerUser    *connect.Client[service.RegisterUserRequest, service.RegisterUserResponse]
+	getUser         *connect.Client[service.GetUserRequest, service.GetUserResponse]
+	updateProfile   *connect.Client[service.UpdateProfileRequest, service.UpdateProfileResponse]
+	uploadAvatar    *connect.Client[service.UploadAvatarRequest, service.UploadAvatarResponse]
+	follow          *connect.Client[service.FollowRequest, service.FollowResponse]
+	unfollow        *connect.Client[service.UnfollowRequest, service.UnfollowResponse]
+	listFollowers   *connect.Client[service.ListFollowersRequest, service.ListFollowersResponse]
+	listFollowing   *connect.Client[service.ListFollowingRequest, service.ListFollowingResponse]
+	listUserPins    *connect.Client[service.ListUserPinsRequest, service.ListUserPinsResponse]
+	getUserStats    *connect.Client[service.GetUserStatsRequest, service.GetUserStatsResponse]
+	blockUser       *connect.Client[service.BlockUserRequest, service.BlockUserResponse]
+	unblockUser     *connect.Client[service.UnblockUserRequest, service.UnblockUserResponse]
+	muteUser        *connect.Client[service.MuteUserRequest, service.MuteUserResponse]
+	deleteAccount   *connect.Client[service.DeleteAccountRequest, service.DeleteAccountResponse]
+	exportMyData    *connect.Client[service.ExportMyDataRequest, service.ExportMyDataResponse]
+	getExportStatus *connect.Client[service.GetExportStatusRequest, service.GetExportStatusResponse]
 }
 
 // GetCurrentUser calls api.v1.service.UserService.GetCurrentUser.
@@ -107,6 +271,76 @@ func (c *userServiceClient) GetUser(ctx context.Context, req *connect.Request[se
 	return c.getUser.CallUnary(ctx, req)
 }
 
+// UpdateProfile calls api.v1.service.UserService.UpdateProfile.
+func (c *userServiceClient) UpdateProfile(ctx context.Context, req *connect.Request[service.UpdateProfileRequest]) (*connect.Response[service.UpdateProfileResponse], error) {
+	return c.updateProfile.CallUnary(ctx, req)
+}
+
+// UploadAvatar calls api.v1.service.UserService.UploadAvatar.
+func (c *userServiceClient) UploadAvatar(ctx context.Context, req *connect.Request[service.UploadAvatarRequest]) (*connect.Response[service.UploadAvatarResponse], error) {
+	return c.uploadAvatar.CallUnary(ctx, req)
+}
+
+// Follow calls api.v1.service.UserService.Follow.
+func (c *userServiceClient) Follow(ctx context.Context, req *connect.Request[service.FollowRequest]) (*connect.Response[service.FollowResponse], error) {
+	return c.follow.CallUnary(ctx, req)
+}
+
+// Unfollow calls api.v1.service.UserService.Unfollow.
+func (c *userServiceClient) Unfollow(ctx context.Context, req *connect.Request[service.UnfollowRequest]) (*connect.Response[service.UnfollowResponse], error) {
+	return c.unfollow.CallUnary(ctx, req)
+}
+
+// ListFollowers calls api.v1.service.UserService.ListFollowers.
+func (c *userServiceClient) ListFollowers(ctx context.Context, req *connect.Request[service.ListFollowersRequest]) (*connect.Response[service.ListFollowersResponse], error) {
+	return c.listFollowers.CallUnary(ctx, req)
+}
+
+// ListFollowing calls api.v1.service.UserService.ListFollowing.
+func (c *userServiceClient) ListFollowing(ctx context.Context, req *connect.Request[service.ListFollowingRequest]) (*connect.Response[service.ListFollowingResponse], error) {
+	return c.listFollowing.CallUnary(ctx, req)
+}
+
+// ListUserPins calls api.v1.service.UserService.ListUserPins.
+func (c *userServiceClient) ListUserPins(ctx context.Context, req *connect.Request[service.ListUserPinsRequest]) (*connect.Response[service.ListUserPinsResponse], error) {
+	return c.listUserPins.CallUnary(ctx, req)
+}
+
+// GetUserStats calls api.v1.service.UserService.GetUserStats.
+func (c *userServiceClient) GetUserStats(ctx context.Context, req *connect.Request[service.GetUserStatsRequest]) (*connect.Response[service.GetUserStatsResponse], error) {
+	return c.getUserStats.CallUnary(ctx, req)
+}
+
+// BlockUser calls api.v1.service.UserService.BlockUser.
+func (c *userServiceClient) BlockUser(ctx context.Context, req *connect.Request[service.BlockUserRequest]) (*connect.Response[service.BlockUserResponse], error) {
+	return c.blockUser.CallUnary(ctx, req)
+}
+
+// UnblockUser calls api.v1.service.UserService.UnblockUser.
+func (c *userServiceClient) UnblockUser(ctx context.Context, req *connect.Request[service.UnblockUserRequest]) (*connect.Response[service.UnblockUserResponse], error) {
+	return c.unblockUser.CallUnary(ctx, req)
+}
+
+// MuteUser calls api.v1.service.UserService.MuteUser.
+func (c *userServiceClient) MuteUser(ctx context.Context, req *connect.Request[service.MuteUserRequest]) (*connect.Response[service.MuteUserResponse], error) {
+	return c.muteUser.CallUnary(ctx, req)
+}
+
+// DeleteAccount calls api.v1.service.UserService.DeleteAccount.
+func (c *userServiceClient) DeleteAccount(ctx context.Context, req *connect.Request[service.DeleteAccountRequest]) (*connect.Response[service.DeleteAccountResponse], error) {
+	return c.deleteAccount.CallUnary(ctx, req)
+}
+
+// ExportMyData calls api.v1.service.UserService.ExportMyData.
+func (c *userServiceClient) ExportMyData(ctx context.Context, req *connect.Request[service.ExportMyDataRequest]) (*connect.Response[service.ExportMyDataResponse], error) {
+	return c.exportMyData.CallUnary(ctx, req)
+}
+
+// GetExportStatus calls api.v1.service.UserService.GetExportStatus.
+func (c *userServiceClient) GetExportStatus(ctx context.Context, req *connect.Request[service.GetExportStatusRequest]) (*connect.Response[service.GetExportStatusResponse], error) {
+	return c.getExportStatus.CallUnary(ctx, req)
+}
+
 // UserServiceHandler is an implementation of the api.v1.service.UserService service.
 type UserServiceHandler interface {
 	// Get or create anonymous user
@@ -115,6 +349,35 @@ type UserServiceHandler interface {
 	RegisterUser(context.Context, *connect.Request[service.RegisterUserRequest]) (*connect.Response[service.RegisterUserResponse], error)
 	// Get user by ID
 	GetUser(context.Context, *connect.Request[service.GetUserRequest]) (*connect.Response[service.GetUserResponse], error)
+	// Update the caller's profile; only fields named in update_mask change
+	UpdateProfile(context.Context, *connect.Request[service.UpdateProfileRequest]) (*connect.Response[service.UpdateProfileResponse], error)
+	// Replace the caller's avatar; the image is cropped and resized server-side
+	UploadAvatar(context.Context, *connect.Request[service.UploadAvatarRequest]) (*connect.Response[service.UploadAvatarResponse], error)
+	// Follow a user to see their pins in the feed
+	Follow(context.Context, *connect.Request[service.FollowRequest]) (*connect.Response[service.FollowResponse], error)
+	// Stop following a user
+	Unfollow(context.Context, *connect.Request[service.UnfollowRequest]) (*connect.Response[service.UnfollowResponse], error)
+	// List the users following a user, most recent first
+	ListFollowers(context.Context, *connect.Request[service.ListFollowersRequest]) (*connect.Response[service.ListFollowersResponse], error)
+	// List the users a user follows, most recent first
+	ListFollowing(context.Context, *connect.Request[service.ListFollowingRequest]) (*connect.Response[service.ListFollowingResponse], error)
+	// List a user's pins, newest first, for their profile page
+	ListUserPins(context.Context, *connect.Request[service.ListUserPinsRequest]) (*connect.Response[service.ListUserPinsResponse], error)
+	// Get the counts shown on a user's profile page
+	GetUserStats(context.Context, *connect.Request[service.GetUserStatsRequest]) (*connect.Response[service.GetUserStatsResponse], error)
+	// Block a user: hide their pins and comments, end follows both ways and
+	// stop them following or commenting on the caller's pins
+	BlockUser(context.Context, *connect.Request[service.BlockUserRequest]) (*connect.Response[service.BlockUserResponse], error)
+	// Remove a block or mute
+	UnblockUser(context.Context, *connect.Request[service.UnblockUserRequest]) (*connect.Response[service.UnblockUserResponse], error)
+	// Mute a user: hide their pins and comments from the caller only
+	MuteUser(context.Context, *connect.Request[service.MuteUserRequest]) (*connect.Response[service.MuteUserResponse], error)
+	// Schedule the caller's account for deletion; signing in again before then cancels it
+	DeleteAccount(context.Context, *connect.Request[service.DeleteAccountRequest]) (*connect.Response[service.DeleteAccountResponse], error)
+	// Start building an archive of the caller's data; poll GetExportStatus for the download link
+	ExportMyData(context.Context, *connect.Request[service.ExportMyDataRequest]) (*connect.Response[service.ExportMyDataResponse], error)
+	// Get the progress of a data export, with a short-lived download link once it is ready
+	GetExportStatus(context.Context, *connect.Request[service.GetExportStatusRequest]) (*connect.Response[service.GetExportStatusResponse], error)
 }
 
 // NewUserServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -142,6 +405,90 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(userServiceMethods.ByName("GetUser")),
 		connect.WithHandlerOptions(opts...),
 	)
+	userServiceUpdateProfileHandler := connect.NewUnaryHandler(
+		UserServiceUpdateProfileProcedure,
+		svc.UpdateProfile,
+		connect.WithSchema(userServiceMethods.ByName("UpdateProfile")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUploadAvatarHandler := connect.NewUnaryHandler(
+		UserServiceUploadAvatarProcedure,
+		svc.UploadAvatar,
+		connect.WithSchema(userServiceMethods.ByName("UploadAvatar")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceFollowHandler := connect.NewUnaryHandler(
+		UserServiceFollowProcedure,
+		svc.Follow,
+		connect.WithSchema(userServiceMethods.ByName("Follow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUnfollowHandler := connect.NewUnaryHandler(
+		UserServiceUnfollowProcedure,
+		svc.Unfollow,
+		connect.WithSchema(userServiceMethods.ByName("Unfollow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListFollowersHandler := connect.NewUnaryHandler(
+		UserServiceListFollowersProcedure,
+		svc.ListFollowers,
+		connect.WithSchema(userServiceMethods.ByName("ListFollowers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListFollowingHandler := connect.NewUnaryHandler(
+		UserServiceListFollowingProcedure,
+		svc.ListFollowing,
+		connect.WithSchema(userServiceMethods.ByName("ListFollowing")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceListUserPinsHandler := connect.NewUnaryHandler(
+		UserServiceListUserPinsProcedure,
+		svc.ListUserPins,
+		connect.WithSchema(userServiceMethods.ByName("ListUserPins")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceGetUserStatsHandler := connect.NewUnaryHandler(
+		UserServiceGetUserStatsProcedure,
+		svc.GetUserStats,
+		connect.WithSchema(userServiceMethods.ByName("GetUserStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceBlockUserHandler := connect.NewUnaryHandler(
+		UserServiceBlockUserProcedure,
+		svc.BlockUser,
+		connect.WithSchema(userServiceMethods.ByName("BlockUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceUnblockUserHandler := connect.NewUnaryHandler(
+		UserServiceUnblockUserProcedure,
+		svc.UnblockUser,
+		connect.WithSchema(userServiceMethods.ByName("UnblockUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceMuteUserHandler := connect.NewUnaryHandler(
+		UserServiceMuteUserProcedure,
+		svc.MuteUser,
+		connect.WithSchema(userServiceMethods.ByName("MuteUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceDeleteAccountHandler := connect.NewUnaryHandler(
+		UserServiceDeleteAccountProcedure,
+		svc.DeleteAccount,
+		connect.WithSchema(userServiceMethods.ByName("DeleteAccount")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceExportMyDataHandler := connect.NewUnaryHandler(
+		UserServiceExportMyDataProcedure,
+		svc.ExportMyData,
+		connect.WithSchema(userServiceMethods.ByName("ExportMyData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	userServiceGetExportStatusHandler := connect.NewUnaryHandler(
+		UserServiceGetExportStatusProcedure,
+		svc.GetExportStatus,
+		connect.WithSchema(userServiceMethods.ByName("GetExportStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/api.v1.service.UserService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case UserServiceGetCurrentUserProcedure:
@@ -150,6 +497,34 @@ func NewUserServiceHandler(svc UserServiceHandler, opts ...connect.HandlerOption
 			userServiceRegisterUserHandler.ServeHTTP(w, r)
 		case UserServiceGetUserProcedure:
 			userServiceGetUserHandler.ServeHTTP(w, r)
+		case UserServiceUpdateProfileProcedure:
+			userServiceUpdateProfileHandler.ServeHTTP(w, r)
+		case UserServiceUploadAvatarProcedure:
+			userServiceUploadAvatarHandler.ServeHTTP(w, r)
+		case UserServiceFollowProcedure:
+			userServiceFollowHandler.ServeHTTP(w, r)
+		case UserServiceUnfollowProcedure:
+			userServiceUnfollowHandler.ServeHTTP(w, r)
+		case UserServiceListFollowersProcedure:
+			userServiceListFollowersHandler.ServeHTTP(w, r)
+		case UserServiceListFollowingProcedure:
+			userServiceListFollowingHandler.ServeHTTP(w, r)
+		case UserServiceListUserPinsProcedure:
+			userServiceListUserPinsHandler.ServeHTTP(w, r)
+		case UserServiceGetUserStatsProcedure:
+			userServiceGetUserStatsHandler.ServeHTTP(w, r)
+		case UserServiceBlockUserProcedure:
+			userServiceBlockUserHandler.ServeHTTP(w, r)
+		case UserServiceUnblockUserProcedure:
+			userServiceUnblockUserHandler.ServeHTTP(w, r)
+		case UserServiceMuteUserProcedure:
+			userServiceMuteUserHandler.ServeHTTP(w, r)
+		case UserServiceDeleteAccountProcedure:
+			userServiceDeleteAccountHandler.ServeHTTP(w, r)
+		case UserServiceExportMyDataProcedure:
+			userServiceExportMyDataHandler.ServeHTTP(w, r)
+		case UserServiceGetExportStatusProcedure:
+			userServiceGetExportStatusHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -170,3 +545,59 @@ func (UnimplementedUserServiceHandler) RegisterUser(context.Context, *connect.Re
 func (UnimplementedUserServiceHandler) GetUser(context.Context, *connect.Request[service.GetUserRequest]) (*connect.Response[service.GetUserResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.GetUser is not implemented"))
 }
+
+func (UnimplementedUserServiceHandler) UpdateProfile(context.Context, *connect.Request[service.UpdateProfileRequest]) (*connect.Response[service.UpdateProfileResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.UpdateProfile is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) UploadAvatar(context.Context, *connect.Request[service.UploadAvatarRequest]) (*connect.Response[service.UploadAvatarResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.UploadAvatar is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) Follow(context.Context, *connect.Request[service.FollowRequest]) (*connect.Response[service.FollowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.Follow is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) Unfollow(context.Context, *connect.Request[service.UnfollowRequest]) (*connect.Response[service.UnfollowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.Unfollow is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListFollowers(context.Context, *connect.Request[service.ListFollowersRequest]) (*connect.Response[service.ListFollowersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.ListFollowers is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListFollowing(context.Context, *connect.Request[service.ListFollowingRequest]) (*connect.Response[service.ListFollowingResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.ListFollowing is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ListUserPins(context.Context, *connect.Request[service.ListUserPinsRequest]) (*connect.Response[service.ListUserPinsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.ListUserPins is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) GetUserStats(context.Context, *connect.Request[service.GetUserStatsRequest]) (*connect.Response[service.GetUserStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.GetUserStats is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) BlockUser(context.Context, *connect.Request[service.BlockUserRequest]) (*connect.Response[service.BlockUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.BlockUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) UnblockUser(context.Context, *connect.Request[service.UnblockUserRequest]) (*connect.Response[service.UnblockUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.UnblockUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) MuteUser(context.Context, *connect.Request[service.MuteUserRequest]) (*connect.Response[service.MuteUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.MuteUser is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) DeleteAccount(context.Context, *connect.Request[service.DeleteAccountRequest]) (*connect.Response[service.DeleteAccountResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.DeleteAccount is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) ExportMyData(context.Context, *connect.Request[service.ExportMyDataRequest]) (*connect.Response[service.ExportMyDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.ExportMyData is not implemented"))
+}
+
+func (UnimplementedUserServiceHandler) GetExportStatus(context.Context, *connect.Request[service.GetExportStatusRequest]) (*connect.Response[service.GetExportStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.service.UserService.GetExportStatus is not implemented"))
+}
@@ -10,6 +10,7 @@ import (
 	entities "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,6 +23,65 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// DataExportStatus is the state of a data export job
+type DataExportStatus int32
+
+const (
+	DataExportStatus_DATA_EXPORT_STATUS_UNSPECIFIED DataExportStatus = 0
+	DataExportStatus_DATA_EXPORT_STATUS_PENDING     DataExportStatus = 1
+	DataExportStatus_DATA_EXPORT_STATUS_RUNNING     DataExportStatus = 2
+	DataExportStatus_DATA_EXPORT_STATUS_READY       DataExportStatus = 3
+	DataExportStatus_DATA_EXPORT_STATUS_FAILED      DataExportStatus = 4
+	DataExportStatus_DATA_EXPORT_STATUS_EXPIRED     DataExportStatus = 5 // The archive was deleted; start a new export
+)
+
+// Enum value maps for DataExportStatus.
+var (
+	DataExportStatus_name = map[int32]string{
+		0: "DATA_EXPORT_STATUS_UNSPECIFIED",
+		1: "DATA_EXPORT_STATUS_PENDING",
+		2: "DATA_EXPORT_STATUS_RUNNING",
+		3: "DATA_EXPORT_STATUS_READY",
+		4: "DATA_EXPORT_STATUS_FAILED",
+		5: "DATA_EXPORT_STATUS_EXPIRED",
+	}
+	DataExportStatus_value = map[string]int32{
+		"DATA_EXPORT_STATUS_UNSPECIFIED": 0,
+		"DATA_EXPORT_STATUS_PENDING":     1,
+		"DATA_EXPORT_STATUS_RUNNING":     2,
+		"DATA_EXPORT_STATUS_READY":       3,
+		"DATA_EXPORT_STATUS_FAILED":      4,
+		"DATA_EXPORT_STATUS_EXPIRED":     5,
+	}
+)
+
+func (x DataExportStatus) Enum() *DataExportStatus {
+	p := new(DataExportStatus)
+	*p = x
+	return p
+}
+
+func (x DataExportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DataExportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v1_service_user_service_proto_enumTypes[0].Descriptor()
+}
+
+func (DataExportStatus) Type() protoreflect.EnumType {
+	return &file_v1_service_user_service_proto_enumTypes[0]
+}
+
+func (x DataExportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DataExportStatus.Descriptor instead.
+func (DataExportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{0}
+}
+
 // GetCurrentUserRequest - empty, uses session/JWT context
 type GetCurrentUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -311,30 +371,1652 @@ func (x *GetUserResponse) GetUser() *entities.User {
 	return nil
 }
 
-var File_v1_service_user_service_proto protoreflect.FileDescriptor
+type UpdateProfileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisplayName   string                 `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"` // Empty clears it
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`                          // Can only change once per cooldown period
+	AvatarUrl     string                 `protobuf:"bytes,3,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`       // Only "" is accepted, to remove the avatar; use UploadAvatar to set one
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`    // "display_name", "username", "avatar_url"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_v1_service_user_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1dv1/service/user_service.proto\x12\x0eapi.v1.service\x1a\x16v1/entities/user.proto\"\x17\n" +
-	"\x15GetCurrentUserRequest\"f\n" +
-	"\x16GetCurrentUserResponse\x12)\n" +
-	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12!\n" +
-	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\"c\n" +
-	"\x13RegisterUserRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\"d\n" +
-	"\x14RegisterUserResponse\x12)\n" +
-	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12!\n" +
-	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\")\n" +
-	"\x0eGetUserRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"<\n" +
-	"\x0fGetUserResponse\x12)\n" +
-	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user2\x95\x02\n" +
+func (x *UpdateProfileRequest) Reset() {
+	*x = UpdateProfileRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileRequest) ProtoMessage() {}
+
+func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateProfileRequest) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UpdateProfileRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateProfileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *entities.User         `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"` // New JWT when the username changed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProfileResponse) Reset() {
+	*x = UpdateProfileResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProfileResponse) ProtoMessage() {}
+
+func (x *UpdateProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProfileResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProfileResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateProfileResponse) GetUser() *entities.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UpdateProfileResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+type UploadAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         []byte                 `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"` // JPEG, PNG or GIF, up to 5 MB
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarRequest) Reset() {
+	*x = UploadAvatarRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarRequest) ProtoMessage() {}
+
+func (x *UploadAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UploadAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UploadAvatarRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type UploadAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *entities.User         `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarResponse) Reset() {
+	*x = UploadAvatarResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarResponse) ProtoMessage() {}
+
+func (x *UploadAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarResponse.ProtoReflect.Descriptor instead.
+func (*UploadAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UploadAvatarResponse) GetUser() *entities.User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type FollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequest) Reset() {
+	*x = FollowRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequest) ProtoMessage() {}
+
+func (x *FollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequest.ProtoReflect.Descriptor instead.
+func (*FollowRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FollowRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type FollowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Also true if already following
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowResponse) Reset() {
+	*x = FollowResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowResponse) ProtoMessage() {}
+
+func (x *FollowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowResponse.ProtoReflect.Descriptor instead.
+func (*FollowResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FollowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UnfollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowRequest) Reset() {
+	*x = UnfollowRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowRequest) ProtoMessage() {}
+
+func (x *UnfollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowRequest.ProtoReflect.Descriptor instead.
+func (*UnfollowRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UnfollowRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnfollowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Also true if not following
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowResponse) Reset() {
+	*x = UnfollowResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowResponse) ProtoMessage() {}
+
+func (x *UnfollowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowResponse.ProtoReflect.Descriptor instead.
+func (*UnfollowResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnfollowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListFollowersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`  // Defaults to 20, at most 100
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor from the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowersRequest) Reset() {
+	*x = ListFollowersRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowersRequest) ProtoMessage() {}
+
+func (x *ListFollowersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowersRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowersRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListFollowersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFollowersRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListFollowersRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type ListFollowersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*entities.User       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`                                   // Public profiles
+	NextCursor    *string                `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"` // Unset on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowersResponse) Reset() {
+	*x = ListFollowersResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowersResponse) ProtoMessage() {}
+
+func (x *ListFollowersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowersResponse.ProtoReflect.Descriptor instead.
+func (*ListFollowersResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFollowersResponse) GetUsers() []*entities.User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListFollowersResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+type ListFollowingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`  // Defaults to 20, at most 100
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor from the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowingRequest) Reset() {
+	*x = ListFollowingRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowingRequest) ProtoMessage() {}
+
+func (x *ListFollowingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowingRequest.ProtoReflect.Descriptor instead.
+func (*ListFollowingRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListFollowingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFollowingRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListFollowingRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type ListFollowingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*entities.User       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`                                   // Public profiles
+	NextCursor    *string                `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"` // Unset on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowingResponse) Reset() {
+	*x = ListFollowingResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowingResponse) ProtoMessage() {}
+
+func (x *ListFollowingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowingResponse.ProtoReflect.Descriptor instead.
+func (*ListFollowingResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListFollowingResponse) GetUsers() []*entities.User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListFollowingResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+type ListUserPinsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`  // Defaults to 20, at most 100
+	Cursor        *string                `protobuf:"bytes,3,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"` // next_cursor from the previous page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserPinsRequest) Reset() {
+	*x = ListUserPinsRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserPinsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserPinsRequest) ProtoMessage() {}
+
+func (x *ListUserPinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserPinsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserPinsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListUserPinsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListUserPinsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListUserPinsRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type ListUserPinsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*entities.Pin        `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`                                     // Only pins the caller may see
+	NextCursor    *string                `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"` // Unset on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserPinsResponse) Reset() {
+	*x = ListUserPinsResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserPinsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserPinsResponse) ProtoMessage() {}
+
+func (x *ListUserPinsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserPinsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserPinsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListUserPinsResponse) GetPins() []*entities.Pin {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+func (x *ListUserPinsResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+type GetUserStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsRequest) Reset() {
+	*x = GetUserStatsRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsRequest) ProtoMessage() {}
+
+func (x *GetUserStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserStatsRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetUserStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// UserStats holds the counts shown on a profile page
+type UserStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PinCount       int64                  `protobuf:"varint,1,opt,name=pin_count,json=pinCount,proto3" json:"pin_count,omitempty"`             // Pins the caller may see
+	CommentCount   int64                  `protobuf:"varint,2,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"` // Comments the user has written
+	FollowerCount  int64                  `protobuf:"varint,3,opt,name=follower_count,json=followerCount,proto3" json:"follower_count,omitempty"`
+	FollowingCount int64                  `protobuf:"varint,4,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
+	MemberSince    int64                  `protobuf:"varint,5,opt,name=member_since,json=memberSince,proto3" json:"member_since,omitempty"` // Unix timestamp of sign-up
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UserStats) Reset() {
+	*x = UserStats{}
+	mi := &file_v1_service_user_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserStats) ProtoMessage() {}
+
+func (x *UserStats) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserStats.ProtoReflect.Descriptor instead.
+func (*UserStats) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UserStats) GetPinCount() int64 {
+	if x != nil {
+		return x.PinCount
+	}
+	return 0
+}
+
+func (x *UserStats) GetCommentCount() int64 {
+	if x != nil {
+		return x.CommentCount
+	}
+	return 0
+}
+
+func (x *UserStats) GetFollowerCount() int64 {
+	if x != nil {
+		return x.FollowerCount
+	}
+	return 0
+}
+
+func (x *UserStats) GetFollowingCount() int64 {
+	if x != nil {
+		return x.FollowingCount
+	}
+	return 0
+}
+
+func (x *UserStats) GetMemberSince() int64 {
+	if x != nil {
+		return x.MemberSince
+	}
+	return 0
+}
+
+type GetUserStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *UserStats             `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserStatsResponse) Reset() {
+	*x = GetUserStatsResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserStatsResponse) ProtoMessage() {}
+
+func (x *GetUserStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserStatsResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUserStatsResponse) GetStats() *UserStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BlockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BlockUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UnblockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Also true if the user was not blocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UnblockUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type MuteUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteUserRequest) Reset() {
+	*x = MuteUserRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteUserRequest) ProtoMessage() {}
+
+func (x *MuteUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteUserRequest.ProtoReflect.Descriptor instead.
+func (*MuteUserRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MuteUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type MuteUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"` // Also true if the user is already muted or blocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteUserResponse) Reset() {
+	*x = MuteUserResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteUserResponse) ProtoMessage() {}
+
+func (x *MuteUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteUserResponse.ProtoReflect.Descriptor instead.
+func (*MuteUserResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MuteUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type DeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAccountRequest) Reset() {
+	*x = DeleteAccountRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountRequest) ProtoMessage() {}
+
+func (x *DeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{29}
+}
+
+type DeleteAccountResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DeletionScheduledAt int64                  `protobuf:"varint,1,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty"` // Unix timestamp the account will be erased at
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DeleteAccountResponse) Reset() {
+	*x = DeleteAccountResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAccountResponse) ProtoMessage() {}
+
+func (x *DeleteAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAccountResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAccountResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteAccountResponse) GetDeletionScheduledAt() int64 {
+	if x != nil {
+		return x.DeletionScheduledAt
+	}
+	return 0
+}
+
+// DataExport is a zip archive of a user's data as JSON, with pins as GeoJSON
+type DataExport struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status               DataExportStatus       `protobuf:"varint,2,opt,name=status,proto3,enum=api.v1.service.DataExportStatus" json:"status,omitempty"`
+	Progress             int32                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`                                                               // Percent complete
+	CreatedAt            int64                  `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                                            // Unix timestamp
+	CompletedAt          *int64                 `protobuf:"varint,5,opt,name=completed_at,json=completedAt,proto3,oneof" json:"completed_at,omitempty"`                                // Unix timestamp
+	ExpiresAt            *int64                 `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3,oneof" json:"expires_at,omitempty"`                                      // Unix timestamp the archive is deleted at
+	DownloadUrl          *string                `protobuf:"bytes,7,opt,name=download_url,json=downloadUrl,proto3,oneof" json:"download_url,omitempty"`                                 // Signed link, only while ready
+	DownloadUrlExpiresAt *int64                 `protobuf:"varint,8,opt,name=download_url_expires_at,json=downloadUrlExpiresAt,proto3,oneof" json:"download_url_expires_at,omitempty"` // Unix timestamp; poll again for a fresh link
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DataExport) Reset() {
+	*x = DataExport{}
+	mi := &file_v1_service_user_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DataExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataExport) ProtoMessage() {}
+
+func (x *DataExport) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataExport.ProtoReflect.Descriptor instead.
+func (*DataExport) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DataExport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DataExport) GetStatus() DataExportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DataExportStatus_DATA_EXPORT_STATUS_UNSPECIFIED
+}
+
+func (x *DataExport) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *DataExport) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *DataExport) GetCompletedAt() int64 {
+	if x != nil && x.CompletedAt != nil {
+		return *x.CompletedAt
+	}
+	return 0
+}
+
+func (x *DataExport) GetExpiresAt() int64 {
+	if x != nil && x.ExpiresAt != nil {
+		return *x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *DataExport) GetDownloadUrl() string {
+	if x != nil && x.DownloadUrl != nil {
+		return *x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *DataExport) GetDownloadUrlExpiresAt() int64 {
+	if x != nil && x.DownloadUrlExpiresAt != nil {
+		return *x.DownloadUrlExpiresAt
+	}
+	return 0
+}
+
+type ExportMyDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataRequest) Reset() {
+	*x = ExportMyDataRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataRequest) ProtoMessage() {}
+
+func (x *ExportMyDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportMyDataRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{32}
+}
+
+type ExportMyDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *DataExport            `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"` // An export already in progress is returned instead of starting another
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportMyDataResponse) Reset() {
+	*x = ExportMyDataResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportMyDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportMyDataResponse) ProtoMessage() {}
+
+func (x *ExportMyDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportMyDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportMyDataResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportMyDataResponse) GetExport() *DataExport {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+type GetExportStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportStatusRequest) Reset() {
+	*x = GetExportStatusRequest{}
+	mi := &file_v1_service_user_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExportStatusRequest) ProtoMessage() {}
+
+func (x *GetExportStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExportStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetExportStatusRequest) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetExportStatusRequest) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+type GetExportStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *DataExport            `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportStatusResponse) Reset() {
+	*x = GetExportStatusResponse{}
+	mi := &file_v1_service_user_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExportStatusResponse) ProtoMessage() {}
+
+func (x *GetExportStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v1_service_user_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExportStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetExportStatusResponse) Descriptor() ([]byte, []int) {
+	return file_v1_service_user_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetExportStatusResponse) GetExport() *DataExport {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+var File_v1_service_user_service_proto protoreflect.FileDescriptor
+
+const file_v1_service_user_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1dv1/service/user_service.proto\x12\x0eapi.v1.service\x1a google/protobuf/field_mask.proto\x1a\x15v1/entities/pin.proto\x1a\x16v1/entities/user.proto\"\x17\n" +
+	"\x15GetCurrentUserRequest\"f\n" +
+	"\x16GetCurrentUserResponse\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\"c\n" +
+	"\x13RegisterUserRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"d\n" +
+	"\x14RegisterUserResponse\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\")\n" +
+	"\x0eGetUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"<\n" +
+	"\x0fGetUserResponse\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\"\xb1\x01\n" +
+	"\x14UpdateProfileRequest\x12!\n" +
+	"\fdisplay_name\x18\x01 \x01(\tR\vdisplayName\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x03 \x01(\tR\tavatarUrl\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"e\n" +
+	"\x15UpdateProfileResponse\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\"+\n" +
+	"\x13UploadAvatarRequest\x12\x14\n" +
+	"\x05image\x18\x01 \x01(\fR\x05image\"A\n" +
+	"\x14UploadAvatarResponse\x12)\n" +
+	"\x04user\x18\x01 \x01(\v2\x15.api.v1.entities.UserR\x04user\"(\n" +
+	"\rFollowRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"*\n" +
+	"\x0eFollowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"*\n" +
+	"\x0fUnfollowRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\",\n" +
+	"\x10UnfollowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"|\n" +
+	"\x14ListFollowersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_cursor\"z\n" +
+	"\x15ListFollowersResponse\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.api.v1.entities.UserR\x05users\x12$\n" +
+	"\vnext_cursor\x18\x02 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor\"|\n" +
+	"\x14ListFollowingRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_cursor\"z\n" +
+	"\x15ListFollowingResponse\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.api.v1.entities.UserR\x05users\x12$\n" +
+	"\vnext_cursor\x18\x02 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor\"{\n" +
+	"\x13ListUserPinsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\x05limit\x18\x02 \x01(\x05H\x00R\x05limit\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x03 \x01(\tH\x01R\x06cursor\x88\x01\x01B\b\n" +
+	"\x06_limitB\t\n" +
+	"\a_cursor\"v\n" +
+	"\x14ListUserPinsResponse\x12(\n" +
+	"\x04pins\x18\x01 \x03(\v2\x14.api.v1.entities.PinR\x04pins\x12$\n" +
+	"\vnext_cursor\x18\x02 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor\".\n" +
+	"\x13GetUserStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc0\x01\n" +
+	"\tUserStats\x12\x1b\n" +
+	"\tpin_count\x18\x01 \x01(\x03R\bpinCount\x12#\n" +
+	"\rcomment_count\x18\x02 \x01(\x03R\fcommentCount\x12%\n" +
+	"\x0efollower_count\x18\x03 \x01(\x03R\rfollowerCount\x12'\n" +
+	"\x0ffollowing_count\x18\x04 \x01(\x03R\x0efollowingCount\x12!\n" +
+	"\fmember_since\x18\x05 \x01(\x03R\vmemberSince\"G\n" +
+	"\x14GetUserStatsResponse\x12/\n" +
+	"\x05stats\x18\x01 \x01(\v2\x19.api.v1.service.UserStatsR\x05stats\"+\n" +
+	"\x10BlockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"-\n" +
+	"\x11BlockUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"-\n" +
+	"\x12UnblockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"/\n" +
+	"\x13UnblockUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"*\n" +
+	"\x0fMuteUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\",\n" +
+	"\x10MuteUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x16\n" +
+	"\x14DeleteAccountRequest\"K\n" +
+	"\x15DeleteAccountResponse\x122\n" +
+	"\x15deletion_scheduled_at\x18\x01 \x01(\x03R\x13deletionScheduledAt\"\x8e\x03\n" +
+	"\n" +
+	"DataExport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x128\n" +
+	"\x06status\x18\x02 \x01(\x0e2 .api.v1.service.DataExportStatusR\x06status\x12\x1a\n" +
+	"\bprogress\x18\x03 \x01(\x05R\bprogress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12&\n" +
+	"\fcompleted_at\x18\x05 \x01(\x03H\x00R\vcompletedAt\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03H\x01R\texpiresAt\x88\x01\x01\x12&\n" +
+	"\fdownload_url\x18\a \x01(\tH\x02R\vdownloadUrl\x88\x01\x01\x12:\n" +
+	"\x17download_url_expires_at\x18\b \x01(\x03H\x03R\x14downloadUrlExpiresAt\x88\x01\x01B\x0f\n" +
+	"\r_completed_atB\r\n" +
+	"\v_expires_atB\x0f\n" +
+	"\r_download_urlB\x1a\n" +
+	"\x18_download_url_expires_at\"\x15\n" +
+	"\x13ExportMyDataRequest\"J\n" +
+	"\x14ExportMyDataResponse\x122\n" +
+	"\x06export\x18\x01 \x01(\v2\x1a.api.v1.service.DataExportR\x06export\"5\n" +
+	"\x16GetExportStatusRequest\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\"M\n" +
+	"\x17GetExportStatusResponse\x122\n" +
+	"\x06export\x18\x01 \x01(\v2\x1a.api.v1.service.DataExportR\x06export*\xd3\x01\n" +
+	"\x10DataExportStatus\x12\"\n" +
+	"\x1eDATA_EXPORT_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aDATA_EXPORT_STATUS_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aDATA_EXPORT_STATUS_RUNNING\x10\x02\x12\x1c\n" +
+	"\x18DATA_EXPORT_STATUS_READY\x10\x03\x12\x1d\n" +
+	"\x19DATA_EXPORT_STATUS_FAILED\x10\x04\x12\x1e\n" +
+	"\x1aDATA_EXPORT_STATUS_EXPIRED\x10\x052\xee\v\n" +
 	"\vUserService\x12_\n" +
 	"\x0eGetCurrentUser\x12%.api.v1.service.GetCurrentUserRequest\x1a&.api.v1.service.GetCurrentUserResponse\x12Y\n" +
 	"\fRegisterUser\x12#.api.v1.service.RegisterUserRequest\x1a$.api.v1.service.RegisterUserResponse\x12J\n" +
-	"\aGetUser\x12\x1e.api.v1.service.GetUserRequest\x1a\x1f.api.v1.service.GetUserResponseBMZKgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/service;servicev1b\x06proto3"
+	"\aGetUser\x12\x1e.api.v1.service.GetUserRequest\x1a\x1f.api.v1.service.GetUserResponse\x12\\\n" +
+	"\rUpdateProfile\x12$.api.v1.service.UpdateProfileRequest\x1a%.api.v1.service.UpdateProfileResponse\x12Y\n" +
+	"\fUploadAvatar\x12#.api.v1.service.UploadAvatarRequest\x1a$.api.v1.service.UploadAvatarResponse\x12G\n" +
+	"\x06Follow\x12\x1d.api.v1.service.FollowRequest\x1a\x1e.api.v1.service.FollowResponse\x12M\n" +
+	"\bUnfollow\x12\x1f.api.v1.service.UnfollowRequest\x1a .api.v1.service.UnfollowResponse\x12\\\n" +
+	"\rListFollowers\x12$.api.v1.service.ListFollowersRequest\x1a%.api.v1.service.ListFollowersResponse\x12\\\n" +
+	"\rListFollowing\x12$.api.v1.service.ListFollowingRequest\x1a%.api.v1.service.ListFollowingResponse\x12Y\n" +
+	"\fListUserPins\x12#.api.v1.service.ListUserPinsRequest\x1a$.api.v1.service.ListUserPinsResponse\x12Y\n" +
+	"\fGetUserStats\x12#.api.v1.service.GetUserStatsRequest\x1a$.api.v1.service.GetUserStatsResponse\x12P\n" +
+	"\tBlockUser\x12 .api.v1.service.BlockUserRequest\x1a!.api.v1.service.BlockUserResponse\x12V\n" +
+	"\vUnblockUser\x12\".api.v1.service.UnblockUserRequest\x1a#.api.v1.service.UnblockUserResponse\x12M\n" +
+	"\bMuteUser\x12\x1f.api.v1.service.MuteUserRequest\x1a .api.v1.service.MuteUserResponse\x12\\\n" +
+	"\rDeleteAccount\x12$.api.v1.service.DeleteAccountRequest\x1a%.api.v1.service.DeleteAccountResponse\x12Y\n" +
+	"\fExportMyData\x12#.api.v1.service.ExportMyDataRequest\x1a$.api.v1.service.ExportMyDataResponse\x12b\n" +
+	"\x0fGetExportStatus\x12&.api.v1.service.GetExportStatusRequest\x1a'.api.v1.service.GetExportStatusResponseBMZKgithub.com/radjathaher/alunalun/api/internal/protocgen/v1/service;servicev1b\x06proto3"
 
 var (
 	file_v1_service_user_service_proto_rawDescOnce sync.Once
@@ -348,31 +2030,103 @@ func file_v1_service_user_service_proto_rawDescGZIP() []byte {
 	return file_v1_service_user_service_proto_rawDescData
 }
 
-var file_v1_service_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_v1_service_user_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v1_service_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_v1_service_user_service_proto_goTypes = []any{
-	(*GetCurrentUserRequest)(nil),  // 0: api.v1.service.GetCurrentUserRequest
-	(*GetCurrentUserResponse)(nil), // 1: api.v1.service.GetCurrentUserResponse
-	(*RegisterUserRequest)(nil),    // 2: api.v1.service.RegisterUserRequest
-	(*RegisterUserResponse)(nil),   // 3: api.v1.service.RegisterUserResponse
-	(*GetUserRequest)(nil),         // 4: api.v1.service.GetUserRequest
-	(*GetUserResponse)(nil),        // 5: api.v1.service.GetUserResponse
-	(*entities.User)(nil),          // 6: api.v1.entities.User
+	(DataExportStatus)(0),           // 0: api.v1.service.DataExportStatus
+	(*GetCurrentUserRequest)(nil),   // 1: api.v1.service.GetCurrentUserRequest
+	(*GetCurrentUserResponse)(nil),  // 2: api.v1.service.GetCurrentUserResponse
+	(*RegisterUserRequest)(nil),     // 3: api.v1.service.RegisterUserRequest
+	(*RegisterUserResponse)(nil),    // 4: api.v1.service.RegisterUserResponse
+	(*GetUserRequest)(nil),          // 5: api.v1.service.GetUserRequest
+	(*GetUserResponse)(nil),         // 6: api.v1.service.GetUserResponse
+	(*UpdateProfileRequest)(nil),    // 7: api.v1.service.UpdateProfileRequest
+	(*UpdateProfileResponse)(nil),   // 8: api.v1.service.UpdateProfileResponse
+	(*UploadAvatarRequest)(nil),     // 9: api.v1.service.UploadAvatarRequest
+	(*UploadAvatarResponse)(nil),    // 10: api.v1.service.UploadAvatarResponse
+	(*FollowRequest)(nil),           // 11: api.v1.service.FollowRequest
+	(*FollowResponse)(nil),          // 12: api.v1.service.FollowResponse
+	(*UnfollowRequest)(nil),         // 13: api.v1.service.UnfollowRequest
+	(*UnfollowResponse)(nil),        // 14: api.v1.service.UnfollowResponse
+	(*ListFollowersRequest)(nil),    // 15: api.v1.service.ListFollowersRequest
+	(*ListFollowersResponse)(nil),   // 16: api.v1.service.ListFollowersResponse
+	(*ListFollowingRequest)(nil),    // 17: api.v1.service.ListFollowingRequest
+	(*ListFollowingResponse)(nil),   // 18: api.v1.service.ListFollowingResponse
+	(*ListUserPinsRequest)(nil),     // 19: api.v1.service.ListUserPinsRequest
+	(*ListUserPinsResponse)(nil),    // 20: api.v1.service.ListUserPinsResponse
+	(*GetUserStatsRequest)(nil),     // 21: api.v1.service.GetUserStatsRequest
+	(*UserStats)(nil),               // 22: api.v1.service.UserStats
+	(*GetUserStatsResponse)(nil),    // 23: api.v1.service.GetUserStatsResponse
+	(*BlockUserRequest)(nil),        // 24: api.v1.service.BlockUserRequest
+	(*BlockUserResponse)(nil),       // 25: api.v1.service.BlockUserResponse
+	(*UnblockUserRequest)(nil),      // 26: api.v1.service.UnblockUserRequest
+	(*UnblockUserResponse)(nil),     // 27: api.v1.service.UnblockUserResponse
+	(*MuteUserRequest)(nil),         // 28: api.v1.service.MuteUserRequest
+	(*MuteUserResponse)(nil),        // 29: api.v1.service.MuteUserResponse
+	(*DeleteAccountRequest)(nil),    // 30: api.v1.service.DeleteAccountRequest
+	(*DeleteAccountResponse)(nil),   // 31: api.v1.service.DeleteAccountResponse
+	(*DataExport)(nil),              // 32: api.v1.service.DataExport
+	(*ExportMyDataRequest)(nil),     // 33: api.v1.service.ExportMyDataRequest
+	(*ExportMyDataResponse)(nil),    // 34: api.v1.service.ExportMyDataResponse
+	(*GetExportStatusRequest)(nil),  // 35: api.v1.service.GetExportStatusRequest
+	(*GetExportStatusResponse)(nil), // 36: api.v1.service.GetExportStatusResponse
+	(*entities.User)(nil),           // 37: api.v1.entities.User
+	(*fieldmaskpb.FieldMask)(nil),   // 38: google.protobuf.FieldMask
+	(*entities.Pin)(nil),            // 39: api.v1.entities.Pin
 }
 var file_v1_service_user_service_proto_depIdxs = []int32{
-	6, // 0: api.v1.service.GetCurrentUserResponse.user:type_name -> api.v1.entities.User
-	6, // 1: api.v1.service.RegisterUserResponse.user:type_name -> api.v1.entities.User
-	6, // 2: api.v1.service.GetUserResponse.user:type_name -> api.v1.entities.User
-	0, // 3: api.v1.service.UserService.GetCurrentUser:input_type -> api.v1.service.GetCurrentUserRequest
-	2, // 4: api.v1.service.UserService.RegisterUser:input_type -> api.v1.service.RegisterUserRequest
-	4, // 5: api.v1.service.UserService.GetUser:input_type -> api.v1.service.GetUserRequest
-	1, // 6: api.v1.service.UserService.GetCurrentUser:output_type -> api.v1.service.GetCurrentUserResponse
-	3, // 7: api.v1.service.UserService.RegisterUser:output_type -> api.v1.service.RegisterUserResponse
-	5, // 8: api.v1.service.UserService.GetUser:output_type -> api.v1.service.GetUserResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	37, // 0: api.v1.service.GetCurrentUserResponse.user:type_name -> api.v1.entities.User
+	37, // 1: api.v1.service.RegisterUserResponse.user:type_name -> api.v1.entities.User
+	37, // 2: api.v1.service.GetUserResponse.user:type_name -> api.v1.entities.User
+	38, // 3: api.v1.service.UpdateProfileRequest.update_mask:type_name -> google.protobuf.FieldMask
+	37, // 4: api.v1.service.UpdateProfileResponse.user:type_name -> api.v1.entities.User
+	37, // 5: api.v1.service.UploadAvatarResponse.user:type_name -> api.v1.entities.User
+	37, // 6: api.v1.service.ListFollowersResponse.users:type_name -> api.v1.entities.User
+	37, // 7: api.v1.service.ListFollowingResponse.users:type_name -> api.v1.entities.User
+	39, // 8: api.v1.service.ListUserPinsResponse.pins:type_name -> api.v1.entities.Pin
+	22, // 9: api.v1.service.GetUserStatsResponse.stats:type_name -> api.v1.service.UserStats
+	0,  // 10: api.v1.service.DataExport.status:type_name -> api.v1.service.DataExportStatus
+	32, // 11: api.v1.service.ExportMyDataResponse.export:type_name -> api.v1.service.DataExport
+	32, // 12: api.v1.service.GetExportStatusResponse.export:type_name -> api.v1.service.DataExport
+	1,  // 13: api.v1.service.UserService.GetCurrentUser:input_type -> api.v1.service.GetCurrentUserRequest
+	3,  // 14: api.v1.service.UserService.RegisterUser:input_type -> api.v1.service.RegisterUserRequest
+	5,  // 15: api.v1.service.UserService.GetUser:input_type -> api.v1.service.GetUserRequest
+	7,  // 16: api.v1.service.UserService.UpdateProfile:input_type -> api.v1.service.UpdateProfileRequest
+	9,  // 17: api.v1.service.UserService.UploadAvatar:input_type -> api.v1.service.UploadAvatarRequest
+	11, // 18: api.v1.service.UserService.Follow:input_type -> api.v1.service.FollowRequest
+	13, // 19: api.v1.service.UserService.Unfollow:input_type -> api.v1.service.UnfollowRequest
+	15, // 20: api.v1.service.UserService.ListFollowers:input_type -> api.v1.service.ListFollowersRequest
+	17, // 21: api.v1.service.UserService.ListFollowing:input_type -> api.v1.service.ListFollowingRequest
+	19, // 22: api.v1.service.UserService.ListUserPins:input_type -> api.v1.service.ListUserPinsRequest
+	21, // 23: api.v1.service.UserService.GetUserStats:input_type -> api.v1.service.GetUserStatsRequest
+	24, // 24: api.v1.service.UserService.BlockUser:input_type -> api.v1.service.BlockUserRequest
+	26, // 25: api.v1.service.UserService.UnblockUser:input_type -> api.v1.service.UnblockUserRequest
+	28, // 26: api.v1.service.UserService.MuteUser:input_type -> api.v1.service.MuteUserRequest
+	30, // 27: api.v1.service.UserService.DeleteAccount:input_type -> api.v1.service.DeleteAccountRequest
+	33, // 28: api.v1.service.UserService.ExportMyData:input_type -> api.v1.service.ExportMyDataRequest
+	35, // 29: api.v1.service.UserService.GetExportStatus:input_type -> api.v1.service.GetExportStatusRequest
+	2,  // 30: api.v1.service.UserService.GetCurrentUser:output_type -> api.v1.service.GetCurrentUserResponse
+	4,  // 31: api.v1.service.UserService.RegisterUser:output_type -> api.v1.service.RegisterUserResponse
+	6,  // 32: api.v1.service.UserService.GetUser:output_type -> api.v1.service.GetUserResponse
+	8,  // 33: api.v1.service.UserService.UpdateProfile:output_type -> api.v1.service.UpdateProfileResponse
+	10, // 34: api.v1.service.UserService.UploadAvatar:output_type -> api.v1.service.UploadAvatarResponse
+	12, // 35: api.v1.service.UserService.Follow:output_type -> api.v1.service.FollowResponse
+	14, // 36: api.v1.service.UserService.Unfollow:output_type -> api.v1.service.UnfollowResponse
+	16, // 37: api.v1.service.UserService.ListFollowers:output_type -> api.v1.service.ListFollowersResponse
+	18, // 38: api.v1.service.UserService.ListFollowing:output_type -> api.v1.service.ListFollowingResponse
+	20, // 39: api.v1.service.UserService.ListUserPins:output_type -> api.v1.service.ListUserPinsResponse
+	23, // 40: api.v1.service.UserService.GetUserStats:output_type -> api.v1.service.GetUserStatsResponse
+	25, // 41: api.v1.service.UserService.BlockUser:output_type -> api.v1.service.BlockUserResponse
+	27, // 42: api.v1.service.UserService.UnblockUser:output_type -> api.v1.service.UnblockUserResponse
+	29, // 43: api.v1.service.UserService.MuteUser:output_type -> api.v1.service.MuteUserResponse
+	31, // 44: api.v1.service.UserService.DeleteAccount:output_type -> api.v1.service.DeleteAccountResponse
+	34, // 45: api.v1.service.UserService.ExportMyData:output_type -> api.v1.service.ExportMyDataResponse
+	36, // 46: api.v1.service.UserService.GetExportStatus:output_type -> api.v1.service.GetExportStatusResponse
+	30, // [30:47] is the sub-list for method output_type
+	13, // [13:30] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_v1_service_user_service_proto_init() }
@@ -380,18 +2134,26 @@ func file_v1_service_user_service_proto_init() {
 	if File_v1_service_user_service_proto != nil {
 		return
 	}
+	file_v1_service_user_service_proto_msgTypes[14].OneofWrappers = []any{}
+	file_v1_service_user_service_proto_msgTypes[15].OneofWrappers = []any{}
+	file_v1_service_user_service_proto_msgTypes[16].OneofWrappers = []any{}
+	file_v1_service_user_service_proto_msgTypes[17].OneofWrappers = []any{}
+	file_v1_service_user_service_proto_msgTypes[18].OneofWrappers = []any{}
+	file_v1_service_user_service_proto_msgTypes[19].OneofWrappers = []any{}
+	file_v1_service_user_service_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v1_service_user_service_proto_rawDesc), len(file_v1_service_user_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_v1_service_user_service_proto_goTypes,
 		DependencyIndexes: file_v1_service_user_service_proto_depIdxs,
+		EnumInfos:         file_v1_service_user_service_proto_enumTypes,
 		MessageInfos:      file_v1_service_user_service_proto_msgTypes,
 	}.Build()
 	File_v1_service_user_service_proto = out.File
@@ -0,0 +1,178 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// deletedUserID is the placeholder author the posts of erased accounts are moved to
+const deletedUserID = "00000000-0000-0000-0000-000000000000"
+
+// PostgresAccountDeletionStore implements auth.AccountDeletionStore with database transactions
+type PostgresAccountDeletionStore struct {
+	db      *pgxpool.Pool
+	queries *repository.Queries
+}
+
+// NewPostgresAccountDeletionStore creates a new PostgreSQL account deletion store
+func NewPostgresAccountDeletionStore(db *pgxpool.Pool, queries *repository.Queries) *PostgresAccountDeletionStore {
+	return &PostgresAccountDeletionStore{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// ScheduleAccountDeletion marks a user for erasure at a time
+func (s *PostgresAccountDeletionStore) ScheduleAccountDeletion(ctx context.Context, userID string, at time.Time) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.ScheduleUserDeletion(ctx, &repository.ScheduleUserDeletionParams{
+		ID:                  id,
+		DeletionScheduledAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
+	}
+	return nil
+}
+
+// CancelAccountDeletion clears a scheduled erasure
+func (s *PostgresAccountDeletionStore) CancelAccountDeletion(ctx context.Context, userID string) (bool, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.CancelUserDeletion(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// PostponeAccountDeletion moves a still-scheduled erasure to a later time
+func (s *PostgresAccountDeletionStore) PostponeAccountDeletion(ctx context.Context, userID string, at time.Time) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := s.queries.PostponeUserDeletion(ctx, &repository.PostponeUserDeletionParams{
+		ID:                  id,
+		DeletionScheduledAt: pgtype.Timestamptz{Time: at, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to postpone account deletion: %w", err)
+	}
+	return nil
+}
+
+// ListAccountsDueForDeletion lists users scheduled for erasure before a time, oldest first
+func (s *PostgresAccountDeletionStore) ListAccountsDueForDeletion(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	ids, err := s.queries.ListUsersDueForDeletion(ctx, &repository.ListUsersDueForDeletionParams{
+		DeletionScheduledAt: pgtype.Timestamptz{Time: before, Valid: true},
+		Limit:               int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts due for deletion: %w", err)
+	}
+
+	userIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		userIDs = append(userIDs, id.String())
+	}
+	return userIDs, nil
+}
+
+// EraseAccount removes or anonymizes a user's posts and deletes the user in a
+// single transaction. Deleting the row cascades to provider links, credentials,
+// MFA, passkeys, roles, API keys, follows and blocks.
+func (s *PostgresAccountDeletionStore) EraseAccount(ctx context.Context, userID string, deleteContent bool) (*auth.User, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	user, err := qtx.GetUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := detachPosts(ctx, qtx, id, deleteContent); err != nil {
+		return nil, err
+	}
+
+	rows, err := qtx.DeleteScheduledUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+	if rows == 0 {
+		return nil, nil // Cancelled by a sign-in; roll back
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	erased := &auth.User{
+		ID:       user.ID.String(),
+		Username: user.Username,
+		Email:    user.Email,
+	}
+	if user.AvatarUrl != nil {
+		erased.Picture = *user.AvatarUrl
+	}
+	return erased, nil
+}
+
+// detachPosts removes or redacts a user's posts and moves the rest to the
+// placeholder author, so deleting the user doesn't cascade through threads
+// other users replied in
+func detachPosts(ctx context.Context, qtx *repository.Queries, id pgtype.UUID, deleteContent bool) error {
+	var placeholderID pgtype.UUID
+	if err := placeholderID.Scan(deletedUserID); err != nil {
+		return fmt.Errorf("invalid placeholder user ID: %w", err)
+	}
+
+	if deleteContent {
+		if _, err := qtx.DeleteUnrepliedPostsByUser(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete posts: %w", err)
+		}
+		// Posts other users replied to stay so their threads survive, but without the content
+		if _, err := qtx.RedactPostsByUser(ctx, id); err != nil {
+			return fmt.Errorf("failed to redact posts: %w", err)
+		}
+	}
+
+	if _, err := qtx.ReassignPostsToUser(ctx, &repository.ReassignPostsToUserParams{
+		NewUserID: placeholderID,
+		OldUserID: id,
+	}); err != nil {
+		return fmt.Errorf("failed to reassign posts: %w", err)
+	}
+	return nil
+}
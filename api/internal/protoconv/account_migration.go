@@ -0,0 +1,215 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// PostgresAccountMigrator implements auth.AccountMigrator with database transactions
+type PostgresAccountMigrator struct {
+	db      *pgxpool.Pool
+	queries *repository.Queries
+}
+
+// NewPostgresAccountMigrator creates a new PostgreSQL account migrator
+func NewPostgresAccountMigrator(db *pgxpool.Pool, queries *repository.Queries) *PostgresAccountMigrator {
+	return &PostgresAccountMigrator{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// MergeAnonymousUser re-owns the anonymous user's content to userID and deletes
+// the anonymous user in a single transaction
+func (m *PostgresAccountMigrator) MergeAnonymousUser(ctx context.Context, anonUserID, userID, username string) error {
+	var anonID, targetID pgtype.UUID
+	if err := anonID.Scan(anonUserID); err != nil {
+		return fmt.Errorf("invalid anonymous user ID: %w", err)
+	}
+	if err := targetID.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := m.queries.WithTx(tx)
+
+	// Load the target first so a missing account aborts before anything moves
+	target, err := qtx.GetUserByID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if _, err := qtx.ReassignPostsToUser(ctx, &repository.ReassignPostsToUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to reassign posts: %w", err)
+	}
+
+	if _, err := qtx.ReassignUserAuthProviders(ctx, &repository.ReassignUserAuthProvidersParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to reassign provider links: %w", err)
+	}
+
+	// Saved areas would otherwise go with the anonymous row. Only as many move
+	// as fit under the account's limit; the rest go with it after all.
+	if err := qtx.LockSavedAreasByUser(ctx, targetID); err != nil {
+		return fmt.Errorf("failed to lock saved areas: %w", err)
+	}
+	if _, err := qtx.ReassignSavedAreasToUser(ctx, &repository.ReassignSavedAreasToUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+		MaxAreas:  MaxSavedAreas,
+	}); err != nil {
+		return fmt.Errorf("failed to reassign saved areas: %w", err)
+	}
+
+	// Notifications and their settings would otherwise go with the anonymous
+	// row, as would notifications others got about its activity
+	if err := qtx.CopyNotificationsToUser(ctx, &repository.CopyNotificationsToUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy notifications: %w", err)
+	}
+	if err := qtx.DeleteDuplicateFollowNotifications(ctx, &repository.DeleteDuplicateFollowNotificationsParams{
+		OldUserID: anonID,
+		NewUserID: targetID,
+	}); err != nil {
+		return fmt.Errorf("failed to delete duplicate follow notifications: %w", err)
+	}
+	if err := qtx.ReassignNotificationActor(ctx, &repository.ReassignNotificationActorParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to reassign notifications: %w", err)
+	}
+	if err := qtx.CopyNotificationPreferences(ctx, &repository.CopyNotificationPreferencesParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy notification preferences: %w", err)
+	}
+
+	// Keep blocks in force both ways, so signing in can't shake one off
+	if err := qtx.CopyBlocksByUser(ctx, &repository.CopyBlocksByUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy blocks: %w", err)
+	}
+	if err := qtx.CopyBlocksOfUser(ctx, &repository.CopyBlocksOfUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy blocks: %w", err)
+	}
+
+	// Follows in both directions, after the blocks so blocked pairs stay apart
+	if err := qtx.CopyFollowsByUser(ctx, &repository.CopyFollowsByUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy follows: %w", err)
+	}
+	if err := qtx.CopyFollowersOfUser(ctx, &repository.CopyFollowersOfUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to copy followers: %w", err)
+	}
+
+	// Push subscriptions stay tied to their session, which moves to the account
+	if err := qtx.ReassignPushSubscriptionsToUser(ctx, &repository.ReassignPushSubscriptionsToUserParams{
+		NewUserID: targetID,
+		OldUserID: anonID,
+	}); err != nil {
+		return fmt.Errorf("failed to reassign push subscriptions: %w", err)
+	}
+
+	// Delete the anonymous row before renaming so its username becomes free
+	if err := qtx.DeleteUser(ctx, anonID); err != nil {
+		return fmt.Errorf("failed to delete anonymous user: %w", err)
+	}
+
+	if username != "" && username != target.Username {
+		if _, err := qtx.UpdateUser(ctx, &repository.UpdateUserParams{
+			ID:              target.ID,
+			Username:        username,
+			Email:           target.Email,
+			DisplayName:     target.DisplayName,
+			AvatarUrl:       target.AvatarUrl,
+			EmailVerifiedAt: target.EmailVerifiedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to update username: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// PromoteAnonymousUser turns the anonymous user row into a registered account
+// and records its provider link in a single transaction
+func (m *PostgresAccountMigrator) PromoteAnonymousUser(ctx context.Context, user *auth.User, link *auth.ProviderLink) error {
+	userParams, err := updateUserParams(user)
+	if err != nil {
+		return err
+	}
+
+	tx, err := m.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := m.queries.WithTx(tx)
+
+	if _, err := qtx.UpdateUser(ctx, userParams); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
+		}
+		return fmt.Errorf("failed to promote user: %w", err)
+	}
+
+	// A registered account is no longer subject to anonymous expiry
+	if err := qtx.DeleteAnonymousAccount(ctx, userParams.ID); err != nil {
+		return fmt.Errorf("failed to stop tracking anonymous account: %w", err)
+	}
+
+	if link != nil && link.ProviderUserID != "" {
+		linkParams, err := createProviderLinkParams(link)
+		if err != nil {
+			return err
+		}
+		created, err := qtx.CreateUserAuthProvider(ctx, linkParams)
+		if err != nil {
+			return fmt.Errorf("failed to create provider link: %w", err)
+		}
+		link.ID = created.ID.String()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package protoconv
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+)
+
+// testPool connects to the migrated database in TEST_DATABASE_URL, skipping
+// the test when none is configured
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+	pool, err := pgxpool.New(context.Background(), dbURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// createTestUser inserts a user that is deleted again when the test ends
+func createTestUser(t *testing.T, pool *pgxpool.Pool) string {
+	t.Helper()
+	id := uuid.NewString()
+	ctx := context.Background()
+	if _, err := pool.Exec(ctx,
+		`INSERT INTO users (id, username, email) VALUES ($1, $2, $3)`,
+		id, "u"+id[:8], id+"@alunalun.test",
+	); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, id)
+	})
+	return id
+}
+
+// TestMergeAnonymousUserWithSharedFollow merges an anonymous user into an
+// account that both followed the same user: the merge must succeed and leave
+// that user one follow notification from the account.
+func TestMergeAnonymousUserWithSharedFollow(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+
+	followee := createTestUser(t, pool)
+	anon := createTestUser(t, pool)
+	account := createTestUser(t, pool)
+
+	for _, follower := range []string{anon, account} {
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO user_follows (follower_id, followee_id) VALUES ($1, $2)`,
+			follower, followee,
+		); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO notifications (user_id, actor_id, kind) VALUES ($1, $2, 'follow')`,
+			followee, follower,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	migrator := NewPostgresAccountMigrator(pool, repository.New(pool))
+	if err := migrator.MergeAnonymousUser(ctx, anon, account, ""); err != nil {
+		t.Fatalf("expected the merge to succeed, got %v", err)
+	}
+
+	var count int
+	if err := pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND actor_id = $2 AND kind = 'follow'`,
+		followee, account,
+	).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("expected one follow notification from the account, got %d", count)
+	}
+}
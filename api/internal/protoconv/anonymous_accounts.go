@@ -0,0 +1,114 @@
+package protoconv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// CreateAnonymousAccount starts tracking an anonymous user
+func (s *PostgresUserStore) CreateAnonymousAccount(ctx context.Context, account *auth.AnonymousAccount) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(account.UserID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	params := &repository.CreateAnonymousAccountParams{
+		UserID:       userID,
+		SessionID:    account.SessionID,
+		IpHash:       account.IPHash,
+		LastActiveAt: pgtype.Timestamptz{Time: account.LastActiveAt, Valid: true},
+		ExpiresAt:    pgtype.Timestamptz{Time: account.ExpiresAt, Valid: true},
+		CreatedAt:    pgtype.Timestamptz{Time: account.CreatedAt, Valid: true},
+	}
+	if account.DeviceHash != "" {
+		params.DeviceHash = &account.DeviceHash
+	}
+
+	if _, err := s.queries.CreateAnonymousAccount(ctx, params); err != nil {
+		return fmt.Errorf("failed to create anonymous account: %w", err)
+	}
+	return nil
+}
+
+// TouchAnonymousAccount records activity and slides the expiry
+func (s *PostgresUserStore) TouchAnonymousAccount(ctx context.Context, userID string, lastActiveAt, expiresAt time.Time) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.TouchAnonymousAccount(ctx, &repository.TouchAnonymousAccountParams{
+		UserID:       id,
+		LastActiveAt: pgtype.Timestamptz{Time: lastActiveAt, Valid: true},
+		ExpiresAt:    pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to touch anonymous account: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "anonymous account not found"}
+	}
+	return nil
+}
+
+// CountAnonymousAccountsByIP counts accounts created from an IP since a time
+func (s *PostgresUserStore) CountAnonymousAccountsByIP(ctx context.Context, ipHash string, since time.Time) (int64, error) {
+	count, err := s.queries.CountAnonymousAccountsByIP(ctx, &repository.CountAnonymousAccountsByIPParams{
+		IpHash:    ipHash,
+		CreatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count anonymous accounts: %w", err)
+	}
+	return count, nil
+}
+
+// CountAnonymousAccountsByDevice counts accounts created from a device since a time
+func (s *PostgresUserStore) CountAnonymousAccountsByDevice(ctx context.Context, deviceHash string, since time.Time) (int64, error) {
+	count, err := s.queries.CountAnonymousAccountsByDevice(ctx, &repository.CountAnonymousAccountsByDeviceParams{
+		DeviceHash: &deviceHash,
+		CreatedAt:  pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count anonymous accounts: %w", err)
+	}
+	return count, nil
+}
+
+// ListExpiredAnonymousAccounts lists accounts that expired before a time, oldest first
+func (s *PostgresUserStore) ListExpiredAnonymousAccounts(ctx context.Context, before time.Time, limit int) ([]*auth.AnonymousAccount, error) {
+	rows, err := s.queries.ListExpiredAnonymousAccounts(ctx, &repository.ListExpiredAnonymousAccountsParams{
+		ExpiresAt: pgtype.Timestamptz{Time: before, Valid: true},
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired anonymous accounts: %w", err)
+	}
+
+	accounts := make([]*auth.AnonymousAccount, 0, len(rows))
+	for _, row := range rows {
+		accounts = append(accounts, anonymousAccountFromRepo(row))
+	}
+	return accounts, nil
+}
+
+// anonymousAccountFromRepo converts repository.AnonymousAccount to auth.AnonymousAccount
+func anonymousAccountFromRepo(row *repository.AnonymousAccount) *auth.AnonymousAccount {
+	account := &auth.AnonymousAccount{
+		UserID:       row.UserID.String(),
+		SessionID:    row.SessionID,
+		IPHash:       row.IpHash,
+		LastActiveAt: row.LastActiveAt.Time,
+		ExpiresAt:    row.ExpiresAt.Time,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+	if row.DeviceHash != nil {
+		account.DeviceHash = *row.DeviceHash
+	}
+	return account
+}
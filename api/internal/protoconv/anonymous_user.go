@@ -0,0 +1,107 @@
+package protoconv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// PostgresAnonymousUserCreator implements auth.AnonymousUserCreator with a database transaction
+type PostgresAnonymousUserCreator struct {
+	db      *pgxpool.Pool
+	queries *repository.Queries
+}
+
+// NewPostgresAnonymousUserCreator creates a new PostgreSQL anonymous user creator
+func NewPostgresAnonymousUserCreator(db *pgxpool.Pool, queries *repository.Queries) *PostgresAnonymousUserCreator {
+	return &PostgresAnonymousUserCreator{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// CreateAnonymousUser creates an anonymous user and its tracking row in a single
+// transaction. Transaction-scoped advisory locks on the IP and device hashes make
+// concurrent creations from one client count each other's accounts.
+func (c *PostgresAnonymousUserCreator) CreateAnonymousUser(
+	ctx context.Context,
+	user *auth.User,
+	account *auth.AnonymousAccount,
+	check func(ctx context.Context, store auth.AnonymousAccountStore) error,
+) error {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := c.queries.WithTx(tx)
+
+	// Always lock the IP before the device, so two creations can't wait on each other
+	if account.IPHash != "" {
+		if err := qtx.LockAnonymousAccountClient(ctx, "anonymous_ip:"+account.IPHash); err != nil {
+			return fmt.Errorf("failed to lock anonymous account client: %w", err)
+		}
+	}
+	if account.DeviceHash != "" {
+		if err := qtx.LockAnonymousAccountClient(ctx, "anonymous_device:"+account.DeviceHash); err != nil {
+			return fmt.Errorf("failed to lock anonymous account client: %w", err)
+		}
+	}
+
+	store := NewPostgresUserStore(qtx)
+	if err := check(ctx, store); err != nil {
+		return err
+	}
+	if err := store.CreateUser(ctx, user); err != nil {
+		return err
+	}
+	if err := store.CreateAnonymousAccount(ctx, account); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit anonymous user: %w", err)
+	}
+	return nil
+}
+
+// DeleteAnonymousUser deletes an anonymous user in a single transaction. Its
+// posts are removed like those of an account erased with the delete content
+// policy; provider links and the tracking row are removed by cascade.
+func (c *PostgresAnonymousUserCreator) DeleteAnonymousUser(ctx context.Context, userID string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := c.queries.WithTx(tx)
+
+	if err := detachPosts(ctx, qtx, id, true); err != nil {
+		return err
+	}
+
+	rows, err := qtx.DeleteAnonymousUser(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete anonymous user: %w", err)
+	}
+	if rows == 0 {
+		// Promoted or merged in the meantime; roll back
+		return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "anonymous account not found"}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// CreateAPIKey stores a new API key
+func (s *PostgresUserStore) CreateAPIKey(ctx context.Context, key *auth.APIKey) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(key.UserID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	var expiresAt pgtype.Timestamptz
+	if key.ExpiresAt != nil {
+		expiresAt = pgtype.Timestamptz{Time: *key.ExpiresAt, Valid: true}
+	}
+
+	row, err := s.queries.CreateApiKey(ctx, &repository.CreateApiKeyParams{
+		UserID:    userID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		KeyHash:   key.KeyHash,
+		Scopes:    key.Scopes,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	key.ID = row.ID.String()
+	key.CreatedAt = row.CreatedAt.Time
+	return nil
+}
+
+// GetAPIKeyByHash finds an API key by its hash
+func (s *PostgresUserStore) GetAPIKeyByHash(ctx context.Context, hash []byte) (*auth.APIKey, error) {
+	row, err := s.queries.GetApiKeyByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &auth.AuthError{Code: auth.ErrTokenInvalid, Message: "invalid API key"}
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return repoAPIKeyToAuth(row), nil
+}
+
+// ListAPIKeys lists a user's unrevoked API keys, newest first
+func (s *PostgresUserStore) ListAPIKeys(ctx context.Context, userID string) ([]*auth.APIKey, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.ListApiKeysByUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	keys := make([]*auth.APIKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, repoAPIKeyToAuth(row))
+	}
+	return keys, nil
+}
+
+// CountActiveAPIKeys counts a user's unrevoked, unexpired API keys
+func (s *PostgresUserStore) CountActiveAPIKeys(ctx context.Context, userID string) (int, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	count, err := s.queries.CountActiveApiKeys(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count API keys: %w", err)
+	}
+	return int(count), nil
+}
+
+// TouchAPIKey records when an API key was last used
+func (s *PostgresUserStore) TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error {
+	var id pgtype.UUID
+	if err := id.Scan(keyID); err != nil {
+		return fmt.Errorf("invalid API key ID: %w", err)
+	}
+
+	if err := s.queries.TouchApiKey(ctx, &repository.TouchApiKeyParams{
+		ID:         id,
+		LastUsedAt: pgtype.Timestamptz{Time: usedAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to update API key: %w", err)
+	}
+	return nil
+}
+
+// RevokeAPIKey revokes one of a user's API keys
+func (s *PostgresUserStore) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	var uid, kid pgtype.UUID
+	if err := uid.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	if err := kid.Scan(keyID); err != nil {
+		return &auth.AuthError{Code: auth.ErrNotFound, Message: "API key not found"}
+	}
+
+	rows, err := s.queries.RevokeApiKey(ctx, &repository.RevokeApiKeyParams{
+		ID:     kid,
+		UserID: uid,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrNotFound, Message: "API key not found"}
+	}
+	return nil
+}
+
+// repoAPIKeyToAuth converts repository.ApiKey to auth.APIKey
+func repoAPIKeyToAuth(row *repository.ApiKey) *auth.APIKey {
+	key := &auth.APIKey{
+		ID:        row.ID.String(),
+		UserID:    row.UserID.String(),
+		Name:      row.Name,
+		Prefix:    row.Prefix,
+		KeyHash:   row.KeyHash,
+		Scopes:    row.Scopes,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.ExpiresAt.Valid {
+		key.ExpiresAt = &row.ExpiresAt.Time
+	}
+	if row.LastUsedAt.Valid {
+		key.LastUsedAt = &row.LastUsedAt.Time
+	}
+	if row.RevokedAt.Valid {
+		key.RevokedAt = &row.RevokedAt.Time
+	}
+	return key
+}
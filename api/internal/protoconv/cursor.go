@@ -0,0 +1,64 @@
+package protoconv
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Page sizes for cursor-paginated lists
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ErrInvalidCursor is returned for cursors that were not issued by EncodeCursor
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageSize returns the page size for a request, applying the default and maximum
+func PageSize(limit *int32) int32 {
+	if limit == nil || *limit <= 0 {
+		return DefaultPageSize
+	}
+	return min(*limit, MaxPageSize)
+}
+
+// EncodeCursor returns an opaque cursor pointing after a row in a list ordered
+// by (created_at DESC, id DESC)
+func EncodeCursor(createdAt pgtype.Timestamptz, id pgtype.UUID) string {
+	raw := strconv.FormatInt(createdAt.Time.UnixMicro(), 10) + ":" + id.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor from EncodeCursor. An empty cursor returns
+// invalid (NULL) values, which start from the newest row.
+func DecodeCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var createdAt pgtype.Timestamptz
+	var id pgtype.UUID
+	if cursor == "" {
+		return createdAt, id, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return createdAt, id, ErrInvalidCursor
+	}
+	micros, idStr, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return createdAt, id, ErrInvalidCursor
+	}
+	usec, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return createdAt, id, ErrInvalidCursor
+	}
+	if err := id.Scan(idStr); err != nil {
+		return createdAt, id, ErrInvalidCursor
+	}
+
+	createdAt = pgtype.Timestamptz{Time: time.UnixMicro(usec), Valid: true}
+	return createdAt, id, nil
+}
@@ -0,0 +1,90 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// SaveEmailToken stores a one-time email token by its hash
+func (s *PostgresUserStore) SaveEmailToken(ctx context.Context, token *auth.EmailToken) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(token.UserID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	_, err := s.queries.CreateEmailToken(ctx, &repository.CreateEmailTokenParams{
+		TokenHash: auth.HashEmailToken(token.Token),
+		UserID:    userID,
+		Purpose:   token.Purpose,
+		Email:     token.Email,
+		ExpiresAt: pgtype.Timestamptz{Time: token.ExpiresAt, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: token.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email token: %w", err)
+	}
+	return nil
+}
+
+// ConsumeEmailToken returns and deletes a one-time email token
+func (s *PostgresUserStore) ConsumeEmailToken(ctx context.Context, purpose, token string) (*auth.EmailToken, error) {
+	row, err := s.queries.ConsumeEmailToken(ctx, &repository.ConsumeEmailTokenParams{
+		TokenHash: auth.HashEmailToken(token),
+		Purpose:   purpose,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &auth.AuthError{Code: auth.ErrTokenInvalid, Message: "invalid or expired token"}
+		}
+		return nil, fmt.Errorf("failed to consume email token: %w", err)
+	}
+
+	return &auth.EmailToken{
+		UserID:    row.UserID.String(),
+		Purpose:   row.Purpose,
+		Email:     row.Email,
+		ExpiresAt: row.ExpiresAt.Time,
+		CreatedAt: row.CreatedAt.Time,
+	}, nil
+}
+
+// CountRecentEmailTokens counts tokens issued to a user for a purpose since a time
+func (s *PostgresUserStore) CountRecentEmailTokens(ctx context.Context, userID, purpose string, since time.Time) (int64, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	count, err := s.queries.CountRecentEmailTokens(ctx, &repository.CountRecentEmailTokensParams{
+		UserID:    id,
+		Purpose:   purpose,
+		CreatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count email tokens: %w", err)
+	}
+	return count, nil
+}
+
+// DeleteEmailTokens removes all of a user's tokens for a purpose
+func (s *PostgresUserStore) DeleteEmailTokens(ctx context.Context, userID, purpose string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := s.queries.DeleteUserEmailTokens(ctx, &repository.DeleteUserEmailTokensParams{
+		UserID:  id,
+		Purpose: purpose,
+	}); err != nil {
+		return fmt.Errorf("failed to delete email tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// GetLoginAttempts returns the failed sign-in counters for a key, or nil if there are none
+func (s *PostgresUserStore) GetLoginAttempts(ctx context.Context, key string) (*auth.LoginAttempts, error) {
+	row, err := s.queries.GetLoginAttempt(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get login attempts: %w", err)
+	}
+	return repoLoginAttemptToAuth(row), nil
+}
+
+// RecordLoginFailure atomically counts a failed sign-in
+func (s *PostgresUserStore) RecordLoginFailure(ctx context.Context, key string, failedAt, windowStart time.Time) (*auth.LoginAttempts, error) {
+	row, err := s.queries.RecordLoginFailure(ctx, &repository.RecordLoginFailureParams{
+		AttemptKey:  key,
+		FailedAt:    pgtype.Timestamptz{Time: failedAt, Valid: true},
+		WindowStart: pgtype.Timestamptz{Time: windowStart, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return repoLoginAttemptToAuth(row), nil
+}
+
+// LockLogin refuses sign-ins for a key until a time
+func (s *PostgresUserStore) LockLogin(ctx context.Context, key string, until time.Time) error {
+	if err := s.queries.LockLogin(ctx, &repository.LockLoginParams{
+		AttemptKey:  key,
+		LockedUntil: pgtype.Timestamptz{Time: until, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to lock login: %w", err)
+	}
+	return nil
+}
+
+// ResetLoginAttempts clears the failed sign-in counters for a key
+func (s *PostgresUserStore) ResetLoginAttempts(ctx context.Context, key string) error {
+	if err := s.queries.ResetLoginAttempts(ctx, key); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
+
+// repoLoginAttemptToAuth converts repository.LoginAttempt to auth.LoginAttempts
+func repoLoginAttemptToAuth(row *repository.LoginAttempt) *auth.LoginAttempts {
+	attempts := &auth.LoginAttempts{
+		Key:           row.AttemptKey,
+		Failures:      int(row.Failures),
+		LastFailureAt: row.LastFailureAt.Time,
+	}
+	if row.LockedUntil.Valid {
+		attempts.LockedUntil = &row.LockedUntil.Time
+	}
+	return attempts
+}
@@ -0,0 +1,152 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// GetTOTP returns a user's TOTP enrollment
+func (s *PostgresUserStore) GetTOTP(ctx context.Context, userID string) (*auth.TOTPEnrollment, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	row, err := s.queries.GetUserTOTP(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &auth.AuthError{Code: auth.ErrNotFound, Message: "two-factor enrollment not found"}
+		}
+		return nil, fmt.Errorf("failed to get TOTP enrollment: %w", err)
+	}
+
+	enrollment := &auth.TOTPEnrollment{
+		UserID:       userID,
+		Secret:       row.Secret,
+		LastUsedStep: row.LastUsedStep,
+		CreatedAt:    row.CreatedAt.Time,
+	}
+	if row.ConfirmedAt.Valid {
+		enrollment.ConfirmedAt = &row.ConfirmedAt.Time
+	}
+	return enrollment, nil
+}
+
+// SaveTOTP starts a pending enrollment, replacing any earlier one
+func (s *PostgresUserStore) SaveTOTP(ctx context.Context, enrollment *auth.TOTPEnrollment) error {
+	var id pgtype.UUID
+	if err := id.Scan(enrollment.UserID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := s.queries.UpsertUserTOTP(ctx, &repository.UpsertUserTOTPParams{
+		UserID: id,
+		Secret: enrollment.Secret,
+	}); err != nil {
+		return fmt.Errorf("failed to save TOTP enrollment: %w", err)
+	}
+	return nil
+}
+
+// ConfirmTOTP enables a pending enrollment
+func (s *PostgresUserStore) ConfirmTOTP(ctx context.Context, userID string, confirmedAt time.Time, step int64) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.ConfirmUserTOTP(ctx, &repository.ConfirmUserTOTPParams{
+		ConfirmedAt:  pgtype.Timestamptz{Time: confirmedAt, Valid: true},
+		LastUsedStep: step,
+		UserID:       id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to confirm TOTP: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrNotFound, Message: "no pending two-factor enrollment"}
+	}
+	return nil
+}
+
+// UseTOTPStep records an accepted time step
+func (s *PostgresUserStore) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.UseTOTPStep(ctx, &repository.UseTOTPStepParams{
+		Step:   step,
+		UserID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record TOTP use: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrTokenInvalid, Message: "code already used"}
+	}
+	return nil
+}
+
+// DeleteTOTP removes a user's enrollment and recovery codes
+func (s *PostgresUserStore) DeleteTOTP(ctx context.Context, userID string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := s.queries.DeleteUserTOTP(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete TOTP enrollment: %w", err)
+	}
+	if err := s.queries.DeleteRecoveryCodes(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes stores new recovery code hashes, dropping the old ones
+func (s *PostgresUserStore) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := s.queries.DeleteRecoveryCodes(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if err := s.queries.CreateRecoveryCodes(ctx, &repository.CreateRecoveryCodesParams{
+		CodeHashes: hashes,
+		UserID:     id,
+	}); err != nil {
+		return fmt.Errorf("failed to create recovery codes: %w", err)
+	}
+	return nil
+}
+
+// ConsumeRecoveryCode deletes a matching recovery code
+func (s *PostgresUserStore) ConsumeRecoveryCode(ctx context.Context, userID, hash string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.ConsumeRecoveryCode(ctx, &repository.ConsumeRecoveryCodeParams{
+		UserID:   id,
+		CodeHash: hash,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrTokenInvalid, Message: "invalid recovery code"}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package protoconv
+
+import (
+	"encoding/json"
+
+	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+)
+
+// NotificationToProto converts a database notification and its actor to a proto Notification
+func NotificationToProto(notification *repository.Notification, actor *repository.User) *entitiesv1.Notification {
+	if notification == nil {
+		return nil
+	}
+
+	protoNotification := &entitiesv1.Notification{
+		Id:        notification.ID.String(),
+		Kind:      NotificationKindToProto(notification.Kind),
+		Read:      notification.ReadAt.Valid,
+		CreatedAt: notification.CreatedAt.Time.Unix(),
+	}
+	if actor != nil {
+		protoNotification.Actor = PublicUserToProto(actor)
+	}
+	if notification.PinID.Valid {
+		pinID := notification.PinID.String()
+		protoNotification.PinId = &pinID
+	}
+	if notification.CommentID.Valid {
+		commentID := notification.CommentID.String()
+		protoNotification.CommentId = &commentID
+	}
+	if notification.AreaID.Valid {
+		areaID := notification.AreaID.String()
+		protoNotification.AreaId = &areaID
+	}
+
+	return protoNotification
+}
+
+// NotificationKindToProto converts a stored notification kind
+func NotificationKindToProto(kind string) entitiesv1.NotificationKind {
+	switch kind {
+	case "comment":
+		return entitiesv1.NotificationKind_NOTIFICATION_KIND_COMMENT
+	case "reply":
+		return entitiesv1.NotificationKind_NOTIFICATION_KIND_REPLY
+	case "follow":
+		return entitiesv1.NotificationKind_NOTIFICATION_KIND_FOLLOW
+	case "area":
+		return entitiesv1.NotificationKind_NOTIFICATION_KIND_AREA
+	default:
+		return entitiesv1.NotificationKind_NOTIFICATION_KIND_UNSPECIFIED
+	}
+}
+
+// NotificationKindFromProto converts a proto notification kind to the stored value, or "" if unset
+func NotificationKindFromProto(kind entitiesv1.NotificationKind) string {
+	switch kind {
+	case entitiesv1.NotificationKind_NOTIFICATION_KIND_COMMENT:
+		return "comment"
+	case entitiesv1.NotificationKind_NOTIFICATION_KIND_REPLY:
+		return "reply"
+	case entitiesv1.NotificationKind_NOTIFICATION_KIND_FOLLOW:
+		return "follow"
+	case entitiesv1.NotificationKind_NOTIFICATION_KIND_AREA:
+		return "area"
+	default:
+		return ""
+	}
+}
+
+// MaxSavedAreas caps the areas a user can save, including areas carried over
+// from an anonymous account
+const MaxSavedAreas = 10
+
+// SavedAreaToProto converts a saved area row to a proto SavedArea. Circles are
+// returned by center and radius; their stored polygon is left out.
+func SavedAreaToProto(area *repository.GetSavedAreaRow) *entitiesv1.SavedArea {
+	if area == nil {
+		return nil
+	}
+
+	protoArea := &entitiesv1.SavedArea{
+		Id:        area.ID.String(),
+		Name:      area.Name,
+		CreatedAt: area.CreatedAt.Time.Unix(),
+	}
+	if area.RadiusMeters != nil {
+		protoArea.Center = LocationFromRowToProto(area.Longitude, area.Latitude, nil)
+		protoArea.RadiusMeters = area.RadiusMeters
+		return protoArea
+	}
+
+	var polygon struct {
+		Coordinates [][][2]float64 `json:"coordinates"`
+	}
+	if err := json.Unmarshal([]byte(area.Polygon), &polygon); err == nil && len(polygon.Coordinates) > 0 {
+		ring := polygon.Coordinates[0]
+		for _, vertex := range ring[:max(len(ring)-1, 0)] { // The last vertex closes the ring
+			protoArea.Polygon = append(protoArea.Polygon, &entitiesv1.Location{
+				Longitude: vertex[0],
+				Latitude:  vertex[1],
+			})
+		}
+	}
+	return protoArea
+}
@@ -0,0 +1,122 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// CreatePasskey stores a new passkey credential
+func (s *PostgresUserStore) CreatePasskey(ctx context.Context, credential *auth.PasskeyCredential) error {
+	var userID pgtype.UUID
+	if err := userID.Scan(credential.UserID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	transports := credential.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	rows, err := s.queries.CreatePasskeyCredential(ctx, &repository.CreatePasskeyCredentialParams{
+		CredentialID: credential.ID,
+		UserID:       userID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+		Transports:   transports,
+		Name:         credential.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create passkey: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrAlreadyExists, Message: "passkey is already registered"}
+	}
+	return nil
+}
+
+// GetPasskey finds a passkey credential by ID
+func (s *PostgresUserStore) GetPasskey(ctx context.Context, credentialID string) (*auth.PasskeyCredential, error) {
+	row, err := s.queries.GetPasskeyCredential(ctx, credentialID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &auth.AuthError{Code: auth.ErrNotFound, Message: "passkey not found"}
+		}
+		return nil, fmt.Errorf("failed to get passkey: %w", err)
+	}
+	return repoPasskeyToAuth(row), nil
+}
+
+// ListPasskeys lists a user's passkey credentials, newest first
+func (s *PostgresUserStore) ListPasskeys(ctx context.Context, userID string) ([]*auth.PasskeyCredential, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.ListPasskeyCredentialsByUser(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	passkeys := make([]*auth.PasskeyCredential, 0, len(rows))
+	for _, row := range rows {
+		passkeys = append(passkeys, repoPasskeyToAuth(row))
+	}
+	return passkeys, nil
+}
+
+// UpdatePasskeyUse records a sign-in with a passkey
+func (s *PostgresUserStore) UpdatePasskeyUse(ctx context.Context, credentialID string, signCount uint32, usedAt time.Time) error {
+	if err := s.queries.UpdatePasskeyCredentialUse(ctx, &repository.UpdatePasskeyCredentialUseParams{
+		CredentialID: credentialID,
+		SignCount:    int64(signCount),
+		LastUsedAt:   pgtype.Timestamptz{Time: usedAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to update passkey: %w", err)
+	}
+	return nil
+}
+
+// DeletePasskey removes a user's passkey credential
+func (s *PostgresUserStore) DeletePasskey(ctx context.Context, userID, credentialID string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.DeletePasskeyCredential(ctx, &repository.DeletePasskeyCredentialParams{
+		UserID:       id,
+		CredentialID: credentialID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete passkey: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrNotFound, Message: "passkey not found"}
+	}
+	return nil
+}
+
+// repoPasskeyToAuth converts repository.PasskeyCredential to auth.PasskeyCredential
+func repoPasskeyToAuth(row *repository.PasskeyCredential) *auth.PasskeyCredential {
+	passkey := &auth.PasskeyCredential{
+		ID:         row.CredentialID,
+		UserID:     row.UserID.String(),
+		PublicKey:  row.PublicKey,
+		SignCount:  uint32(row.SignCount),
+		Transports: row.Transports,
+		Name:       row.Name,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+	if row.LastUsedAt.Valid {
+		passkey.LastUsedAt = &row.LastUsedAt.Time
+	}
+	return passkey
+}
@@ -0,0 +1,46 @@
+package protoconv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// PostgresPasswordUserCreator implements auth.PasswordUserCreator with a database transaction
+type PostgresPasswordUserCreator struct {
+	db      *pgxpool.Pool
+	queries *repository.Queries
+}
+
+// NewPostgresPasswordUserCreator creates a new PostgreSQL password user creator
+func NewPostgresPasswordUserCreator(db *pgxpool.Pool, queries *repository.Queries) *PostgresPasswordUserCreator {
+	return &PostgresPasswordUserCreator{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// CreateUserWithPassword creates a user and their password hash in a single transaction
+func (c *PostgresPasswordUserCreator) CreateUserWithPassword(ctx context.Context, user *auth.User, hash string) error {
+	tx, err := c.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	store := NewPostgresUserStore(c.queries.WithTx(tx))
+	if err := store.CreateUser(ctx, user); err != nil {
+		return err
+	}
+	if err := store.SetPasswordHash(ctx, user.ID, hash); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit registration: %w", err)
+	}
+	return nil
+}
@@ -18,11 +18,12 @@ func PinToProto(post *repository.Post, location *repository.PostsLocation, autho
 
 	pin := &entitiesv1.Pin{
 		Id:           post.ID.String(),
-		UserId:       post.AuthorUserID.String(),
+		UserId:       post.UserID.String(),
 		CreatedAt:    post.CreatedAt.Time.Unix(),
-		UpdatedAt:    post.UpdatedAt.Time.Unix(),
+		UpdatedAt:    post.CreatedAt.Time.Unix(), // Posts are immutable, no updated_at column
 		CommentCount: commentCount,
 		Content:      post.Content, // Content is now string directly, not pointer
+		Visibility:   PinVisibilityToProto(post.Visibility),
 	}
 
 	// Add location if available
@@ -32,7 +33,7 @@ func PinToProto(post *repository.Post, location *repository.PostsLocation, autho
 
 	// Add author if available
 	if author != nil {
-		pin.Author = UserToProto(author)
+		pin.Author = PublicUserToProto(author)
 	}
 
 	return pin
@@ -47,7 +48,7 @@ func LocationToProto(loc *repository.PostsLocation) *entitiesv1.Location {
 	// Parse geometry point
 	// Assuming location is stored as PostGIS POINT
 	// This will need proper PostGIS decoding based on your driver setup
-	lat, lng, alt := parseGeometry(loc.Location)
+	lat, lng, alt := parseGeometry(loc.Coordinates)
 
 	protoLoc := &entitiesv1.Location{
 		Latitude:  lat,
@@ -60,8 +61,8 @@ func LocationToProto(loc *repository.PostsLocation) *entitiesv1.Location {
 	}
 
 	// Set geohash if available
-	if loc.Geohash != nil {
-		protoLoc.Geohash = loc.Geohash
+	if loc.Geohash != "" {
+		protoLoc.Geohash = &loc.Geohash
 	}
 
 	return protoLoc
@@ -114,12 +115,29 @@ func PinFromRowToProto(id, authorID string, content string, createdAt, updatedAt
 
 	// Add author if available
 	if author != nil {
-		pin.Author = UserToProto(author)
+		pin.Author = PublicUserToProto(author)
 	}
 
 	return pin
 }
 
+// PinVisibilityToProto converts a stored post visibility to its proto enum; NULL is public
+func PinVisibilityToProto(visibility *string) entitiesv1.PinVisibility {
+	if visibility != nil && *visibility == "followers" {
+		return entitiesv1.PinVisibility_PIN_VISIBILITY_FOLLOWERS
+	}
+	return entitiesv1.PinVisibility_PIN_VISIBILITY_PUBLIC
+}
+
+// PinVisibilityFromProto converts a requested visibility to the stored value
+func PinVisibilityFromProto(visibility entitiesv1.PinVisibility) *string {
+	stored := "public"
+	if visibility == entitiesv1.PinVisibility_PIN_VISIBILITY_FOLLOWERS {
+		stored = "followers"
+	}
+	return &stored
+}
+
 // ProtoToCreatePinParams converts protobuf CreatePinRequest to repository params
 func ProtoToCreatePinParams(userID string, content string, location *entitiesv1.Location) (*repository.CreatePostParams, *repository.CreatePostLocationParams, error) {
 	// Generate UUID for pin
@@ -140,18 +158,14 @@ func ProtoToCreatePinParams(userID string, content string, location *entitiesv1.
 
 	// Create post params
 	postParams := &repository.CreatePostParams{
-		ID:           postIDUUID,
-		AuthorUserID: authorIDUUID,
-		Type:         "pin",
-		Content:      content, // Content is now string directly, not pointer
+		ID:      postIDUUID,
+		UserID:  authorIDUUID,
+		Type:    "pin",
+		Content: content, // Content is now string directly, not pointer
 		CreatedAt: pgtype.Timestamptz{
 			Time:  now,
 			Valid: true,
 		},
-		UpdatedAt: pgtype.Timestamptz{
-			Time:  now,
-			Valid: true,
-		},
 	}
 
 	// Create location params if location provided
@@ -172,7 +186,11 @@ func ProtoToCreatePinParams(userID string, content string, location *entitiesv1.
 			// PostGIS coordinates - ST_MakePoint(longitude, latitude)
 			StMakepoint:   location.Longitude,
 			StMakepoint_2: location.Latitude,
-			Geohash:       &ghash,
+			Geohash:       ghash,
+			CreatedAt: pgtype.Timestamptz{
+				Time:  now,
+				Valid: true,
+			},
 		}
 	}
 
@@ -196,20 +214,27 @@ func ProtoToCreateCommentParams(userID, pinID, content string, parentID *string)
 		return nil, err
 	}
 
-	return &repository.CreatePostParams{
-		ID:           commentIDUUID,
-		AuthorUserID: authorIDUUID,
-		Type:         "comment",
-		Content:      content, // Content is now string directly, not pointer
+	params := &repository.CreatePostParams{
+		ID:      commentIDUUID,
+		UserID:  authorIDUUID,
+		Type:    "comment",
+		Content: content, // Content is now string directly, not pointer
 		CreatedAt: pgtype.Timestamptz{
 			Time:  now,
 			Valid: true,
 		},
-		UpdatedAt: pgtype.Timestamptz{
-			Time:  now,
-			Valid: true,
-		},
-	}, nil
+	}
+
+	// Thread under the parent comment, or directly under the pin
+	parent := pinID
+	if parentID != nil && *parentID != "" {
+		parent = *parentID
+	}
+	if err := params.ParentID.Scan(parent); err != nil {
+		return nil, err
+	}
+
+	return params, nil
 }
 
 // CommentToProto converts repository Post (type=comment) to protobuf Comment
@@ -220,14 +245,18 @@ func CommentToProto(post *repository.Post, author *repository.User) *entitiesv1.
 
 	comment := &entitiesv1.Comment{
 		Id:        post.ID.String(),
-		UserId:    post.AuthorUserID.String(),
+		UserId:    post.UserID.String(),
 		CreatedAt: post.CreatedAt.Time.Unix(),
 		Content:   post.Content, // Content is now string directly, not pointer
 	}
+	if post.ReplyToID.Valid {
+		parentID := post.ReplyToID.String()
+		comment.ParentId = &parentID
+	}
 
 	// Add author if available
 	if author != nil {
-		comment.Author = UserToProto(author)
+		comment.Author = PublicUserToProto(author)
 	}
 
 	return comment
@@ -0,0 +1,152 @@
+package protoconv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// CreateProviderLink links a provider identity to a user
+func (s *PostgresUserStore) CreateProviderLink(ctx context.Context, link *auth.ProviderLink) error {
+	params, err := createProviderLinkParams(link)
+	if err != nil {
+		return err
+	}
+
+	created, err := s.queries.CreateUserAuthProvider(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to create provider link: %w", err)
+	}
+
+	link.ID = created.ID.String()
+	return nil
+}
+
+// GetProviderLink finds the link for a provider identity
+func (s *PostgresUserStore) GetProviderLink(ctx context.Context, provider, providerUserID string) (*auth.ProviderLink, error) {
+	row, err := s.queries.GetUserAuthProviderByProviderID(ctx, &repository.GetUserAuthProviderByProviderIDParams{
+		Provider:       provider,
+		ProviderUserID: providerUserID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &auth.AuthError{Code: auth.ErrUserNotFound, Message: "provider link not found"}
+		}
+		return nil, fmt.Errorf("failed to get provider link: %w", err)
+	}
+
+	return authProviderToLink(row), nil
+}
+
+// ListProviderLinks lists all providers linked to a user
+func (s *PostgresUserStore) ListProviderLinks(ctx context.Context, userID string) ([]*auth.ProviderLink, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.ListUserAuthProviders(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider links: %w", err)
+	}
+
+	links := make([]*auth.ProviderLink, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, authProviderToLink(row))
+	}
+	return links, nil
+}
+
+// DeleteProviderLink removes a user's link for a provider unless it is their last
+func (s *PostgresUserStore) DeleteProviderLink(ctx context.Context, userID, provider string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.DeleteUserAuthProviderUnlessLast(ctx, &repository.DeleteUserAuthProviderUnlessLastParams{
+		UserID:   id,
+		Provider: provider,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete provider link: %w", err)
+	}
+	if rows > 0 {
+		return nil
+	}
+
+	// Nothing was deleted: either the provider isn't linked or it is the last link
+	if _, err := s.queries.GetUserAuthProviderByUserAndProvider(ctx, &repository.GetUserAuthProviderByUserAndProviderParams{
+		UserID:   id,
+		Provider: provider,
+	}); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "provider link not found"}
+		}
+		return fmt.Errorf("failed to get provider link: %w", err)
+	}
+	return &auth.AuthError{Code: auth.ErrLastLoginMethod, Message: "cannot unlink the last remaining login method"}
+}
+
+// createProviderLinkParams builds repository.CreateUserAuthProviderParams from auth.ProviderLink
+func createProviderLinkParams(link *auth.ProviderLink) (*repository.CreateUserAuthProviderParams, error) {
+	var id, userID pgtype.UUID
+	if err := id.Scan(uuid.New().String()); err != nil {
+		return nil, fmt.Errorf("failed to generate link ID: %w", err)
+	}
+	if err := userID.Scan(link.UserID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	metadata := map[string]interface{}{}
+	for k, v := range link.Metadata {
+		metadata[k] = v
+	}
+	if link.Email != "" {
+		metadata["email"] = link.Email
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal provider metadata: %w", err)
+	}
+
+	return &repository.CreateUserAuthProviderParams{
+		ID:               id,
+		UserID:           userID,
+		Provider:         link.Provider,
+		ProviderUserID:   link.ProviderUserID,
+		ProviderMetadata: metadataJSON,
+		CreatedAt: pgtype.Timestamptz{
+			Time:  link.CreatedAt,
+			Valid: true,
+		},
+	}, nil
+}
+
+// authProviderToLink converts repository.UserAuthProvider to auth.ProviderLink
+func authProviderToLink(row *repository.UserAuthProvider) *auth.ProviderLink {
+	link := &auth.ProviderLink{
+		ID:             row.ID.String(),
+		UserID:         row.UserID.String(),
+		Provider:       row.Provider,
+		ProviderUserID: row.ProviderUserID,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+
+	if len(row.ProviderMetadata) > 0 {
+		var metadata map[string]interface{}
+		if err := json.Unmarshal(row.ProviderMetadata, &metadata); err == nil {
+			link.Metadata = metadata
+			link.Email, _ = metadata["email"].(string)
+		}
+	}
+
+	return link
+}
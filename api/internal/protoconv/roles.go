@@ -0,0 +1,66 @@
+package protoconv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// ListUserRoles lists a user's elevated roles
+func (s *PostgresUserStore) ListUserRoles(ctx context.Context, userID string) ([]string, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	roles, err := s.queries.ListUserRoles(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+// GrantRole gives a user an elevated role
+func (s *PostgresUserStore) GrantRole(ctx context.Context, userID, role, grantedBy string) error {
+	var id, granter pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	if grantedBy != "" {
+		if err := granter.Scan(grantedBy); err != nil {
+			return fmt.Errorf("invalid granting user ID: %w", err)
+		}
+	}
+
+	if err := s.queries.GrantUserRole(ctx, &repository.GrantUserRoleParams{
+		UserID:    id,
+		Role:      role,
+		GrantedBy: granter,
+	}); err != nil {
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+	return nil
+}
+
+// RevokeRole removes an elevated role from a user
+func (s *PostgresUserStore) RevokeRole(ctx context.Context, userID, role string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	rows, err := s.queries.RevokeUserRole(ctx, &repository.RevokeUserRoleParams{
+		UserID: id,
+		Role:   role,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+	if rows == 0 {
+		return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user does not have this role"}
+	}
+	return nil
+}
@@ -1,8 +1,10 @@
 package protoconv
 
 import (
+	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
 	"github.com/radjathaher/alunalun/api/internal/repository"
@@ -18,26 +20,86 @@ func UserToProto(user *repository.User) *entitiesv1.User {
 	protoUser := &entitiesv1.User{
 		Id:        user.ID.String(),
 		Email:     &user.Email, // Email is string, convert to pointer
+		Username:  user.Username,
 		CreatedAt: user.CreatedAt.Time.Unix(),
 		UpdatedAt: user.UpdatedAt.Time.Unix(),
+		DisplayName: user.DisplayName,
+		AvatarUrl:   user.AvatarUrl,
+		Status:      UserStatusToProto(user.Status),
+		Metadata:    MetadataToProto(user.Metadata),
 	}
-
-	// Set display name as username (since we don't have username field)
-	if user.DisplayName != nil {
-		protoUser.Username = *user.DisplayName
-	} else {
-		protoUser.Username = "Anonymous"
+	if user.DeletionScheduledAt.Valid {
+		deletionScheduledAt := user.DeletionScheduledAt.Time.Unix()
+		protoUser.DeletionScheduledAt = &deletionScheduledAt
 	}
 
 	return protoUser
 }
 
+// PublicUserToProto converts repository.User to the profile anyone may see,
+// without email, status or metadata
+func PublicUserToProto(user *repository.User) *entitiesv1.User {
+	if user == nil {
+		return nil
+	}
+
+	return &entitiesv1.User{
+		Id:          user.ID.String(),
+		Username:    user.Username,
+		CreatedAt:   user.CreatedAt.Time.Unix(),
+		DisplayName: user.DisplayName,
+		AvatarUrl:   user.AvatarUrl,
+	}
+}
+
+// UserStatusToProto converts a stored user status to its proto enum
+func UserStatusToProto(status string) entitiesv1.UserStatus {
+	switch status {
+	case "active":
+		return entitiesv1.UserStatus_USER_STATUS_ACTIVE
+	case "disabled":
+		return entitiesv1.UserStatus_USER_STATUS_DISABLED
+	case "pending":
+		return entitiesv1.UserStatus_USER_STATUS_PENDING
+	default:
+		return entitiesv1.UserStatus_USER_STATUS_UNSPECIFIED
+	}
+}
+
+// MetadataToProto flattens JSON user metadata into proto string values.
+// Strings are kept as-is; other values are re-encoded as JSON.
+func MetadataToProto(data []byte) map[string]string {
+	var raw map[string]json.RawMessage
+	if len(data) == 0 || json.Unmarshal(data, &raw) != nil || len(raw) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(raw))
+	for key, value := range raw {
+		var str string
+		if json.Unmarshal(value, &str) == nil {
+			metadata[key] = str
+		} else {
+			metadata[key] = string(value)
+		}
+	}
+	return metadata
+}
+
+// metadataFromProto encodes proto user metadata for the JSONB column
+func metadataFromProto(metadata map[string]string) ([]byte, error) {
+	if len(metadata) == 0 {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(metadata)
+}
+
 // ProtoToCreateUserParams converts protobuf User to repository CreateUserParams
 func ProtoToCreateUserParams(user *entitiesv1.User) (*repository.CreateUserParams, error) {
 	// Generate ID if not provided
 	idStr := user.Id
 	if idStr == "" {
-		idStr = ksuid.New().String()
+		idStr = uuid.New().String()
 	}
 
 	// Parse UUID
@@ -53,16 +115,23 @@ func ProtoToCreateUserParams(user *entitiesv1.User) (*repository.CreateUserParam
 		now = time.Unix(user.CreatedAt, 0)
 	}
 
+	metadata, err := metadataFromProto(user.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
 	params := &repository.CreateUserParams{
-		ID: userID,
+		ID:       userID,
+		Username: user.Username,
 		CreatedAt: pgtype.Timestamptz{
 			Time:  now,
 			Valid: true,
 		},
-		UpdatedAt: pgtype.Timestamptz{
-			Time:  now,
-			Valid: true,
-		},
+		Status:   "active",
+		Metadata: metadata,
+	}
+	if user.Status == entitiesv1.UserStatus_USER_STATUS_PENDING {
+		params.Status = "pending"
 	}
 
 	// Set email if provided
@@ -88,11 +157,8 @@ func ProtoToUpdateUserParams(user *entitiesv1.User) (*repository.UpdateUserParam
 	}
 
 	params := &repository.UpdateUserParams{
-		ID: userID,
-		UpdatedAt: pgtype.Timestamptz{
-			Time:  time.Now(),
-			Valid: true,
-		},
+		ID:       userID,
+		Username: user.Username,
 	}
 
 	// Set email if provided
@@ -0,0 +1,45 @@
+package protoconv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// GetPasswordHash returns a user's password hash
+func (s *PostgresUserStore) GetPasswordHash(ctx context.Context, userID string) (string, error) {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return "", fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	credential, err := s.queries.GetUserCredential(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user has no password"}
+		}
+		return "", fmt.Errorf("failed to get password: %w", err)
+	}
+	return credential.PasswordHash, nil
+}
+
+// SetPasswordHash creates or replaces a user's password hash
+func (s *PostgresUserStore) SetPasswordHash(ctx context.Context, userID, hash string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := s.queries.UpsertUserCredential(ctx, &repository.UpsertUserCredentialParams{
+		UserID:       id,
+		PasswordHash: hash,
+	}); err != nil {
+		return fmt.Errorf("failed to set password: %w", err)
+	}
+	return nil
+}
@@ -2,14 +2,16 @@ package protoconv
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/radjathaher/alunalun/api/internal/repository"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
-	"github.com/segmentio/ksuid"
 )
 
 // PostgresUserStore implements auth.UserStore interface
@@ -29,7 +31,7 @@ func (s *PostgresUserStore) CreateUser(ctx context.Context, user *auth.User) err
 	// Generate ID if not provided
 	idStr := user.ID
 	if idStr == "" {
-		idStr = ksuid.New().String()
+		idStr = uuid.New().String()
 	}
 
 	// Parse UUID
@@ -41,34 +43,45 @@ func (s *PostgresUserStore) CreateUser(ctx context.Context, user *auth.User) err
 
 	// Create user params
 	params := &repository.CreateUserParams{
-		ID: userID,
-		Email: user.Email, // Email is string in DB, not pointer
+		ID:       userID,
+		Username: user.Username,
+		Email:    user.Email, // Email is string in DB, not pointer
 		CreatedAt: pgtype.Timestamptz{
 			Time:  user.CreatedAt,
 			Valid: true,
 		},
-		UpdatedAt: pgtype.Timestamptz{
-			Time:  user.UpdatedAt,
-			Valid: true,
-		},
+		EmailVerifiedAt: emailVerifiedAt(user),
+		Status:          user.Status,
+	}
+	if params.Status == "" {
+		params.Status = "active"
 	}
 
-	// Set display name from username or first/last name
-	if user.Username != "" {
-		params.DisplayName = &user.Username
-	} else if user.FirstName != "" || user.LastName != "" {
-		displayName := fmt.Sprintf("%s %s", user.FirstName, user.LastName)
-		params.DisplayName = &displayName
+	// Set display name and avatar from profile fields
+	params.DisplayName = displayNameFromUser(user)
+	if user.Picture != "" {
+		params.AvatarUrl = &user.Picture
 	}
 
+	metadata, err := marshalUserMetadata(user.Metadata)
+	if err != nil {
+		return err
+	}
+	if metadata == nil {
+		metadata = []byte("{}")
+	}
+	params.Metadata = metadata
+
 	// Create user in database
 	createdUser, err := s.queries.CreateUser(ctx, params)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// Update user ID from database
+	// Update user ID and defaults from database
 	user.ID = createdUser.ID.String()
+	user.Status = createdUser.Status
+	user.UpdatedAt = createdUser.UpdatedAt.Time
 	return nil
 }
 
@@ -77,7 +90,7 @@ func (s *PostgresUserStore) GetUserByEmail(ctx context.Context, email string) (*
 	repoUser, err := s.queries.GetUserByEmail(ctx, email) // Email is string, not pointer
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
@@ -97,7 +110,7 @@ func (s *PostgresUserStore) GetUserByID(ctx context.Context, userID string) (*au
 	repoUser, err := s.queries.GetUserByID(ctx, id)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("user not found")
+			return nil, &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
 		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
@@ -107,45 +120,43 @@ func (s *PostgresUserStore) GetUserByID(ctx context.Context, userID string) (*au
 
 // UpdateUser updates user information
 func (s *PostgresUserStore) UpdateUser(ctx context.Context, user *auth.User) error {
-	// Parse UUID
-	var userID pgtype.UUID
-	err := userID.Scan(user.ID)
+	params, err := updateUserParams(user)
 	if err != nil {
-		return fmt.Errorf("invalid user ID: %w", err)
-	}
-
-	params := &repository.UpdateUserParams{
-		ID: userID,
-		Email: user.Email, // Email is string in DB, not pointer
-		UpdatedAt: pgtype.Timestamptz{
-			Time:  time.Now(),
-			Valid: true,
-		},
+		return err
 	}
 
-	// Set display name from username or first/last name
-	if user.Username != "" {
-		params.DisplayName = &user.Username
-	} else if user.FirstName != "" || user.LastName != "" {
-		displayName := fmt.Sprintf("%s %s", user.FirstName, user.LastName)
-		params.DisplayName = &displayName
-	}
-
-	_, err = s.queries.UpdateUser(ctx, params)
+	updated, err := s.queries.UpdateUser(ctx, params)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return fmt.Errorf("user not found")
+			return &auth.AuthError{Code: auth.ErrUserNotFound, Message: "user not found"}
 		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
+	user.UpdatedAt = updated.UpdatedAt.Time
+	return nil
+}
+
+// RevokeTokens rejects the user's tokens signed in before a time. An earlier
+// time never replaces a later one.
+func (s *PostgresUserStore) RevokeTokens(ctx context.Context, userID string, before time.Time) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := s.queries.RevokeUserTokens(ctx, &repository.RevokeUserTokensParams{
+		ID:              id,
+		TokensRevokedAt: pgtype.Timestamptz{Time: before, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
 	return nil
 }
 
 // CheckUsernameAvailable checks if a username is available
-// Since we don't have a dedicated username field, check display_name instead
 func (s *PostgresUserStore) CheckUsernameAvailable(ctx context.Context, username string) (bool, error) {
-	_, err := s.queries.GetUserByDisplayName(ctx, &username)
+	_, err := s.queries.GetUserByUsername(ctx, username)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			// Username not found, so it's available
@@ -159,27 +170,114 @@ func (s *PostgresUserStore) CheckUsernameAvailable(ctx context.Context, username
 
 // repoUserToAuthUser converts repository.User to auth.User
 func (s *PostgresUserStore) repoUserToAuthUser(repoUser *repository.User) (*auth.User, error) {
-	// Use display name as username
-	username := "Anonymous"
-	if repoUser.DisplayName != nil {
-		username = *repoUser.DisplayName
+	picture := ""
+	if repoUser.AvatarUrl != nil {
+		picture = *repoUser.AvatarUrl
+	}
+
+	var verifiedAt *time.Time
+	if repoUser.EmailVerifiedAt.Valid {
+		verifiedAt = &repoUser.EmailVerifiedAt.Time
+	}
+
+	var deletionScheduledAt *time.Time
+	if repoUser.DeletionScheduledAt.Valid {
+		deletionScheduledAt = &repoUser.DeletionScheduledAt.Time
+	}
+
+	var tokensRevokedAt *time.Time
+	if repoUser.TokensRevokedAt.Valid {
+		tokensRevokedAt = &repoUser.TokensRevokedAt.Time
+	}
+
+	var metadata map[string]interface{}
+	if len(repoUser.Metadata) > 0 {
+		if err := json.Unmarshal(repoUser.Metadata, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode user metadata: %w", err)
+		}
 	}
 
 	return &auth.User{
 		ID:        repoUser.ID.String(),
 		Email:     repoUser.Email, // Email is string in DB
-		Username:  username,
+		Username:  repoUser.Username,
 		CreatedAt: repoUser.CreatedAt.Time,
 		UpdatedAt: repoUser.UpdatedAt.Time,
-		Status:    "active", // Default status
+		Status:    repoUser.Status,
+		Metadata:  metadata,
+		Picture:   picture,
+		EmailVerified:   verifiedAt != nil,
+		EmailVerifiedAt: verifiedAt,
+		DeletionScheduledAt: deletionScheduledAt,
+		TokensRevokedAt:     tokensRevokedAt,
 		// Fields not available in current schema
 		FirstName:       "",
 		LastName:        "",
-		Picture:         "",
-		EmailVerified:   false,
-		EmailVerifiedAt: nil,
 		LastLoginAt:     nil,
-		Metadata:        nil,
-		PasswordHash:    "",
+		PasswordHash:    "", // Stored in user_credentials, see GetPasswordHash
 	}, nil
-}
\ No newline at end of file
+}
+
+// updateUserParams builds repository.UpdateUserParams from auth.User
+func updateUserParams(user *auth.User) (*repository.UpdateUserParams, error) {
+	// Parse UUID
+	var userID pgtype.UUID
+	if err := userID.Scan(user.ID); err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	params := &repository.UpdateUserParams{
+		ID:              userID,
+		Username:        user.Username,
+		Email:           user.Email, // Email is string in DB, not pointer
+		EmailVerifiedAt: emailVerifiedAt(user),
+		Status:          user.Status, // Empty keeps the stored status
+	}
+
+	// Set display name and avatar from profile fields
+	params.DisplayName = displayNameFromUser(user)
+	if user.Picture != "" {
+		params.AvatarUrl = &user.Picture
+	}
+
+	// Empty metadata keeps the stored metadata
+	metadata, err := marshalUserMetadata(user.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	params.Metadata = metadata
+
+	return params, nil
+}
+
+// marshalUserMetadata encodes user metadata for the JSONB column; empty metadata encodes as nil
+func marshalUserMetadata(metadata map[string]interface{}) ([]byte, error) {
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode user metadata: %w", err)
+	}
+	return data, nil
+}
+
+// emailVerifiedAt returns the verification time to persist; unverified users store NULL
+func emailVerifiedAt(user *auth.User) pgtype.Timestamptz {
+	if !user.EmailVerified || user.EmailVerifiedAt == nil || user.EmailVerifiedAt.IsZero() {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *user.EmailVerifiedAt, Valid: true}
+}
+
+// displayNameFromUser builds a display name from first/last name, falling back to username
+func displayNameFromUser(user *auth.User) *string {
+	if user.FirstName != "" || user.LastName != "" {
+		displayName := strings.TrimSpace(fmt.Sprintf("%s %s", user.FirstName, user.LastName))
+		return &displayName
+	}
+	if user.Username != "" {
+		return &user.Username
+	}
+	return nil
+}
@@ -67,6 +67,34 @@ func (q *Queries) DeleteUserAuthProvider(ctx context.Context, id pgtype.UUID) er
 	return err
 }
 
+const deleteUserAuthProviderUnlessLast = `-- name: DeleteUserAuthProviderUnlessLast :execrows
+DELETE FROM user_auth_providers d
+WHERE d.user_id = $1 AND d.provider = $2
+    AND (
+        SELECT COUNT(*) FROM (
+            SELECT 1 FROM user_auth_providers o
+            WHERE o.user_id = $1
+            FOR UPDATE
+        ) locked
+    ) > 1
+`
+
+type DeleteUserAuthProviderUnlessLastParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	Provider string      `json:"provider"`
+}
+
+// Removes a user's link for a provider while another link remains. The count
+// locks the user's links, so a concurrent unlink waits and recounts without
+// the link removed here.
+func (q *Queries) DeleteUserAuthProviderUnlessLast(ctx context.Context, arg *DeleteUserAuthProviderUnlessLastParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserAuthProviderUnlessLast, arg.UserID, arg.Provider)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getUserAuthProviderByID = `-- name: GetUserAuthProviderByID :one
 SELECT id, user_id, provider, provider_user_id, provider_metadata, created_at FROM user_auth_providers WHERE id = $1
 `
@@ -109,6 +137,30 @@ func (q *Queries) GetUserAuthProviderByProviderID(ctx context.Context, arg *GetU
 	return &i, err
 }
 
+const getUserAuthProviderByUserAndProvider = `-- name: GetUserAuthProviderByUserAndProvider :one
+SELECT id, user_id, provider, provider_user_id, provider_metadata, created_at FROM user_auth_providers
+WHERE user_id = $1 AND provider = $2
+`
+
+type GetUserAuthProviderByUserAndProviderParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	Provider string      `json:"provider"`
+}
+
+func (q *Queries) GetUserAuthProviderByUserAndProvider(ctx context.Context, arg *GetUserAuthProviderByUserAndProviderParams) (*UserAuthProvider, error) {
+	row := q.db.QueryRow(ctx, getUserAuthProviderByUserAndProvider, arg.UserID, arg.Provider)
+	var i UserAuthProvider
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.ProviderUserID,
+		&i.ProviderMetadata,
+		&i.CreatedAt,
+	)
+	return &i, err
+}
+
 const listUserAuthProviders = `-- name: ListUserAuthProviders :many
 SELECT id, user_id, provider, provider_user_id, provider_metadata, created_at FROM user_auth_providers
 WHERE user_id = $1
@@ -141,3 +193,22 @@ func (q *Queries) ListUserAuthProviders(ctx context.Context, userID pgtype.UUID)
 	}
 	return items, nil
 }
+
+const reassignUserAuthProviders = `-- name: ReassignUserAuthProviders :execrows
+UPDATE user_auth_providers
+SET user_id = $1
+WHERE user_id = $2
+`
+
+type ReassignUserAuthProvidersParams struct {
+	NewUserID pgtype.UUID `json:"new_user_id"`
+	OldUserID pgtype.UUID `json:"old_user_id"`
+}
+
+func (q *Queries) ReassignUserAuthProviders(ctx context.Context, arg *ReassignUserAuthProvidersParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignUserAuthProviders, arg.NewUserID, arg.OldUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
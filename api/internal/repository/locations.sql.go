@@ -99,7 +99,7 @@ func (q *Queries) DeletePostLocation(ctx context.Context, postID pgtype.UUID) er
 
 const getPinWithLocation = `-- name: GetPinWithLocation :one
 SELECT 
-    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at,
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
     u.username as author_username,
     u.display_name as author_display_name,
     u.avatar_url as author_avatar_url,
@@ -122,6 +122,7 @@ type GetPinWithLocationRow struct {
 	Visibility        *string            `json:"visibility"`
 	Metadata          []byte             `json:"metadata"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ReplyToID         pgtype.UUID        `json:"reply_to_id"`
 	AuthorUsername    *string            `json:"author_username"`
 	AuthorDisplayName *string            `json:"author_display_name"`
 	AuthorAvatarUrl   *string            `json:"author_avatar_url"`
@@ -143,6 +144,7 @@ func (q *Queries) GetPinWithLocation(ctx context.Context, id pgtype.UUID) (*GetP
 		&i.Visibility,
 		&i.Metadata,
 		&i.CreatedAt,
+		&i.ReplyToID,
 		&i.AuthorUsername,
 		&i.AuthorDisplayName,
 		&i.AuthorAvatarUrl,
@@ -188,7 +190,7 @@ func (q *Queries) GetPostLocation(ctx context.Context, postID pgtype.UUID) (*Get
 
 const listNearbyPins = `-- name: ListNearbyPins :many
 SELECT 
-    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at,
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
     u.username as author_username,
     u.display_name as author_display_name,
     u.avatar_url as author_avatar_url,
@@ -229,6 +231,7 @@ type ListNearbyPinsRow struct {
 	Visibility        *string            `json:"visibility"`
 	Metadata          []byte             `json:"metadata"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ReplyToID         pgtype.UUID        `json:"reply_to_id"`
 	AuthorUsername    *string            `json:"author_username"`
 	AuthorDisplayName *string            `json:"author_display_name"`
 	AuthorAvatarUrl   *string            `json:"author_avatar_url"`
@@ -261,6 +264,7 @@ func (q *Queries) ListNearbyPins(ctx context.Context, arg *ListNearbyPinsParams)
 			&i.Visibility,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.ReplyToID,
 			&i.AuthorUsername,
 			&i.AuthorDisplayName,
 			&i.AuthorAvatarUrl,
@@ -281,7 +285,7 @@ func (q *Queries) ListNearbyPins(ctx context.Context, arg *ListNearbyPinsParams)
 
 const listPinsByGeohash = `-- name: ListPinsByGeohash :many
 SELECT 
-    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at,
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
     u.username as author_username,
     u.display_name as author_display_name,
     u.avatar_url as author_avatar_url,
@@ -294,13 +298,20 @@ JOIN posts_location pl ON p.id = pl.post_id
 WHERE p.type = 'pin' 
     AND p.created_at > NOW() - INTERVAL '24 hours'
     AND pl.geohash LIKE $1 || '%'
+    -- Followers-only pins are visible to their author and the author's followers
+    AND (COALESCE(p.visibility, 'public') = 'public'
+        OR p.user_id = $3
+        OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = $3 AND f.followee_id = p.user_id))
+    -- Hide pins from users the viewer blocked or muted
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = $3 AND b.blocked_id = p.user_id)
 ORDER BY p.created_at DESC
 LIMIT $2
 `
 
 type ListPinsByGeohashParams struct {
-	Column1 *string `json:"column_1"`
-	Limit   int32   `json:"limit"`
+	Column1 *string     `json:"column_1"`
+	Limit   int32       `json:"limit"`
+	UserID  pgtype.UUID `json:"user_id"`
 }
 
 type ListPinsByGeohashRow struct {
@@ -312,6 +323,7 @@ type ListPinsByGeohashRow struct {
 	Visibility        *string            `json:"visibility"`
 	Metadata          []byte             `json:"metadata"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ReplyToID         pgtype.UUID        `json:"reply_to_id"`
 	AuthorUsername    *string            `json:"author_username"`
 	AuthorDisplayName *string            `json:"author_display_name"`
 	AuthorAvatarUrl   *string            `json:"author_avatar_url"`
@@ -321,7 +333,7 @@ type ListPinsByGeohashRow struct {
 }
 
 func (q *Queries) ListPinsByGeohash(ctx context.Context, arg *ListPinsByGeohashParams) ([]*ListPinsByGeohashRow, error) {
-	rows, err := q.db.Query(ctx, listPinsByGeohash, arg.Column1, arg.Limit)
+	rows, err := q.db.Query(ctx, listPinsByGeohash, arg.Column1, arg.Limit, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -338,6 +350,7 @@ func (q *Queries) ListPinsByGeohash(ctx context.Context, arg *ListPinsByGeohashP
 			&i.Visibility,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.ReplyToID,
 			&i.AuthorUsername,
 			&i.AuthorDisplayName,
 			&i.AuthorAvatarUrl,
@@ -357,7 +370,7 @@ func (q *Queries) ListPinsByGeohash(ctx context.Context, arg *ListPinsByGeohashP
 
 const listPinsInBoundingBox = `-- name: ListPinsInBoundingBox :many
 SELECT 
-    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at,
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
     u.username as author_username,
     u.display_name as author_display_name,
     u.avatar_url as author_avatar_url,
@@ -395,6 +408,7 @@ type ListPinsInBoundingBoxRow struct {
 	Visibility        *string            `json:"visibility"`
 	Metadata          []byte             `json:"metadata"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ReplyToID         pgtype.UUID        `json:"reply_to_id"`
 	AuthorUsername    *string            `json:"author_username"`
 	AuthorDisplayName *string            `json:"author_display_name"`
 	AuthorAvatarUrl   *string            `json:"author_avatar_url"`
@@ -428,6 +442,7 @@ func (q *Queries) ListPinsInBoundingBox(ctx context.Context, arg *ListPinsInBoun
 			&i.Visibility,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.ReplyToID,
 			&i.AuthorUsername,
 			&i.AuthorDisplayName,
 			&i.AuthorAvatarUrl,
@@ -8,6 +8,95 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AnonymousAccount struct {
+	UserID       pgtype.UUID        `json:"user_id"`
+	SessionID    string             `json:"session_id"`
+	IpHash       string             `json:"ip_hash"`
+	DeviceHash   *string            `json:"device_hash"`
+	LastActiveAt pgtype.Timestamptz `json:"last_active_at"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type ApiKey struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	KeyHash    []byte             `json:"key_hash"`
+	Scopes     []string           `json:"scopes"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type DataExport struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Status      string             `json:"status"`
+	Progress    int16              `json:"progress"`
+	ArchiveKey  *string            `json:"archive_key"`
+	Error       *string            `json:"error"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	StartedAt   pgtype.Timestamptz `json:"started_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+}
+
+type EmailToken struct {
+	TokenHash string             `json:"token_hash"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Purpose   string             `json:"purpose"`
+	Email     string             `json:"email"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginAttempt struct {
+	AttemptKey    string             `json:"attempt_key"`
+	Failures      int32              `json:"failures"`
+	LastFailureAt pgtype.Timestamptz `json:"last_failure_at"`
+	LockedUntil   pgtype.Timestamptz `json:"locked_until"`
+}
+
+type MfaRecoveryCode struct {
+	CodeHash  string             `json:"code_hash"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Notification struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ActorID   pgtype.UUID        `json:"actor_id"`
+	Kind      string             `json:"kind"`
+	PinID     pgtype.UUID        `json:"pin_id"`
+	CommentID pgtype.UUID        `json:"comment_id"`
+	ReadAt    pgtype.Timestamptz `json:"read_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	PushedAt  pgtype.Timestamptz `json:"pushed_at"`
+	AreaID    pgtype.UUID        `json:"area_id"`
+}
+
+type NotificationPreference struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Kind      string             `json:"kind"`
+	Push      bool               `json:"push"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type PasskeyCredential struct {
+	CredentialID string             `json:"credential_id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	PublicKey    []byte             `json:"public_key"`
+	SignCount    int64              `json:"sign_count"`
+	Transports   []string           `json:"transports"`
+	Name         string             `json:"name"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
+}
+
 type Post struct {
 	ID         pgtype.UUID        `json:"id"`
 	UserID     pgtype.UUID        `json:"user_id"`
@@ -17,6 +106,7 @@ type Post struct {
 	Visibility *string            `json:"visibility"`
 	Metadata   []byte             `json:"metadata"`
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ReplyToID  pgtype.UUID        `json:"reply_to_id"`
 }
 
 type PostsLocation struct {
@@ -26,13 +116,41 @@ type PostsLocation struct {
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
+type PushSubscription struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	SessionID string             `json:"session_id"`
+	Endpoint  string             `json:"endpoint"`
+	P256dh    string             `json:"p256dh"`
+	Auth      string             `json:"auth"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type SavedArea struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	Name         string             `json:"name"`
+	Area         interface{}        `json:"area"`
+	Center       interface{}        `json:"center"`
+	RadiusMeters *int32             `json:"radius_meters"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
 type User struct {
-	ID          pgtype.UUID        `json:"id"`
-	Username    string             `json:"username"`
-	Email       string             `json:"email"`
-	DisplayName *string            `json:"display_name"`
-	AvatarUrl   *string            `json:"avatar_url"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ID                  pgtype.UUID        `json:"id"`
+	Username            string             `json:"username"`
+	Email               string             `json:"email"`
+	DisplayName         *string            `json:"display_name"`
+	AvatarUrl           *string            `json:"avatar_url"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	EmailVerifiedAt     pgtype.Timestamptz `json:"email_verified_at"`
+	UsernameChangedAt   pgtype.Timestamptz `json:"username_changed_at"`
+	Status              string             `json:"status"`
+	Metadata            []byte             `json:"metadata"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	DeletionScheduledAt pgtype.Timestamptz `json:"deletion_scheduled_at"`
+	TokensRevokedAt     pgtype.Timestamptz `json:"tokens_revoked_at"`
 }
 
 type UserAuthProvider struct {
@@ -43,3 +161,38 @@ type UserAuthProvider struct {
 	ProviderMetadata []byte             `json:"provider_metadata"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 }
+
+type UserBlock struct {
+	BlockerID pgtype.UUID        `json:"blocker_id"`
+	BlockedID pgtype.UUID        `json:"blocked_id"`
+	Kind      string             `json:"kind"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserCredential struct {
+	UserID       pgtype.UUID        `json:"user_id"`
+	PasswordHash string             `json:"password_hash"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserFollow struct {
+	FollowerID pgtype.UUID        `json:"follower_id"`
+	FolloweeID pgtype.UUID        `json:"followee_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserRole struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Role      string             `json:"role"`
+	GrantedBy pgtype.UUID        `json:"granted_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserTotp struct {
+	UserID       pgtype.UUID        `json:"user_id"`
+	Secret       string             `json:"secret"`
+	ConfirmedAt  pgtype.Timestamptz `json:"confirmed_at"`
+	LastUsedStep int64              `json:"last_used_step"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
@@ -24,20 +24,67 @@ func (q *Queries) CountCommentsByParent(ctx context.Context, parentID pgtype.UUI
 }
 
 const countPostsByUser = `-- name: CountPostsByUser :one
-SELECT COUNT(*) FROM posts WHERE user_id = $1
+SELECT COUNT(*) FROM posts p
+WHERE p.user_id = $1
+    AND p.type = $2
+    AND (COALESCE(p.visibility, 'public') = 'public'
+        OR p.user_id = $3
+        OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = $3 AND f.followee_id = p.user_id))
 `
 
-func (q *Queries) CountPostsByUser(ctx context.Context, userID pgtype.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countPostsByUser, userID)
+type CountPostsByUserParams struct {
+	UserID   pgtype.UUID `json:"user_id"`
+	Type     string      `json:"type"`
+	ViewerID pgtype.UUID `json:"viewer_id"`
+}
+
+// Counts the posts ListPostsByUser would show viewer_id
+func (q *Queries) CountPostsByUser(ctx context.Context, arg *CountPostsByUserParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPostsByUser, arg.UserID, arg.Type, arg.ViewerID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
+const createComment = `-- name: CreateComment :one
+INSERT INTO posts (user_id, type, parent_id, reply_to_id, content)
+VALUES ($1, 'comment', $2, $3, $4)
+RETURNING id, user_id, type, parent_id, content, visibility, metadata, created_at, reply_to_id
+`
+
+type CreateCommentParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	PinID     pgtype.UUID `json:"pin_id"`
+	ReplyToID pgtype.UUID `json:"reply_to_id"`
+	Content   string      `json:"content"`
+}
+
+func (q *Queries) CreateComment(ctx context.Context, arg *CreateCommentParams) (*Post, error) {
+	row := q.db.QueryRow(ctx, createComment,
+		arg.UserID,
+		arg.PinID,
+		arg.ReplyToID,
+		arg.Content,
+	)
+	var i Post
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.ParentID,
+		&i.Content,
+		&i.Visibility,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.ReplyToID,
+	)
+	return &i, err
+}
+
 const createPost = `-- name: CreatePost :one
 INSERT INTO posts (id, user_id, type, parent_id, content, visibility, metadata, created_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, user_id, type, parent_id, content, visibility, metadata, created_at
+RETURNING id, user_id, type, parent_id, content, visibility, metadata, created_at, reply_to_id
 `
 
 type CreatePostParams struct {
@@ -72,6 +119,7 @@ func (q *Queries) CreatePost(ctx context.Context, arg *CreatePostParams) (*Post,
 		&i.Visibility,
 		&i.Metadata,
 		&i.CreatedAt,
+		&i.ReplyToID,
 	)
 	return &i, err
 }
@@ -85,8 +133,23 @@ func (q *Queries) DeletePost(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const deleteUnrepliedPostsByUser = `-- name: DeleteUnrepliedPostsByUser :execrows
+DELETE FROM posts p
+WHERE p.user_id = $1
+    AND NOT EXISTS (SELECT 1 FROM posts r WHERE (r.parent_id = p.id OR r.reply_to_id = p.id) AND r.user_id <> $1)
+`
+
+// Deletes a user's posts that no other user has replied to
+func (q *Queries) DeleteUnrepliedPostsByUser(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUnrepliedPostsByUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getPostByID = `-- name: GetPostByID :one
-SELECT id, user_id, type, parent_id, content, visibility, metadata, created_at FROM posts WHERE id = $1
+SELECT id, user_id, type, parent_id, content, visibility, metadata, created_at, reply_to_id FROM posts WHERE id = $1
 `
 
 func (q *Queries) GetPostByID(ctx context.Context, id pgtype.UUID) (*Post, error) {
@@ -101,13 +164,14 @@ func (q *Queries) GetPostByID(ctx context.Context, id pgtype.UUID) (*Post, error
 		&i.Visibility,
 		&i.Metadata,
 		&i.CreatedAt,
+		&i.ReplyToID,
 	)
 	return &i, err
 }
 
 const getPostWithAuthor = `-- name: GetPostWithAuthor :one
 SELECT 
-    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at,
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
     u.id as author_id,
     u.username as author_username,
     u.display_name as author_display_name,
@@ -126,6 +190,7 @@ type GetPostWithAuthorRow struct {
 	Visibility        *string            `json:"visibility"`
 	Metadata          []byte             `json:"metadata"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ReplyToID         pgtype.UUID        `json:"reply_to_id"`
 	AuthorID          pgtype.UUID        `json:"author_id"`
 	AuthorUsername    *string            `json:"author_username"`
 	AuthorDisplayName *string            `json:"author_display_name"`
@@ -144,6 +209,7 @@ func (q *Queries) GetPostWithAuthor(ctx context.Context, id pgtype.UUID) (*GetPo
 		&i.Visibility,
 		&i.Metadata,
 		&i.CreatedAt,
+		&i.ReplyToID,
 		&i.AuthorID,
 		&i.AuthorUsername,
 		&i.AuthorDisplayName,
@@ -154,21 +220,23 @@ func (q *Queries) GetPostWithAuthor(ctx context.Context, id pgtype.UUID) (*GetPo
 
 const listCommentsByParent = `-- name: ListCommentsByParent :many
 SELECT 
-    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at,
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
     u.username as author_username,
     u.display_name as author_display_name,
     u.avatar_url as author_avatar_url
 FROM posts p
 LEFT JOIN users u ON p.user_id = u.id
 WHERE p.parent_id = $1 AND p.type = 'comment'
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = $2 AND b.blocked_id = p.user_id)
 ORDER BY p.created_at ASC
-LIMIT $2 OFFSET $3
+LIMIT $4 OFFSET $3
 `
 
 type ListCommentsByParentParams struct {
-	ParentID pgtype.UUID `json:"parent_id"`
-	Limit    int32       `json:"limit"`
-	Offset   int32       `json:"offset"`
+	ParentID   pgtype.UUID `json:"parent_id"`
+	ViewerID   pgtype.UUID `json:"viewer_id"`
+	PageOffset int32       `json:"page_offset"`
+	PageLimit  int32       `json:"page_limit"`
 }
 
 type ListCommentsByParentRow struct {
@@ -180,13 +248,20 @@ type ListCommentsByParentRow struct {
 	Visibility        *string            `json:"visibility"`
 	Metadata          []byte             `json:"metadata"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ReplyToID         pgtype.UUID        `json:"reply_to_id"`
 	AuthorUsername    *string            `json:"author_username"`
 	AuthorDisplayName *string            `json:"author_display_name"`
 	AuthorAvatarUrl   *string            `json:"author_avatar_url"`
 }
 
+// Comments from users the viewer blocked or muted are left out
 func (q *Queries) ListCommentsByParent(ctx context.Context, arg *ListCommentsByParentParams) ([]*ListCommentsByParentRow, error) {
-	rows, err := q.db.Query(ctx, listCommentsByParent, arg.ParentID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listCommentsByParent,
+		arg.ParentID,
+		arg.ViewerID,
+		arg.PageOffset,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -203,6 +278,7 @@ func (q *Queries) ListCommentsByParent(ctx context.Context, arg *ListCommentsByP
 			&i.Visibility,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.ReplyToID,
 			&i.AuthorUsername,
 			&i.AuthorDisplayName,
 			&i.AuthorAvatarUrl,
@@ -218,7 +294,7 @@ func (q *Queries) ListCommentsByParent(ctx context.Context, arg *ListCommentsByP
 }
 
 const listPostsByType = `-- name: ListPostsByType :many
-SELECT id, user_id, type, parent_id, content, visibility, metadata, created_at FROM posts
+SELECT id, user_id, type, parent_id, content, visibility, metadata, created_at, reply_to_id FROM posts
 WHERE type = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -248,6 +324,7 @@ func (q *Queries) ListPostsByType(ctx context.Context, arg *ListPostsByTypeParam
 			&i.Visibility,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.ReplyToID,
 		); err != nil {
 			return nil, err
 		}
@@ -260,27 +337,66 @@ func (q *Queries) ListPostsByType(ctx context.Context, arg *ListPostsByTypeParam
 }
 
 const listPostsByUser = `-- name: ListPostsByUser :many
-SELECT id, user_id, type, parent_id, content, visibility, metadata, created_at FROM posts 
-WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3
+SELECT 
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
+    ST_X(pl.coordinates) as longitude,
+    ST_Y(pl.coordinates) as latitude,
+    pl.geohash
+FROM posts p
+LEFT JOIN posts_location pl ON p.id = pl.post_id
+WHERE p.user_id = $1
+    AND p.type = $2
+    AND (COALESCE(p.visibility, 'public') = 'public'
+        OR p.user_id = $3
+        OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = $3 AND f.followee_id = p.user_id))
+    AND ($4::timestamptz IS NULL
+        OR (p.created_at, p.id) < ($4::timestamptz, $5::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT $6
 `
 
 type ListPostsByUserParams struct {
-	UserID pgtype.UUID `json:"user_id"`
-	Limit  int32       `json:"limit"`
-	Offset int32       `json:"offset"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	Type            string             `json:"type"`
+	ViewerID        pgtype.UUID        `json:"viewer_id"`
+	BeforeCreatedAt pgtype.Timestamptz `json:"before_created_at"`
+	BeforeID        pgtype.UUID        `json:"before_id"`
+	PageLimit       int32              `json:"page_limit"`
 }
 
-func (q *Queries) ListPostsByUser(ctx context.Context, arg *ListPostsByUserParams) ([]*Post, error) {
-	rows, err := q.db.Query(ctx, listPostsByUser, arg.UserID, arg.Limit, arg.Offset)
+type ListPostsByUserRow struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	Type       string             `json:"type"`
+	ParentID   pgtype.UUID        `json:"parent_id"`
+	Content    string             `json:"content"`
+	Visibility *string            `json:"visibility"`
+	Metadata   []byte             `json:"metadata"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ReplyToID  pgtype.UUID        `json:"reply_to_id"`
+	Longitude  interface{}        `json:"longitude"`
+	Latitude   interface{}        `json:"latitude"`
+	Geohash    *string            `json:"geohash"`
+}
+
+// Keyset pagination as in ListFeedPins. Followers-only posts are included
+// when viewer_id is the author or one of their followers.
+func (q *Queries) ListPostsByUser(ctx context.Context, arg *ListPostsByUserParams) ([]*ListPostsByUserRow, error) {
+	rows, err := q.db.Query(ctx, listPostsByUser,
+		arg.UserID,
+		arg.Type,
+		arg.ViewerID,
+		arg.BeforeCreatedAt,
+		arg.BeforeID,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []*Post{}
+	items := []*ListPostsByUserRow{}
 	for rows.Next() {
-		var i Post
+		var i ListPostsByUserRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -290,6 +406,10 @@ func (q *Queries) ListPostsByUser(ctx context.Context, arg *ListPostsByUserParam
 			&i.Visibility,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.ReplyToID,
+			&i.Longitude,
+			&i.Latitude,
+			&i.Geohash,
 		); err != nil {
 			return nil, err
 		}
@@ -303,7 +423,7 @@ func (q *Queries) ListPostsByUser(ctx context.Context, arg *ListPostsByUserParam
 
 const listRecentPins = `-- name: ListRecentPins :many
 SELECT 
-    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at,
+    p.id, p.user_id, p.type, p.parent_id, p.content, p.visibility, p.metadata, p.created_at, p.reply_to_id,
     u.username as author_username,
     u.display_name as author_display_name,
     u.avatar_url as author_avatar_url
@@ -329,6 +449,7 @@ type ListRecentPinsRow struct {
 	Visibility        *string            `json:"visibility"`
 	Metadata          []byte             `json:"metadata"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	ReplyToID         pgtype.UUID        `json:"reply_to_id"`
 	AuthorUsername    *string            `json:"author_username"`
 	AuthorDisplayName *string            `json:"author_display_name"`
 	AuthorAvatarUrl   *string            `json:"author_avatar_url"`
@@ -352,6 +473,7 @@ func (q *Queries) ListRecentPins(ctx context.Context, arg *ListRecentPinsParams)
 			&i.Visibility,
 			&i.Metadata,
 			&i.CreatedAt,
+			&i.ReplyToID,
 			&i.AuthorUsername,
 			&i.AuthorDisplayName,
 			&i.AuthorAvatarUrl,
@@ -366,6 +488,39 @@ func (q *Queries) ListRecentPins(ctx context.Context, arg *ListRecentPinsParams)
 	return items, nil
 }
 
+const reassignPostsToUser = `-- name: ReassignPostsToUser :execrows
+UPDATE posts
+SET user_id = $1
+WHERE user_id = $2
+`
+
+type ReassignPostsToUserParams struct {
+	NewUserID pgtype.UUID `json:"new_user_id"`
+	OldUserID pgtype.UUID `json:"old_user_id"`
+}
+
+func (q *Queries) ReassignPostsToUser(ctx context.Context, arg *ReassignPostsToUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignPostsToUser, arg.NewUserID, arg.OldUserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const redactPostsByUser = `-- name: RedactPostsByUser :execrows
+UPDATE posts
+SET content = '[deleted]', metadata = '{}'
+WHERE user_id = $1
+`
+
+func (q *Queries) RedactPostsByUser(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, redactPostsByUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updatePost = `-- name: UpdatePost :one
 UPDATE posts
 SET 
@@ -373,7 +528,7 @@ SET
     visibility = $3,
     metadata = $4
 WHERE id = $1
-RETURNING id, user_id, type, parent_id, content, visibility, metadata, created_at
+RETURNING id, user_id, type, parent_id, content, visibility, metadata, created_at, reply_to_id
 `
 
 type UpdatePostParams struct {
@@ -400,6 +555,7 @@ func (q *Queries) UpdatePost(ctx context.Context, arg *UpdatePostParams) (*Post,
 		&i.Visibility,
 		&i.Metadata,
 		&i.CreatedAt,
+		&i.ReplyToID,
 	)
 	return &i, err
 }
@@ -11,6 +11,53 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelUserDeletion = `-- name: CancelUserDeletion :execrows
+UPDATE users SET deletion_scheduled_at = NULL, updated_at = NOW()
+WHERE id = $1 AND deletion_scheduled_at IS NOT NULL
+`
+
+func (q *Queries) CancelUserDeletion(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelUserDeletion, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const changeUsername = `-- name: ChangeUsername :one
+UPDATE users SET username = $2, username_changed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND (username_changed_at IS NULL OR username_changed_at <= $3)
+RETURNING id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at
+`
+
+type ChangeUsernameParams struct {
+	ID                pgtype.UUID        `json:"id"`
+	Username          string             `json:"username"`
+	UsernameChangedAt pgtype.Timestamptz `json:"username_changed_at"`
+}
+
+// Only changes the username if the last change was at or before $3
+func (q *Queries) ChangeUsername(ctx context.Context, arg *ChangeUsernameParams) (*User, error) {
+	row := q.db.QueryRow(ctx, changeUsername, arg.ID, arg.Username, arg.UsernameChangedAt)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
+	)
+	return &i, err
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
 `
@@ -23,18 +70,21 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 }
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (id, username, email, display_name, avatar_url, created_at) 
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, username, email, display_name, avatar_url, created_at
+INSERT INTO users (id, username, email, display_name, avatar_url, created_at, email_verified_at, status, metadata, updated_at) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $6)
+RETURNING id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at
 `
 
 type CreateUserParams struct {
-	ID          pgtype.UUID        `json:"id"`
-	Username    string             `json:"username"`
-	Email       string             `json:"email"`
-	DisplayName *string            `json:"display_name"`
-	AvatarUrl   *string            `json:"avatar_url"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ID              pgtype.UUID        `json:"id"`
+	Username        string             `json:"username"`
+	Email           string             `json:"email"`
+	DisplayName     *string            `json:"display_name"`
+	AvatarUrl       *string            `json:"avatar_url"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+	Status          string             `json:"status"`
+	Metadata        []byte             `json:"metadata"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User, error) {
@@ -45,6 +95,9 @@ func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User,
 		arg.DisplayName,
 		arg.AvatarUrl,
 		arg.CreatedAt,
+		arg.EmailVerifiedAt,
+		arg.Status,
+		arg.Metadata,
 	)
 	var i User
 	err := row.Scan(
@@ -54,12 +107,42 @@ func (q *Queries) CreateUser(ctx context.Context, arg *CreateUserParams) (*User,
 		&i.DisplayName,
 		&i.AvatarUrl,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
 	)
 	return &i, err
 }
 
+const deleteScheduledUser = `-- name: DeleteScheduledUser :execrows
+DELETE FROM users WHERE id = $1 AND deletion_scheduled_at <= NOW()
+`
+
+// Only deletes the user if the deletion is still scheduled and due, so a sign-in
+// that cancelled it in the meantime wins
+func (q *Queries) DeleteScheduledUser(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteScheduledUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteUser, id)
+	return err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, display_name, avatar_url, created_at FROM users WHERE email = $1
+SELECT id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at FROM users WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (*User, error) {
@@ -72,12 +155,19 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (*User, erro
 		&i.DisplayName,
 		&i.AvatarUrl,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
 	)
 	return &i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, email, display_name, avatar_url, created_at FROM users WHERE id = $1
+SELECT id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (*User, error) {
@@ -90,12 +180,19 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (*User, error
 		&i.DisplayName,
 		&i.AvatarUrl,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
 	)
 	return &i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, email, display_name, avatar_url, created_at FROM users WHERE username = $1
+SELECT id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at FROM users WHERE username = $1
 `
 
 func (q *Queries) GetUserByUsername(ctx context.Context, username string) (*User, error) {
@@ -108,12 +205,19 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (*User
 		&i.DisplayName,
 		&i.AvatarUrl,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
 	)
 	return &i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, username, email, display_name, avatar_url, created_at FROM users 
+SELECT id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at FROM users 
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
@@ -139,6 +243,13 @@ func (q *Queries) ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User,
 			&i.DisplayName,
 			&i.AvatarUrl,
 			&i.CreatedAt,
+			&i.EmailVerifiedAt,
+			&i.UsernameChangedAt,
+			&i.Status,
+			&i.Metadata,
+			&i.UpdatedAt,
+			&i.DeletionScheduledAt,
+			&i.TokensRevokedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -150,33 +261,189 @@ func (q *Queries) ListUsers(ctx context.Context, arg *ListUsersParams) ([]*User,
 	return items, nil
 }
 
+const listUsersDueForDeletion = `-- name: ListUsersDueForDeletion :many
+SELECT id FROM users
+WHERE deletion_scheduled_at <= $1
+ORDER BY deletion_scheduled_at
+LIMIT $2
+`
+
+type ListUsersDueForDeletionParams struct {
+	DeletionScheduledAt pgtype.Timestamptz `json:"deletion_scheduled_at"`
+	Limit               int32              `json:"limit"`
+}
+
+func (q *Queries) ListUsersDueForDeletion(ctx context.Context, arg *ListUsersDueForDeletionParams) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listUsersDueForDeletion, arg.DeletionScheduledAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var id pgtype.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const postponeUserDeletion = `-- name: PostponeUserDeletion :exec
+UPDATE users SET deletion_scheduled_at = $2
+WHERE id = $1 AND deletion_scheduled_at IS NOT NULL
+`
+
+type PostponeUserDeletionParams struct {
+	ID                  pgtype.UUID        `json:"id"`
+	DeletionScheduledAt pgtype.Timestamptz `json:"deletion_scheduled_at"`
+}
+
+// Only moves a deletion that is still scheduled, so a cancelled one stays cancelled
+func (q *Queries) PostponeUserDeletion(ctx context.Context, arg *PostponeUserDeletionParams) error {
+	_, err := q.db.Exec(ctx, postponeUserDeletion, arg.ID, arg.DeletionScheduledAt)
+	return err
+}
+
+const revokeUserTokens = `-- name: RevokeUserTokens :exec
+UPDATE users SET tokens_revoked_at = $2
+WHERE id = $1 AND (tokens_revoked_at IS NULL OR tokens_revoked_at < $2)
+`
+
+type RevokeUserTokensParams struct {
+	ID              pgtype.UUID        `json:"id"`
+	TokensRevokedAt pgtype.Timestamptz `json:"tokens_revoked_at"`
+}
+
+func (q *Queries) RevokeUserTokens(ctx context.Context, arg *RevokeUserTokensParams) error {
+	_, err := q.db.Exec(ctx, revokeUserTokens, arg.ID, arg.TokensRevokedAt)
+	return err
+}
+
+const scheduleUserDeletion = `-- name: ScheduleUserDeletion :execrows
+UPDATE users SET deletion_scheduled_at = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type ScheduleUserDeletionParams struct {
+	ID                  pgtype.UUID        `json:"id"`
+	DeletionScheduledAt pgtype.Timestamptz `json:"deletion_scheduled_at"`
+}
+
+func (q *Queries) ScheduleUserDeletion(ctx context.Context, arg *ScheduleUserDeletionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, scheduleUserDeletion, arg.ID, arg.DeletionScheduledAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users 
 SET 
-    username = $2,
-    email = $3,
-    display_name = $4,
-    avatar_url = $5
-WHERE id = $1
-RETURNING id, username, email, display_name, avatar_url, created_at
+    username = $1,
+    email = $2,
+    display_name = $3,
+    avatar_url = $4,
+    email_verified_at = $5,
+    status = COALESCE(NULLIF($6::varchar, ''), status),
+    metadata = COALESCE($7::jsonb, metadata),
+    updated_at = NOW()
+WHERE id = $8
+RETURNING id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at
 `
 
 type UpdateUserParams struct {
-	ID          pgtype.UUID `json:"id"`
-	Username    string      `json:"username"`
-	Email       string      `json:"email"`
-	DisplayName *string     `json:"display_name"`
-	AvatarUrl   *string     `json:"avatar_url"`
+	Username        string             `json:"username"`
+	Email           string             `json:"email"`
+	DisplayName     *string            `json:"display_name"`
+	AvatarUrl       *string            `json:"avatar_url"`
+	EmailVerifiedAt pgtype.Timestamptz `json:"email_verified_at"`
+	Status          string             `json:"status"`
+	Metadata        []byte             `json:"metadata"`
+	ID              pgtype.UUID        `json:"id"`
 }
 
+// An empty status or NULL metadata keeps the stored value
 func (q *Queries) UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User, error) {
 	row := q.db.QueryRow(ctx, updateUser,
-		arg.ID,
 		arg.Username,
 		arg.Email,
 		arg.DisplayName,
 		arg.AvatarUrl,
+		arg.EmailVerifiedAt,
+		arg.Status,
+		arg.Metadata,
+		arg.ID,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
+	)
+	return &i, err
+}
+
+const updateUserAvatar = `-- name: UpdateUserAvatar :one
+UPDATE users SET avatar_url = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at
+`
+
+type UpdateUserAvatarParams struct {
+	ID        pgtype.UUID `json:"id"`
+	AvatarUrl *string     `json:"avatar_url"`
+}
+
+func (q *Queries) UpdateUserAvatar(ctx context.Context, arg *UpdateUserAvatarParams) (*User, error) {
+	row := q.db.QueryRow(ctx, updateUserAvatar, arg.ID, arg.AvatarUrl)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.DisplayName,
+		&i.AvatarUrl,
+		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
 	)
+	return &i, err
+}
+
+const updateUserDisplayName = `-- name: UpdateUserDisplayName :one
+UPDATE users SET display_name = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, username, email, display_name, avatar_url, created_at, email_verified_at, username_changed_at, status, metadata, updated_at, deletion_scheduled_at, tokens_revoked_at
+`
+
+type UpdateUserDisplayNameParams struct {
+	ID          pgtype.UUID `json:"id"`
+	DisplayName *string     `json:"display_name"`
+}
+
+func (q *Queries) UpdateUserDisplayName(ctx context.Context, arg *UpdateUserDisplayNameParams) (*User, error) {
+	row := q.db.QueryRow(ctx, updateUserDisplayName, arg.ID, arg.DisplayName)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -185,6 +452,13 @@ func (q *Queries) UpdateUser(ctx context.Context, arg *UpdateUserParams) (*User,
 		&i.DisplayName,
 		&i.AvatarUrl,
 		&i.CreatedAt,
+		&i.EmailVerifiedAt,
+		&i.UsernameChangedAt,
+		&i.Status,
+		&i.Metadata,
+		&i.UpdatedAt,
+		&i.DeletionScheduledAt,
+		&i.TokensRevokedAt,
 	)
 	return &i, err
 }
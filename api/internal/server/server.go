@@ -2,23 +2,29 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/radjathaher/alunalun/api/internal/middleware"
-	"github.com/radjathaher/alunalun/api/internal/protoconv"
 	authServicePb "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service/auth_servicev1connect"
-	pinServicePb "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
-	userServicePb "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
+	servicePb "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
 	"github.com/radjathaher/alunalun/api/internal/repository"
+	apiKeyService "github.com/radjathaher/alunalun/api/internal/services/apikey"
 	authService "github.com/radjathaher/alunalun/api/internal/services/auth"
+	notificationService "github.com/radjathaher/alunalun/api/internal/services/notification"
 	pinService "github.com/radjathaher/alunalun/api/internal/services/pin"
+	pushService "github.com/radjathaher/alunalun/api/internal/services/push"
 	userService "github.com/radjathaher/alunalun/api/internal/services/user"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+	"github.com/radjathaher/alunalun/api/internal/utils/media"
 	"github.com/radjathaher/alunalun/api/internal/utils/oauth"
+	"github.com/radjathaher/alunalun/api/internal/utils/push"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 )
@@ -27,6 +33,7 @@ import (
 type Config struct {
 	// Server
 	Addr         string
+	Development  bool // Allows development-only fallbacks such as logged emails
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
@@ -45,6 +52,25 @@ type Config struct {
 	GoogleClientID     string
 	GoogleClientSecret string
 	GoogleRedirectURL  string
+	AppleClientID      string
+	AppleTeamID        string
+	AppleKeyID         string
+	ApplePrivateKey    string
+	AppleRedirectURL   string
+	AppleBundleIDs     []string
+
+	// Uploaded media, stored on local disk and served at MediaURL
+	MediaPath string
+	MediaURL  string
+
+	// Data exports, stored on local disk and served at ExportURL through signed links
+	ExportPath       string
+	ExportURL        string
+	ExportSigningKey []byte
+
+	// Web Push, disabled without a VAPID key. Browsers must subscribe again if the key changes.
+	VAPIDPrivateKey string
+	VAPIDSubject    string
 
 	// Future dependencies
 	// S3Client    *s3.Client
@@ -59,12 +85,31 @@ type Server struct {
 	mux        *http.ServeMux
 
 	// Services
-	authService *authService.Service
-	userService *userService.Service
-	pinService  *pinService.Service
+	authService         *authService.Service
+	userService         *userService.Service
+	pinService          *pinService.Service
+	apiKeyService       *apiKeyService.Service
+	notificationService *notificationService.Service
+	pushService         *pushService.Service
 
 	// Handlers
 	oauthHandler *authService.OAuthHandler
+
+	// Providers shared between services
+	emailProvider *auth.EmailPasswordProvider
+	apiKeyManager *auth.APIKeyManager
+	mediaStore    *media.LocalStore
+	exportStore   *media.PrivateStore
+
+	// Background jobs
+	anonymousManager      *auth.AnonymousManager
+	deletionManager       *auth.AccountDeletionManager
+	passwordResetter      *auth.PasswordResetter
+	emailVerifier         *auth.EmailVerifier
+	exporter              *userService.Exporter
+	notificationGenerator *notificationService.Generator
+	pushDeliverer         *pushService.Deliverer // nil when Web Push is disabled
+	stopJobs              context.CancelFunc
 }
 
 // New creates a new server instance
@@ -108,6 +153,14 @@ func (s *Server) setupServices() error {
 	// This implements auth.UserStore interface that auth service needs
 	userStore := protoconv.NewPostgresUserStore(s.config.Queries)
 
+	// Signing out everywhere also rejects tokens whose sessions are gone
+	s.config.SessionManager.SetTokenRevoker(userStore)
+
+	authConfig := s.config.AuthConfig
+	if authConfig == nil {
+		authConfig = auth.DefaultConfig()
+	}
+
 	// Create auth service
 	var err error
 	s.authService, err = authService.NewService(
@@ -115,32 +168,193 @@ func (s *Server) setupServices() error {
 		s.config.TokenManager,
 		s.config.SessionManager,
 		userStore,
-		s.config.AuthConfig,
+		authConfig,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create auth service: %w", err)
 	}
 
+	// Move anonymous accounts and their content onto registered ones on sign-in
+	s.authService.SetAccountMigrator(protoconv.NewPostgresAccountMigrator(s.config.DB, s.config.Queries))
+
+	// Expire, limit and clean up anonymous accounts
+	s.anonymousManager, err = auth.NewAnonymousManager(
+		authConfig.Anonymous,
+		userStore,
+		protoconv.NewPostgresAnonymousUserCreator(s.config.DB, s.config.Queries),
+		s.config.SessionManager,
+		s.config.TokenManager,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create anonymous manager: %w", err)
+	}
+	s.authService.SetAnonymousManager(s.anonymousManager)
+
+	// Anonymous users are created together with their tracking, so it is registered here
+	anonProvider, err := auth.NewAnonymousProvider(s.config.SessionManager, userStore)
+	if err != nil {
+		return fmt.Errorf("failed to create anonymous provider: %w", err)
+	}
+	anonProvider.SetAnonymousManager(s.anonymousManager)
+	if err := registry.Register(anonProvider); err != nil {
+		return fmt.Errorf("failed to register anonymous provider: %w", err)
+	}
+
+	// Send emails through the configured mail server; only an explicit
+	// APP_ENV=development may run without one, logging who each email would
+	// have gone to
+	var emailSender auth.EmailSender
+	switch {
+	case authConfig.SMTP.Host != "":
+		emailSender, err = auth.NewSMTPEmailSender(authConfig.SMTP)
+		if err != nil {
+			return fmt.Errorf("failed to create email sender: %w", err)
+		}
+	case s.config.Development:
+		log.Println("SMTP_HOST is not set; emails are logged instead of sent")
+		emailSender = auth.NewLogEmailSender()
+	default:
+		return errors.New("SMTP_HOST is required unless APP_ENV=development")
+	}
+
+	// Send and check email verification links
+	emailVerifier, err := auth.NewEmailVerifier(userStore, userStore, emailSender, authConfig.Email)
+	if err != nil {
+		return fmt.Errorf("failed to create email verifier: %w", err)
+	}
+	s.authService.SetEmailVerifier(emailVerifier)
+	s.emailVerifier = emailVerifier
+
+	// Register email/password sign-in; hashes are kept in user_credentials
+	s.emailProvider, err = auth.NewEmailPasswordProvider(userStore, authConfig.Email)
+	if err != nil {
+		return fmt.Errorf("failed to create email provider: %w", err)
+	}
+	s.emailProvider.SetEmailVerifier(emailVerifier)
+	s.emailProvider.SetPasswordUserCreator(protoconv.NewPostgresPasswordUserCreator(s.config.DB, s.config.Queries))
+
+	// Back off and lock out repeated password failures per account and IP
+	loginThrottle, err := auth.NewLoginThrottle(userStore, emailSender, authConfig.Lockout)
+	if err != nil {
+		return fmt.Errorf("failed to create login throttle: %w", err)
+	}
+	s.emailProvider.SetLoginThrottle(loginThrottle)
+
+	if err := registry.Register(s.emailProvider); err != nil {
+		return fmt.Errorf("failed to register email provider: %w", err)
+	}
+
+	// Reset forgotten passwords by email
+	passwordResetter, err := auth.NewPasswordResetter(userStore, userStore, emailSender, s.config.SessionManager, authConfig.Email)
+	if err != nil {
+		return fmt.Errorf("failed to create password resetter: %w", err)
+	}
+	passwordResetter.SetLoginThrottle(loginThrottle)
+	s.authService.SetPasswordResetter(passwordResetter)
+	s.passwordResetter = passwordResetter
+
+	// TOTP two-factor authentication; challenges are sealed with the OAuth state key
+	mfaManager, err := auth.NewMFAManager(userStore, s.config.StateManager, authConfig.MFA)
+	if err != nil {
+		return fmt.Errorf("failed to create MFA manager: %w", err)
+	}
+	mfaManager.SetLoginThrottle(loginThrottle)
+	s.authService.SetMFAManager(mfaManager)
+	s.emailProvider.SetMFAManager(mfaManager)
+
+	// Passwordless sign-in with WebAuthn passkeys
+	passkeyProvider, err := auth.NewPasskeyProvider(userStore, userStore, s.config.StateManager, authConfig.Passkey)
+	if err != nil {
+		return fmt.Errorf("failed to create passkey provider: %w", err)
+	}
+	if err := registry.Register(passkeyProvider); err != nil {
+		return fmt.Errorf("failed to register passkey provider: %w", err)
+	}
+	s.authService.SetPasskeyProvider(passkeyProvider)
+
 	// Create user service
 	s.userService = userService.NewService(
 		s.config.Queries,
 		s.config.TokenManager,
 	)
+	s.userService.SetEmailPasswordProvider(s.emailProvider)
+	s.userService.SetTokenIssuer(s.authService)
+
+	// Avatar uploads
+	s.mediaStore, err = media.NewLocalStore(s.config.MediaPath, s.config.MediaURL)
+	if err != nil {
+		return fmt.Errorf("failed to create media store: %w", err)
+	}
+	s.userService.SetMediaStore(s.mediaStore)
+
+	// Scheduled account deletion; signing in during the grace period cancels it
+	s.deletionManager, err = auth.NewAccountDeletionManager(
+		authConfig.Deletion,
+		protoconv.NewPostgresAccountDeletionStore(s.config.DB, s.config.Queries),
+		s.config.SessionManager,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create account deletion manager: %w", err)
+	}
+	s.deletionManager.SetMediaStore(s.mediaStore)
+	s.userService.SetAccountDeletionManager(s.deletionManager)
+	s.authService.SetAccountDeletionManager(s.deletionManager)
+
+	// Data exports, built in the background
+	s.exportStore, err = media.NewPrivateStore(s.config.ExportPath, s.config.ExportURL, s.config.ExportSigningKey)
+	if err != nil {
+		return fmt.Errorf("failed to create export store: %w", err)
+	}
+	s.exporter, err = userService.NewExporter(s.config.Queries, s.config.SessionManager, s.exportStore)
+	if err != nil {
+		return fmt.Errorf("failed to create exporter: %w", err)
+	}
+	s.userService.SetExporter(s.exporter)
 
 	// Create pin service
 	s.pinService = pinService.NewService(
 		s.config.DB,
 		s.config.Queries,
-		s.config.TokenManager,
 	)
 
+	// Notifications, generated in the background from comments and follows
+	notificationHub := notificationService.NewHub()
+	s.notificationGenerator, err = notificationService.NewGenerator(s.config.Queries, notificationHub)
+	if err != nil {
+		return fmt.Errorf("failed to create notification generator: %w", err)
+	}
+	s.notificationService = notificationService.NewService(s.config.DB, s.config.Queries, notificationHub)
+
+	// Web Push delivery of notifications, when a VAPID key is configured
+	var pushSender *push.Sender
+	if s.config.VAPIDPrivateKey != "" {
+		pushSender, err = push.NewSender(s.config.VAPIDPrivateKey, s.config.VAPIDSubject)
+		if err != nil {
+			return fmt.Errorf("failed to create push sender: %w", err)
+		}
+		s.pushDeliverer, err = pushService.NewDeliverer(s.config.Queries, pushSender)
+		if err != nil {
+			return fmt.Errorf("failed to create push deliverer: %w", err)
+		}
+	}
+	s.pushService = pushService.NewService(s.config.Queries, pushSender)
+
+	// Signed-out browsers stop receiving pushes
+	s.config.SessionManager.SetSessionListener(s.pushService)
+
+	// Personal API keys for scripts; the auth interceptor also checks them
+	s.apiKeyManager, err = auth.NewAPIKeyManager(userStore, userStore, authConfig.APIKeys)
+	if err != nil {
+		return fmt.Errorf("failed to create API key manager: %w", err)
+	}
+	s.apiKeyService = apiKeyService.NewService(s.apiKeyManager, userStore)
+
 	// Create OAuth HTTP handler
 	s.oauthHandler = authService.NewOAuthHandler(
 		s.authService,
 		s.config.StateManager,
 		registry,
 		s.config.TokenManager,
-		s.config.SessionManager,
 	)
 
 	return nil
@@ -163,19 +377,29 @@ func (s *Server) setupProviders(registry *auth.ProviderRegistry) error {
 		}
 	}
 
-	// Register anonymous provider for testing
-	anonProvider, err := auth.NewAnonymousProvider(s.config.SessionManager, protoconv.NewPostgresUserStore(s.config.Queries))
-	if err != nil {
-		return fmt.Errorf("failed to create anonymous provider: %w", err)
-	}
-	if err := registry.Register(anonProvider); err != nil {
-		return fmt.Errorf("failed to register anonymous provider: %w", err)
+	// Register Sign in with Apple if configured
+	if s.config.AppleClientID != "" {
+		provider, err := oauth.NewAppleProvider(
+			s.config.AppleClientID,
+			s.config.AppleTeamID,
+			s.config.AppleKeyID,
+			s.config.ApplePrivateKey,
+			s.config.AppleRedirectURL,
+			s.config.AppleBundleIDs,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create Apple provider: %w", err)
+		}
+		if err := registry.Register(provider); err != nil {
+			return fmt.Errorf("failed to register Apple provider: %w", err)
+		}
 	}
 
+	// Anonymous and email/password are registered in setupServices once their
+	// lifecycle and verification are set up
+
 	// Future: Register other providers
-	// - Apple OAuth
 	// - GitHub OAuth
-	// - Email/Password
 
 	return nil
 }
@@ -184,21 +408,50 @@ func (s *Server) setupProviders(registry *auth.ProviderRegistry) error {
 func (s *Server) setupRoutes() error {
 	// Create auth interceptor
 	authInterceptor := middleware.NewAuthInterceptor(s.config.TokenManager)
+	authInterceptor.SetAnonymousManager(s.anonymousManager)
+	authInterceptor.SetRoleStore(protoconv.NewPostgresUserStore(s.config.Queries))
+	authInterceptor.SetUserStore(protoconv.NewPostgresUserStore(s.config.Queries))
+	authInterceptor.SetAPIKeyManager(s.apiKeyManager)
 	interceptors := connect.WithInterceptors(authInterceptor)
 
-	// Mount OAuth HTTP routes
+	// Mount OAuth HTTP routes, identifying callers like the RPCs
+	s.oauthHandler.SetAuthInterceptor(authInterceptor)
 	s.oauthHandler.RegisterRoutes(s.mux)
 
 	// Mount ConnectRPC services
 	authPath, authHandler := authServicePb.NewAuthServiceHandler(s.authService, interceptors)
 	s.mux.Handle(authPath, authHandler)
 
-	userPath, userHandler := userServicePb.NewUserServiceHandler(s.userService, interceptors)
+	// Leave room for avatar uploads
+	userPath, userHandler := servicePb.NewUserServiceHandler(
+		s.userService,
+		interceptors,
+		connect.WithReadMaxBytes(media.MaxImageBytes+64<<10),
+	)
 	s.mux.Handle(userPath, userHandler)
 
-	pinPath, pinHandler := pinServicePb.NewPinServiceHandler(s.pinService, interceptors)
+	pinPath, pinHandler := servicePb.NewPinServiceHandler(s.pinService, interceptors)
 	s.mux.Handle(pinPath, pinHandler)
 
+	apiKeyPath, apiKeyHandler := servicePb.NewApiKeyServiceHandler(s.apiKeyService, interceptors)
+	s.mux.Handle(apiKeyPath, apiKeyHandler)
+
+	// Notification streams outlive the server's write timeout
+	notificationPath, notificationHandler := servicePb.NewNotificationServiceHandler(s.notificationService, interceptors)
+	s.mux.Handle(notificationPath, withoutWriteDeadline(
+		notificationHandler,
+		servicePb.NotificationServiceWatchNotificationsProcedure,
+	))
+
+	pushPath, pushHandler := servicePb.NewPushSubscriptionServiceHandler(s.pushService, interceptors)
+	s.mux.Handle(pushPath, pushHandler)
+
+	// Uploaded media
+	s.mux.Handle("/media/", http.StripPrefix("/media/", s.mediaStore.Handler()))
+
+	// Data export downloads; the signed link is the only credential
+	s.mux.Handle("/exports/", http.StripPrefix("/exports/", s.exportStore.Handler()))
+
 	// Health check endpoint
 	s.mux.HandleFunc("/health", s.handleHealth)
 
@@ -227,16 +480,45 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("OK"))
 }
 
-// Start starts the HTTP server
+// Start starts background jobs and the HTTP server
 func (s *Server) Start() error {
+	jobsCtx, cancel := context.WithCancel(context.Background())
+	s.stopJobs = cancel
+	go s.anonymousManager.Run(jobsCtx)
+	go s.deletionManager.Run(jobsCtx)
+	go s.passwordResetter.Run(jobsCtx)
+	go s.emailVerifier.Run(jobsCtx)
+	go s.exporter.Run(jobsCtx)
+	go s.notificationGenerator.Run(jobsCtx)
+	if s.pushDeliverer != nil {
+		go s.pushDeliverer.Run(jobsCtx)
+	}
+
 	return s.httpServer.ListenAndServe()
 }
 
-// Shutdown gracefully shuts down the server
+// Shutdown stops background jobs and gracefully shuts down the server
 func (s *Server) Shutdown(ctx context.Context) error {
+	if s.stopJobs != nil {
+		s.stopJobs()
+	}
 	return s.httpServer.Shutdown(ctx)
 }
 
+// withoutWriteDeadline lifts the server's write timeout for long-lived streaming procedures
+func withoutWriteDeadline(next http.Handler, procedures ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, procedure := range procedures {
+			if r.URL.Path == procedure {
+				// Not every ResponseWriter supports deadlines; the timeout then still applies
+				_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+				break
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // corsMiddleware adds CORS headers
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -249,6 +531,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, Connect-Protocol-Version")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Set("Access-Control-Expose-Headers", middleware.RenewedTokenHeader+", Retry-After")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -0,0 +1,158 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// Service implements the ApiKeyService
+type Service struct {
+	servicev1connect.UnimplementedApiKeyServiceHandler
+	manager   *auth.APIKeyManager
+	userStore auth.UserStore
+}
+
+// NewService creates a new API key service
+func NewService(manager *auth.APIKeyManager, userStore auth.UserStore) *Service {
+	return &Service{
+		manager:   manager,
+		userStore: userStore,
+	}
+}
+
+// CreateApiKey issues a key for the signed-in user
+func (s *Service) CreateApiKey(
+	ctx context.Context,
+	req *connect.Request[servicev1.CreateApiKeyRequest],
+) (*connect.Response[servicev1.CreateApiKeyResponse], error) {
+	claims, err := requireSignedIn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.ExpiresInSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expires_in_seconds must not be negative"))
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+
+	ttl := time.Duration(req.Msg.ExpiresInSeconds) * time.Second
+	secret, key, err := s.manager.Create(ctx, user, req.Msg.Name, req.Msg.Scopes, ttl)
+	if err != nil {
+		return nil, apiKeyErrorToConnect(err)
+	}
+
+	return connect.NewResponse(&servicev1.CreateApiKeyResponse{
+		ApiKey: apiKeyToProto(key),
+		Key:    secret,
+	}), nil
+}
+
+// ListApiKeys lists the signed-in user's active keys
+func (s *Service) ListApiKeys(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListApiKeysRequest],
+) (*connect.Response[servicev1.ListApiKeysResponse], error) {
+	claims, err := requireSignedIn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.manager.List(ctx, claims.UserID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list API keys: %w", err))
+	}
+
+	protoKeys := make([]*servicev1.ApiKey, 0, len(keys))
+	for _, key := range keys {
+		protoKeys = append(protoKeys, apiKeyToProto(key))
+	}
+
+	return connect.NewResponse(&servicev1.ListApiKeysResponse{
+		ApiKeys: protoKeys,
+	}), nil
+}
+
+// RevokeApiKey revokes one of the signed-in user's keys
+func (s *Service) RevokeApiKey(
+	ctx context.Context,
+	req *connect.Request[servicev1.RevokeApiKeyRequest],
+) (*connect.Response[servicev1.RevokeApiKeyResponse], error) {
+	claims, err := requireSignedIn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.ApiKeyId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("api_key_id is required"))
+	}
+
+	if err := s.manager.Revoke(ctx, claims.UserID, req.Msg.ApiKeyId); err != nil {
+		return nil, apiKeyErrorToConnect(err)
+	}
+
+	return connect.NewResponse(&servicev1.RevokeApiKeyResponse{
+		Success: true,
+	}), nil
+}
+
+// requireSignedIn returns the caller's claims. Keys can only be managed from
+// a registered user's sign-in, not from anonymous sessions or other keys.
+func requireSignedIn(ctx context.Context) (*auth.Claims, error) {
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	if claims.IsAnonymous {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("sign in to manage API keys"))
+	}
+	if claims.Provider == auth.ProviderAPIKey {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("API keys cannot manage API keys"))
+	}
+	return claims, nil
+}
+
+// apiKeyToProto converts auth.APIKey to its proto form, without the secret
+func apiKeyToProto(key *auth.APIKey) *servicev1.ApiKey {
+	protoKey := &servicev1.ApiKey{
+		Id:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedAt: key.CreatedAt.Unix(),
+	}
+	if key.ExpiresAt != nil {
+		expiresAt := key.ExpiresAt.Unix()
+		protoKey.ExpiresAt = &expiresAt
+	}
+	if key.LastUsedAt != nil {
+		lastUsedAt := key.LastUsedAt.Unix()
+		protoKey.LastUsedAt = &lastUsedAt
+	}
+	return protoKey
+}
+
+// apiKeyErrorToConnect maps API key errors to connect errors
+func apiKeyErrorToConnect(err error) error {
+	var authErr *auth.AuthError
+	if errors.As(err, &authErr) {
+		switch authErr.Code {
+		case auth.ErrInvalidArgument:
+			return connect.NewError(connect.CodeInvalidArgument, errors.New(authErr.Message))
+		case auth.ErrRateLimited:
+			return connect.NewError(connect.CodeResourceExhausted, errors.New(authErr.Message))
+		case auth.ErrNotFound:
+			return connect.NewError(connect.CodeNotFound, errors.New(authErr.Message))
+		}
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// LinkProvider links another login provider to the authenticated user.
+// The caller must present a fresh credential for the provider being linked.
+func (s *Service) LinkProvider(
+	ctx context.Context,
+	req *connect.Request[servicev1.LinkProviderRequest],
+) (*connect.Response[servicev1.LinkProviderResponse], error) {
+	claims, err := s.requireRegisteredUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.Provider == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("provider is required"))
+	}
+	if req.Msg.Credential == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("credential is required"))
+	}
+	if req.Msg.Provider == "anonymous" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("anonymous sessions cannot be linked"))
+	}
+
+	// Get the provider
+	provider, err := s.registry.Get(req.Msg.Provider)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("provider %s not found: %w", req.Msg.Provider, err))
+	}
+
+	// Authenticate freshly with the provider to prove ownership of the identity
+	ctx = auth.ContextWithClientIP(ctx, auth.ClientIP(req.Peer().Addr, req.Header(), s.config.Anonymous.TrustForwardedFor))
+	userInfo, err := provider.Authenticate(ctx, req.Msg.Credential)
+	if err != nil {
+		return nil, authErrorToConnect(err)
+	}
+
+	link := auth.ProviderLinkFromUserInfo(claims.UserID, userInfo)
+
+	// The identity must not already belong to another account
+	existing, err := s.linkStore.GetProviderLink(ctx, link.Provider, link.ProviderUserID)
+	if err != nil {
+		var authErr *auth.AuthError
+		if !errors.As(err, &authErr) || authErr.Code != auth.ErrUserNotFound {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get provider link: %w", err))
+		}
+	} else {
+		if existing.UserID != claims.UserID {
+			return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("this login is already linked to another account"))
+		}
+		return connect.NewResponse(&servicev1.LinkProviderResponse{
+			Provider: linkToProto(existing),
+		}), nil
+	}
+
+	// One identity per provider per user
+	links, err := s.linkStore.ListProviderLinks(ctx, claims.UserID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list providers: %w", err))
+	}
+	for _, l := range links {
+		if l.Provider == link.Provider {
+			return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("a %s login is already linked; unlink it first", link.Provider))
+		}
+	}
+
+	if err := s.linkStore.CreateProviderLink(ctx, link); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to link provider: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.LinkProviderResponse{
+		Provider: linkToProto(link),
+	}), nil
+}
+
+// UnlinkProvider removes a login provider from the authenticated user
+func (s *Service) UnlinkProvider(
+	ctx context.Context,
+	req *connect.Request[servicev1.UnlinkProviderRequest],
+) (*connect.Response[servicev1.UnlinkProviderResponse], error) {
+	claims, err := s.requireRegisteredUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.Provider == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("provider is required"))
+	}
+
+	// The store never removes the last way to sign in
+	if err := s.linkStore.DeleteProviderLink(ctx, claims.UserID, req.Msg.Provider); err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			switch authErr.Code {
+			case auth.ErrUserNotFound:
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("provider %s is not linked", req.Msg.Provider))
+			case auth.ErrLastLoginMethod:
+				return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New(authErr.Message))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to unlink provider: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.UnlinkProviderResponse{}), nil
+}
+
+// ListLinkedProviders lists the login providers linked to the authenticated user
+func (s *Service) ListLinkedProviders(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListLinkedProvidersRequest],
+) (*connect.Response[servicev1.ListLinkedProvidersResponse], error) {
+	claims, err := s.requireRegisteredUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.linkStore.ListProviderLinks(ctx, claims.UserID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list providers: %w", err))
+	}
+
+	providers := make([]*servicev1.LinkedProvider, 0, len(links))
+	for _, link := range links {
+		providers = append(providers, linkToProto(link))
+	}
+
+	return connect.NewResponse(&servicev1.ListLinkedProvidersResponse{
+		Providers: providers,
+	}), nil
+}
+
+// requireRegisteredUser returns the caller's claims, rejecting anonymous callers
+// and stores without provider link support
+func (s *Service) requireRegisteredUser(ctx context.Context) (*auth.Claims, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	if claims.IsAnonymous {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("anonymous users cannot manage login providers"))
+	}
+	if s.linkStore == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("account linking is not supported by this user store"))
+	}
+	return claims, nil
+}
+
+// findProviderLink looks up the link for an authenticated identity. Returns nil
+// if the identity is not linked.
+func (s *Service) findProviderLink(ctx context.Context, info *auth.UserInfo) (*auth.ProviderLink, error) {
+	if s.linkStore == nil || info.Provider == "" {
+		return nil, nil
+	}
+
+	candidate := auth.ProviderLinkFromUserInfo("", info)
+	if candidate.ProviderUserID == "" {
+		return nil, nil
+	}
+
+	link, err := s.linkStore.GetProviderLink(ctx, candidate.Provider, candidate.ProviderUserID)
+	if err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) && authErr.Code == auth.ErrUserNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get provider link: %w", err)
+	}
+	return link, nil
+}
+
+// ensureProviderLink records the identity for a user if it is not linked yet
+func (s *Service) ensureProviderLink(ctx context.Context, userID string, info *auth.UserInfo) error {
+	if s.linkStore == nil || info.Provider == "" || info.Provider == "anonymous" {
+		return nil
+	}
+
+	link := auth.ProviderLinkFromUserInfo(userID, info)
+	if link.ProviderUserID == "" {
+		return nil
+	}
+	_, err := s.linkStore.GetProviderLink(ctx, link.Provider, link.ProviderUserID)
+	if err == nil {
+		return nil
+	}
+	var authErr *auth.AuthError
+	if !errors.As(err, &authErr) || authErr.Code != auth.ErrUserNotFound {
+		return fmt.Errorf("failed to get provider link: %w", err)
+	}
+
+	return s.linkStore.CreateProviderLink(ctx, link)
+}
+
+// authErrorToConnect maps provider authentication errors to connect errors
+func authErrorToConnect(err error) error {
+	var authErr *auth.AuthError
+	if errors.As(err, &authErr) {
+		switch authErr.Code {
+		case "MAGIC_LINK_SENT":
+			return connect.NewError(connect.CodeFailedPrecondition, errors.New("magic link sent; retry with the token from the email"))
+		case auth.ErrInvalidCredentials:
+			return connect.NewError(connect.CodeUnauthenticated, errors.New(authErr.Message))
+		case auth.ErrUserNotFound:
+			return connect.NewError(connect.CodeNotFound, errors.New(authErr.Message))
+		case auth.ErrUserDisabled:
+			return connect.NewError(connect.CodePermissionDenied, errors.New(authErr.Message))
+		case auth.ErrEmailNotVerified:
+			return connect.NewError(connect.CodeFailedPrecondition, errors.New(authErr.Message))
+		case auth.ErrTooManyAttempts:
+			return tooManyAttemptsToConnect(authErr)
+		}
+	}
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("authentication failed: %w", err))
+}
+
+// tooManyAttemptsToConnect maps a throttled sign-in to ResourceExhausted with a Retry-After hint
+func tooManyAttemptsToConnect(authErr *auth.AuthError) error {
+	connectErr := connect.NewError(connect.CodeResourceExhausted, errors.New(authErr.Message))
+	if seconds, ok := authErr.Details["retry_after_seconds"].(int); ok && seconds > 0 {
+		connectErr.Meta().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	return connectErr
+}
+
+// linkToProto converts a ProviderLink to proto LinkedProvider
+func linkToProto(link *auth.ProviderLink) *servicev1.LinkedProvider {
+	pb := &servicev1.LinkedProvider{
+		Provider:       link.Provider,
+		ProviderUserId: link.ProviderUserID,
+		LinkedAt:       link.CreatedAt.Unix(),
+	}
+	if link.Email != "" {
+		pb.Email = &link.Email
+	}
+	return pb
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+const (
+	testUserID  = "2b7e1516-28ae-4d2a-a6f7-15884f3c9a10"
+	otherUserID = "5f4dcc3b-5aa7-4c5d-8b2f-9e1a0c6d7e8f"
+)
+
+// testProvider authenticates any credential as the identity it names
+type testProvider struct {
+	name string
+}
+
+func (p *testProvider) Name() string { return p.name }
+
+func (p *testProvider) Type() string { return "oauth" }
+
+func (p *testProvider) ValidateConfig() error { return nil }
+
+func (p *testProvider) Authenticate(ctx context.Context, credential string) (*auth.UserInfo, error) {
+	return &auth.UserInfo{
+		Provider:   p.name,
+		ProviderID: credential,
+		Email:      credential + "@alunalun.test",
+	}, nil
+}
+
+// failingLinkStore fails every provider link lookup
+type failingLinkStore struct {
+	*auth.InMemoryProviderLinkStore
+	created int
+}
+
+func (s *failingLinkStore) GetProviderLink(ctx context.Context, provider, providerUserID string) (*auth.ProviderLink, error) {
+	return nil, errors.New("database unavailable")
+}
+
+func (s *failingLinkStore) CreateProviderLink(ctx context.Context, link *auth.ProviderLink) error {
+	s.created++
+	return s.InMemoryProviderLinkStore.CreateProviderLink(ctx, link)
+}
+
+// newLinkTestService returns a service that links google identities into a store
+func newLinkTestService(t *testing.T, links auth.ProviderLinkStore) *Service {
+	t.Helper()
+	registry := auth.NewProviderRegistry()
+	if err := registry.Register(&testProvider{name: "google"}); err != nil {
+		t.Fatal(err)
+	}
+	return &Service{
+		registry:  registry,
+		linkStore: links,
+		config:    auth.DefaultConfig(),
+	}
+}
+
+// userContext signs a registered user in
+func userContext(userID string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{UserID: userID, Provider: "email"})
+}
+
+// addLink links an identity to a user directly in the store
+func addLink(t *testing.T, links auth.ProviderLinkStore, userID, provider, providerUserID string) {
+	t.Helper()
+	if err := links.CreateProviderLink(context.Background(), &auth.ProviderLink{
+		UserID:         userID,
+		Provider:       provider,
+		ProviderUserID: providerUserID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func requireCode(t *testing.T, err error, code connect.Code) {
+	t.Helper()
+	if connect.CodeOf(err) != code {
+		t.Fatalf("expected %v, got %v", code, err)
+	}
+}
+
+// TestUnlinkProviderKeepsLastLoginMethod unlinks down to one provider, which
+// stays linked
+func TestUnlinkProviderKeepsLastLoginMethod(t *testing.T) {
+	links := auth.NewInMemoryProviderLinkStore()
+	s := newLinkTestService(t, links)
+	ctx := userContext(testUserID)
+	addLink(t, links, testUserID, "email", "user@alunalun.test")
+	addLink(t, links, testUserID, "google", "google-user")
+
+	_, err := s.UnlinkProvider(ctx, connect.NewRequest(&servicev1.UnlinkProviderRequest{Provider: "apple"}))
+	requireCode(t, err, connect.CodeNotFound)
+
+	if _, err := s.UnlinkProvider(ctx, connect.NewRequest(&servicev1.UnlinkProviderRequest{Provider: "google"})); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UnlinkProvider(ctx, connect.NewRequest(&servicev1.UnlinkProviderRequest{Provider: "email"}))
+	requireCode(t, err, connect.CodeFailedPrecondition)
+
+	remaining, err := links.ListProviderLinks(context.Background(), testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].Provider != "email" {
+		t.Fatalf("expected the email login to remain, got %+v", remaining)
+	}
+}
+
+// TestUnlinkProviderConcurrently unlinks both of two providers at once: only
+// one unlink may succeed
+func TestUnlinkProviderConcurrently(t *testing.T) {
+	links := auth.NewInMemoryProviderLinkStore()
+	s := newLinkTestService(t, links)
+	ctx := userContext(testUserID)
+	addLink(t, links, testUserID, "email", "user@alunalun.test")
+	addLink(t, links, testUserID, "google", "google-user")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, provider := range []string{"email", "google"} {
+		wg.Add(1)
+		go func(i int, provider string) {
+			defer wg.Done()
+			_, errs[i] = s.UnlinkProvider(ctx, connect.NewRequest(&servicev1.UnlinkProviderRequest{Provider: provider}))
+		}(i, provider)
+	}
+	wg.Wait()
+
+	if (errs[0] == nil) == (errs[1] == nil) {
+		t.Fatalf("expected exactly one unlink to succeed, got %v and %v", errs[0], errs[1])
+	}
+	remaining, err := links.ListProviderLinks(context.Background(), testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 {
+		t.Fatalf("expected one login method to remain, got %d", len(remaining))
+	}
+}
+
+// TestLinkProvider links a fresh identity once, and refuses identities of
+// other accounts and second identities of a linked provider
+func TestLinkProvider(t *testing.T) {
+	links := auth.NewInMemoryProviderLinkStore()
+	s := newLinkTestService(t, links)
+	addLink(t, links, otherUserID, "google", "other-user")
+
+	link := func(userID, credential string) (*connect.Response[servicev1.LinkProviderResponse], error) {
+		return s.LinkProvider(userContext(userID), connect.NewRequest(&servicev1.LinkProviderRequest{
+			Provider:   "google",
+			Credential: credential,
+		}))
+	}
+
+	resp, err := link(testUserID, "google-user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Msg.Provider.ProviderUserId != "google-user" {
+		t.Fatalf("expected the linked identity, got %+v", resp.Msg.Provider)
+	}
+	if _, err := link(testUserID, "google-user"); err != nil {
+		t.Fatalf("expected linking the same identity again to succeed, got %v", err)
+	}
+
+	_, err = link(testUserID, "other-user")
+	requireCode(t, err, connect.CodeAlreadyExists)
+	_, err = link(testUserID, "second-google-user")
+	requireCode(t, err, connect.CodeAlreadyExists)
+
+	userLinks, err := links.ListProviderLinks(context.Background(), testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(userLinks) != 1 {
+		t.Fatalf("expected one link, got %d", len(userLinks))
+	}
+}
+
+// TestLinkProviderLookupFailure reports a failed lookup instead of linking
+// the identity as if it were new
+func TestLinkProviderLookupFailure(t *testing.T) {
+	links := &failingLinkStore{InMemoryProviderLinkStore: auth.NewInMemoryProviderLinkStore()}
+	s := newLinkTestService(t, links)
+
+	_, err := s.LinkProvider(userContext(testUserID), connect.NewRequest(&servicev1.LinkProviderRequest{
+		Provider:   "google",
+		Credential: "google-user",
+	}))
+	requireCode(t, err, connect.CodeInternal)
+
+	if err := s.ensureProviderLink(context.Background(), testUserID, &auth.UserInfo{Provider: "google", ProviderID: "google-user"}); err == nil {
+		t.Fatal("expected ensureProviderLink to report the failed lookup")
+	}
+	if links.created != 0 {
+		t.Fatalf("expected no link to be created, got %d", links.created)
+	}
+}
@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// EnrollTOTP starts TOTP enrollment for the caller
+func (s *Service) EnrollTOTP(
+	ctx context.Context,
+	req *connect.Request[servicev1.EnrollTOTPRequest],
+) (*connect.Response[servicev1.EnrollTOTPResponse], error) {
+	user, err := s.requireMFAUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, uri, err := s.mfa.Enroll(ctx, user)
+	if err != nil {
+		return nil, mfaErrorToConnect(err)
+	}
+
+	return connect.NewResponse(&servicev1.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: uri,
+	}), nil
+}
+
+// ConfirmTOTP enables TOTP with the first code from the authenticator app
+func (s *Service) ConfirmTOTP(
+	ctx context.Context,
+	req *connect.Request[servicev1.ConfirmTOTPRequest],
+) (*connect.Response[servicev1.ConfirmTOTPResponse], error) {
+	if req.Msg.Code == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("code is required"))
+	}
+	user, err := s.requireMFAUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.mfa.Confirm(ctx, user.ID, req.Msg.Code)
+	if err != nil {
+		return nil, mfaErrorToConnect(err)
+	}
+
+	return connect.NewResponse(&servicev1.ConfirmTOTPResponse{
+		RecoveryCodes: codes,
+	}), nil
+}
+
+// DisableTOTP turns off TOTP after checking a current code or recovery code.
+// Holders of elevated roles must have them revoked first, since GrantRole
+// requires TOTP.
+func (s *Service) DisableTOTP(
+	ctx context.Context,
+	req *connect.Request[servicev1.DisableTOTPRequest],
+) (*connect.Response[servicev1.DisableTOTPResponse], error) {
+	if req.Msg.Code == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("code is required"))
+	}
+	user, err := s.requireMFAUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.roleStore != nil {
+		roles, err := s.roleStore.ListUserRoles(ctx, user.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list roles: %w", err))
+		}
+		if len(roles) > 0 {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("moderators and admins cannot disable two-factor authentication"))
+		}
+	}
+
+	if err := s.mfa.Disable(ctx, user, req.Msg.Code); err != nil {
+		return nil, mfaErrorToConnect(err)
+	}
+
+	return connect.NewResponse(&servicev1.DisableTOTPResponse{}), nil
+}
+
+// CompleteMFA finishes a sign-in that returned an MFA challenge
+func (s *Service) CompleteMFA(
+	ctx context.Context,
+	req *connect.Request[servicev1.CompleteMFARequest],
+) (*connect.Response[servicev1.CompleteMFAResponse], error) {
+	if s.mfa == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("two-factor authentication is not configured"))
+	}
+	if req.Msg.MfaChallenge == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("mfa challenge is required"))
+	}
+	if req.Msg.Code == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("code is required"))
+	}
+
+	challenge, err := s.mfa.OpenChallenge(req.Msg.MfaChallenge)
+	if err != nil {
+		return nil, mfaErrorToConnect(err)
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid or expired sign-in challenge"))
+	}
+
+	// Wrong codes count towards the same lockout as wrong passwords
+	ctx = auth.ContextWithClientIP(ctx, auth.ClientIP(req.Peer().Addr, req.Header(), s.config.Anonymous.TrustForwardedFor))
+	if err := s.mfa.Verify(ctx, user, req.Msg.Code); err != nil {
+		return nil, mfaErrorToConnect(err)
+	}
+	if err := s.mfa.ConsumeChallenge(ctx, challenge); err != nil {
+		return nil, mfaErrorToConnect(err)
+	}
+
+	// Finish the sign-in the first factor started
+	user, sessionMigrated, err := s.signInUser(ctx, challenge.UserInfo, challenge.SessionID, challenge.UsernameResolution)
+	if err != nil {
+		return nil, signInErrorToConnect(err)
+	}
+
+	token, err := s.IssueToken(ctx, user, challenge.UserInfo.Provider)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&servicev1.CompleteMFAResponse{
+		Token:           token,
+		User:            s.userToProto(user),
+		SessionMigrated: sessionMigrated,
+	}), nil
+}
+
+// mfaChallenge returns a challenge when the identity belongs to a user with
+// two-factor authentication enabled, or an empty string if none is needed
+func (s *Service) mfaChallenge(ctx context.Context, info *auth.UserInfo, sessionID, resolution string) (string, error) {
+	if s.mfa == nil {
+		return "", nil
+	}
+
+	// A passkey with user verification already proves possession and a PIN or biometric
+	if verified, _ := info.Metadata["user_verified"].(bool); info.Provider == "passkey" && verified {
+		return "", nil
+	}
+
+	user, err := s.findExistingUser(ctx, info)
+	if err != nil || user == nil {
+		return "", err
+	}
+	// No second factor for an account that can't sign in anyway
+	if isDisabled(user) {
+		return "", errUserDisabled
+	}
+
+	enabled, err := s.mfa.Enabled(ctx, user.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check two-factor status: %w", err)
+	}
+	if !enabled {
+		return "", nil
+	}
+
+	return s.mfa.IssueChallenge(user.ID, info, sessionID, resolution)
+}
+
+// requireMFAUser returns the registered caller for managing two-factor settings
+func (s *Service) requireMFAUser(ctx context.Context) (*auth.User, error) {
+	if s.mfa == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("two-factor authentication is not configured"))
+	}
+
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	if claims.IsAnonymous {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("anonymous users cannot use two-factor authentication"))
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+	return user, nil
+}
+
+// mfaErrorToConnect maps two-factor errors to connect errors
+func mfaErrorToConnect(err error) error {
+	var authErr *auth.AuthError
+	if errors.As(err, &authErr) {
+		switch authErr.Code {
+		case auth.ErrInvalidCredentials, auth.ErrTokenExpired:
+			return connect.NewError(connect.CodeUnauthenticated, errors.New(authErr.Message))
+		case auth.ErrTokenInvalid:
+			return connect.NewError(connect.CodeFailedPrecondition, errors.New(authErr.Message))
+		case auth.ErrAlreadyExists:
+			return connect.NewError(connect.CodeAlreadyExists, errors.New(authErr.Message))
+		case auth.ErrTooManyAttempts:
+			return tooManyAttemptsToConnect(authErr)
+		}
+	}
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("two-factor authentication failed: %w", err))
+}
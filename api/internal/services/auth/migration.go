@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// signInUser resolves the user for an authenticated identity. When an anonymous
+// session is supplied, the anonymous account is promoted in place if the identity
+// is new, or merged into the existing account otherwise. Signing in cancels a
+// scheduled account deletion. Reports whether the anonymous session was migrated.
+func (s *Service) signInUser(ctx context.Context, info *auth.UserInfo, sessionID, resolution string) (*auth.User, bool, error) {
+	anonUser := s.anonymousSessionUser(ctx, sessionID)
+	if anonUser == nil {
+		user, err := s.findOrCreateUser(ctx, info)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := s.cancelDeletion(ctx, user); err != nil {
+			return nil, false, err
+		}
+		// Without a migrator (or a user bound to the session) only the session moves
+		return user, sessionID != "" && s.migrateSession(ctx, sessionID, user.ID), nil
+	}
+
+	existing, err := s.findExistingUser(ctx, info)
+	if err != nil {
+		return nil, false, err
+	}
+	if isDisabled(existing) {
+		return nil, false, errUserDisabled
+	}
+	var user *auth.User
+	switch {
+	case existing == nil:
+		user, err = s.promoteAnonymousUser(ctx, anonUser, info)
+	case existing.ID == anonUser.ID:
+		user = existing
+	default:
+		user, err = s.mergeAnonymousUser(ctx, anonUser, existing, info, resolution)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.cancelDeletion(ctx, user); err != nil {
+		return nil, false, err
+	}
+
+	return user, s.migrateSession(ctx, sessionID, user.ID), nil
+}
+
+// cancelDeletion keeps an account that was scheduled for deletion, since its owner signed in again
+func (s *Service) cancelDeletion(ctx context.Context, user *auth.User) error {
+	if s.deletion == nil || user.DeletionScheduledAt == nil {
+		return nil
+	}
+	if _, err := s.deletion.Cancel(ctx, user.ID); err != nil {
+		return err
+	}
+	user.DeletionScheduledAt = nil
+	return nil
+}
+
+// errForeignSession refuses to migrate an anonymous session the caller's token is not for
+var errForeignSession = errors.New("session_id must belong to the caller's anonymous token")
+
+// verifyAnonymousSession checks that an anonymous session to migrate is the
+// caller's own: their anonymous token must name the session and its user.
+// Knowing a session ID alone must not let anyone absorb that account.
+func (s *Service) verifyAnonymousSession(ctx context.Context, claims *auth.Claims, sessionID string) error {
+	if sessionID == "" {
+		return nil
+	}
+	if claims == nil || !claims.IsAnonymous || claims.SessionID != sessionID || claims.UserID == "" {
+		return errForeignSession
+	}
+
+	session, err := s.sessionManager.Validate(ctx, sessionID)
+	if err != nil || session.UserID != claims.UserID {
+		return errForeignSession
+	}
+	return nil
+}
+
+// anonymousSessionUser returns the anonymous user bound to a session, or nil if
+// the session is not an anonymous one that can be migrated
+func (s *Service) anonymousSessionUser(ctx context.Context, sessionID string) *auth.User {
+	if sessionID == "" || s.migrator == nil {
+		return nil
+	}
+
+	session, err := s.sessionManager.Validate(ctx, sessionID)
+	if err != nil || !session.IsAnonymous || session.UserID == "" {
+		return nil
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, session.UserID)
+	if err != nil || !auth.IsAnonymousEmail(user.Email) {
+		return nil
+	}
+	return user
+}
+
+// promoteAnonymousUser turns the anonymous account into the registered one,
+// keeping its ID, username and content
+func (s *Service) promoteAnonymousUser(ctx context.Context, anonUser *auth.User, info *auth.UserInfo) (*auth.User, error) {
+	now := time.Now()
+	user := *anonUser
+	user.Email = info.Email
+	user.FirstName = info.FirstName
+	user.LastName = info.LastName
+	user.Picture = info.Picture
+	user.EmailVerified = info.EmailVerified
+	user.EmailVerifiedAt = nil
+	if info.EmailVerified {
+		verifiedAt := info.VerifiedAt
+		user.EmailVerifiedAt = &verifiedAt
+	}
+	user.UpdatedAt = now
+	user.LastLoginAt = &now
+	// Same metadata as a user created by this provider; drops the anonymous markers
+	user.Metadata = map[string]interface{}{
+		"provider":    info.Provider,
+		"provider_id": info.ProviderID,
+	}
+
+	var link *auth.ProviderLink
+	if s.linkStore != nil && info.Provider != "" {
+		link = auth.ProviderLinkFromUserInfo(user.ID, info)
+	}
+
+	if err := s.migrator.PromoteAnonymousUser(ctx, &user, link); err != nil {
+		return nil, fmt.Errorf("failed to promote anonymous user: %w", err)
+	}
+	return &user, nil
+}
+
+// mergeAnonymousUser moves the anonymous account's content onto an existing
+// account. Differing usernames must be resolved explicitly by the caller.
+func (s *Service) mergeAnonymousUser(ctx context.Context, anonUser, user *auth.User, info *auth.UserInfo, resolution string) (*auth.User, error) {
+	username := ""
+	if anonUser.Username != user.Username {
+		switch resolution {
+		case auth.UsernameKeepRegistered:
+		case auth.UsernameKeepAnonymous:
+			username = anonUser.Username
+		default:
+			return nil, &auth.AuthError{
+				Code:    auth.ErrUsernameConflict,
+				Message: "choose whether to keep the anonymous or the registered username",
+				Details: map[string]interface{}{
+					"anonymous_username":  anonUser.Username,
+					"registered_username": user.Username,
+				},
+			}
+		}
+	}
+
+	if err := s.migrator.MergeAnonymousUser(ctx, anonUser.ID, user.ID, username); err != nil {
+		return nil, fmt.Errorf("failed to merge anonymous user: %w", err)
+	}
+	if username != "" {
+		user.Username = username
+	}
+
+	s.refreshUser(ctx, user, info)
+	if err := s.ensureProviderLink(ctx, user.ID, info); err != nil {
+		return nil, fmt.Errorf("failed to link provider: %w", err)
+	}
+	return user, nil
+}
+
+// migrateSession moves an anonymous session to the user, reporting success
+func (s *Service) migrateSession(ctx context.Context, sessionID, userID string) bool {
+	session, err := s.sessionManager.Validate(ctx, sessionID)
+	if err != nil || !session.IsAnonymous {
+		return false
+	}
+	if err := s.sessionManager.MigrateToUser(ctx, sessionID, userID); err != nil {
+		// Log error but continue
+		log.Printf("failed to migrate session %s: %v", sessionID, err)
+		return false
+	}
+	return true
+}
+
+// usernameResolutionFromProto converts the proto enum to an auth username resolution
+func usernameResolutionFromProto(resolution servicev1.UsernameResolution) string {
+	switch resolution {
+	case servicev1.UsernameResolution_USERNAME_RESOLUTION_KEEP_REGISTERED:
+		return auth.UsernameKeepRegistered
+	case servicev1.UsernameResolution_USERNAME_RESOLUTION_KEEP_ANONYMOUS:
+		return auth.UsernameKeepAnonymous
+	default:
+		return ""
+	}
+}
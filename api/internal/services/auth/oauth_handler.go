@@ -3,22 +3,27 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 	
+	"github.com/radjathaher/alunalun/api/internal/middleware"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
 	"github.com/radjathaher/alunalun/api/internal/utils/oauth"
+	"golang.org/x/oauth2"
 )
 
 // OAuthHandler handles HTTP OAuth endpoints
 type OAuthHandler struct {
-	service       *Service
-	stateManager  *auth.StateManager
-	registry      *auth.ProviderRegistry
-	tokenManager  *auth.TokenManager
-	sessionManager *auth.SessionManager
+	service      *Service
+	stateManager *auth.StateManager
+	registry     *auth.ProviderRegistry
+	tokenManager *auth.TokenManager
+	loginCodes   auth.LoginCodeStore
+	interceptor  *middleware.AuthInterceptor // nil refuses session migration
 }
 
 // NewOAuthHandler creates a new OAuth HTTP handler
@@ -27,28 +32,45 @@ func NewOAuthHandler(
 	stateManager *auth.StateManager,
 	registry *auth.ProviderRegistry,
 	tokenManager *auth.TokenManager,
-	sessionManager *auth.SessionManager,
 ) *OAuthHandler {
 	return &OAuthHandler{
-		service:       service,
-		stateManager:  stateManager,
-		registry:      registry,
-		tokenManager:  tokenManager,
-		sessionManager: sessionManager,
+		service:      service,
+		stateManager: stateManager,
+		registry:     registry,
+		tokenManager: tokenManager,
+		loginCodes:   auth.NewInMemoryLoginCodeStore(),
 	}
 }
 
+// SetLoginCodeStore replaces the default in-memory login code store
+func (h *OAuthHandler) SetLoginCodeStore(store auth.LoginCodeStore) {
+	h.loginCodes = store
+}
+
+// SetAuthInterceptor identifies callers the same way as the RPCs, so a POST
+// can bind the caller's anonymous session into the sign-in
+func (h *OAuthHandler) SetAuthInterceptor(interceptor *middleware.AuthInterceptor) {
+	h.interceptor = interceptor
+}
+
 // RegisterRoutes registers OAuth HTTP routes
 func (h *OAuthHandler) RegisterRoutes(mux *http.ServeMux) {
 	// OAuth initiation endpoints
+	initiate := http.Handler(http.HandlerFunc(h.handleOAuthInitiate))
+	if h.interceptor != nil {
+		initiate = h.interceptor.WrapHTTP(initiate)
+	}
 	mux.HandleFunc("/auth/oauth/", h.handleOAuthRoot)
-	mux.HandleFunc("/auth/oauth/google", h.handleOAuthInitiate)
-	mux.HandleFunc("/auth/oauth/apple", h.handleOAuthInitiate)
+	mux.Handle("/auth/oauth/google", initiate)
+	mux.Handle("/auth/oauth/apple", initiate)
 	
 	// OAuth callback endpoints
 	mux.HandleFunc("/auth/oauth/google/callback", h.handleOAuthCallback)
 	mux.HandleFunc("/auth/oauth/apple/callback", h.handleOAuthCallback)
 	
+	// One-time login code exchange for native clients
+	mux.HandleFunc("/auth/oauth/exchange", h.handleLoginCodeExchange)
+	
 	// Generic endpoints
 	mux.HandleFunc("/auth/refresh", h.handleRefreshToken)
 	mux.HandleFunc("/auth/public-key", h.handlePublicKey)
@@ -70,13 +92,29 @@ func (h *OAuthHandler) handleOAuthRoot(w http.ResponseWriter, r *http.Request) {
 		"endpoints": map[string]string{
 			"initiate": "/auth/oauth/{provider}",
 			"callback": "/auth/oauth/{provider}/callback",
+			"exchange": "/auth/oauth/exchange",
 			"refresh":  "/auth/refresh",
 		},
 	})
 }
 
-// handleOAuthInitiate starts the OAuth flow
+// handleOAuthInitiate starts the OAuth flow. A GET redirects the browser to
+// the provider. Migrating an anonymous account needs the caller's anonymous
+// token, which a browser navigation can't send, so a POST carrying the token
+// returns the provider URL for the client to open instead.
 func (h *OAuthHandler) handleOAuthInitiate(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "OPTIONS":
+		// Handle CORS preflight
+		h.setCORSHeaders(w)
+		w.WriteHeader(http.StatusOK)
+		return
+	case "GET", "POST":
+	default:
+		h.respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	
 	// Extract provider from URL path
 	provider := h.extractProvider(r.URL.Path)
 	if provider == "" {
@@ -84,15 +122,70 @@ func (h *OAuthHandler) handleOAuthInitiate(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	
-	// Get redirect URI from query params
-	redirectURI := r.URL.Query().Get("redirect_uri")
-	if redirectURI == "" {
-		h.respondError(w, http.StatusBadRequest, "redirect_uri is required")
+	params := r.URL.Query()
+	if r.Method == "POST" {
+		var req struct {
+			RedirectURI         string `json:"redirect_uri"`
+			SessionID           string `json:"session_id"`
+			UsernameResolution  string `json:"username_resolution"`
+			CodeChallenge       string `json:"code_challenge"`
+			CodeChallengeMethod string `json:"code_challenge_method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.respondError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		params = url.Values{}
+		params.Set("redirect_uri", req.RedirectURI)
+		params.Set("session_id", req.SessionID)
+		params.Set("username_resolution", req.UsernameResolution)
+		params.Set("code_challenge", req.CodeChallenge)
+		params.Set("code_challenge_method", req.CodeChallengeMethod)
+	}
+	
+	// Get redirect URI and check it against the allowlist
+	redirectURI := params.Get("redirect_uri")
+	redirect, err := h.service.config.Redirect.ValidateRedirectURI(redirectURI)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	
-	// Optional session ID for migration
-	sessionID := r.URL.Query().Get("session_id")
+	// Any app can register a custom scheme and intercept the one-time code,
+	// so clients receiving one must prove they started the sign-in (PKCE, S256)
+	codeChallenge := params.Get("code_challenge")
+	if redirect.TokenDelivery == auth.TokenDeliveryCode {
+		if method := params.Get("code_challenge_method"); method != "" && method != "S256" {
+			h.respondError(w, http.StatusBadRequest, "code_challenge_method must be S256")
+			return
+		}
+		if !auth.ValidCodeChallenge(codeChallenge) {
+			h.respondError(w, http.StatusBadRequest, "code_challenge is required for this redirect_uri")
+			return
+		}
+	}
+	
+	// Optional session ID for migration, and the username to keep if the
+	// anonymous account is merged into an existing one. The session is only
+	// bound into the state for the anonymous caller it belongs to.
+	sessionID := params.Get("session_id")
+	if sessionID != "" {
+		if r.Method != "POST" {
+			h.respondError(w, http.StatusBadRequest, "session_id requires a POST with the anonymous token")
+			return
+		}
+		if err := h.service.verifyAnonymousSession(r.Context(), middleware.GetClaims(r.Context()), sessionID); err != nil {
+			h.respondError(w, http.StatusForbidden, err.Error())
+			return
+		}
+	}
+	usernameResolution := params.Get("username_resolution")
+	switch usernameResolution {
+	case "", auth.UsernameKeepRegistered, auth.UsernameKeepAnonymous:
+	default:
+		h.respondError(w, http.StatusBadRequest, "invalid username_resolution")
+		return
+	}
 	
 	// Get the OAuth provider
 	p, err := h.registry.Get(provider)
@@ -108,15 +201,27 @@ func (h *OAuthHandler) handleOAuthInitiate(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	
-	// Generate encrypted state
-	state, err := h.stateManager.GenerateState(provider, redirectURI, sessionID)
+	// Generate encrypted state carrying the nonce and PKCE verifier
+	stateToken, state, err := h.stateManager.GenerateState(provider, redirectURI, sessionID, usernameResolution, codeChallenge)
 	if err != nil {
 		h.respondError(w, http.StatusInternalServerError, "failed to generate state")
 		return
 	}
 	
-	// Get OAuth authorization URL
-	authURL := oauthProvider.GetAuthURL(state)
+	// Get OAuth authorization URL with OIDC nonce and PKCE challenge
+	opts := []oauth2.AuthCodeOption{oauth.NonceOption(state.Nonce)}
+	if oauthProvider.SupportsPKCE() {
+		opts = append(opts, oauth2.S256ChallengeOption(state.CodeVerifier))
+	}
+	authURL := oauthProvider.GetAuthURL(stateToken, opts...)
+	
+	// The state is single-use, so the URL starts one sign-in only
+	if r.Method == "POST" {
+		h.respondJSON(w, http.StatusOK, map[string]string{
+			"auth_url": authURL,
+		})
+		return
+	}
 	
 	// Redirect to OAuth provider
 	http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
@@ -124,18 +229,25 @@ func (h *OAuthHandler) handleOAuthInitiate(w http.ResponseWriter, r *http.Reques
 
 // handleOAuthCallback handles the OAuth provider callback
 func (h *OAuthHandler) handleOAuthCallback(w http.ResponseWriter, r *http.Request) {
-	// Get state and code from query params
-	stateParam := r.URL.Query().Get("state")
+	// Providers such as Apple use response_mode=form_post, so read
+	// parameters from either the query string or the POST body
+	if err := r.ParseForm(); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid callback request")
+		return
+	}
+	
+	// Get state and code from request params
+	stateParam := r.FormValue("state")
 	if stateParam == "" {
 		h.respondError(w, http.StatusBadRequest, "state parameter missing")
 		return
 	}
 	
-	code := r.URL.Query().Get("code")
+	code := r.FormValue("code")
 	if code == "" {
 		// Check for error from OAuth provider
-		if errParam := r.URL.Query().Get("error"); errParam != "" {
-			errDesc := r.URL.Query().Get("error_description")
+		if errParam := r.FormValue("error"); errParam != "" {
+			errDesc := r.FormValue("error_description")
 			h.respondError(w, http.StatusUnauthorized, fmt.Sprintf("OAuth error: %s - %s", errParam, errDesc))
 			return
 		}
@@ -143,10 +255,18 @@ func (h *OAuthHandler) handleOAuthCallback(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	
-	// Validate and decrypt state
-	state, err := h.stateManager.ValidateState(stateParam)
+	// Validate, decrypt and consume state (single-use)
+	ctx := r.Context()
+	state, err := h.stateManager.ConsumeState(ctx, stateParam)
 	if err != nil {
-		h.respondError(w, http.StatusBadRequest, "invalid or expired state")
+		h.respondError(w, http.StatusBadRequest, "invalid, expired or already used state")
+		return
+	}
+	
+	// Re-check the redirect URI in case the allowlist changed since initiation
+	redirect, err := h.service.config.Redirect.ValidateRedirectURI(state.RedirectURI)
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 	
@@ -163,79 +283,233 @@ func (h *OAuthHandler) handleOAuthCallback(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	
-	// Exchange code for tokens
-	ctx := context.Background()
-	token, err := oauthProvider.ExchangeCode(ctx, code)
+	// Exchange code for tokens, proving possession of the PKCE verifier
+	var exchangeOpts []oauth2.AuthCodeOption
+	if oauthProvider.SupportsPKCE() && state.CodeVerifier != "" {
+		exchangeOpts = append(exchangeOpts, oauth2.VerifierOption(state.CodeVerifier))
+	}
+	token, err := oauthProvider.ExchangeCode(ctx, code, exchangeOpts...)
 	if err != nil {
 		h.respondError(w, http.StatusInternalServerError, fmt.Sprintf("code exchange failed: %v", err))
 		return
 	}
 	
-	// Get user info using the access token
-	userInfo, err := oauthProvider.Authenticate(ctx, token.AccessToken)
+	// Get user info from the exchanged tokens
+	userInfo, err := oauthProvider.GetUserInfo(ctx, token)
 	if err != nil {
 		h.respondError(w, http.StatusInternalServerError, fmt.Sprintf("failed to get user info: %v", err))
 		return
 	}
 	
-	// Find or create user
-	user, err := h.service.findOrCreateUser(ctx, userInfo)
+	// Reject ID tokens not minted for this authorization request
+	if err := oauth.VerifyNonce(token, userInfo, state.Nonce); err != nil {
+		h.respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	
+	// Apple only posts the user's name on the first authorization
+	if state.Provider == string(oauth.ProviderTypeApple) {
+		oauth.MergeAppleUser(userInfo, r.PostFormValue("user"))
+	}
+	
+	// Users with two-factor authentication finish sign-in with CompleteMFA
+	challenge, err := h.service.mfaChallenge(ctx, userInfo, state.SessionID, state.UsernameResolution)
+	if errors.Is(err, errUserDisabled) {
+		h.respondError(w, http.StatusForbidden, errUserDisabled.Message)
+		return
+	}
+	if errors.Is(err, errLinkFromSettings) {
+		h.respondError(w, http.StatusConflict, errLinkFromSettings.Message)
+		return
+	}
 	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "failed to process user")
+		h.respondError(w, http.StatusInternalServerError, "failed to check two-factor authentication")
+		return
+	}
+	if challenge != "" {
+		h.respondMFAChallenge(w, r, state, redirect.TokenDelivery, challenge)
 		return
 	}
 	
-	// Handle session migration if session ID was provided
-	sessionMigrated := false
-	if state.SessionID != "" {
-		session, err := h.sessionManager.Validate(ctx, state.SessionID)
-		if err == nil && session.IsAnonymous {
-			if err := h.sessionManager.MigrateToUser(ctx, state.SessionID, user.ID); err == nil {
-				sessionMigrated = true
-			}
+	// Find or create user, migrating the anonymous account if a session was provided
+	user, sessionMigrated, err := h.service.signInUser(ctx, userInfo, state.SessionID, state.UsernameResolution)
+	if err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) && authErr.Code == auth.ErrUsernameConflict {
+			h.respondError(w, http.StatusConflict, authErr.Message)
+			return
 		}
+		if errors.Is(err, errUserDisabled) {
+			h.respondError(w, http.StatusForbidden, errUserDisabled.Message)
+			return
+		}
+		if errors.Is(err, errLinkFromSettings) {
+			h.respondError(w, http.StatusConflict, errLinkFromSettings.Message)
+			return
+		}
+		h.respondError(w, http.StatusInternalServerError, "failed to process user")
+		return
 	}
 	
-	// Create authenticated session
-	session, err := h.sessionManager.CreateAuthenticated(ctx, user.ID, time.Hour)
+	// Create a session and token, like any other sign-in
+	jwtToken, err := h.service.IssueToken(ctx, user, state.Provider)
 	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "failed to create session")
+		h.respondError(w, http.StatusInternalServerError, "failed to sign in")
 		return
 	}
 	
-	// Generate JWT token
-	claims := &auth.Claims{
-		UserID:      user.ID,
-		SessionID:   session.ID,
-		Username:    user.Username,
-		Email:       user.Email,
-		Provider:    state.Provider,
-		IsAnonymous: false,
+	// Deliver the token the way the redirect URI's allowlist entry says
+	redirectURL, err := h.buildRedirectURL(r.Context(), state, redirect.TokenDelivery, jwtToken, user, sessionMigrated)
+	if err != nil {
+		h.respondError(w, http.StatusInternalServerError, "failed to build redirect")
+		return
 	}
-	
-	jwtToken, err := h.tokenManager.GenerateToken(claims, time.Hour)
+	// 303 so a form_post callback is followed with GET
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// respondMFAChallenge hands the client a challenge to complete with CompleteMFA.
+// Like tokens, it stays out of the query string: web clients get it in the
+// fragment and native clients through the one-time code exchange.
+func (h *OAuthHandler) respondMFAChallenge(w http.ResponseWriter, r *http.Request, state *auth.OAuthState, delivery, challenge string) {
+	u, err := url.Parse(state.RedirectURI)
 	if err != nil {
-		h.respondError(w, http.StatusInternalServerError, "failed to generate token")
+		h.respondError(w, http.StatusInternalServerError, "failed to build redirect")
 		return
 	}
 	
-	// Detect client type and respond accordingly
-	if h.isMobileClient(r) {
-		// Mobile: Return JSON response
-		h.respondJSON(w, http.StatusOK, map[string]interface{}{
-			"token":            jwtToken,
-			"user":             h.userToJSON(user),
-			"session_migrated": sessionMigrated,
+	switch delivery {
+	case auth.TokenDeliveryCode:
+		code, err := h.saveLoginCode(r.Context(), &auth.LoginCode{
+			CodeChallenge: state.CodeChallenge,
+			MFAChallenge:  challenge,
 		})
-	} else {
-		// Web: Redirect with token
-		// Using fragment (#) to keep token client-side only
-		redirectURL := fmt.Sprintf("%s#token=%s", state.RedirectURI, jwtToken)
+		if err != nil {
+			h.respondError(w, http.StatusInternalServerError, "failed to build redirect")
+			return
+		}
+		q := u.Query()
+		q.Set("code", code)
+		u.RawQuery = q.Encode()
+		
+	default:
+		fragment := url.Values{}
+		fragment.Set("mfa_challenge", challenge)
+		u.Fragment = fragment.Encode()
+	}
+	http.Redirect(w, r, u.String(), http.StatusSeeOther)
+}
+
+// saveLoginCode stores a login result under a new one-time code and returns the code
+func (h *OAuthHandler) saveLoginCode(ctx context.Context, loginCode *auth.LoginCode) (string, error) {
+	code, err := auth.GenerateLoginCode()
+	if err != nil {
+		return "", err
+	}
+	ttl := h.service.config.Redirect.LoginCodeTTL
+	if ttl == 0 {
+		ttl = time.Minute
+	}
+	loginCode.Code = code
+	loginCode.ExpiresAt = time.Now().Add(ttl)
+	if err := h.loginCodes.Save(ctx, loginCode); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// buildRedirectURL appends the login result to the client redirect URI without
+// putting the token in the query string
+func (h *OAuthHandler) buildRedirectURL(ctx context.Context, state *auth.OAuthState, delivery, jwtToken string, user *auth.User, sessionMigrated bool) (string, error) {
+	u, err := url.Parse(state.RedirectURI)
+	if err != nil {
+		return "", err
+	}
+	
+	switch delivery {
+	case auth.TokenDeliveryCode:
+		// Native: hand over a one-time code, exchanged via /auth/oauth/exchange
+		code, err := h.saveLoginCode(ctx, &auth.LoginCode{
+			CodeChallenge:   state.CodeChallenge,
+			Token:           jwtToken,
+			User:            user,
+			SessionMigrated: sessionMigrated,
+		})
+		if err != nil {
+			return "", err
+		}
+		q := u.Query()
+		q.Set("code", code)
+		u.RawQuery = q.Encode()
+		
+	default:
+		// Web: fragment (#) keeps the token client-side only
+		fragment := url.Values{}
+		fragment.Set("token", jwtToken)
 		if sessionMigrated {
-			redirectURL += "&session_migrated=true"
+			fragment.Set("session_migrated", "true")
 		}
-		http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+		u.Fragment = fragment.Encode()
+	}
+	
+	return u.String(), nil
+}
+
+// handleLoginCodeExchange exchanges a one-time login code for a JWT. The
+// code_verifier must match the code_challenge sent when the sign-in started.
+func (h *OAuthHandler) handleLoginCodeExchange(w http.ResponseWriter, r *http.Request) {
+	// Handle CORS preflight
+	if r.Method == "OPTIONS" {
+		h.setCORSHeaders(w)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	
+	if r.Method != "POST" {
+		h.respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
 	}
+	
+	var req struct {
+		Code         string `json:"code"`
+		CodeVerifier string `json:"code_verifier"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	
+	if req.Code == "" || req.CodeVerifier == "" {
+		h.respondError(w, http.StatusBadRequest, "code and code_verifier are required")
+		return
+	}
+	
+	loginCode, err := h.loginCodes.Consume(r.Context(), req.Code)
+	if err != nil {
+		h.respondError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	
+	// The code is consumed either way, so an interceptor gets a single guess
+	if !loginCode.VerifyCodeVerifier(req.CodeVerifier) {
+		h.respondError(w, http.StatusUnauthorized, "code_verifier does not match")
+		return
+	}
+	
+	if loginCode.MFAChallenge != "" {
+		h.respondJSON(w, http.StatusOK, map[string]interface{}{
+			"mfa_required":  true,
+			"mfa_challenge": loginCode.MFAChallenge,
+		})
+		return
+	}
+	
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"token":            loginCode.Token,
+		"user":             h.userToJSON(loginCode.User),
+		"session_migrated": loginCode.SessionMigrated,
+	})
 }
 
 // handleRefreshToken handles JWT refresh requests
@@ -267,7 +541,11 @@ func (h *OAuthHandler) handleRefreshToken(w http.ResponseWriter, r *http.Request
 	}
 	
 	// Refresh the token
-	newToken, err := h.tokenManager.RefreshToken(req.ExpiredToken, time.Hour)
+	newToken, err := h.service.refreshToken(r.Context(), req.ExpiredToken, h.service.config.JWT.AccessTokenTTL)
+	if errors.Is(err, errUserDisabled) {
+		h.respondError(w, http.StatusForbidden, errUserDisabled.Message)
+		return
+	}
 	if err != nil {
 		h.respondError(w, http.StatusUnauthorized, fmt.Sprintf("refresh failed: %v", err))
 		return
@@ -304,32 +582,6 @@ func (h *OAuthHandler) extractProvider(path string) string {
 	return ""
 }
 
-// isMobileClient detects if the request is from a mobile client
-func (h *OAuthHandler) isMobileClient(r *http.Request) bool {
-	// Check custom header first
-	if r.Header.Get("X-Client-Type") == "mobile" {
-		return true
-	}
-	
-	// Check User-Agent for mobile patterns
-	userAgent := strings.ToLower(r.Header.Get("User-Agent"))
-	mobilePatterns := []string{
-		"ios", "iphone", "ipad",
-		"android",
-		"mobile",
-		"react-native",
-		"flutter",
-	}
-	
-	for _, pattern := range mobilePatterns {
-		if strings.Contains(userAgent, pattern) {
-			return true
-		}
-	}
-	
-	return false
-}
-
 // setCORSHeaders sets CORS headers for cross-origin requests
 func (h *OAuthHandler) setCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// BeginPasskeyRegistration returns WebAuthn creation options for the caller
+func (s *Service) BeginPasskeyRegistration(
+	ctx context.Context,
+	req *connect.Request[servicev1.BeginPasskeyRegistrationRequest],
+) (*connect.Response[servicev1.BeginPasskeyRegistrationResponse], error) {
+	user, err := s.requirePasskeyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	options, challengeToken, err := s.passkeys.BeginRegistration(ctx, user)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to start passkey registration: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.BeginPasskeyRegistrationResponse{
+		OptionsJson:    options,
+		ChallengeToken: challengeToken,
+	}), nil
+}
+
+// FinishPasskeyRegistration verifies the authenticator's response and stores the passkey
+func (s *Service) FinishPasskeyRegistration(
+	ctx context.Context,
+	req *connect.Request[servicev1.FinishPasskeyRegistrationRequest],
+) (*connect.Response[servicev1.FinishPasskeyRegistrationResponse], error) {
+	if req.Msg.ChallengeToken == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("challenge token is required"))
+	}
+	if req.Msg.CredentialJson == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("credential is required"))
+	}
+	name := strings.TrimSpace(req.Msg.Name)
+	if len(name) > 100 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name must be at most 100 characters"))
+	}
+	user, err := s.requirePasskeyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	passkey, err := s.passkeys.FinishRegistration(ctx, user, req.Msg.ChallengeToken, req.Msg.CredentialJson, name)
+	if err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			switch authErr.Code {
+			case auth.ErrInvalidCredentials, auth.ErrTokenInvalid, auth.ErrTokenExpired:
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(authErr.Message))
+			case auth.ErrAlreadyExists:
+				return nil, connect.NewError(connect.CodeAlreadyExists, errors.New(authErr.Message))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to register passkey: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.FinishPasskeyRegistrationResponse{
+		Passkey: passkeyToProto(passkey),
+	}), nil
+}
+
+// BeginPasskeyLogin returns WebAuthn request options for a passkey sign-in
+func (s *Service) BeginPasskeyLogin(
+	ctx context.Context,
+	req *connect.Request[servicev1.BeginPasskeyLoginRequest],
+) (*connect.Response[servicev1.BeginPasskeyLoginResponse], error) {
+	if s.passkeys == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("passkeys are not configured"))
+	}
+
+	options, challengeToken, err := s.passkeys.BeginLogin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to start passkey sign-in: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.BeginPasskeyLoginResponse{
+		OptionsJson:    options,
+		ChallengeToken: challengeToken,
+	}), nil
+}
+
+// ListPasskeys lists the caller's passkeys
+func (s *Service) ListPasskeys(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListPasskeysRequest],
+) (*connect.Response[servicev1.ListPasskeysResponse], error) {
+	user, err := s.requirePasskeyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys, err := s.passkeys.ListCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list passkeys: %w", err))
+	}
+
+	pbPasskeys := make([]*servicev1.Passkey, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		pbPasskeys = append(pbPasskeys, passkeyToProto(passkey))
+	}
+
+	return connect.NewResponse(&servicev1.ListPasskeysResponse{
+		Passkeys: pbPasskeys,
+	}), nil
+}
+
+// DeletePasskey removes one of the caller's passkeys
+func (s *Service) DeletePasskey(
+	ctx context.Context,
+	req *connect.Request[servicev1.DeletePasskeyRequest],
+) (*connect.Response[servicev1.DeletePasskeyResponse], error) {
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+	user, err := s.requirePasskeyUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.passkeys.DeleteCredential(ctx, user.ID, req.Msg.Id); err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) && authErr.Code == auth.ErrNotFound {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("passkey not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete passkey: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.DeletePasskeyResponse{}), nil
+}
+
+// requirePasskeyUser returns the registered caller for managing passkeys
+func (s *Service) requirePasskeyUser(ctx context.Context) (*auth.User, error) {
+	if s.passkeys == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("passkeys are not configured"))
+	}
+
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	if claims.IsAnonymous {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("anonymous users cannot register passkeys"))
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+	return user, nil
+}
+
+// passkeyToProto converts a PasskeyCredential to proto Passkey
+func passkeyToProto(passkey *auth.PasskeyCredential) *servicev1.Passkey {
+	pb := &servicev1.Passkey{
+		Id:         passkey.ID,
+		Name:       passkey.Name,
+		Transports: passkey.Transports,
+		CreatedAt:  passkey.CreatedAt.Unix(),
+	}
+	if passkey.LastUsedAt != nil {
+		lastUsedAt := passkey.LastUsedAt.Unix()
+		pb.LastUsedAt = &lastUsedAt
+	}
+	return pb
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// RequestPasswordReset sends a password reset link. The response is the same
+// whether or not the email belongs to an account.
+func (s *Service) RequestPasswordReset(
+	ctx context.Context,
+	req *connect.Request[servicev1.RequestPasswordResetRequest],
+) (*connect.Response[servicev1.RequestPasswordResetResponse], error) {
+	if s.resetter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("password reset is not configured"))
+	}
+	if req.Msg.Email == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+	}
+
+	if err := s.resetter.Request(ctx, req.Msg.Email); err != nil {
+		// Don't reveal failures for a specific address
+		log.Printf("failed to request password reset: %v", err)
+	}
+
+	return connect.NewResponse(&servicev1.RequestPasswordResetResponse{}), nil
+}
+
+// ResetPassword sets a new password using a token from a reset link and signs
+// the user out of all sessions
+func (s *Service) ResetPassword(
+	ctx context.Context,
+	req *connect.Request[servicev1.ResetPasswordRequest],
+) (*connect.Response[servicev1.ResetPasswordResponse], error) {
+	if s.resetter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("password reset is not configured"))
+	}
+	if req.Msg.Token == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("token is required"))
+	}
+	if req.Msg.NewPassword == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("new password is required"))
+	}
+
+	if _, err := s.resetter.Reset(ctx, req.Msg.Token, req.Msg.NewPassword); err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			switch authErr.Code {
+			case auth.ErrTokenInvalid, auth.ErrTokenExpired, auth.ErrWeakPassword:
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(authErr.Message))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to reset password: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.ResetPasswordResponse{}), nil
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// GrantRole gives a user an elevated role. The interceptor only lets admins
+// call it. Users must have two-factor authentication enabled first.
+func (s *Service) GrantRole(
+	ctx context.Context,
+	req *connect.Request[servicev1.GrantRoleRequest],
+) (*connect.Response[servicev1.GrantRoleResponse], error) {
+	claims, user, err := s.roleTarget(ctx, req.Msg.UserId, req.Msg.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.mfa != nil {
+		enabled, err := s.mfa.Enabled(ctx, user.ID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check two-factor status: %w", err))
+		}
+		if !enabled {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("user must enable two-factor authentication before receiving elevated roles"))
+		}
+	}
+
+	if err := s.roleStore.GrantRole(ctx, user.ID, req.Msg.Role, claims.UserID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to grant role: %w", err))
+	}
+
+	roles, err := s.roleStore.ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list roles: %w", err))
+	}
+	return connect.NewResponse(&servicev1.GrantRoleResponse{
+		Roles: roles,
+	}), nil
+}
+
+// RevokeRole removes an elevated role from a user. The interceptor only lets admins call it.
+func (s *Service) RevokeRole(
+	ctx context.Context,
+	req *connect.Request[servicev1.RevokeRoleRequest],
+) (*connect.Response[servicev1.RevokeRoleResponse], error) {
+	claims, user, err := s.roleTarget(ctx, req.Msg.UserId, req.Msg.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep at least one way back in; another admin can demote this one
+	if user.ID == claims.UserID && req.Msg.Role == auth.RoleAdmin {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("admins cannot revoke their own admin role"))
+	}
+
+	if err := s.roleStore.RevokeRole(ctx, user.ID, req.Msg.Role); err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) && authErr.Code == auth.ErrUserNotFound {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New(authErr.Message))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke role: %w", err))
+	}
+
+	roles, err := s.roleStore.ListUserRoles(ctx, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list roles: %w", err))
+	}
+	return connect.NewResponse(&servicev1.RevokeRoleResponse{
+		Roles: roles,
+	}), nil
+}
+
+// roleTarget validates a role change and returns the caller's claims and the target user
+func (s *Service) roleTarget(ctx context.Context, userID, role string) (*auth.Claims, *auth.User, error) {
+	if s.roleStore == nil {
+		return nil, nil, connect.NewError(connect.CodeUnimplemented, errors.New("roles are not supported by this user store"))
+	}
+	if userID == "" {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user_id is required"))
+	}
+	if !auth.IsElevatedRole(role) {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("role must be %q or %q", auth.RoleModerator, auth.RoleAdmin))
+	}
+
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || !claims.HasRole(auth.RoleAdmin) {
+		return nil, nil, connect.NewError(connect.CodePermissionDenied, errors.New("admin role required"))
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+	if auth.IsAnonymousEmail(user.Email) {
+		return nil, nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("anonymous users cannot receive elevated roles"))
+	}
+	return claims, user, nil
+}
+
+// userRoles returns a user's elevated roles for their token. Lookup failures
+// are logged and yield no elevated roles.
+func (s *Service) userRoles(ctx context.Context, userID string) []string {
+	if s.roleStore == nil {
+		return nil
+	}
+
+	roles, err := s.roleStore.ListUserRoles(ctx, userID)
+	if err != nil {
+		log.Printf("failed to load roles for user %s: %v", userID, err)
+		return nil
+	}
+	return roles
+}
@@ -1,10 +1,10 @@
 package auth
 
 import (
-	"context"
 	"net/http"
 	
 	"connectrpc.com/connect"
+	"github.com/radjathaher/alunalun/api/internal/middleware"
 	"github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service/auth_servicev1connect"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
 	"github.com/rs/cors"
@@ -14,16 +14,19 @@ import (
 type Router struct {
 	service       *Service
 	oauthHandler  *OAuthHandler
+	interceptor   *middleware.AuthInterceptor
 	corsHandler   *cors.Cors
 }
 
-// NewRouter creates a new combined router
+// NewRouter creates a new combined router. The interceptor is the one
+// configured for the rest of the server, so callers are identified the same
+// way on every route.
 func NewRouter(
 	service *Service,
 	stateManager *auth.StateManager,
 	registry *auth.ProviderRegistry,
 	tokenManager *auth.TokenManager,
-	sessionManager *auth.SessionManager,
+	interceptor *middleware.AuthInterceptor,
 ) *Router {
 	// Create OAuth handler
 	oauthHandler := NewOAuthHandler(
@@ -31,8 +34,8 @@ func NewRouter(
 		stateManager,
 		registry,
 		tokenManager,
-		sessionManager,
 	)
+	oauthHandler.SetAuthInterceptor(interceptor)
 	
 	// Configure CORS
 	corsHandler := cors.New(cors.Options{
@@ -47,6 +50,7 @@ func NewRouter(
 	return &Router{
 		service:      service,
 		oauthHandler: oauthHandler,
+		interceptor:  interceptor,
 		corsHandler:  corsHandler,
 	}
 }
@@ -59,7 +63,10 @@ func (r *Router) Handler() http.Handler {
 	r.oauthHandler.RegisterRoutes(mux)
 	
 	// Register ConnectRPC endpoints
-	path, handler := auth_servicev1connect.NewAuthServiceHandler(r.service)
+	path, handler := auth_servicev1connect.NewAuthServiceHandler(
+		r.service,
+		connect.WithInterceptors(r.interceptor),
+	)
 	mux.Handle(path, handler)
 	
 	// Apply CORS to all routes
@@ -70,47 +77,3 @@ func (r *Router) Handler() http.Handler {
 func (r *Router) ListenAndServe(addr string) error {
 	return http.ListenAndServe(addr, r.Handler())
 }
-
-// AuthInterceptor provides authentication middleware for ConnectRPC
-func AuthInterceptor(tokenManager *auth.TokenManager) connect.UnaryInterceptorFunc {
-	return func(next connect.UnaryFunc) connect.UnaryFunc {
-		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-			// Skip auth for certain endpoints
-			procedure := req.Spec().Procedure
-			skipAuth := []string{
-				"CheckUsername",
-				"InitAnonymous",
-				"Authenticate",
-				"RefreshToken",
-			}
-			
-			for _, skip := range skipAuth {
-				if procedure == skip {
-					return next(ctx, req)
-				}
-			}
-			
-			// Extract token from Authorization header
-			authHeader := req.Header().Get("Authorization")
-			if authHeader == "" {
-				return nil, connect.NewError(connect.CodeUnauthenticated, nil)
-			}
-			
-			// Validate token
-			token := authHeader
-			if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-				token = authHeader[7:]
-			}
-			
-			claims, err := tokenManager.ValidateToken(token)
-			if err != nil {
-				return nil, connect.NewError(connect.CodeUnauthenticated, err)
-			}
-			
-			// Add claims to context
-			ctx = auth.ContextWithClaims(ctx, claims)
-			
-			return next(ctx, req)
-		}
-	}
-}
\ No newline at end of file
@@ -2,17 +2,23 @@ package auth
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"log"
+	"regexp"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 	
 	"connectrpc.com/connect"
 	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
 	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
 	"github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service/auth_servicev1connect"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
-	"github.com/radjathaher/alunalun/api/internal/utils/oauth"
 )
 
 // Service implements the authentication service orchestrator
@@ -22,6 +28,15 @@ type Service struct {
 	tokenManager   *auth.TokenManager
 	sessionManager *auth.SessionManager
 	userStore      auth.UserStore
+	linkStore      auth.ProviderLinkStore // nil if the user store does not track provider links
+	roleStore      auth.RoleStore         // nil if the user store does not track roles
+	migrator       auth.AccountMigrator   // nil disables anonymous content migration
+	anonymous      *auth.AnonymousManager // nil disables anonymous expiry and limits
+	verifier       *auth.EmailVerifier    // nil disables email verification
+	resetter       *auth.PasswordResetter // nil disables password reset
+	mfa            *auth.MFAManager       // nil disables two-factor authentication
+	passkeys       *auth.PasskeyProvider  // nil disables passkey registration
+	deletion       *auth.AccountDeletionManager // nil skips cancelling account deletions on sign-in
 	config         *auth.Config
 }
 
@@ -49,15 +64,59 @@ func NewService(
 		config = auth.DefaultConfig()
 	}
 	
+	// Use the user store for provider links when it supports them
+	linkStore, _ := userStore.(auth.ProviderLinkStore)
+	
+	// Carry elevated roles in tokens when the user store supports them
+	roleStore, _ := userStore.(auth.RoleStore)
+	
 	return &Service{
 		registry:       registry,
 		tokenManager:   tokenManager,
 		sessionManager: sessionManager,
 		userStore:      userStore,
+		linkStore:      linkStore,
+		roleStore:      roleStore,
 		config:         config,
 	}, nil
 }
 
+// SetAccountMigrator enables moving anonymous accounts onto registered ones on sign-in
+func (s *Service) SetAccountMigrator(migrator auth.AccountMigrator) {
+	s.migrator = migrator
+}
+
+// SetAnonymousManager enables expiry and per-client limits for anonymous accounts
+func (s *Service) SetAnonymousManager(manager *auth.AnonymousManager) {
+	s.anonymous = manager
+}
+
+// SetEmailVerifier enables sending and checking email verification links
+func (s *Service) SetEmailVerifier(verifier *auth.EmailVerifier) {
+	s.verifier = verifier
+}
+
+// SetPasswordResetter enables password reset by email
+func (s *Service) SetPasswordResetter(resetter *auth.PasswordResetter) {
+	s.resetter = resetter
+}
+
+// SetMFAManager enables TOTP two-factor authentication
+func (s *Service) SetMFAManager(manager *auth.MFAManager) {
+	s.mfa = manager
+}
+
+// SetPasskeyProvider enables passkey registration and sign-in ceremonies.
+// The provider must also be registered for Authenticate to accept passkeys.
+func (s *Service) SetPasskeyProvider(provider *auth.PasskeyProvider) {
+	s.passkeys = provider
+}
+
+// SetAccountDeletionManager enables cancelling scheduled account deletions when their owner signs in
+func (s *Service) SetAccountDeletionManager(manager *auth.AccountDeletionManager) {
+	s.deletion = manager
+}
+
 // CheckUsername checks if a username is available
 func (s *Service) CheckUsername(
 	ctx context.Context,
@@ -101,45 +160,56 @@ func (s *Service) InitAnonymous(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("anonymous provider not available: %w", err))
 	}
-	
-	// Create anonymous request
-	anonReq := auth.AnonymousRequest{
-		Username: req.Msg.Username,
+	anonProvider, ok := provider.(*auth.AnonymousProvider)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("anonymous provider not available"))
 	}
 	
-	credential, err := json.Marshal(anonReq)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to marshal request: %w", err))
+	// Create the account; with an anonymous manager it is tracked from the
+	// start and capped per network and device
+	client := auth.AnonymousClient{
+		IP:                auth.ClientIP(req.Peer().Addr, req.Header(), s.config.Anonymous.TrustForwardedFor),
+		DeviceFingerprint: req.Msg.GetDeviceFingerprint(),
 	}
-	
-	// Authenticate anonymously
-	userInfo, err := provider.Authenticate(ctx, string(credential))
+	userInfo, err := anonProvider.Create(ctx, req.Msg.Username, client)
 	if err != nil {
-		if authErr, ok := err.(*auth.AuthError); ok {
-			if authErr.Code == "USERNAME_TAKEN" {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			switch authErr.Code {
+			case "USERNAME_TAKEN":
 				return nil, connect.NewError(connect.CodeAlreadyExists, errors.New(authErr.Message))
+			case auth.ErrRateLimited:
+				return nil, connect.NewError(connect.CodeResourceExhausted, errors.New(authErr.Message))
 			}
 		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create anonymous session: %w", err))
 	}
 	
-	// Generate JWT token (no expiry for anonymous)
+	// The anonymous session is what a later sign-in migrates
+	sessionID, _ := userInfo.Metadata["session_id"].(string)
+	
+	var ttl time.Duration
+	if s.anonymous != nil {
+		ttl = s.anonymous.TTL()
+	}
+	
+	// Generate JWT token (renewed on activity; no expiry without an anonymous manager)
 	claims := &auth.Claims{
-		UserID:      userInfo.ID, // Use session ID as user ID for anonymous
-		SessionID:   userInfo.ID,
+		UserID:      userInfo.ID,
+		SessionID:   sessionID,
 		Username:    userInfo.Username,
 		Provider:    "anonymous",
 		IsAnonymous: true,
 	}
 	
-	token, err := s.tokenManager.GenerateToken(claims, 0) // No expiry
+	token, err := s.tokenManager.GenerateToken(claims, ttl)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
 	}
 	
 	return connect.NewResponse(&servicev1.InitAnonymousResponse{
 		Token:     token,
-		SessionId: userInfo.ID,
+		SessionId: sessionID,
 		Username:  req.Msg.Username,
 	}), nil
 }
@@ -155,6 +225,15 @@ func (s *Service) Authenticate(
 	if req.Msg.Credential == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("credential is required"))
 	}
+	if req.Msg.Provider == "anonymous" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("use InitAnonymous for anonymous sessions"))
+	}
+	
+	// Only the caller's own anonymous session can be migrated
+	claims, _ := auth.ClaimsFromContext(ctx)
+	if err := s.verifyAnonymousSession(ctx, claims, req.Msg.GetSessionId()); err != nil {
+		return nil, connect.NewError(connect.CodePermissionDenied, err)
+	}
 	
 	// Get the provider
 	provider, err := s.registry.Get(req.Msg.Provider)
@@ -162,7 +241,8 @@ func (s *Service) Authenticate(
 		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("provider %s not found: %w", req.Msg.Provider, err))
 	}
 	
-	// Authenticate with the provider
+	// Authenticate with the provider; the client IP feeds brute-force protection
+	ctx = auth.ContextWithClientIP(ctx, auth.ClientIP(req.Peer().Addr, req.Header(), s.config.Anonymous.TrustForwardedFor))
 	userInfo, err := provider.Authenticate(ctx, req.Msg.Credential)
 	if err != nil {
 		// Check if it's a special case (like magic link sent)
@@ -174,7 +254,7 @@ func (s *Service) Authenticate(
 					Token: "", // No token yet
 					User:  nil,
 				}), nil
-			case auth.ErrInvalidCredentials:
+			case auth.ErrInvalidCredentials, auth.ErrTokenInvalid, auth.ErrTokenExpired:
 				return nil, connect.NewError(connect.CodeUnauthenticated, errors.New(authErr.Message))
 			case auth.ErrUserNotFound:
 				return nil, connect.NewError(connect.CodeNotFound, errors.New(authErr.Message))
@@ -182,62 +262,71 @@ func (s *Service) Authenticate(
 				return nil, connect.NewError(connect.CodePermissionDenied, errors.New(authErr.Message))
 			case auth.ErrEmailNotVerified:
 				return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New(authErr.Message))
+			case auth.ErrTooManyAttempts:
+				return nil, tooManyAttemptsToConnect(authErr)
 			}
 		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("authentication failed: %w", err))
 	}
 	
-	// Find or create user in our system
-	user, err := s.findOrCreateUser(ctx, userInfo)
+	// Users with two-factor authentication get a challenge instead of a token
+	resolution := usernameResolutionFromProto(req.Msg.UsernameResolution)
+	challenge, err := s.mfaChallenge(ctx, userInfo, req.Msg.GetSessionId(), resolution)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to process user: %w", err))
-	}
-	
-	// Handle session migration if provided
-	sessionMigrated := false
-	if req.Msg.SessionId != nil && *req.Msg.SessionId != "" {
-		// Check if this is an anonymous session
-		session, err := s.sessionManager.Validate(ctx, *req.Msg.SessionId)
-		if err == nil && session.IsAnonymous {
-			// Migrate the session to the authenticated user
-			if err := s.sessionManager.MigrateToUser(ctx, *req.Msg.SessionId, user.ID); err != nil {
-				// Log error but continue
-				fmt.Printf("failed to migrate session: %v\n", err)
-			} else {
-				sessionMigrated = true
-			}
-		}
+		return nil, signInErrorToConnect(err)
+	}
+	if challenge != "" {
+		return connect.NewResponse(&servicev1.AuthenticateResponse{
+			MfaRequired:  true,
+			MfaChallenge: challenge,
+		}), nil
+	}
+	
+	// Find or create user in our system, migrating the anonymous account if provided
+	user, sessionMigrated, err := s.signInUser(ctx, userInfo, req.Msg.GetSessionId(), resolution)
+	if err != nil {
+		return nil, signInErrorToConnect(err)
 	}
 	
-	// Create a new authenticated session
+	// Create a session and token
+	token, err := s.IssueToken(ctx, user, req.Msg.Provider)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	
+	// Convert to proto user
+	protoUser := s.userToProto(user)
+	
+	return connect.NewResponse(&servicev1.AuthenticateResponse{
+		Token:           token,
+		User:            protoUser,
+		SessionMigrated: sessionMigrated,
+	}), nil
+}
+
+// IssueToken creates an authenticated session for a signed-in user and returns
+// its JWT. Every sign-in goes through it, including registration.
+func (s *Service) IssueToken(ctx context.Context, user *auth.User, provider string) (string, error) {
 	session, err := s.sessionManager.CreateAuthenticated(ctx, user.ID, s.config.Session.TTL)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create session: %w", err))
+		return "", fmt.Errorf("failed to create session: %w", err)
 	}
 	
-	// Generate JWT token
 	claims := &auth.Claims{
 		UserID:      user.ID,
 		SessionID:   session.ID,
 		Username:    user.Username,
 		Email:       user.Email,
-		Provider:    req.Msg.Provider,
+		Provider:    provider,
 		IsAnonymous: false,
+		Roles:       s.userRoles(ctx, user.ID),
 	}
 	
 	token, err := s.tokenManager.GenerateToken(claims, s.config.JWT.AccessTokenTTL)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+		return "", fmt.Errorf("failed to generate token: %w", err)
 	}
-	
-	// Convert to proto user
-	protoUser := s.userToProto(user)
-	
-	return connect.NewResponse(&servicev1.AuthenticateResponse{
-		Token:           token,
-		User:            protoUser,
-		SessionMigrated: sessionMigrated,
-	}), nil
+	return token, nil
 }
 
 // RefreshToken refreshes an expired JWT token
@@ -250,7 +339,10 @@ func (s *Service) RefreshToken(
 	}
 	
 	// Refresh the token
-	newToken, err := s.tokenManager.RefreshToken(req.Msg.ExpiredToken, s.config.JWT.AccessTokenTTL)
+	newToken, err := s.refreshToken(ctx, req.Msg.ExpiredToken, s.config.JWT.AccessTokenTTL)
+	if errors.Is(err, errUserDisabled) {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New(errUserDisabled.Message))
+	}
 	if err != nil {
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to refresh token: %w", err))
 	}
@@ -260,11 +352,42 @@ func (s *Service) RefreshToken(
 	}), nil
 }
 
+// refreshToken re-mints an expired token unless its user was disabled or has
+// signed out everywhere since the token's sign-in
+func (s *Service) refreshToken(ctx context.Context, expiredToken string, ttl time.Duration) (string, error) {
+	newToken, err := s.tokenManager.RefreshToken(expiredToken, ttl)
+	if err != nil {
+		return "", err
+	}
+
+	// The new token keeps the sign-in time of the expired one
+	claims, err := s.tokenManager.ValidateToken(newToken)
+	if err != nil {
+		return "", err
+	}
+	user, err := s.userStore.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		return "", err
+	}
+	if isDisabled(user) {
+		return "", errUserDisabled
+	}
+	if user.TokenRevoked(claims) {
+		return "", errTokenRevoked
+	}
+	return newToken, nil
+}
+
 // findOrCreateUser finds an existing user or creates a new one based on UserInfo
 func (s *Service) findOrCreateUser(ctx context.Context, info *auth.UserInfo) (*auth.User, error) {
-	// Try to find user by email
-	user, err := s.userStore.GetUserByEmail(ctx, info.Email)
+	user, err := s.findExistingUser(ctx, info)
 	if err != nil {
+		return nil, err
+	}
+	if isDisabled(user) {
+		return nil, errUserDisabled
+	}
+	if user == nil {
 		// User doesn't exist, create new one
 		now := time.Now()
 		user = &auth.User{
@@ -285,9 +408,9 @@ func (s *Service) findOrCreateUser(ctx context.Context, info *auth.UserInfo) (*a
 			},
 		}
 		
-		// If username is empty, use email
-		if user.Username == "" {
-			user.Username = user.Email
+		// Provider usernames are often emails, which don't fit the username column
+		if !usernamePattern.MatchString(user.Username) {
+			user.Username = derivedUsername(info)
 		}
 		
 		if err := s.userStore.CreateUser(ctx, user); err != nil {
@@ -295,34 +418,156 @@ func (s *Service) findOrCreateUser(ctx context.Context, info *auth.UserInfo) (*a
 		}
 		
 		// Reload user to get generated ID
-		user, err = s.userStore.GetUserByEmail(ctx, info.Email)
+		reloaded, err := s.userStore.GetUserByEmail(ctx, info.Email)
 		if err != nil {
 			return nil, fmt.Errorf("failed to reload user: %w", err)
 		}
+		user = reloaded
 	} else {
-		// Update last login
-		now := time.Now()
-		user.LastLoginAt = &now
-		user.UpdatedAt = now
-		
-		// Update user info if changed
-		if info.FirstName != "" && user.FirstName != info.FirstName {
-			user.FirstName = info.FirstName
+		s.refreshUser(ctx, user, info)
+	}
+	
+	// Record the provider identity so later logins resolve through the link
+	if err := s.ensureProviderLink(ctx, user.ID, info); err != nil {
+		return nil, fmt.Errorf("failed to link provider: %w", err)
+	}
+	
+	return user, nil
+}
+
+// accountProviders authenticate against a stored user and report its ID as UserInfo.ID
+var accountProviders = map[string]bool{
+	"anonymous":  true,
+	"email":      true,
+	"magic_link": true,
+	"passkey":    true,
+}
+
+// findExistingUser resolves the local user for an identity: through its provider
+// link, through the account an internal provider checked, or by email when both
+// the provider and the account have verified that email. Any other email match
+// returns errLinkFromSettings, since linking it would hand the account to whoever
+// controls the identity. Returns nil if no user exists.
+func (s *Service) findExistingUser(ctx context.Context, info *auth.UserInfo) (*auth.User, error) {
+	link, err := s.findProviderLink(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	if link != nil {
+		user, err := s.userStore.GetUserByID(ctx, link.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get linked user: %w", err)
 		}
-		if info.LastName != "" && user.LastName != info.LastName {
-			user.LastName = info.LastName
+		return user, nil
+	}
+
+	if accountProviders[info.Provider] && info.ID != "" {
+		user, err := s.userStore.GetUserByID(ctx, info.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
 		}
-		if info.Picture != "" && user.Picture != info.Picture {
-			user.Picture = info.Picture
+		return user, nil
+	}
+
+	if info.Email == "" {
+		return nil, nil
+	}
+	user, err := s.userStore.GetUserByEmail(ctx, info.Email)
+	if err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) && authErr.Code == auth.ErrUserNotFound {
+			return nil, nil
 		}
-		
-		if err := s.userStore.UpdateUser(ctx, user); err != nil {
-			// Log error but continue
-			fmt.Printf("failed to update user: %v\n", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if !info.EmailVerified || !user.EmailVerified {
+		return nil, errLinkFromSettings
+	}
+	return user, nil
+}
+
+// usernamePattern matches usernames that can be used as they are
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]{3,30}$`)
+
+// derivedUsername builds a username for a new identity from its username or
+// email prefix, suffixed with a hash of the identity so it stays unique
+func derivedUsername(info *auth.UserInfo) string {
+	base := info.Username
+	if base == "" {
+		base, _, _ = strings.Cut(info.Email, "@")
+	}
+	base = strings.Map(func(r rune) rune {
+		if r == '_' || r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
 		}
+		return -1
+	}, base)
+	if len(base) > 21 {
+		base = base[:21]
 	}
+	if len(base) < 3 {
+		base = info.Provider
+	}
+
+	sum := sha256.Sum256([]byte(info.Provider + ":" + info.ProviderID))
+	return base + "_" + hex.EncodeToString(sum[:4])
+}
+
+// errUserDisabled refuses sign-in to a disabled account, whichever provider verified the identity
+var errUserDisabled = &auth.AuthError{Code: auth.ErrUserDisabled, Message: "user account is disabled"}
+
+// errTokenRevoked refuses to refresh a token from before a sign-out everywhere, such as a password reset
+var errTokenRevoked = &auth.AuthError{Code: auth.ErrTokenInvalid, Message: "token has been revoked; sign in again"}
+
+// errLinkFromSettings refuses to attach a new identity to an account by an unverified email match
+var errLinkFromSettings = &auth.AuthError{
+	Code:    auth.ErrAlreadyExists,
+	Message: "an account with this email already exists; sign in to it and link this provider from settings",
+}
+
+// isDisabled reports whether an existing user has been disabled
+func isDisabled(user *auth.User) bool {
+	return user != nil && user.Status == "disabled"
+}
+
+// signInErrorToConnect maps errors from resolving a signed-in user to connect errors
+func signInErrorToConnect(err error) error {
+	var authErr *auth.AuthError
+	if errors.As(err, &authErr) {
+		switch authErr.Code {
+		case auth.ErrUsernameConflict:
+			return connect.NewError(connect.CodeFailedPrecondition, errors.New(authErr.Message))
+		case auth.ErrUserDisabled:
+			return connect.NewError(connect.CodePermissionDenied, errors.New(authErr.Message))
+		case auth.ErrAlreadyExists:
+			return connect.NewError(connect.CodeAlreadyExists, errors.New(authErr.Message))
+		}
+	}
+	return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to process user: %w", err))
+}
+
+// refreshUser records a login and copies changed profile fields from the provider
+func (s *Service) refreshUser(ctx context.Context, user *auth.User, info *auth.UserInfo) {
+	// Update last login
+	now := time.Now()
+	user.LastLoginAt = &now
+	user.UpdatedAt = now
 	
-	return user, nil
+	// Update user info if changed
+	if info.FirstName != "" && user.FirstName != info.FirstName {
+		user.FirstName = info.FirstName
+	}
+	if info.LastName != "" && user.LastName != info.LastName {
+		user.LastName = info.LastName
+	}
+	if info.Picture != "" && user.Picture != info.Picture {
+		user.Picture = info.Picture
+	}
+	
+	if err := s.userStore.UpdateUser(ctx, user); err != nil {
+		// Log error but continue
+		log.Printf("failed to update user %s: %v", user.ID, err)
+	}
 }
 
 // userToProto converts internal User to proto User
@@ -335,9 +580,17 @@ func (s *Service) userToProto(user *auth.User) *entitiesv1.User {
 		Id:       user.ID,
 		Email:    &user.Email,
 		Username: user.Username,
+		Status:   protoconv.UserStatusToProto(user.Status),
 		Metadata: make(map[string]string),
 	}
 	
+	// Stored metadata first, so profile fields below take precedence
+	for key, value := range user.Metadata {
+		if str, ok := value.(string); ok {
+			protoUser.Metadata[key] = str
+		}
+	}
+	
 	// Set timestamps
 	if user.CreatedAt.Unix() > 0 {
 		protoUser.CreatedAt = user.CreatedAt.Unix()
@@ -347,6 +600,11 @@ func (s *Service) userToProto(user *auth.User) *entitiesv1.User {
 		protoUser.UpdatedAt = user.UpdatedAt.Unix()
 	}
 	
+	if user.DeletionScheduledAt != nil {
+		deletionScheduledAt := user.DeletionScheduledAt.Unix()
+		protoUser.DeletionScheduledAt = &deletionScheduledAt
+	}
+	
 	// Add additional user info to metadata
 	if user.FirstName != "" {
 		protoUser.Metadata["first_name"] = user.FirstName
@@ -357,76 +615,9 @@ func (s *Service) userToProto(user *auth.User) *entitiesv1.User {
 	if user.Picture != "" {
 		protoUser.Metadata["avatar_url"] = user.Picture
 	}
-	if user.Status != "" {
-		protoUser.Metadata["status"] = user.Status
+	if user.EmailVerified {
+		protoUser.Metadata["email_verified"] = "true"
 	}
 	
 	return protoUser
 }
-
-
-// RegisterProviders registers all configured providers with the registry
-func (s *Service) RegisterProviders() error {
-	// Register OAuth providers
-	for name, config := range s.config.Providers {
-		if !config.Enabled {
-			continue
-		}
-		
-		switch config.Type {
-		case "oauth":
-			// Create OAuth provider based on name
-			switch name {
-			case "google":
-				provider, err := oauth.NewGoogleProvider(
-					config.Config["client_id"],
-					config.Config["client_secret"],
-					config.Config["redirect_url"],
-				)
-				if err != nil {
-					return fmt.Errorf("failed to create Google provider: %w", err)
-				}
-				if err := s.registry.Register(provider); err != nil {
-					return fmt.Errorf("failed to register Google provider: %w", err)
-				}
-			// Add more OAuth providers as needed
-			}
-			
-		case "internal":
-			// Register internal providers
-			switch name {
-			case "email":
-				emailConfig := auth.EmailProviderConfig{
-					MinLength:           8,
-					RequireUppercase:    true,
-					RequireLowercase:    true,
-					RequireNumbers:      true,
-					RequireSpecialChar:  false,
-					BcryptCost:          12,
-					RequireVerification: false, // For development
-				}
-				
-				provider, err := auth.NewEmailPasswordProvider(s.userStore, emailConfig)
-				if err != nil {
-					return fmt.Errorf("failed to create email provider: %w", err)
-				}
-				if err := s.registry.Register(provider); err != nil {
-					return fmt.Errorf("failed to register email provider: %w", err)
-				}
-				
-			case "anonymous":
-				provider, err := auth.NewAnonymousProvider(s.sessionManager, s.userStore)
-				if err != nil {
-					return fmt.Errorf("failed to create anonymous provider: %w", err)
-				}
-				if err := s.registry.Register(provider); err != nil {
-					return fmt.Errorf("failed to register anonymous provider: %w", err)
-				}
-				
-			// Add magic link provider when email sender is available
-			}
-		}
-	}
-	
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/auth_service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// SendVerificationEmail sends an email verification link. Without an email the
+// authenticated caller's address is used. The email is sent in the background
+// and the call succeeds for unknown, verified and rate-limited addresses alike,
+// so it cannot be used to discover accounts.
+func (s *Service) SendVerificationEmail(
+	ctx context.Context,
+	req *connect.Request[servicev1.SendVerificationEmailRequest],
+) (*connect.Response[servicev1.SendVerificationEmailResponse], error) {
+	if s.verifier == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("email verification is not configured"))
+	}
+
+	email := strings.TrimSpace(req.Msg.Email)
+	if email == "" {
+		claims, ok := auth.ClaimsFromContext(ctx)
+		if !ok || claims == nil || claims.IsAnonymous {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("email is required"))
+		}
+		user, err := s.userStore.GetUserByID(ctx, claims.UserID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+		}
+		email = user.Email
+	}
+
+	if err := s.verifier.SendTo(ctx, email); err != nil {
+		// Don't reveal lookup failures for a specific address
+		log.Printf("failed to queue verification email: %v", err)
+	}
+
+	return connect.NewResponse(&servicev1.SendVerificationEmailResponse{}), nil
+}
+
+// VerifyEmail marks an email address as verified using a token from a verification link
+func (s *Service) VerifyEmail(
+	ctx context.Context,
+	req *connect.Request[servicev1.VerifyEmailRequest],
+) (*connect.Response[servicev1.VerifyEmailResponse], error) {
+	if s.verifier == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("email verification is not configured"))
+	}
+	if req.Msg.Token == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("token is required"))
+	}
+
+	user, err := s.verifier.Verify(ctx, req.Msg.Token)
+	if err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			switch authErr.Code {
+			case auth.ErrTokenInvalid, auth.ErrTokenExpired:
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(authErr.Message))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to verify email: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.VerifyEmailResponse{
+		User: s.userToProto(user),
+	}), nil
+}
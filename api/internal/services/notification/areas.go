@@ -0,0 +1,230 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+)
+
+// Saved area limits
+const (
+	maxAreaNameLength  = 100
+	minAreaRadius      = 100    // Meters
+	maxAreaRadius      = 50_000 // Meters
+	minPolygonVertices = 3
+	maxPolygonVertices = 100
+	maxPolygonSpan     = 1.0 // Degrees of latitude or longitude, about 110 km
+)
+
+// SaveArea saves an area the caller is notified about new pins in
+func (s *Service) SaveArea(
+	ctx context.Context,
+	req *connect.Request[servicev1.SaveAreaRequest],
+) (*connect.Response[servicev1.SaveAreaResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Msg.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxAreaNameLength {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("name must be 1-%d characters", maxAreaNameLength))
+	}
+
+	isCircle := req.Msg.Center != nil || req.Msg.RadiusMeters != nil
+	if isCircle == (len(req.Msg.Polygon) > 0) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("either polygon or center and radius_meters is required"))
+	}
+
+	// Validate the shape before checking the limit, so bad requests get the specific error
+	var polygon string
+	if isCircle {
+		if req.Msg.Center == nil || req.Msg.RadiusMeters == nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("circles need both center and radius_meters"))
+		}
+		if err := validateLocation(req.Msg.Center); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if *req.Msg.RadiusMeters < minAreaRadius || *req.Msg.RadiusMeters > maxAreaRadius {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("radius_meters must be %d-%d", minAreaRadius, maxAreaRadius))
+		}
+	} else {
+		polygon, err = polygonGeoJSON(req.Msg.Polygon)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+
+	// Count and insert under the user's lock, so concurrent saves can't both pass the limit
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to start transaction: %w", err))
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	if err := qtx.LockSavedAreasByUser(ctx, userID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to lock saved areas: %w", err))
+	}
+	count, err := qtx.CountSavedAreasByUser(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count saved areas: %w", err))
+	}
+	if count >= protoconv.MaxSavedAreas {
+		return nil, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("you can save at most %d areas", protoconv.MaxSavedAreas))
+	}
+
+	var area *repository.GetSavedAreaRow
+	if isCircle {
+		row, err := qtx.CreateSavedCircleArea(ctx, &repository.CreateSavedCircleAreaParams{
+			UserID:       userID,
+			Name:         name,
+			Longitude:    req.Msg.Center.Longitude,
+			Latitude:     req.Msg.Center.Latitude,
+			RadiusMeters: *req.Msg.RadiusMeters,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to save area: %w", err))
+		}
+		area = (*repository.GetSavedAreaRow)(row)
+	} else {
+		row, err := qtx.CreateSavedPolygonArea(ctx, &repository.CreateSavedPolygonAreaParams{
+			UserID:  userID,
+			Name:    name,
+			Polygon: polygon,
+		})
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23514" {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("polygon must not cross itself"))
+			}
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to save area: %w", err))
+		}
+		area = (*repository.GetSavedAreaRow)(row)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.SaveAreaResponse{
+		Area: protoconv.SavedAreaToProto(area),
+	}), nil
+}
+
+// ListSavedAreas lists the caller's saved areas, oldest first
+func (s *Service) ListSavedAreas(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListSavedAreasRequest],
+) (*connect.Response[servicev1.ListSavedAreasResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListSavedAreasByUser(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list saved areas: %w", err))
+	}
+
+	resp := &servicev1.ListSavedAreasResponse{
+		Areas: make([]*entitiesv1.SavedArea, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.Areas = append(resp.Areas, protoconv.SavedAreaToProto((*repository.GetSavedAreaRow)(row)))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// DeleteSavedArea deletes one of the caller's saved areas
+func (s *Service) DeleteSavedArea(
+	ctx context.Context,
+	req *connect.Request[servicev1.DeleteSavedAreaRequest],
+) (*connect.Response[servicev1.DeleteSavedAreaResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var areaID pgtype.UUID
+	if err := areaID.Scan(req.Msg.AreaId); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid area ID: %w", err))
+	}
+
+	// Other users' areas look missing
+	rows, err := s.queries.DeleteSavedArea(ctx, &repository.DeleteSavedAreaParams{
+		ID:     areaID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete saved area: %w", err))
+	}
+	if rows == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("saved area not found"))
+	}
+
+	return connect.NewResponse(&servicev1.DeleteSavedAreaResponse{
+		Success: true,
+	}), nil
+}
+
+// polygonGeoJSON validates polygon vertices and returns them as a closed GeoJSON polygon
+func polygonGeoJSON(vertices []*entitiesv1.Location) (string, error) {
+	// Clients may close the ring themselves
+	if n := len(vertices); n > 1 &&
+		vertices[0].Latitude == vertices[n-1].Latitude && vertices[0].Longitude == vertices[n-1].Longitude {
+		vertices = vertices[:n-1]
+	}
+	if len(vertices) < minPolygonVertices || len(vertices) > maxPolygonVertices {
+		return "", fmt.Errorf("polygon must have %d-%d vertices", minPolygonVertices, maxPolygonVertices)
+	}
+
+	ring := make([][2]float64, 0, len(vertices)+1)
+	minLat, maxLat := vertices[0].Latitude, vertices[0].Latitude
+	minLng, maxLng := vertices[0].Longitude, vertices[0].Longitude
+	for _, vertex := range vertices {
+		if err := validateLocation(vertex); err != nil {
+			return "", err
+		}
+		minLat, maxLat = min(minLat, vertex.Latitude), max(maxLat, vertex.Latitude)
+		minLng, maxLng = min(minLng, vertex.Longitude), max(maxLng, vertex.Longitude)
+		ring = append(ring, [2]float64{vertex.Longitude, vertex.Latitude})
+	}
+	if maxLat-minLat > maxPolygonSpan || maxLng-minLng > maxPolygonSpan {
+		return "", fmt.Errorf("polygon must span at most %g degrees", maxPolygonSpan)
+	}
+	ring = append(ring, ring[0])
+
+	geoJSON, err := json.Marshal(map[string]any{
+		"type":        "Polygon",
+		"coordinates": [][][2]float64{ring},
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(geoJSON), nil
+}
+
+// validateLocation checks that a location is a valid coordinate
+func validateLocation(location *entitiesv1.Location) error {
+	if location == nil {
+		return errors.New("location is required")
+	}
+	if location.Latitude < -90 || location.Latitude > 90 || location.Longitude < -180 || location.Longitude > 180 {
+		return errors.New("latitude must be -90 to 90 and longitude -180 to 180")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+)
+
+// Notification generation timing
+const (
+	generatePollInterval = 5 * time.Second
+	generateOverlap      = time.Minute    // Rescanned so rows committed late aren't missed
+	generateLookback     = 24 * time.Hour // Scanned on startup to cover downtime
+	areaAlertLimit       = 10             // Area notifications per user per areaAlertWindow
+	areaAlertWindow      = time.Hour
+)
+
+// Generator creates notifications from new comments, follows and pins in
+// saved areas in the background, so the write paths of AddComment, Follow and
+// CreatePin don't depend on it. Rescans are safe: notifications are unique per
+// comment, per follower and per pin.
+type Generator struct {
+	queries *repository.Queries
+	hub     *Hub // nil disables live delivery
+	since   time.Time
+}
+
+// NewGenerator creates a new notification generator
+func NewGenerator(queries *repository.Queries, hub *Hub) (*Generator, error) {
+	if queries == nil {
+		return nil, errors.New("queries are required")
+	}
+
+	return &Generator{
+		queries: queries,
+		hub:     hub,
+		since:   time.Now().Add(-generateLookback),
+	}, nil
+}
+
+// Generate creates notifications for activity since the last scan and wakes
+// the recipients' streams. Returns how many were created.
+func (g *Generator) Generate(ctx context.Context) (int, error) {
+	scanStart := time.Now()
+	since := pgtype.Timestamptz{Time: g.since.Add(-generateOverlap), Valid: true}
+
+	// Replies go first: a reply on the recipient's own pin is reported once, as a reply
+	var created []*repository.Notification
+	for _, generate := range []struct {
+		kind string
+		fn   func(context.Context, pgtype.Timestamptz) ([]*repository.Notification, error)
+	}{
+		{"reply", g.queries.CreateReplyNotifications},
+		{"comment", g.queries.CreateCommentNotifications},
+		{"follow", g.queries.CreateFollowNotifications},
+		{"area", g.createAreaNotifications},
+	} {
+		notifications, err := generate.fn(ctx, since)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create %s notifications: %w", generate.kind, err)
+		}
+		created = append(created, notifications...)
+	}
+	g.since = scanStart
+
+	if g.hub != nil {
+		recipients := make(map[string]struct{})
+		for _, notification := range created {
+			recipients[notification.UserID.String()] = struct{}{}
+		}
+		for userID := range recipients {
+			g.hub.Publish(userID)
+		}
+	}
+
+	return len(created), nil
+}
+
+// createAreaNotifications notifies users of new pins in their saved areas, throttled per user
+func (g *Generator) createAreaNotifications(ctx context.Context, since pgtype.Timestamptz) ([]*repository.Notification, error) {
+	return g.queries.CreateAreaNotifications(ctx, &repository.CreateAreaNotificationsParams{
+		Since:         since,
+		ThrottleSince: pgtype.Timestamptz{Time: time.Now().Add(-areaAlertWindow), Valid: true},
+		ThrottleLimit: areaAlertLimit,
+	})
+}
+
+// Run generates notifications every generatePollInterval until ctx is done
+func (g *Generator) Run(ctx context.Context) {
+	ticker := time.NewTicker(generatePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := g.Generate(ctx); err != nil {
+				log.Printf("notification generation failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package notification
+
+import "sync"
+
+// Hub wakes the notification streams of users who have new notifications.
+// It only reaches streams on this instance; streams also poll, so other
+// instances pick up notifications within watchPollInterval.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+// NewHub creates a new notification hub
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe returns a channel that is signalled when the user has new
+// notifications, and a function that stops the subscription
+func (h *Hub) Subscribe(userID string) (<-chan struct{}, func()) {
+	signal := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[userID][signal] = struct{}{}
+	h.mu.Unlock()
+
+	return signal, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[userID], signal)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+	}
+}
+
+// Publish signals the user's streams. A stream that hasn't caught up with an
+// earlier signal already has one pending, so none are dropped.
+func (h *Hub) Publish(userID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for signal := range h.subscribers[userID] {
+		select {
+		case signal <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+)
+
+// notificationKinds are the kinds of notifications users have preferences for
+var notificationKinds = []string{"comment", "reply", "follow", "area"}
+
+// GetPreferences returns the caller's notification preferences
+func (s *Service) GetPreferences(
+	ctx context.Context,
+	req *connect.Request[servicev1.GetPreferencesRequest],
+) (*connect.Response[servicev1.GetPreferencesResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&servicev1.GetPreferencesResponse{
+		Preferences: preferences,
+	}), nil
+}
+
+// UpdatePreferences changes the caller's preferences for the listed kinds
+func (s *Service) UpdatePreferences(
+	ctx context.Context,
+	req *connect.Request[servicev1.UpdatePreferencesRequest],
+) (*connect.Response[servicev1.UpdatePreferencesResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate every preference before changing any
+	kinds := make([]string, len(req.Msg.Preferences))
+	for i, preference := range req.Msg.Preferences {
+		kinds[i] = protoconv.NotificationKindFromProto(preference.Kind)
+		if kinds[i] == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("notification kind is required"))
+		}
+	}
+
+	for i, preference := range req.Msg.Preferences {
+		if err := s.queries.UpsertNotificationPreference(ctx, &repository.UpsertNotificationPreferenceParams{
+			UserID: userID,
+			Kind:   kinds[i],
+			Push:   preference.Push,
+		}); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update notification preference: %w", err))
+		}
+	}
+
+	preferences, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&servicev1.UpdatePreferencesResponse{
+		Preferences: preferences,
+	}), nil
+}
+
+// preferences returns a user's preference for every kind, filling in the defaults
+func (s *Service) preferences(ctx context.Context, userID pgtype.UUID) ([]*servicev1.NotificationPreference, error) {
+	rows, err := s.queries.ListNotificationPreferences(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get notification preferences: %w", err))
+	}
+
+	push := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		push[row.Kind] = row.Push
+	}
+
+	preferences := make([]*servicev1.NotificationPreference, 0, len(notificationKinds))
+	for _, kind := range notificationKinds {
+		enabled, ok := push[kind]
+		preferences = append(preferences, &servicev1.NotificationPreference{
+			Kind: protoconv.NotificationKindToProto(kind),
+			Push: enabled || !ok,
+		})
+	}
+	return preferences, nil
+}
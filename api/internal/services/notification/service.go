@@ -0,0 +1,250 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// Notification stream limits
+const (
+	watchPollInterval = 15 * time.Second
+	watchOverlap      = 5 * time.Second // Rescanned since notifications can commit after the scan that missed them
+	maxWatchDuration  = time.Hour       // Clients reconnect, which re-checks their token
+	maxMarkReadIDs    = 100
+)
+
+// Service implements the NotificationService
+type Service struct {
+	servicev1connect.UnimplementedNotificationServiceHandler
+	db      *pgxpool.Pool
+	queries *repository.Queries
+	hub     *Hub
+}
+
+// NewService creates a new notification service. Callers are identified by
+// the auth interceptor, which must wrap the handler.
+func NewService(db *pgxpool.Pool, queries *repository.Queries, hub *Hub) *Service {
+	return &Service{
+		db:      db,
+		queries: queries,
+		hub:     hub,
+	}
+}
+
+// ListNotifications lists the caller's notifications, newest first
+func (s *Service) ListNotifications(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListNotificationsRequest],
+) (*connect.Response[servicev1.ListNotificationsResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	beforeCreatedAt, beforeID, err := protoconv.DecodeCursor(req.Msg.GetCursor())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	limit := protoconv.PageSize(req.Msg.Limit)
+
+	// One extra row tells whether there is another page
+	rows, err := s.queries.ListNotifications(ctx, &repository.ListNotificationsParams{
+		UserID:          userID,
+		UnreadOnly:      req.Msg.UnreadOnly,
+		BeforeCreatedAt: beforeCreatedAt,
+		BeforeID:        beforeID,
+		PageLimit:       limit + 1,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list notifications: %w", err))
+	}
+
+	resp := &servicev1.ListNotificationsResponse{
+		Notifications: make([]*entitiesv1.Notification, 0, min(len(rows), int(limit))),
+	}
+	for i, row := range rows {
+		if i == int(limit) {
+			prev := rows[i-1].Notification
+			cursor := protoconv.EncodeCursor(prev.CreatedAt, prev.ID)
+			resp.NextCursor = &cursor
+			break
+		}
+		resp.Notifications = append(resp.Notifications, protoconv.NotificationToProto(&row.Notification, &row.User))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// MarkRead marks some or all of the caller's notifications as read
+func (s *Service) MarkRead(
+	ctx context.Context,
+	req *connect.Request[servicev1.MarkReadRequest],
+) (*connect.Response[servicev1.MarkReadResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.All {
+		if _, err := s.queries.MarkAllNotificationsRead(ctx, userID); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to mark notifications read: %w", err))
+		}
+	} else {
+		if len(req.Msg.NotificationIds) == 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("notification_ids or all is required"))
+		}
+		if len(req.Msg.NotificationIds) > maxMarkReadIDs {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at most %d notification_ids per request", maxMarkReadIDs))
+		}
+
+		ids := make([]pgtype.UUID, len(req.Msg.NotificationIds))
+		for i, id := range req.Msg.NotificationIds {
+			if err := ids[i].Scan(id); err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid notification ID: %w", err))
+			}
+		}
+
+		// Other users' notifications are silently skipped
+		if _, err := s.queries.MarkNotificationsRead(ctx, &repository.MarkNotificationsReadParams{
+			UserID: userID,
+			Ids:    ids,
+		}); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to mark notifications read: %w", err))
+		}
+	}
+
+	unread, err := s.queries.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count unread notifications: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.MarkReadResponse{
+		UnreadCount: unread,
+	}), nil
+}
+
+// GetUnreadCount counts the caller's unread notifications
+func (s *Service) GetUnreadCount(
+	ctx context.Context,
+	req *connect.Request[servicev1.GetUnreadCountRequest],
+) (*connect.Response[servicev1.GetUnreadCountResponse], error) {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.queries.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count unread notifications: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.GetUnreadCountResponse{
+		UnreadCount: unread,
+	}), nil
+}
+
+// WatchNotifications streams the caller's notifications as they are created.
+// Streams are woken by the generator on this instance and poll otherwise, and
+// end after maxWatchDuration so clients reconnect with a current token.
+func (s *Service) WatchNotifications(
+	ctx context.Context,
+	req *connect.Request[servicev1.WatchNotificationsRequest],
+	stream *connect.ServerStream[servicev1.WatchNotificationsResponse],
+) error {
+	userID, err := requireUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, maxWatchDuration)
+	defer cancel()
+
+	signal, unsubscribe := s.hub.Subscribe(userID.String())
+	defer unsubscribe()
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	since := time.Now()
+	sent := make(map[pgtype.UUID]time.Time) // Notifications sent within the overlap, by creation time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-signal:
+		case <-ticker.C:
+		}
+
+		scanStart := time.Now()
+		rows, err := s.queries.ListNotificationsSince(ctx, &repository.ListNotificationsSinceParams{
+			UserID:    userID,
+			CreatedAt: pgtype.Timestamptz{Time: since.Add(-watchOverlap), Valid: true},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list notifications: %w", err))
+		}
+
+		var unsent []*repository.ListNotificationsSinceRow
+		for _, row := range rows {
+			if _, ok := sent[row.Notification.ID]; !ok {
+				unsent = append(unsent, row)
+			}
+		}
+		since = scanStart
+		for id, createdAt := range sent {
+			if createdAt.Before(since.Add(-watchOverlap)) {
+				delete(sent, id)
+			}
+		}
+		if len(unsent) == 0 {
+			continue
+		}
+
+		unread, err := s.queries.CountUnreadNotifications(ctx, userID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count unread notifications: %w", err))
+		}
+
+		for _, row := range unsent {
+			if err := stream.Send(&servicev1.WatchNotificationsResponse{
+				Notification: protoconv.NotificationToProto(&row.Notification, &row.User),
+				UnreadCount:  unread,
+			}); err != nil {
+				return err
+			}
+			sent[row.Notification.ID] = row.Notification.CreatedAt.Time
+		}
+	}
+}
+
+// requireUser returns the caller's user ID
+func requireUser(ctx context.Context) (pgtype.UUID, error) {
+	var userID pgtype.UUID
+
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return userID, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required - please sign in"))
+	}
+	if err := userID.Scan(claims.UserID); err != nil {
+		return userID, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID: %w", err))
+	}
+	return userID, nil
+}
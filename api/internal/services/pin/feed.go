@@ -0,0 +1,90 @@
+package pin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// ListFeed returns recent pins from the users the caller follows, wherever
+// they are on the map, newest first
+func (s *Service) ListFeed(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListFeedRequest],
+) (*connect.Response[servicev1.ListFeedResponse], error) {
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return nil, connect.NewError(
+			connect.CodeUnauthenticated,
+			errors.New("authentication required to view your feed"),
+		)
+	}
+
+	var followerID pgtype.UUID
+	if err := followerID.Scan(claims.UserID); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID: %w", err))
+	}
+
+	beforeCreatedAt, beforeID, err := protoconv.DecodeCursor(req.Msg.GetCursor())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	limit := protoconv.PageSize(req.Msg.Limit)
+
+	// One extra row tells whether there is another page
+	rows, err := s.queries.ListFeedPins(ctx, &repository.ListFeedPinsParams{
+		FollowerID:      followerID,
+		BeforeCreatedAt: beforeCreatedAt,
+		BeforeID:        beforeID,
+		PageLimit:       limit + 1,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list feed: %w", err))
+	}
+
+	resp := &servicev1.ListFeedResponse{
+		Pins: make([]*entitiesv1.Pin, 0, min(len(rows), int(limit))),
+	}
+	authors := make(map[pgtype.UUID]*repository.User)
+	for i, row := range rows {
+		if i == int(limit) {
+			cursor := protoconv.EncodeCursor(rows[i-1].CreatedAt, rows[i-1].ID)
+			resp.NextCursor = &cursor
+			break
+		}
+
+		// A page usually holds several pins per author
+		author, seen := authors[row.UserID]
+		if !seen {
+			author, _ = s.queries.GetUserByID(ctx, row.UserID)
+			authors[row.UserID] = author
+		}
+		commentCount, _ := s.queries.CountCommentsByParent(ctx, row.ID)
+
+		pin := protoconv.PinFromRowToProto(
+			row.ID.String(),
+			row.UserID.String(),
+			row.Content,
+			row.CreatedAt.Time.Unix(),
+			row.CreatedAt.Time.Unix(),
+			row.Longitude,
+			row.Latitude,
+			&row.Geohash,
+			author,
+			int32(commentCount),
+		)
+		pin.Visibility = protoconv.PinVisibilityToProto(row.Visibility)
+		resp.Pins = append(resp.Pins, pin)
+	}
+
+	return connect.NewResponse(resp), nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5"
@@ -19,20 +18,22 @@ import (
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
 )
 
+// maxPinComments caps the comments returned with a pin
+const maxPinComments = 200
+
 // Service implements the PinService
 type Service struct {
 	servicev1connect.UnimplementedPinServiceHandler
-	db           *pgxpool.Pool
-	queries      *repository.Queries
-	tokenManager *auth.TokenManager
+	db      *pgxpool.Pool
+	queries *repository.Queries
 }
 
-// NewService creates a new pin service
-func NewService(db *pgxpool.Pool, queries *repository.Queries, tokenManager *auth.TokenManager) *Service {
+// NewService creates a new pin service. Callers are identified by the auth
+// interceptor, which must wrap the handler.
+func NewService(db *pgxpool.Pool, queries *repository.Queries) *Service {
 	return &Service{
-		db:           db,
-		queries:      queries,
-		tokenManager: tokenManager,
+		db:      db,
+		queries: queries,
 	}
 }
 
@@ -41,9 +42,9 @@ func (s *Service) CreatePin(
 	ctx context.Context,
 	req *connect.Request[servicev1.CreatePinRequest],
 ) (*connect.Response[servicev1.CreatePinResponse], error) {
-	// Extract user from JWT context
-	claims := s.extractClaims(req.Header())
-	if claims == nil || claims.UserID == "" {
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
 		return nil, connect.NewError(
 			connect.CodeUnauthenticated,
 			errors.New("authentication required to create pins"),
@@ -57,6 +58,12 @@ func (s *Service) CreatePin(
 			errors.New("location is required"),
 		)
 	}
+	if req.Msg.Visibility == entitiesv1.PinVisibility_PIN_VISIBILITY_FOLLOWERS && claims.IsAnonymous {
+		return nil, connect.NewError(
+			connect.CodeFailedPrecondition,
+			errors.New("anonymous users have no followers; sign in to post followers-only pins"),
+		)
+	}
 
 	// Start transaction
 	tx, err := s.db.Begin(ctx)
@@ -76,6 +83,7 @@ func (s *Service) CreatePin(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to prepare params: %w", err))
 	}
+	postParams.Visibility = protoconv.PinVisibilityFromProto(req.Msg.Visibility)
 
 	// Create post
 	post, err := qtx.CreatePost(ctx, postParams)
@@ -116,13 +124,13 @@ func (s *Service) CreatePin(
 	if locationRow != nil {
 		pin = protoconv.PinFromRowToProto(
 			post.ID.String(),
-			post.AuthorUserID.String(),
+			post.UserID.String(),
 			post.Content,
 			post.CreatedAt.Time.Unix(),
-			post.UpdatedAt.Time.Unix(),
+			post.CreatedAt.Time.Unix(),
 			locationRow.Longitude,
 			locationRow.Latitude,
-			locationRow.Geohash,
+			&locationRow.Geohash,
 			author,
 			0, // No comments for new pin
 		)
@@ -130,15 +138,16 @@ func (s *Service) CreatePin(
 		// Fallback for pins without location
 		pin = protoconv.PinFromRowToProto(
 			post.ID.String(),
-			post.AuthorUserID.String(),
+			post.UserID.String(),
 			post.Content,
 			post.CreatedAt.Time.Unix(),
-			post.UpdatedAt.Time.Unix(),
+			post.CreatedAt.Time.Unix(),
 			nil, nil, nil,
 			author,
 			0,
 		)
 	}
+	pin.Visibility = protoconv.PinVisibilityToProto(post.Visibility)
 
 	return connect.NewResponse(&servicev1.CreatePinResponse{
 		Pin: pin,
@@ -150,7 +159,8 @@ func (s *Service) ListPins(
 	ctx context.Context,
 	req *connect.Request[servicev1.ListPinsRequest],
 ) (*connect.Response[servicev1.ListPinsResponse], error) {
-	// Public read - no auth required
+	// Public read - no auth required; signed-in callers also see followers-only pins they may view
+	viewerID := viewerFromContext(ctx)
 
 	// Zoom-based query with center point
 	zoom := req.Msg.Zoom
@@ -173,6 +183,7 @@ func (s *Service) ListPins(
 	pins, err := s.queries.ListPinsByGeohash(ctx, &repository.ListPinsByGeohashParams{
 		Column1: &ghash,
 		Limit:   limit,
+		UserID:  viewerID,
 	})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list pins: %w", err))
@@ -182,22 +193,23 @@ func (s *Service) ListPins(
 	protoPins := make([]*entitiesv1.Pin, len(pins))
 	for i, pinRow := range pins {
 		// Fetch author and comment count for each pin
-		author, _ := s.queries.GetUserByID(ctx, pinRow.AuthorUserID)
-		commentCount, _ := s.queries.CountCommentsByPinID(ctx)
+		author, _ := s.queries.GetUserByID(ctx, pinRow.UserID)
+		commentCount, _ := s.queries.CountCommentsByParent(ctx, pinRow.ID)
 
 		// Convert pin row to proto using direct row conversion
 		protoPins[i] = protoconv.PinFromRowToProto(
 			pinRow.ID.String(),
-			pinRow.AuthorUserID.String(),
+			pinRow.UserID.String(),
 			pinRow.Content,
 			pinRow.CreatedAt.Time.Unix(),
-			pinRow.UpdatedAt.Time.Unix(),
+			pinRow.CreatedAt.Time.Unix(),
 			pinRow.Longitude,
 			pinRow.Latitude,
-			pinRow.Geohash,
+			&pinRow.Geohash,
 			author,
 			int32(commentCount),
 		)
+		protoPins[i].Visibility = protoconv.PinVisibilityToProto(pinRow.Visibility)
 	}
 
 	return connect.NewResponse(&servicev1.ListPinsResponse{
@@ -235,25 +247,70 @@ func (s *Service) GetPin(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get pin: %w", err))
 	}
 
+	// Followers-only pins, and pins from blocked or muted users, look like missing pins
+	visible, err := s.canView(ctx, pinWithLocation.UserID, pinWithLocation.Visibility)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check pin visibility: %w", err))
+	}
+	if !visible {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("pin not found"))
+	}
+
 	// Get author
-	author, _ := s.queries.GetUserByID(ctx, pinWithLocation.AuthorUserID)
+	author, _ := s.queries.GetUserByID(ctx, pinWithLocation.UserID)
+
+	// Get comments, oldest first
+	comments, err := s.queries.ListCommentsByParent(ctx, &repository.ListCommentsByParentParams{
+		ParentID:  pinID,
+		ViewerID:  viewerFromContext(ctx),
+		PageLimit: maxPinComments,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list comments: %w", err))
+	}
+	protoComments := make([]*entitiesv1.Comment, 0, len(comments))
+	for _, row := range comments {
+		post := &repository.Post{
+			ID:        row.ID,
+			UserID:    row.UserID,
+			Type:      row.Type,
+			ParentID:  row.ParentID,
+			Content:   row.Content,
+			CreatedAt: row.CreatedAt,
+			ReplyToID: row.ReplyToID,
+		}
+		var commentAuthor *repository.User
+		if row.AuthorUsername != nil {
+			commentAuthor = &repository.User{
+				ID:          row.UserID,
+				Username:    *row.AuthorUsername,
+				DisplayName: row.AuthorDisplayName,
+				AvatarUrl:   row.AuthorAvatarUrl,
+			}
+		}
+		protoComments = append(protoComments, protoconv.CommentToProto(post, commentAuthor))
+	}
 
-	// Get comments - for now, return empty array as comments are not implemented
-	protoComments := []*entitiesv1.Comment{}
+	// Count the same way ListPins and ListFeed do, not from the capped list
+	commentCount, err := s.queries.CountCommentsByParent(ctx, pinID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count comments: %w", err))
+	}
 
 	// Convert pin to proto using row data directly
 	pin := protoconv.PinFromRowToProto(
 		pinWithLocation.ID.String(),
-		pinWithLocation.AuthorUserID.String(),
+		pinWithLocation.UserID.String(),
 		pinWithLocation.Content,
 		pinWithLocation.CreatedAt.Time.Unix(),
-		pinWithLocation.UpdatedAt.Time.Unix(),
+		pinWithLocation.CreatedAt.Time.Unix(),
 		pinWithLocation.Longitude,
 		pinWithLocation.Latitude,
-		pinWithLocation.Geohash,
+		&pinWithLocation.Geohash,
 		author,
-		int32(0), // No comments for now
+		int32(commentCount),
 	)
+	pin.Visibility = protoconv.PinVisibilityToProto(pinWithLocation.Visibility)
 
 	return connect.NewResponse(&servicev1.GetPinResponse{
 		Pin:      pin,
@@ -261,14 +318,14 @@ func (s *Service) GetPin(
 	}), nil
 }
 
-// DeletePin deletes a pin (requires auth + ownership)
+// DeletePin deletes a pin (requires auth + ownership, or the moderator role)
 func (s *Service) DeletePin(
 	ctx context.Context,
 	req *connect.Request[servicev1.DeletePinRequest],
 ) (*connect.Response[servicev1.DeletePinResponse], error) {
-	// Extract user from JWT context
-	claims := s.extractClaims(req.Header())
-	if claims == nil || claims.UserID == "" {
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
 		return nil, connect.NewError(
 			connect.CodeUnauthenticated,
 			errors.New("authentication required"),
@@ -297,9 +354,13 @@ func (s *Service) DeletePin(
 		}
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get pin: %w", err))
 	}
+	// Comments are posts too, but this endpoint only deletes pins
+	if post.Type != "pin" {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("pin not found"))
+	}
 
-	// Check ownership
-	if post.AuthorUserID.String() != claims.UserID {
+	// Check ownership; moderators may delete any pin
+	if post.UserID.String() != claims.UserID && !claims.HasRole(auth.RoleModerator) {
 		return nil, connect.NewError(
 			connect.CodePermissionDenied,
 			errors.New("you can only delete your own pins"),
@@ -321,9 +382,9 @@ func (s *Service) AddComment(
 	ctx context.Context,
 	req *connect.Request[servicev1.AddCommentRequest],
 ) (*connect.Response[servicev1.AddCommentResponse], error) {
-	// Extract user from JWT context
-	claims := s.extractClaims(req.Header())
-	if claims == nil || claims.UserID == "" {
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
 		return nil, connect.NewError(
 			connect.CodeUnauthenticated,
 			errors.New("authentication required to add comments"),
@@ -352,7 +413,7 @@ func (s *Service) AddComment(
 	}
 
 	// Verify pin exists
-	_, err = s.queries.GetPostByID(ctx, pinID)
+	pinPost, err := s.queries.GetPostByID(ctx, pinID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, connect.NewError(connect.CodeNotFound, errors.New("pin not found"))
@@ -360,53 +421,116 @@ func (s *Service) AddComment(
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to verify pin: %w", err))
 	}
 
-	// For now, comments are not fully implemented, so return a placeholder response
-	// TODO: Implement proper comment creation when schema supports parent_id relationships
-	
-	// Get author for response
-	var authorID pgtype.UUID
-	err = authorID.Scan(claims.UserID)
+	// Pins the caller can't see look missing
+	if pinPost.Type != "pin" {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("pin not found"))
+	}
+	visible, err := s.canView(ctx, pinPost.UserID, pinPost.Visibility)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("invalid user ID: %w", err))
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check pin visibility: %w", err))
+	}
+	if !visible {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("pin not found"))
 	}
-	author, _ := s.queries.GetUserByID(ctx, authorID)
 
-	// Create a placeholder comment response
-	placeholderComment := &entitiesv1.Comment{
-		Id:        req.Msg.PinId, // Use pin ID as placeholder
-		UserId:    claims.UserID,
-		Content:   req.Msg.Content,
-		CreatedAt: 0, // Placeholder timestamp
+	// Users blocked by the pin's author can't comment on it
+	var commenterID pgtype.UUID
+	if err := commenterID.Scan(claims.UserID); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID: %w", err))
 	}
-	if author != nil && author.DisplayName != nil {
-		placeholderComment.Author = protoconv.UserToProto(author)
+	blocked, err := s.queries.IsBlocked(ctx, &repository.IsBlockedParams{
+		BlockerID: pinPost.UserID,
+		BlockedID: commenterID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check blocks: %w", err))
+	}
+	if blocked {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("you can't comment on this pin"))
+	}
+
+	// Replies must answer a comment on the same pin
+	var replyToID pgtype.UUID
+	if req.Msg.ParentId != nil && *req.Msg.ParentId != "" {
+		if err := replyToID.Scan(*req.Msg.ParentId); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid parent ID: %w", err))
+		}
+		parent, err := s.queries.GetPostByID(ctx, replyToID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("parent comment not found"))
+			}
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get parent comment: %w", err))
+		}
+		if parent.Type != "comment" || parent.ParentID != pinID {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("parent comment is not on this pin"))
+		}
+	}
+
+	// Notifications are generated from the stored comment by the notification job
+	comment, err := s.queries.CreateComment(ctx, &repository.CreateCommentParams{
+		UserID:    commenterID,
+		PinID:     pinID,
+		ReplyToID: replyToID,
+		Content:   req.Msg.Content,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create comment: %w", err))
 	}
 
+	author, _ := s.queries.GetUserByID(ctx, commenterID)
+
 	return connect.NewResponse(&servicev1.AddCommentResponse{
-		Comment: placeholderComment,
+		Comment: protoconv.CommentToProto(comment, author),
 	}), nil
 }
 
-// extractClaims extracts JWT claims from request headers
-func (s *Service) extractClaims(headers http.Header) *auth.Claims {
-	authHeader := headers.Get("Authorization")
-	if authHeader == "" {
-		return nil
+// viewerFromContext returns the caller's user ID, or NULL for callers without a token
+func viewerFromContext(ctx context.Context) pgtype.UUID {
+	var viewerID pgtype.UUID
+	if claims, ok := auth.ClaimsFromContext(ctx); ok && claims != nil {
+		_ = viewerID.Scan(claims.UserID) // Stays NULL if invalid
 	}
+	return viewerID
+}
 
-	// Remove "Bearer " prefix
-	token := authHeader
-	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-		token = authHeader[7:]
+// canView reports whether the caller may see a pin with the given author and visibility.
+// Followers-only pins are visible to the author, their followers and moderators.
+// Pins from users the caller blocked or muted are hidden from the caller.
+func (s *Service) canView(ctx context.Context, authorID pgtype.UUID, visibility *string) (bool, error) {
+	viewerID := viewerFromContext(ctx)
+	if viewerID.Valid && viewerID != authorID {
+		hidden, err := s.queries.IsHidden(ctx, &repository.IsHiddenParams{
+			BlockerID: viewerID,
+			BlockedID: authorID,
+		})
+		if err != nil || hidden {
+			return false, err
+		}
 	}
 
-	// Validate token
-	claims, err := s.tokenManager.ValidateToken(token)
-	if err != nil {
-		return nil
+	if visibility == nil || *visibility != "followers" {
+		return true, nil
 	}
 
-	return claims
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil {
+		return false, nil
+	}
+	if claims.HasRole(auth.RoleModerator) {
+		return true, nil
+	}
+
+	if !viewerID.Valid {
+		return false, nil
+	}
+	if viewerID == authorID {
+		return true, nil
+	}
+	return s.queries.IsFollowing(ctx, &repository.IsFollowingParams{
+		FollowerID: viewerID,
+		FolloweeID: authorID,
+	})
 }
 
 // trackUserEvent tracks user events for analytics and rate limiting
@@ -0,0 +1,276 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/push"
+)
+
+// Push delivery settings
+const (
+	deliverPollInterval = 5 * time.Second
+	deliverBatchSize    = 100
+	pushMaxAge          = time.Hour      // Older notifications are not pushed, e.g. after downtime
+	pushTTL             = 24 * time.Hour // How long push services hold a message for an offline device
+	maxSnippetLength    = 120
+	maxParallelSends    = 16
+	sendTimeout         = 5 * time.Second // Per message, so a slow push service can't hold up the others
+)
+
+// payload is the JSON message the service worker shows as a notification
+type payload struct {
+	ID    string `json:"id"`
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	PinID string `json:"pin_id,omitempty"`
+}
+
+// Deliverer sends new notifications to their recipients' browsers. Each
+// notification is claimed once before sending, so a crash mid-send drops its
+// pushes rather than repeating them; the notification itself is kept.
+type Deliverer struct {
+	queries deliveryQueries
+	sender  messageSender
+}
+
+// deliveryQueries are the queries the deliverer runs
+type deliveryQueries interface {
+	ClaimUnpushedNotifications(ctx context.Context, limit int32) ([]*repository.Notification, error)
+	IsPushEnabled(ctx context.Context, arg *repository.IsPushEnabledParams) (bool, error)
+	ListPushSubscriptionsByUser(ctx context.Context, userID pgtype.UUID) ([]*repository.PushSubscription, error)
+	DeletePushSubscriptionByEndpoint(ctx context.Context, endpoint string) error
+	GetUserByID(ctx context.Context, id pgtype.UUID) (*repository.User, error)
+	GetSavedArea(ctx context.Context, id pgtype.UUID) (*repository.GetSavedAreaRow, error)
+	GetPostByID(ctx context.Context, id pgtype.UUID) (*repository.Post, error)
+}
+
+// messageSender delivers a message to a push service, as push.Sender does
+type messageSender interface {
+	Send(ctx context.Context, sub *push.Subscription, payload []byte, ttl time.Duration) error
+}
+
+// delivery is a message for one subscription
+type delivery struct {
+	notificationID string
+	subscription   *repository.PushSubscription
+	message        []byte
+}
+
+// NewDeliverer creates a new push deliverer
+func NewDeliverer(queries *repository.Queries, sender *push.Sender) (*Deliverer, error) {
+	if queries == nil {
+		return nil, errors.New("queries are required")
+	}
+	if sender == nil {
+		return nil, errors.New("push sender is required")
+	}
+
+	return &Deliverer{
+		queries: queries,
+		sender:  sender,
+	}, nil
+}
+
+// Deliver pushes notifications created since the last run. Returns how many messages were sent.
+func (d *Deliverer) Deliver(ctx context.Context) (int, error) {
+	sent := 0
+	for ctx.Err() == nil {
+		notifications, err := d.queries.ClaimUnpushedNotifications(ctx, deliverBatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to claim notifications: %w", err)
+		}
+		var deliveries []delivery
+		for _, notification := range notifications {
+			deliveries = append(deliveries, d.prepare(ctx, notification)...)
+		}
+		sent += d.send(ctx, deliveries)
+		if len(notifications) < deliverBatchSize {
+			break
+		}
+	}
+	return sent, nil
+}
+
+// Run pushes new notifications every deliverPollInterval until ctx is done
+func (d *Deliverer) Run(ctx context.Context) {
+	ticker := time.NewTicker(deliverPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := d.Deliver(ctx); err != nil {
+				log.Printf("push delivery failed: %v", err)
+			}
+		}
+	}
+}
+
+// prepare returns the messages for one notification, one per subscription of
+// its recipient, unless they turned pushes off for its kind
+func (d *Deliverer) prepare(ctx context.Context, notification *repository.Notification) []delivery {
+	if time.Since(notification.CreatedAt.Time) > pushMaxAge {
+		return nil
+	}
+
+	enabled, err := d.queries.IsPushEnabled(ctx, &repository.IsPushEnabledParams{
+		UserID: notification.UserID,
+		Kind:   notification.Kind,
+	})
+	if err != nil {
+		log.Printf("failed to check push preference of user %s: %v", notification.UserID.String(), err)
+		return nil
+	}
+	if !enabled {
+		return nil
+	}
+
+	subscriptions, err := d.queries.ListPushSubscriptionsByUser(ctx, notification.UserID)
+	if err != nil {
+		log.Printf("failed to list push subscriptions of user %s: %v", notification.UserID.String(), err)
+		return nil
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	message, err := d.payload(ctx, notification)
+	if err != nil {
+		log.Printf("failed to build push message for notification %s: %v", notification.ID.String(), err)
+		return nil
+	}
+
+	deliveries := make([]delivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, delivery{
+			notificationID: notification.ID.String(),
+			subscription:   subscription,
+			message:        message,
+		})
+	}
+	return deliveries
+}
+
+// send delivers messages in parallel and returns how many were sent.
+// Subscriptions the push service no longer knows are deleted.
+func (d *Deliverer) send(ctx context.Context, deliveries []delivery) int {
+	var sent atomic.Int64
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxParallelSends)
+
+	for _, delivery := range deliveries {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			if d.sendOne(ctx, delivery) {
+				sent.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return int(sent.Load())
+}
+
+// sendOne delivers one message within sendTimeout
+func (d *Deliverer) sendOne(ctx context.Context, delivery delivery) bool {
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	err := d.sender.Send(sendCtx, &push.Subscription{
+		Endpoint: delivery.subscription.Endpoint,
+		P256dh:   delivery.subscription.P256dh,
+		Auth:     delivery.subscription.Auth,
+	}, delivery.message, pushTTL)
+	if errors.Is(err, push.ErrSubscriptionGone) {
+		if err := d.queries.DeletePushSubscriptionByEndpoint(ctx, delivery.subscription.Endpoint); err != nil {
+			log.Printf("failed to delete expired push subscription: %v", err)
+		}
+		return false
+	}
+	if err != nil {
+		log.Printf("failed to push notification %s: %v", delivery.notificationID, err)
+		return false
+	}
+	return true
+}
+
+// payload builds the message for a notification, naming the actor and quoting the post
+func (d *Deliverer) payload(ctx context.Context, notification *repository.Notification) ([]byte, error) {
+	actor, err := d.queries.GetUserByID(ctx, notification.ActorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get actor: %w", err)
+	}
+	name := "@" + actor.Username
+	if actor.DisplayName != nil && *actor.DisplayName != "" {
+		name = *actor.DisplayName
+	}
+
+	message := payload{
+		ID:    notification.ID.String(),
+		Kind:  notification.Kind,
+		Title: "Alunalun",
+	}
+	if notification.PinID.Valid {
+		message.PinID = notification.PinID.String()
+	}
+
+	switch notification.Kind {
+	case "comment":
+		message.Body = name + " commented on your pin"
+	case "reply":
+		message.Body = name + " replied to your comment"
+	case "follow":
+		message.Body = name + " started following you"
+	case "area":
+		message.Body = name + " pinned in your saved area"
+		if notification.AreaID.Valid {
+			if area, err := d.queries.GetSavedArea(ctx, notification.AreaID); err == nil {
+				message.Body = name + " pinned in " + area.Name
+			}
+		}
+	default:
+		message.Body = "You have a new notification"
+	}
+
+	// Quote the comment, or the pin for area alerts
+	quoted := notification.CommentID
+	if notification.Kind == "area" {
+		quoted = notification.PinID
+	}
+	if quoted.Valid {
+		post, err := d.queries.GetPostByID(ctx, quoted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get post: %w", err)
+		}
+		message.Body += ": " + snippet(post.Content)
+	}
+
+	return json.Marshal(message)
+}
+
+// snippet shortens text to maxSnippetLength characters
+func snippet(text string) string {
+	if utf8.RuneCountInString(text) <= maxSnippetLength {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:maxSnippetLength-1]) + "…"
+}
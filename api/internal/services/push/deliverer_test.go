@@ -0,0 +1,120 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/push"
+)
+
+// testQueries serves one batch of notifications and records deleted subscriptions
+type testQueries struct {
+	notifications []*repository.Notification
+	subscriptions []*repository.PushSubscription
+
+	mu      sync.Mutex
+	deleted []string
+}
+
+func (q *testQueries) ClaimUnpushedNotifications(ctx context.Context, limit int32) ([]*repository.Notification, error) {
+	notifications := q.notifications
+	q.notifications = nil
+	return notifications, nil
+}
+
+func (q *testQueries) IsPushEnabled(ctx context.Context, arg *repository.IsPushEnabledParams) (bool, error) {
+	return true, nil
+}
+
+func (q *testQueries) ListPushSubscriptionsByUser(ctx context.Context, userID pgtype.UUID) ([]*repository.PushSubscription, error) {
+	return q.subscriptions, nil
+}
+
+func (q *testQueries) DeletePushSubscriptionByEndpoint(ctx context.Context, endpoint string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.deleted = append(q.deleted, endpoint)
+	return nil
+}
+
+func (q *testQueries) GetUserByID(ctx context.Context, id pgtype.UUID) (*repository.User, error) {
+	return &repository.User{ID: id, Username: "actor"}, nil
+}
+
+func (q *testQueries) GetSavedArea(ctx context.Context, id pgtype.UUID) (*repository.GetSavedAreaRow, error) {
+	return nil, errors.New("not found")
+}
+
+func (q *testQueries) GetPostByID(ctx context.Context, id pgtype.UUID) (*repository.Post, error) {
+	return nil, errors.New("not found")
+}
+
+// testSender answers each endpoint with a fixed error. Every send waits until
+// all expected sends have started, so a sequential deliverer times out.
+type testSender struct {
+	errors  map[string]error
+	started sync.WaitGroup
+}
+
+func (s *testSender) Send(ctx context.Context, sub *push.Subscription, payload []byte, ttl time.Duration) error {
+	s.started.Done()
+	waited := make(chan struct{})
+	go func() {
+		s.started.Wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		return s.errors[sub.Endpoint]
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestDeliverSendsInParallelAndDeletesGoneSubscriptions(t *testing.T) {
+	notification := &repository.Notification{
+		ID:        pgtype.UUID{Bytes: [16]byte{1}, Valid: true},
+		UserID:    pgtype.UUID{Bytes: [16]byte{2}, Valid: true},
+		ActorID:   pgtype.UUID{Bytes: [16]byte{3}, Valid: true},
+		Kind:      "follow",
+		CreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+	queries := &testQueries{
+		notifications: []*repository.Notification{notification},
+		subscriptions: []*repository.PushSubscription{
+			{Endpoint: "https://push.test/ok"},
+			{Endpoint: "https://push.test/not-found"},
+			{Endpoint: "https://push.test/gone"},
+			{Endpoint: "https://push.test/failing"},
+		},
+	}
+	sender := &testSender{errors: map[string]error{
+		"https://push.test/not-found": push.ErrSubscriptionGone,
+		"https://push.test/gone":      push.ErrSubscriptionGone,
+		"https://push.test/failing":   errors.New("push service returned 500"),
+	}}
+	sender.started.Add(len(queries.subscriptions))
+	deliverer := &Deliverer{queries: queries, sender: sender}
+
+	sent, err := deliverer.Deliver(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Fatalf("sent %d messages, expected 1", sent)
+	}
+
+	deleted := map[string]bool{}
+	for _, endpoint := range queries.deleted {
+		deleted[endpoint] = true
+	}
+	if len(queries.deleted) != 2 || !deleted["https://push.test/not-found"] || !deleted["https://push.test/gone"] {
+		t.Fatalf("deleted %v, expected only the gone subscriptions", queries.deleted)
+	}
+}
@@ -0,0 +1,171 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5/pgtype"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+	"github.com/radjathaher/alunalun/api/internal/utils/push"
+)
+
+// maxEndpointLength bounds stored endpoints; real ones are a few hundred bytes
+const maxEndpointLength = 2048
+
+// Service implements the PushSubscriptionService
+type Service struct {
+	servicev1connect.UnimplementedPushSubscriptionServiceHandler
+	queries *repository.Queries
+	sender  *push.Sender // nil disables Web Push
+}
+
+// NewService creates a new push subscription service. Callers are identified
+// by the auth interceptor, which must wrap the handler.
+func NewService(queries *repository.Queries, sender *push.Sender) *Service {
+	return &Service{
+		queries: queries,
+		sender:  sender,
+	}
+}
+
+// GetVapidPublicKey returns the key browsers subscribe with (public)
+func (s *Service) GetVapidPublicKey(
+	ctx context.Context,
+	req *connect.Request[servicev1.GetVapidPublicKeyRequest],
+) (*connect.Response[servicev1.GetVapidPublicKeyResponse], error) {
+	if s.sender == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("push notifications are not enabled"))
+	}
+
+	return connect.NewResponse(&servicev1.GetVapidPublicKeyResponse{
+		PublicKey: s.sender.PublicKey(),
+	}), nil
+}
+
+// Subscribe registers the browser's push subscription for the caller's session.
+// A session has one subscription; subscribing again replaces it.
+func (s *Service) Subscribe(
+	ctx context.Context,
+	req *connect.Request[servicev1.SubscribeRequest],
+) (*connect.Response[servicev1.SubscribeResponse], error) {
+	if s.sender == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("push notifications are not enabled"))
+	}
+
+	claims, userID, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Msg.Endpoint) > maxEndpointLength {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("endpoint is too long"))
+	}
+	if err := push.ValidateEndpoint(req.Msg.Endpoint); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if req.Msg.P256Dh == "" || req.Msg.Auth == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("p256dh and auth are required"))
+	}
+
+	// Check the keys now rather than on the first delivery
+	if err := push.ValidateKeys(req.Msg.P256Dh, req.Msg.Auth); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := s.queries.DeleteOtherSessionPushSubscriptions(ctx, &repository.DeleteOtherSessionPushSubscriptionsParams{
+		SessionID: claims.SessionID,
+		Endpoint:  req.Msg.Endpoint,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to replace push subscription: %w", err))
+	}
+
+	if _, err := s.queries.UpsertPushSubscription(ctx, &repository.UpsertPushSubscriptionParams{
+		UserID:    userID,
+		SessionID: claims.SessionID,
+		Endpoint:  req.Msg.Endpoint,
+		P256dh:    req.Msg.P256Dh,
+		Auth:      req.Msg.Auth,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to save push subscription: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.SubscribeResponse{
+		Success: true,
+	}), nil
+}
+
+// Unsubscribe removes one of the caller's subscriptions, or all of their session's
+func (s *Service) Unsubscribe(
+	ctx context.Context,
+	req *connect.Request[servicev1.UnsubscribeRequest],
+) (*connect.Response[servicev1.UnsubscribeResponse], error) {
+	claims, userID, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.Endpoint != nil {
+		_, err = s.queries.DeletePushSubscription(ctx, &repository.DeletePushSubscriptionParams{
+			UserID:   userID,
+			Endpoint: *req.Msg.Endpoint,
+		})
+	} else {
+		_, err = s.queries.DeletePushSubscriptionsBySession(ctx, &repository.DeletePushSubscriptionsBySessionParams{
+			UserID:    userID,
+			SessionID: claims.SessionID,
+		})
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete push subscription: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.UnsubscribeResponse{
+		Success: true,
+	}), nil
+}
+
+// SessionRevoked deletes the subscription of a revoked session, so a signed-out
+// browser stops receiving the user's notifications
+func (s *Service) SessionRevoked(ctx context.Context, sessionID string) error {
+	if err := s.queries.DeletePushSubscriptionsBySessionID(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to delete push subscriptions: %w", err)
+	}
+	return nil
+}
+
+// UserSessionsRevoked deletes all of a user's subscriptions when they are
+// signed out everywhere, e.g. after a password reset
+func (s *Service) UserSessionsRevoked(ctx context.Context, userID string) error {
+	var id pgtype.UUID
+	if err := id.Scan(userID); err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	if err := s.queries.DeletePushSubscriptionsByUser(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete push subscriptions: %w", err)
+	}
+	return nil
+}
+
+// requireSession returns the caller's claims and user ID. Subscriptions belong
+// to a session, so callers without one, such as API keys, are refused.
+func requireSession(ctx context.Context) (*auth.Claims, pgtype.UUID, error) {
+	var userID pgtype.UUID
+
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return nil, userID, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required - please sign in"))
+	}
+	if claims.SessionID == "" {
+		return nil, userID, connect.NewError(connect.CodePermissionDenied, errors.New("push subscriptions require a signed-in session"))
+	}
+	if err := userID.Scan(claims.UserID); err != nil {
+		return nil, userID, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID: %w", err))
+	}
+	return claims, userID, nil
+}
@@ -0,0 +1,49 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// DeleteAccount schedules the caller's account for erasure after the grace period
+// and signs them out everywhere. The caller must have signed in recently.
+// Signing in again before then cancels it.
+func (s *Service) DeleteAccount(
+	ctx context.Context,
+	req *connect.Request[servicev1.DeleteAccountRequest],
+) (*connect.Response[servicev1.DeleteAccountResponse], error) {
+	if s.deletion == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("account deletion is not enabled"))
+	}
+
+	claims, _, err := requireProfileOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if claims.IsAnonymous {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("anonymous accounts are deleted automatically when they expire"))
+	}
+
+	at, err := s.deletion.Schedule(ctx, claims.UserID, claims.SignedInAt())
+	if err != nil {
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			switch authErr.Code {
+			case auth.ErrUserNotFound:
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+			case auth.ErrReauthRequired:
+				return nil, connect.NewError(connect.CodePermissionDenied, errors.New(authErr.Message))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to schedule account deletion: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.DeleteAccountResponse{
+		DeletionScheduledAt: at.Unix(),
+	}), nil
+}
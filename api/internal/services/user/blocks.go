@@ -0,0 +1,107 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+)
+
+// BlockUser blocks a user for the caller. Anonymous users can block too, since
+// anyone may post near them.
+func (s *Service) BlockUser(
+	ctx context.Context,
+	req *connect.Request[servicev1.BlockUserRequest],
+) (*connect.Response[servicev1.BlockUserResponse], error) {
+	blockerID, blockedID, err := s.blockTarget(ctx, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.BlockUser(ctx, &repository.BlockUserParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to block user: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.BlockUserResponse{
+		Success: true,
+	}), nil
+}
+
+// UnblockUser removes the caller's block or mute of a user
+func (s *Service) UnblockUser(
+	ctx context.Context,
+	req *connect.Request[servicev1.UnblockUserRequest],
+) (*connect.Response[servicev1.UnblockUserResponse], error) {
+	_, blockerID, err := requireProfileOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	blockedID, err := parseUserID(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.UnblockUser(ctx, &repository.UnblockUserParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to unblock user: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.UnblockUserResponse{
+		Success: true,
+	}), nil
+}
+
+// MuteUser hides a user's pins and comments from the caller without blocking them
+func (s *Service) MuteUser(
+	ctx context.Context,
+	req *connect.Request[servicev1.MuteUserRequest],
+) (*connect.Response[servicev1.MuteUserResponse], error) {
+	muterID, mutedID, err := s.blockTarget(ctx, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.queries.MuteUser(ctx, &repository.MuteUserParams{
+		BlockerID: muterID,
+		BlockedID: mutedID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to mute user: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.MuteUserResponse{
+		Success: true,
+	}), nil
+}
+
+// blockTarget returns the caller's user ID and the existing user they want to block or mute
+func (s *Service) blockTarget(ctx context.Context, id string) (pgtype.UUID, pgtype.UUID, error) {
+	_, callerID, err := requireProfileOwner(ctx)
+	if err != nil {
+		return callerID, pgtype.UUID{}, err
+	}
+	targetID, err := parseUserID(id)
+	if err != nil {
+		return callerID, targetID, err
+	}
+	if targetID == callerID {
+		return callerID, targetID, connect.NewError(connect.CodeInvalidArgument, errors.New("you can't block or mute yourself"))
+	}
+
+	if _, err := s.queries.GetUserByID(ctx, targetID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return callerID, targetID, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+		}
+		return callerID, targetID, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get user: %w", err))
+	}
+	return callerID, targetID, nil
+}
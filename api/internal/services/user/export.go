@@ -0,0 +1,142 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+)
+
+// ExportMyData queues an archive of the caller's data. An export already in
+// progress is returned instead of starting another.
+func (s *Service) ExportMyData(
+	ctx context.Context,
+	req *connect.Request[servicev1.ExportMyDataRequest],
+) (*connect.Response[servicev1.ExportMyDataResponse], error) {
+	if s.exporter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("data exports are not enabled"))
+	}
+
+	_, userID, err := requireProfileOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.queries.GetActiveDataExport(ctx, userID)
+	if err == nil {
+		return connect.NewResponse(&servicev1.ExportMyDataResponse{
+			Export: s.dataExportToProto(active),
+		}), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get data export: %w", err))
+	}
+
+	count, err := s.queries.CountDataExportsSince(ctx, &repository.CountDataExportsSinceParams{
+		UserID:    userID,
+		CreatedAt: pgtype.Timestamptz{Time: time.Now().Add(-24 * time.Hour), Valid: true},
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count data exports: %w", err))
+	}
+	if count >= maxExportsPerDay {
+		return nil, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("at most %d exports can be started per day", maxExportsPerDay))
+	}
+
+	export, err := s.queries.CreateDataExport(ctx, userID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create data export: %w", err))
+	}
+	s.exporter.Notify()
+
+	return connect.NewResponse(&servicev1.ExportMyDataResponse{
+		Export: s.dataExportToProto(export),
+	}), nil
+}
+
+// GetExportStatus returns the progress of one of the caller's exports, with a
+// fresh download link once it is ready
+func (s *Service) GetExportStatus(
+	ctx context.Context,
+	req *connect.Request[servicev1.GetExportStatusRequest],
+) (*connect.Response[servicev1.GetExportStatusResponse], error) {
+	if s.exporter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("data exports are not enabled"))
+	}
+
+	_, userID, err := requireProfileOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var exportID pgtype.UUID
+	if err := exportID.Scan(req.Msg.ExportId); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("invalid export_id"))
+	}
+
+	// Scoped to the caller, so other users' exports look missing
+	export, err := s.queries.GetDataExport(ctx, &repository.GetDataExportParams{
+		ID:     exportID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("export not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get data export: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.GetExportStatusResponse{
+		Export: s.dataExportToProto(export),
+	}), nil
+}
+
+// dataExportToProto converts a data export job, signing a download link if the archive is ready
+func (s *Service) dataExportToProto(export *repository.DataExport) *servicev1.DataExport {
+	protoExport := &servicev1.DataExport{
+		Id:        export.ID.String(),
+		Status:    dataExportStatusToProto(export),
+		Progress:  int32(export.Progress),
+		CreatedAt: export.CreatedAt.Time.Unix(),
+	}
+	if export.CompletedAt.Valid {
+		completedAt := export.CompletedAt.Time.Unix()
+		protoExport.CompletedAt = &completedAt
+	}
+	if export.ExpiresAt.Valid {
+		expiresAt := export.ExpiresAt.Time.Unix()
+		protoExport.ExpiresAt = &expiresAt
+	}
+	if protoExport.Status == servicev1.DataExportStatus_DATA_EXPORT_STATUS_READY {
+		url, expiresAt := s.exporter.DownloadURL(*export.ArchiveKey)
+		linkExpiresAt := expiresAt.Unix()
+		protoExport.DownloadUrl = &url
+		protoExport.DownloadUrlExpiresAt = &linkExpiresAt
+	}
+	return protoExport
+}
+
+// dataExportStatusToProto converts a stored export status; ready exports whose archive is gone are expired
+func dataExportStatusToProto(export *repository.DataExport) servicev1.DataExportStatus {
+	switch export.Status {
+	case "pending":
+		return servicev1.DataExportStatus_DATA_EXPORT_STATUS_PENDING
+	case "running":
+		return servicev1.DataExportStatus_DATA_EXPORT_STATUS_RUNNING
+	case "ready":
+		if export.ArchiveKey == nil || (export.ExpiresAt.Valid && time.Now().After(export.ExpiresAt.Time)) {
+			return servicev1.DataExportStatus_DATA_EXPORT_STATUS_EXPIRED
+		}
+		return servicev1.DataExportStatus_DATA_EXPORT_STATUS_READY
+	case "failed":
+		return servicev1.DataExportStatus_DATA_EXPORT_STATUS_FAILED
+	default:
+		return servicev1.DataExportStatus_DATA_EXPORT_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,428 @@
+package user
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+	"github.com/radjathaher/alunalun/api/internal/utils/media"
+)
+
+// Data export limits
+const (
+	exportLinkTTL      = 15 * time.Minute
+	exportRetention    = 7 * 24 * time.Hour
+	exportStaleAfter   = time.Hour // Running exports older than this are retried
+	exportPollInterval = 30 * time.Second
+	exportCleanupBatch = 100
+	maxExportsPerDay   = 3
+)
+
+// Exporter builds data export archives in the background and deletes them once they expire
+type Exporter struct {
+	queries  *repository.Queries
+	sessions *auth.SessionManager
+	store    *media.PrivateStore
+	wake     chan struct{}
+}
+
+// NewExporter creates a new data exporter
+func NewExporter(queries *repository.Queries, sessions *auth.SessionManager, store *media.PrivateStore) (*Exporter, error) {
+	if queries == nil {
+		return nil, errors.New("queries are required")
+	}
+	if sessions == nil {
+		return nil, errors.New("session manager is required")
+	}
+	if store == nil {
+		return nil, errors.New("export store is required")
+	}
+
+	return &Exporter{
+		queries:  queries,
+		sessions: sessions,
+		store:    store,
+		wake:     make(chan struct{}, 1),
+	}, nil
+}
+
+// Notify wakes the job to pick up a new export without waiting for the next poll
+func (e *Exporter) Notify() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// DownloadURL returns a signed link to an export archive and when it expires
+func (e *Exporter) DownloadURL(archiveKey string) (string, time.Time) {
+	expiresAt := time.Now().Add(exportLinkTTL)
+	return e.store.SignedURL(archiveKey, expiresAt), expiresAt
+}
+
+// Run builds queued exports and deletes expired archives until ctx is done
+func (e *Exporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(exportPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.deleteExpired(ctx)
+		case <-e.wake:
+		}
+		e.processQueue(ctx)
+	}
+}
+
+// processQueue builds exports until none are left to claim
+func (e *Exporter) processQueue(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, err := e.queries.ClaimDataExport(ctx, pgtype.Timestamptz{Time: time.Now().Add(-exportStaleAfter), Valid: true})
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				log.Printf("failed to claim data export: %v", err)
+			}
+			return
+		}
+		e.export(ctx, job)
+	}
+}
+
+// export builds and stores one archive, recording failures on the job
+func (e *Exporter) export(ctx context.Context, job *repository.DataExport) {
+	archive, err := e.build(ctx, job)
+	if err == nil {
+		key := fmt.Sprintf("%s/%s.zip", job.UserID.String(), job.ID.String())
+		if err = e.store.Put(ctx, key, archive); err == nil {
+			err = e.queries.CompleteDataExport(ctx, &repository.CompleteDataExportParams{
+				ID:         job.ID,
+				ArchiveKey: &key,
+				ExpiresAt:  pgtype.Timestamptz{Time: time.Now().Add(exportRetention), Valid: true},
+			})
+		}
+	}
+	if err == nil {
+		return
+	}
+
+	log.Printf("data export %s failed: %v", job.ID.String(), err)
+	message := "the export could not be built; please try again"
+	if err := e.queries.FailDataExport(ctx, &repository.FailDataExportParams{
+		ID:    job.ID,
+		Error: &message,
+	}); err != nil {
+		log.Printf("failed to record data export failure %s: %v", job.ID.String(), err)
+	}
+}
+
+// build writes every section of a user's data into a zip archive
+func (e *Exporter) build(ctx context.Context, job *repository.DataExport) ([]byte, error) {
+	sections := []struct {
+		name  string
+		build func(context.Context, pgtype.UUID) (any, error)
+	}{
+		{"profile.json", e.profile},
+		{"providers.json", e.providers},
+		{"sessions.json", e.sessionList},
+		{"pins.geojson", e.pins},
+		{"comments.json", e.comments},
+		{"follows.json", e.follows},
+		{"blocks.json", e.blocks},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for i, section := range sections {
+		data, err := section.build(ctx, job.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", section.name, err)
+		}
+		encoded, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", section.name, err)
+		}
+
+		file, err := archive.Create(section.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", section.name, err)
+		}
+		if _, err := file.Write(encoded); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", section.name, err)
+		}
+
+		// Progress is informational, so a failed update doesn't stop the export
+		progress := int16((i + 1) * 100 / (len(sections) + 1))
+		if err := e.queries.UpdateDataExportProgress(ctx, &repository.UpdateDataExportProgressParams{
+			ID:       job.ID,
+			Progress: progress,
+		}); err != nil {
+			log.Printf("failed to update data export progress %s: %v", job.ID.String(), err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// deleteExpired removes archives past their retention
+func (e *Exporter) deleteExpired(ctx context.Context) {
+	jobs, err := e.queries.ListExpiredDataExports(ctx, &repository.ListExpiredDataExportsParams{
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Limit:     exportCleanupBatch,
+	})
+	if err != nil {
+		log.Printf("failed to list expired data exports: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if job.ArchiveKey == nil {
+			continue
+		}
+		if err := e.store.Delete(ctx, *job.ArchiveKey); err != nil {
+			log.Printf("failed to delete data export %s: %v", job.ID.String(), err)
+			continue
+		}
+		if err := e.queries.ExpireDataExport(ctx, job.ID); err != nil {
+			log.Printf("failed to expire data export %s: %v", job.ID.String(), err)
+		}
+	}
+}
+
+// exportedProfile is the user's own profile, including private fields
+type exportedProfile struct {
+	ID                  string          `json:"id"`
+	Username            string          `json:"username"`
+	Email               string          `json:"email"`
+	DisplayName         *string         `json:"display_name"`
+	AvatarURL           *string         `json:"avatar_url"`
+	Status              string          `json:"status"`
+	Metadata            json.RawMessage `json:"metadata"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           *time.Time      `json:"updated_at"`
+	EmailVerifiedAt     *time.Time      `json:"email_verified_at"`
+	UsernameChangedAt   *time.Time      `json:"username_changed_at"`
+	DeletionScheduledAt *time.Time      `json:"deletion_scheduled_at"`
+}
+
+// profile exports the user row
+func (e *Exporter) profile(ctx context.Context, userID pgtype.UUID) (any, error) {
+	user, err := e.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &exportedProfile{
+		ID:                  user.ID.String(),
+		Username:            user.Username,
+		Email:               user.Email,
+		DisplayName:         user.DisplayName,
+		AvatarURL:           user.AvatarUrl,
+		Status:              user.Status,
+		Metadata:            rawJSON(user.Metadata),
+		CreatedAt:           user.CreatedAt.Time,
+		UpdatedAt:           timePtr(user.UpdatedAt),
+		EmailVerifiedAt:     timePtr(user.EmailVerifiedAt),
+		UsernameChangedAt:   timePtr(user.UsernameChangedAt),
+		DeletionScheduledAt: timePtr(user.DeletionScheduledAt),
+	}, nil
+}
+
+// providers exports the user's linked sign-in identities
+func (e *Exporter) providers(ctx context.Context, userID pgtype.UUID) (any, error) {
+	rows, err := e.queries.ListUserAuthProviders(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	type provider struct {
+		Provider       string          `json:"provider"`
+		ProviderUserID string          `json:"provider_user_id"`
+		Metadata       json.RawMessage `json:"metadata"`
+		CreatedAt      time.Time       `json:"created_at"`
+	}
+	providers := make([]provider, 0, len(rows))
+	for _, row := range rows {
+		providers = append(providers, provider{
+			Provider:       row.Provider,
+			ProviderUserID: row.ProviderUserID,
+			Metadata:       rawJSON(row.ProviderMetadata),
+			CreatedAt:      row.CreatedAt.Time,
+		})
+	}
+	return providers, nil
+}
+
+// sessionList exports the user's sessions, without their IDs since those are credentials
+func (e *Exporter) sessionList(ctx context.Context, userID pgtype.UUID) (any, error) {
+	sessions, err := e.sessions.ListForUser(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	type session struct {
+		IsAnonymous bool       `json:"is_anonymous"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+		ExpiresAt   *time.Time `json:"expires_at"`
+	}
+	exported := make([]session, 0, len(sessions))
+	for _, s := range sessions {
+		exported = append(exported, session{
+			IsAnonymous: s.IsAnonymous,
+			CreatedAt:   s.CreatedAt,
+			UpdatedAt:   s.UpdatedAt,
+			ExpiresAt:   s.ExpiresAt,
+		})
+	}
+	return exported, nil
+}
+
+// geoJSONFeature is a GeoJSON feature; geometry is null for pins without a location
+type geoJSONFeature struct {
+	Type       string         `json:"type"`
+	Geometry   any            `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+// pins exports the user's pins as a GeoJSON FeatureCollection
+func (e *Exporter) pins(ctx context.Context, userID pgtype.UUID) (any, error) {
+	rows, err := e.queries.ListUserPinsForExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]geoJSONFeature, 0, len(rows))
+	for _, row := range rows {
+		feature := geoJSONFeature{
+			Type: "Feature",
+			Properties: map[string]any{
+				"id":         row.ID.String(),
+				"content":    row.Content,
+				"visibility": row.Visibility,
+				"metadata":   rawJSON(row.Metadata),
+				"geohash":    row.Geohash,
+				"created_at": row.CreatedAt.Time,
+			},
+		}
+		lng, okLng := row.Longitude.(float64)
+		lat, okLat := row.Latitude.(float64)
+		if okLng && okLat {
+			feature.Geometry = map[string]any{
+				"type":        "Point",
+				"coordinates": []float64{lng, lat},
+			}
+		}
+		features = append(features, feature)
+	}
+
+	return map[string]any{
+		"type":     "FeatureCollection",
+		"features": features,
+	}, nil
+}
+
+// comments exports the user's comments
+func (e *Exporter) comments(ctx context.Context, userID pgtype.UUID) (any, error) {
+	rows, err := e.queries.ListUserCommentsForExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	type comment struct {
+		ID        string          `json:"id"`
+		PinID     string          `json:"pin_id"`
+		Content   string          `json:"content"`
+		Metadata  json.RawMessage `json:"metadata"`
+		CreatedAt time.Time       `json:"created_at"`
+	}
+	comments := make([]comment, 0, len(rows))
+	for _, row := range rows {
+		comments = append(comments, comment{
+			ID:        row.ID.String(),
+			PinID:     row.ParentID.String(),
+			Content:   row.Content,
+			Metadata:  rawJSON(row.Metadata),
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	return comments, nil
+}
+
+// follows exports who the user follows and who follows them
+func (e *Exporter) follows(ctx context.Context, userID pgtype.UUID) (any, error) {
+	rows, err := e.queries.ListFollowsForExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	type follow struct {
+		UserID    string    `json:"user_id"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	following := make([]follow, 0)
+	followers := make([]follow, 0)
+	for _, row := range rows {
+		if row.FollowerID == userID {
+			following = append(following, follow{UserID: row.FolloweeID.String(), CreatedAt: row.CreatedAt.Time})
+		} else {
+			followers = append(followers, follow{UserID: row.FollowerID.String(), CreatedAt: row.CreatedAt.Time})
+		}
+	}
+	return map[string]any{
+		"following": following,
+		"followers": followers,
+	}, nil
+}
+
+// blocks exports the users the user blocked or muted
+func (e *Exporter) blocks(ctx context.Context, userID pgtype.UUID) (any, error) {
+	rows, err := e.queries.ListBlocksForExport(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	type block struct {
+		UserID    string    `json:"user_id"`
+		Kind      string    `json:"kind"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	blocks := make([]block, 0, len(rows))
+	for _, row := range rows {
+		blocks = append(blocks, block{
+			UserID:    row.BlockedID.String(),
+			Kind:      row.Kind,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	return blocks, nil
+}
+
+// timePtr returns a nullable timestamp as a pointer
+func timePtr(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	return &ts.Time
+}
+
+// rawJSON embeds stored JSON as is
+func rawJSON(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.RawMessage(data)
+}
@@ -0,0 +1,204 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// Follow makes the caller follow another user. Following someone twice is a no-op.
+func (s *Service) Follow(
+	ctx context.Context,
+	req *connect.Request[servicev1.FollowRequest],
+) (*connect.Response[servicev1.FollowResponse], error) {
+	followerID, err := requireFollower(ctx)
+	if err != nil {
+		return nil, err
+	}
+	followeeID, err := parseUserID(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if followeeID == followerID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("you can't follow yourself"))
+	}
+
+	followee, err := s.queries.GetUserByID(ctx, followeeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get user: %w", err))
+	}
+	if followee.Status == "disabled" {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+	// Anonymous accounts are temporary, so there is nothing lasting to follow
+	if auth.IsAnonymousEmail(followee.Email) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("anonymous users can't be followed"))
+	}
+
+	blocked, err := s.queries.IsBlockedEitherWay(ctx, &repository.IsBlockedEitherWayParams{
+		BlockerID: followerID,
+		BlockedID: followeeID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check blocks: %w", err))
+	}
+	if blocked {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("you can't follow this user"))
+	}
+
+	if _, err := s.queries.FollowUser(ctx, &repository.FollowUserParams{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to follow user: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.FollowResponse{
+		Success: true,
+	}), nil
+}
+
+// Unfollow makes the caller stop following a user
+func (s *Service) Unfollow(
+	ctx context.Context,
+	req *connect.Request[servicev1.UnfollowRequest],
+) (*connect.Response[servicev1.UnfollowResponse], error) {
+	followerID, err := requireFollower(ctx)
+	if err != nil {
+		return nil, err
+	}
+	followeeID, err := parseUserID(req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.queries.UnfollowUser(ctx, &repository.UnfollowUserParams{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to unfollow user: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.UnfollowResponse{
+		Success: true,
+	}), nil
+}
+
+// ListFollowers lists the users following a user (public read)
+func (s *Service) ListFollowers(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListFollowersRequest],
+) (*connect.Response[servicev1.ListFollowersResponse], error) {
+	user, err := s.publicProfileUser(ctx, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	beforeCreatedAt, beforeID, err := protoconv.DecodeCursor(req.Msg.GetCursor())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	limit := protoconv.PageSize(req.Msg.Limit)
+
+	// One extra row tells whether there is another page
+	rows, err := s.queries.ListFollowers(ctx, &repository.ListFollowersParams{
+		UserID:          user.ID,
+		BeforeCreatedAt: beforeCreatedAt,
+		BeforeID:        beforeID,
+		PageLimit:       limit + 1,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list followers: %w", err))
+	}
+
+	resp := &servicev1.ListFollowersResponse{
+		Users: make([]*entitiesv1.User, 0, min(len(rows), int(limit))),
+	}
+	for i, row := range rows {
+		if i == int(limit) {
+			cursor := protoconv.EncodeCursor(rows[i-1].FollowedAt, rows[i-1].User.ID)
+			resp.NextCursor = &cursor
+			break
+		}
+		resp.Users = append(resp.Users, protoconv.PublicUserToProto(&row.User))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// ListFollowing lists the users a user follows (public read)
+func (s *Service) ListFollowing(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListFollowingRequest],
+) (*connect.Response[servicev1.ListFollowingResponse], error) {
+	user, err := s.publicProfileUser(ctx, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	beforeCreatedAt, beforeID, err := protoconv.DecodeCursor(req.Msg.GetCursor())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	limit := protoconv.PageSize(req.Msg.Limit)
+
+	// One extra row tells whether there is another page
+	rows, err := s.queries.ListFollowing(ctx, &repository.ListFollowingParams{
+		UserID:          user.ID,
+		BeforeCreatedAt: beforeCreatedAt,
+		BeforeID:        beforeID,
+		PageLimit:       limit + 1,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list followed users: %w", err))
+	}
+
+	resp := &servicev1.ListFollowingResponse{
+		Users: make([]*entitiesv1.User, 0, min(len(rows), int(limit))),
+	}
+	for i, row := range rows {
+		if i == int(limit) {
+			cursor := protoconv.EncodeCursor(rows[i-1].FollowedAt, rows[i-1].User.ID)
+			resp.NextCursor = &cursor
+			break
+		}
+		resp.Users = append(resp.Users, protoconv.PublicUserToProto(&row.User))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// requireFollower returns the caller's user ID. Anonymous sessions can't follow
+// anyone, since their follows would be lost when they sign in.
+func requireFollower(ctx context.Context) (pgtype.UUID, error) {
+	claims, userID, err := requireProfileOwner(ctx)
+	if err != nil {
+		return userID, err
+	}
+	if claims.IsAnonymous {
+		return userID, connect.NewError(connect.CodePermissionDenied, errors.New("sign in to follow people"))
+	}
+	return userID, nil
+}
+
+// parseUserID parses a user ID from a request
+func parseUserID(id string) (pgtype.UUID, error) {
+	var userID pgtype.UUID
+	if id == "" {
+		return userID, connect.NewError(connect.CodeInvalidArgument, errors.New("user_id is required"))
+	}
+	if err := userID.Scan(id); err != nil {
+		return userID, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID: %w", err))
+	}
+	return userID, nil
+}
@@ -0,0 +1,293 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+	"github.com/radjathaher/alunalun/api/internal/utils/media"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Profile limits
+const (
+	maxDisplayNameLength   = 50
+	avatarSize             = 256
+	usernameChangeCooldown = 30 * 24 * time.Hour
+)
+
+// usernamePattern matches valid usernames
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]{3,30}$`)
+
+// UpdateProfile changes the fields of the caller's profile named in the update mask
+func (s *Service) UpdateProfile(
+	ctx context.Context,
+	req *connect.Request[servicev1.UpdateProfileRequest],
+) (*connect.Response[servicev1.UpdateProfileResponse], error) {
+	claims, userID, err := requireProfileOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := req.Msg.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("update_mask is required"))
+	}
+
+	// Validate every field before changing any
+	var displayName *string
+	var changeUsername, changeDisplayName, clearAvatar bool
+	for _, path := range paths {
+		switch path {
+		case "display_name":
+			normalized, err := normalizeDisplayName(req.Msg.DisplayName)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInvalidArgument, err)
+			}
+			if normalized != "" {
+				displayName = &normalized
+			}
+			changeDisplayName = true
+		case "username":
+			if claims.IsAnonymous {
+				return nil, connect.NewError(connect.CodePermissionDenied, errors.New("sign in to change your username"))
+			}
+			if !usernamePattern.MatchString(req.Msg.Username) {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("username must be 3-30 letters, digits or underscores"))
+			}
+			changeUsername = true
+		case "avatar_url":
+			if req.Msg.AvatarUrl != "" {
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("avatar_url can only be cleared; use UploadAvatar to set an avatar"))
+			}
+			clearAvatar = true
+		default:
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported update_mask path %q", path))
+		}
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	// The username goes first since it is the change most likely to be refused
+	var accessToken string
+	if changeUsername && req.Msg.Username != user.Username {
+		user, err = s.changeUsername(ctx, user, req.Msg.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		// Existing tokens carry the old username. The new token keeps the old
+		// one's session, sign-in time and expiry, so renaming can't extend it.
+		renewed := *claims
+		renewed.Username = user.Username
+		accessToken, err = s.tokenManager.GenerateToken(&renewed, 0)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+		}
+	}
+
+	if changeDisplayName {
+		user, err = s.queries.UpdateUserDisplayName(ctx, &repository.UpdateUserDisplayNameParams{
+			ID:          userID,
+			DisplayName: displayName,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update display name: %w", err))
+		}
+	}
+
+	if clearAvatar && user.AvatarUrl != nil {
+		oldURL := *user.AvatarUrl
+		user, err = s.queries.UpdateUserAvatar(ctx, &repository.UpdateUserAvatarParams{
+			ID:        userID,
+			AvatarUrl: nil,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to remove avatar: %w", err))
+		}
+		s.deleteAvatar(ctx, oldURL)
+	}
+
+	return connect.NewResponse(&servicev1.UpdateProfileResponse{
+		User:        protoconv.UserToProto(user),
+		AccessToken: accessToken,
+	}), nil
+}
+
+// UploadAvatar stores a new avatar for the caller. The image is center-cropped,
+// resized and re-encoded, so clients can send photos straight from the camera.
+func (s *Service) UploadAvatar(
+	ctx context.Context,
+	req *connect.Request[servicev1.UploadAvatarRequest],
+) (*connect.Response[servicev1.UploadAvatarResponse], error) {
+	if s.media == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("avatar uploads are not enabled"))
+	}
+
+	_, userID, err := requireProfileOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	avatar, err := media.SquareJPEG(req.Msg.Image, avatarSize)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	// A fresh key per upload lets the old URL stay cached forever
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to name avatar: %w", err))
+	}
+	key := fmt.Sprintf("avatars/%s-%s.jpg", userID.String(), hex.EncodeToString(suffix))
+
+	avatarURL, err := s.media.Put(ctx, key, avatar, "image/jpeg")
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to store avatar: %w", err))
+	}
+
+	updated, err := s.queries.UpdateUserAvatar(ctx, &repository.UpdateUserAvatarParams{
+		ID:        userID,
+		AvatarUrl: &avatarURL,
+	})
+	if err != nil {
+		s.deleteAvatar(ctx, avatarURL)
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update avatar: %w", err))
+	}
+
+	if user.AvatarUrl != nil {
+		s.deleteAvatar(ctx, *user.AvatarUrl)
+	}
+
+	return connect.NewResponse(&servicev1.UploadAvatarResponse{
+		User: protoconv.UserToProto(updated),
+	}), nil
+}
+
+// changeUsername renames a user unless they changed their username within the cooldown
+func (s *Service) changeUsername(ctx context.Context, user *repository.User, username string) (*repository.User, error) {
+	if user.UsernameChangedAt.Valid {
+		next := user.UsernameChangedAt.Time.Add(usernameChangeCooldown)
+		if time.Now().Before(next) {
+			return nil, connect.NewError(
+				connect.CodeFailedPrecondition,
+				fmt.Errorf("username can be changed again after %s", next.UTC().Format(time.RFC3339)),
+			)
+		}
+	}
+
+	if _, err := s.queries.GetUserByUsername(ctx, username); err == nil {
+		return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("username is already taken"))
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check username: %w", err))
+	}
+
+	// The cooldown is checked again in the update, in case of concurrent changes
+	updated, err := s.queries.ChangeUsername(ctx, &repository.ChangeUsernameParams{
+		ID:                user.ID,
+		Username:          username,
+		UsernameChangedAt: pgtype.Timestamptz{Time: time.Now().Add(-usernameChangeCooldown), Valid: true},
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, connect.NewError(connect.CodeAlreadyExists, errors.New("username is already taken"))
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("username was changed too recently"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to change username: %w", err))
+	}
+	return updated, nil
+}
+
+// deleteAvatar removes a replaced avatar from the media store. Failures only leave an orphaned file.
+func (s *Service) deleteAvatar(ctx context.Context, avatarURL string) {
+	if s.media == nil {
+		return
+	}
+	key, ok := s.media.KeyFromURL(avatarURL)
+	if !ok {
+		return // Not ours, e.g. an OAuth profile picture
+	}
+	if err := s.media.Delete(ctx, key); err != nil {
+		log.Printf("failed to delete avatar %s: %v", key, err)
+	}
+}
+
+// requireProfileOwner returns the caller's claims and user ID
+func requireProfileOwner(ctx context.Context) (*auth.Claims, pgtype.UUID, error) {
+	var userID pgtype.UUID
+
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
+		return nil, userID, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required - please sign in"))
+	}
+	if err := userID.Scan(claims.UserID); err != nil {
+		return nil, userID, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID: %w", err))
+	}
+	return claims, userID, nil
+}
+
+// normalizeDisplayName applies NFC normalization, trims and collapses
+// whitespace, and rejects control and invisible formatting characters.
+// An empty result clears the display name.
+func normalizeDisplayName(name string) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", errors.New("display name must be valid UTF-8")
+	}
+
+	var b strings.Builder
+	pendingSpace := false
+	for _, r := range norm.NFC.String(name) {
+		switch {
+		case unicode.IsSpace(r):
+			pendingSpace = b.Len() > 0
+			continue
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r) && r != '\u200d': // Keep ZWJ for emoji sequences
+			return "", errors.New("display name contains invalid characters")
+		}
+		if pendingSpace {
+			b.WriteByte(' ')
+			pendingSpace = false
+		}
+		b.WriteRune(r)
+	}
+
+	normalized := b.String()
+	if utf8.RuneCountInString(normalized) > maxDisplayNameLength {
+		return "", fmt.Errorf("display name must be at most %d characters", maxDisplayNameLength)
+	}
+	return normalized, nil
+}
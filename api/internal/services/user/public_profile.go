@@ -0,0 +1,166 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
+	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
+	"github.com/radjathaher/alunalun/api/internal/protoconv"
+	"github.com/radjathaher/alunalun/api/internal/repository"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+)
+
+// ListUserPins lists a user's pins for their profile page (public read).
+// Followers-only pins are included for the user and their followers.
+func (s *Service) ListUserPins(
+	ctx context.Context,
+	req *connect.Request[servicev1.ListUserPinsRequest],
+) (*connect.Response[servicev1.ListUserPinsResponse], error) {
+	user, err := s.publicProfileUser(ctx, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+	beforeCreatedAt, beforeID, err := protoconv.DecodeCursor(req.Msg.GetCursor())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	limit := protoconv.PageSize(req.Msg.Limit)
+
+	// One extra row tells whether there is another page
+	rows, err := s.queries.ListPostsByUser(ctx, &repository.ListPostsByUserParams{
+		UserID:          user.ID,
+		Type:            "pin",
+		ViewerID:        viewerFromContext(ctx),
+		BeforeCreatedAt: beforeCreatedAt,
+		BeforeID:        beforeID,
+		PageLimit:       limit + 1,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list pins: %w", err))
+	}
+
+	resp := &servicev1.ListUserPinsResponse{
+		Pins: make([]*entitiesv1.Pin, 0, min(len(rows), int(limit))),
+	}
+	for i, row := range rows {
+		if i == int(limit) {
+			cursor := protoconv.EncodeCursor(rows[i-1].CreatedAt, rows[i-1].ID)
+			resp.NextCursor = &cursor
+			break
+		}
+
+		commentCount, _ := s.queries.CountCommentsByParent(ctx, row.ID)
+		pin := protoconv.PinFromRowToProto(
+			row.ID.String(),
+			row.UserID.String(),
+			row.Content,
+			row.CreatedAt.Time.Unix(),
+			row.CreatedAt.Time.Unix(),
+			row.Longitude,
+			row.Latitude,
+			row.Geohash,
+			user,
+			int32(commentCount),
+		)
+		pin.Visibility = protoconv.PinVisibilityToProto(row.Visibility)
+		resp.Pins = append(resp.Pins, pin)
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// GetUserStats returns the counts shown on a user's profile page (public read)
+func (s *Service) GetUserStats(
+	ctx context.Context,
+	req *connect.Request[servicev1.GetUserStatsRequest],
+) (*connect.Response[servicev1.GetUserStatsResponse], error) {
+	user, err := s.publicProfileUser(ctx, req.Msg.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pin count matches what ListUserPins shows the caller
+	viewerID := viewerFromContext(ctx)
+	pinCount, err := s.queries.CountPostsByUser(ctx, &repository.CountPostsByUserParams{
+		UserID:   user.ID,
+		Type:     "pin",
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count pins: %w", err))
+	}
+	commentCount, err := s.queries.CountPostsByUser(ctx, &repository.CountPostsByUserParams{
+		UserID:   user.ID,
+		Type:     "comment",
+		ViewerID: viewerID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count comments: %w", err))
+	}
+	followerCount, err := s.queries.CountFollowers(ctx, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count followers: %w", err))
+	}
+	followingCount, err := s.queries.CountFollowing(ctx, user.ID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to count followed users: %w", err))
+	}
+
+	return connect.NewResponse(&servicev1.GetUserStatsResponse{
+		Stats: &servicev1.UserStats{
+			PinCount:       pinCount,
+			CommentCount:   commentCount,
+			FollowerCount:  followerCount,
+			FollowingCount: followingCount,
+			MemberSince:    user.CreatedAt.Time.Unix(),
+		},
+	}), nil
+}
+
+// publicProfileUser loads the user whose profile is being viewed. Disabled
+// users, and users who blocked the caller, look like missing ones.
+func (s *Service) publicProfileUser(ctx context.Context, id string) (*repository.User, error) {
+	userID, err := parseUserID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get user: %w", err))
+	}
+	if user.Status == "disabled" {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+	}
+
+	if viewerID := viewerFromContext(ctx); viewerID.Valid {
+		blocked, err := s.queries.IsBlocked(ctx, &repository.IsBlockedParams{
+			BlockerID: user.ID,
+			BlockedID: viewerID,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check blocks: %w", err))
+		}
+		if blocked {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
+		}
+	}
+	return user, nil
+}
+
+// viewerFromContext returns the caller's user ID, or NULL for callers without a token
+func viewerFromContext(ctx context.Context) pgtype.UUID {
+	var viewerID pgtype.UUID
+	if claims, ok := auth.ClaimsFromContext(ctx); ok && claims != nil {
+		_ = viewerID.Scan(claims.UserID) // Stays NULL if invalid
+	}
+	return viewerID
+}
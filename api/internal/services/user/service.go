@@ -4,24 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 
 	"connectrpc.com/connect"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	entitiesv1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/entities"
 	servicev1 "github.com/radjathaher/alunalun/api/internal/protocgen/v1/service"
 	"github.com/radjathaher/alunalun/api/internal/protocgen/v1/service/servicev1connect"
 	"github.com/radjathaher/alunalun/api/internal/protoconv"
 	"github.com/radjathaher/alunalun/api/internal/repository"
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+	"github.com/radjathaher/alunalun/api/internal/utils/media"
 )
 
 // Service implements the UserService
 type Service struct {
 	servicev1connect.UnimplementedUserServiceHandler
-	queries      *repository.Queries
-	tokenManager *auth.TokenManager
+	queries       *repository.Queries
+	tokenManager  *auth.TokenManager
+	emailProvider *auth.EmailPasswordProvider  // nil disables password registration
+	media         media.Store                  // nil disables avatar uploads
+	deletion      *auth.AccountDeletionManager // nil disables account deletion
+	exporter      *Exporter                    // nil disables data exports
+	issuer        TokenIssuer                  // nil leaves new password users to sign in
+}
+
+// TokenIssuer signs a user in with a new session, as AuthService does after
+// authenticating them
+type TokenIssuer interface {
+	IssueToken(ctx context.Context, user *auth.User, provider string) (string, error)
 }
 
 // NewService creates a new user service
@@ -32,14 +42,39 @@ func NewService(queries *repository.Queries, tokenManager *auth.TokenManager) *S
 	}
 }
 
+// SetEmailPasswordProvider enables registering users with a password
+func (s *Service) SetEmailPasswordProvider(provider *auth.EmailPasswordProvider) {
+	s.emailProvider = provider
+}
+
+// SetTokenIssuer signs users in right after they register with a password
+func (s *Service) SetTokenIssuer(issuer TokenIssuer) {
+	s.issuer = issuer
+}
+
+// SetMediaStore enables avatar uploads
+func (s *Service) SetMediaStore(store media.Store) {
+	s.media = store
+}
+
+// SetAccountDeletionManager enables account deletion
+func (s *Service) SetAccountDeletionManager(manager *auth.AccountDeletionManager) {
+	s.deletion = manager
+}
+
+// SetExporter enables data exports
+func (s *Service) SetExporter(exporter *Exporter) {
+	s.exporter = exporter
+}
+
 // GetCurrentUser returns the current authenticated user or error if not authenticated
 func (s *Service) GetCurrentUser(
 	ctx context.Context,
 	req *connect.Request[servicev1.GetCurrentUserRequest],
 ) (*connect.Response[servicev1.GetCurrentUserResponse], error) {
-	// Extract user from JWT context
-	claims := s.extractClaims(req.Header())
-	if claims == nil || claims.UserID == "" {
+	// Caller identity is set by the auth interceptor
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims == nil || claims.UserID == "" {
 		// No authentication - return error prompting to sign in
 		return nil, connect.NewError(
 			connect.CodeUnauthenticated,
@@ -77,15 +112,12 @@ func (s *Service) GetCurrentUser(
 	}), nil
 }
 
-// RegisterUser converts an anonymous user to registered or creates new registered user
-// This is typically called after OAuth authentication succeeds
+// RegisterUser creates an account with an email and password. Other providers
+// create accounts by signing in with AuthService.Authenticate or OAuth.
 func (s *Service) RegisterUser(
 	ctx context.Context,
 	req *connect.Request[servicev1.RegisterUserRequest],
 ) (*connect.Response[servicev1.RegisterUserResponse], error) {
-	// This would typically be called internally after OAuth flow
-	// For MVP, registration happens through OAuth providers only
-	
 	// Validate request
 	if req.Msg.Email == "" {
 		return nil, connect.NewError(
@@ -99,67 +131,64 @@ func (s *Service) RegisterUser(
 			errors.New("username is required"),
 		)
 	}
-
-	// Check if email already exists
-	existingUser, err := s.queries.GetUserByEmail(ctx, req.Msg.Email)
-	if err == nil && existingUser != nil {
+	if req.Msg.Password == "" {
 		return nil, connect.NewError(
-			connect.CodeAlreadyExists,
-			errors.New("email already registered"),
+			connect.CodeInvalidArgument,
+			errors.New("password is required; other providers register by signing in"),
 		)
 	}
 
-	// Check if username is taken (use display_name)
-	existingUser, err = s.queries.GetUserByDisplayName(ctx, &req.Msg.Username)
-	if err == nil && existingUser != nil {
-		return nil, connect.NewError(
-			connect.CodeAlreadyExists,
-			errors.New("username already taken"),
-		)
-	}
+	return s.registerWithPassword(ctx, req.Msg)
+}
 
-	// Create new user
-	newUser := &entitiesv1.User{
-		Email:    &req.Msg.Email,
-		Username: req.Msg.Username,
-		Metadata: map[string]string{
-			"registered_via": "oauth",
-		},
+// registerWithPassword creates a user with an email and password. A token is
+// only returned when the email does not need verification first; it is issued
+// with a session like any other sign-in.
+func (s *Service) registerWithPassword(
+	ctx context.Context,
+	msg *servicev1.RegisterUserRequest,
+) (*connect.Response[servicev1.RegisterUserResponse], error) {
+	if s.emailProvider == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("password registration is not enabled"))
 	}
 
-	// Convert to repository params
-	createParams, err := protoconv.ProtoToCreateUserParams(newUser)
+	user, err := s.emailProvider.RegisterUser(ctx, msg.Email, msg.Password, msg.Username)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to prepare user: %w", err))
+		var authErr *auth.AuthError
+		if errors.As(err, &authErr) {
+			switch authErr.Code {
+			case auth.ErrInvalidCredentials, auth.ErrWeakPassword:
+				return nil, connect.NewError(connect.CodeInvalidArgument, errors.New(authErr.Message))
+			case auth.ErrAlreadyExists:
+				return nil, connect.NewError(connect.CodeAlreadyExists, errors.New(authErr.Message))
+			}
+		}
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to register user: %w", err))
 	}
 
-	// Create user in database
-	createdUser, err := s.queries.CreateUser(ctx, createParams)
+	// Reload to return the stored profile
+	var userID pgtype.UUID
+	if err := userID.Scan(user.ID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("invalid user ID: %w", err))
+	}
+	createdUser, err := s.queries.GetUserByID(ctx, userID)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to create user: %w", err))
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get user: %w", err))
 	}
 
-	// Convert to proto
-	protoUser := protoconv.UserToProto(createdUser)
-
-	// Generate JWT token for the new user
-	claims := &auth.Claims{
-		UserID:      createdUser.ID.String(),
-		Username:    *createdUser.DisplayName,
-		Email:       createdUser.Email,
-		Provider:    "oauth",
-		IsAnonymous: false,
+	resp := &servicev1.RegisterUserResponse{
+		User: protoconv.UserToProto(createdUser),
 	}
 
-	token, err := s.tokenManager.GenerateToken(claims, 3600) // 1 hour
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+	// Unverified users sign in with AuthService.Authenticate after verifying
+	if user.EmailVerified && s.issuer != nil {
+		resp.AccessToken, err = s.issuer.IssueToken(ctx, user, s.emailProvider.Name())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to generate token: %w", err))
+		}
 	}
 
-	return connect.NewResponse(&servicev1.RegisterUserResponse{
-		User:        protoUser,
-		AccessToken: token,
-	}), nil
+	return connect.NewResponse(resp), nil
 }
 
 // GetUser returns a user by ID (public information only)
@@ -167,67 +196,19 @@ func (s *Service) GetUser(
 	ctx context.Context,
 	req *connect.Request[servicev1.GetUserRequest],
 ) (*connect.Response[servicev1.GetUserResponse], error) {
-	if req.Msg.UserId == "" {
-		return nil, connect.NewError(
-			connect.CodeInvalidArgument,
-			errors.New("user_id is required"),
-		)
-	}
-
-	// Parse UUID
-	var userID pgtype.UUID
-	err := userID.Scan(req.Msg.UserId)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid user ID: %w", err))
-	}
-
-	// Get user from database
-	user, err := s.queries.GetUserByID(ctx, userID)
+	user, err := s.publicProfileUser(ctx, req.Msg.UserId)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found"))
-		}
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get user: %w", err))
+		return nil, err
 	}
 
-	// Convert to proto (public info only)
-	protoUser := protoconv.UserToProto(user)
-	
-	// Remove sensitive metadata for public view
-	publicUser := &entitiesv1.User{
-		Id:        protoUser.Id,
-		Username:  protoUser.Username,
-		CreatedAt: protoUser.CreatedAt,
-		// Don't expose email or full metadata publicly
-	}
+	// Convert to proto (public info only; no email, status or metadata)
+	publicUser := protoconv.PublicUserToProto(user)
 
 	return connect.NewResponse(&servicev1.GetUserResponse{
 		User: publicUser,
 	}), nil
 }
 
-// extractClaims extracts JWT claims from request headers
-func (s *Service) extractClaims(headers http.Header) *auth.Claims {
-	authHeader := headers.Get("Authorization")
-	if authHeader == "" {
-		return nil
-	}
-
-	// Remove "Bearer " prefix
-	token := authHeader
-	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-		token = authHeader[7:]
-	}
-
-	// Validate token
-	claims, err := s.tokenManager.ValidateToken(token)
-	if err != nil {
-		return nil
-	}
-
-	return claims
-}
-
 // Future extension for anonymous support (not implemented in MVP)
 // When OAuth limits are hit, this could be enabled:
 /*
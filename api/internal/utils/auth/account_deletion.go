@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/radjathaher/alunalun/api/internal/utils/media"
+)
+
+// Content policies for the posts of deleted accounts
+const (
+	DeletionContentAnonymize = "anonymize"
+	DeletionContentDelete    = "delete"
+)
+
+// AccountDeletionStore persists scheduled account deletions
+type AccountDeletionStore interface {
+	// ScheduleAccountDeletion marks a user for erasure at a time.
+	// Returns ErrUserNotFound if the user does not exist.
+	ScheduleAccountDeletion(ctx context.Context, userID string, at time.Time) error
+
+	// CancelAccountDeletion clears a scheduled erasure. Returns whether one was scheduled.
+	CancelAccountDeletion(ctx context.Context, userID string) (bool, error)
+
+	// ListAccountsDueForDeletion lists users scheduled for erasure before a time, oldest first
+	ListAccountsDueForDeletion(ctx context.Context, before time.Time, limit int) ([]string, error)
+
+	// PostponeAccountDeletion moves a still-scheduled erasure to a later time
+	PostponeAccountDeletion(ctx context.Context, userID string, at time.Time) error
+
+	// EraseAccount deletes a user whose erasure is due, with their providers,
+	// credentials and other personal data. Their posts are removed or moved to a
+	// placeholder author depending on deleteContent. Returns the erased user, or
+	// nil if the erasure was cancelled in the meantime.
+	EraseAccount(ctx context.Context, userID string, deleteContent bool) (*User, error)
+}
+
+// AccountDeletionManager schedules account deletions and erases accounts once their grace period ends
+type AccountDeletionManager struct {
+	config         AccountDeletionConfig
+	store          AccountDeletionStore
+	sessionManager *SessionManager
+	media          media.Store // nil keeps uploaded avatars
+}
+
+// NewAccountDeletionManager creates a new account deletion manager
+func NewAccountDeletionManager(
+	config AccountDeletionConfig,
+	store AccountDeletionStore,
+	sessionManager *SessionManager,
+) (*AccountDeletionManager, error) {
+	if store == nil {
+		return nil, errors.New("account deletion store is required")
+	}
+	if sessionManager == nil {
+		return nil, errors.New("session manager is required")
+	}
+
+	// Set defaults
+	if config.RetryDelay == 0 {
+		config.RetryDelay = 6 * time.Hour
+	}
+	if config.ReauthWindow == 0 {
+		config.ReauthWindow = 10 * time.Minute
+	}
+
+	switch config.ContentPolicy {
+	case "":
+		config.ContentPolicy = DeletionContentAnonymize
+	case DeletionContentAnonymize, DeletionContentDelete:
+	default:
+		return nil, fmt.Errorf("unknown account deletion content policy %q", config.ContentPolicy)
+	}
+
+	return &AccountDeletionManager{
+		config:         config,
+		store:          store,
+		sessionManager: sessionManager,
+	}, nil
+}
+
+// SetMediaStore sets the store uploaded avatars are deleted from
+func (m *AccountDeletionManager) SetMediaStore(store media.Store) {
+	m.media = store
+}
+
+// Schedule marks a user's account for erasure after the grace period and
+// returns when it happens. The user must have signed in within ReauthWindow.
+// All their sessions are revoked and APIKeyManager refuses their API keys,
+// so only signing in again, which cancels the deletion, keeps the account.
+func (m *AccountDeletionManager) Schedule(ctx context.Context, userID string, signedInAt time.Time) (time.Time, error) {
+	if time.Since(signedInAt) > m.config.ReauthWindow {
+		return time.Time{}, &AuthError{
+			Code:    ErrReauthRequired,
+			Message: "please sign in again to delete your account",
+		}
+	}
+
+	at := time.Now().Add(m.config.GracePeriod)
+	if err := m.store.ScheduleAccountDeletion(ctx, userID, at); err != nil {
+		return time.Time{}, err
+	}
+	if err := m.sessionManager.RevokeAllForUser(ctx, userID); err != nil {
+		return time.Time{}, fmt.Errorf("failed to sign out account scheduled for deletion: %w", err)
+	}
+	return at, nil
+}
+
+// Cancel keeps an account that was scheduled for erasure. Returns whether one was scheduled.
+func (m *AccountDeletionManager) Cancel(ctx context.Context, userID string) (bool, error) {
+	cancelled, err := m.store.CancelAccountDeletion(ctx, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+	return cancelled, nil
+}
+
+// Cleanup erases accounts whose grace period has ended and revokes their
+// sessions. Accounts that fail to erase are retried after RetryDelay, so they
+// don't hold up the accounts listed after them.
+func (m *AccountDeletionManager) Cleanup(ctx context.Context) (int, error) {
+	now := time.Now()
+	userIDs, err := m.store.ListAccountsDueForDeletion(ctx, now, m.config.CleanupBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list accounts due for deletion: %w", err)
+	}
+
+	deleteContent := m.config.ContentPolicy == DeletionContentDelete
+
+	erased := 0
+	for _, userID := range userIDs {
+		user, err := m.store.EraseAccount(ctx, userID, deleteContent)
+		if err != nil {
+			log.Printf("failed to erase account %s: %v", userID, err)
+			if err := m.store.PostponeAccountDeletion(ctx, userID, now.Add(m.config.RetryDelay)); err != nil {
+				log.Printf("failed to postpone erasure of account %s: %v", userID, err)
+			}
+			continue
+		}
+		if user == nil {
+			continue // Signed in again since it was listed
+		}
+		if err := m.sessionManager.RevokeAllForUser(ctx, userID); err != nil {
+			log.Printf("failed to revoke sessions of erased account %s: %v", userID, err)
+		}
+		m.deleteAvatar(ctx, user.Picture)
+		erased++
+	}
+
+	return erased, nil
+}
+
+// Run erases due accounts every CleanupInterval until ctx is done
+func (m *AccountDeletionManager) Run(ctx context.Context) {
+	if m.config.CleanupInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			erased, err := m.Cleanup(ctx)
+			if err != nil {
+				log.Printf("account deletion cleanup failed: %v", err)
+				continue
+			}
+			if erased > 0 {
+				log.Printf("erased %d deleted accounts", erased)
+			}
+		}
+	}
+}
+
+// deleteAvatar removes an erased user's uploaded avatar. Failures only leave an orphaned file.
+func (m *AccountDeletionManager) deleteAvatar(ctx context.Context, avatarURL string) {
+	if m.media == nil || avatarURL == "" {
+		return
+	}
+	key, ok := m.media.KeyFromURL(avatarURL)
+	if !ok {
+		return // Not ours, e.g. an OAuth profile picture
+	}
+	if err := m.media.Delete(ctx, key); err != nil {
+		log.Printf("failed to delete avatar %s: %v", key, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"time"
 	
 	"github.com/google/uuid"
@@ -14,6 +15,7 @@ import (
 type AnonymousProvider struct {
 	sessionManager *SessionManager
 	userStore      UserStore
+	anonymous      *AnonymousManager // nil creates users without expiry or creation limits
 }
 
 // AnonymousRequest represents an anonymous authentication request
@@ -33,6 +35,11 @@ func NewAnonymousProvider(sessionManager *SessionManager, userStore UserStore) (
 	}, nil
 }
 
+// SetAnonymousManager makes new anonymous users tracked, and limited, from creation
+func (p *AnonymousProvider) SetAnonymousManager(anonymous *AnonymousManager) {
+	p.anonymous = anonymous
+}
+
 // Name returns the provider name
 func (p *AnonymousProvider) Name() string {
 	return "anonymous"
@@ -54,7 +61,12 @@ func (p *AnonymousProvider) Authenticate(ctx context.Context, credential string)
 		}
 	}
 	
-	if req.Username == "" {
+	return p.Create(ctx, req.Username, AnonymousClient{})
+}
+
+// Create creates an anonymous user and session for a client
+func (p *AnonymousProvider) Create(ctx context.Context, username string, client AnonymousClient) (*UserInfo, error) {
+	if username == "" {
 		return nil, &AuthError{
 			Code:    ErrInvalidCredentials,
 			Message: "username is required",
@@ -63,7 +75,7 @@ func (p *AnonymousProvider) Authenticate(ctx context.Context, credential string)
 	
 	// Check username availability if userStore is available
 	if p.userStore != nil {
-		available, err := p.userStore.CheckUsernameAvailable(ctx, req.Username)
+		available, err := p.userStore.CheckUsernameAvailable(ctx, username)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check username: %w", err)
 		}
@@ -75,22 +87,22 @@ func (p *AnonymousProvider) Authenticate(ctx context.Context, credential string)
 		}
 	}
 	
-	// Create anonymous session
-	session, err := p.sessionManager.CreateAnonymous(ctx, req.Username)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create anonymous session: %w", err)
-	}
-	
 	// Generate a proper UUID for anonymous user
 	userID := uuid.New().String()
 	now := time.Now()
 	
+	// Create anonymous session bound to the user so it can be migrated later
+	session, err := p.sessionManager.CreateAnonymous(ctx, userID, username)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create anonymous session: %w", err)
+	}
+	
 	// Create user record in database for anonymous user
 	if p.userStore != nil {
 		user := &User{
 			ID:        userID,
-			Email:     fmt.Sprintf("anonymous-%s@local.user", userID), // Placeholder email for database constraint
-			Username:  req.Username,
+			Email:     AnonymousEmail(userID), // Placeholder email for database constraint
+			Username:  username,
 			CreatedAt: now,
 			UpdatedAt: now,
 			Status:    "active",
@@ -101,8 +113,20 @@ func (p *AnonymousProvider) Authenticate(ctx context.Context, credential string)
 			},
 		}
 		
-		// Create user in database
-		if err := p.userStore.CreateUser(ctx, user); err != nil {
+		// Create user in database, with its tracking when anonymous accounts are managed
+		if p.anonymous != nil {
+			err = p.anonymous.Create(ctx, user, session.ID, client)
+		} else {
+			err = p.userStore.CreateUser(ctx, user)
+		}
+		if err != nil {
+			if revokeErr := p.sessionManager.Revoke(ctx, session.ID); revokeErr != nil {
+				log.Printf("failed to revoke session of uncreated anonymous user %s: %v", userID, revokeErr)
+			}
+			var authErr *AuthError
+			if errors.As(err, &authErr) {
+				return nil, err
+			}
 			return nil, fmt.Errorf("failed to create anonymous user in database: %w", err)
 		}
 	}
@@ -0,0 +1,317 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// AnonymousAccount tracks the lifecycle of an anonymous user
+type AnonymousAccount struct {
+	UserID       string
+	SessionID    string
+	IPHash       string
+	DeviceHash   string // empty if the client sent no device fingerprint
+	LastActiveAt time.Time
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+// AnonymousAccountStore persists anonymous account lifecycle data
+type AnonymousAccountStore interface {
+	// CreateAnonymousAccount starts tracking an anonymous user
+	CreateAnonymousAccount(ctx context.Context, account *AnonymousAccount) error
+
+	// TouchAnonymousAccount records activity and slides the expiry.
+	// Returns ErrUserNotFound if the account is no longer tracked.
+	TouchAnonymousAccount(ctx context.Context, userID string, lastActiveAt, expiresAt time.Time) error
+
+	// CountAnonymousAccountsByIP counts accounts created from an IP since a time
+	CountAnonymousAccountsByIP(ctx context.Context, ipHash string, since time.Time) (int64, error)
+
+	// CountAnonymousAccountsByDevice counts accounts created from a device since a time
+	CountAnonymousAccountsByDevice(ctx context.Context, deviceHash string, since time.Time) (int64, error)
+
+	// ListExpiredAnonymousAccounts lists accounts that expired before a time, oldest first
+	ListExpiredAnonymousAccounts(ctx context.Context, before time.Time, limit int) ([]*AnonymousAccount, error)
+}
+
+// AnonymousUserCreator creates and deletes anonymous users together with their tracking
+type AnonymousUserCreator interface {
+	// CreateAnonymousUser creates the user and its anonymous account in one
+	// transaction. Creations from the same IP or device hash wait for each other,
+	// and check runs once they hold the turn, against the transaction's store;
+	// an error from check creates nothing.
+	CreateAnonymousUser(ctx context.Context, user *User, account *AnonymousAccount, check func(ctx context.Context, store AnonymousAccountStore) error) error
+
+	// DeleteAnonymousUser deletes an anonymous user and its content in one
+	// transaction. Posts other users replied to are redacted and moved to a
+	// placeholder author instead, so their threads survive, as with
+	// DeletionContentDelete. Returns ErrUserNotFound if the user is no longer
+	// anonymous.
+	DeleteAnonymousUser(ctx context.Context, userID string) error
+}
+
+// AnonymousClient identifies where an anonymous account is created from
+type AnonymousClient struct {
+	IP                string
+	DeviceFingerprint string
+}
+
+// AnonymousManager enforces expiry, creation limits and cleanup for anonymous accounts
+type AnonymousManager struct {
+	config         AnonymousConfig
+	store          AnonymousAccountStore
+	creator        AnonymousUserCreator
+	sessionManager *SessionManager
+	tokenManager   *TokenManager
+}
+
+// NewAnonymousManager creates a new anonymous account manager
+func NewAnonymousManager(
+	config AnonymousConfig,
+	store AnonymousAccountStore,
+	creator AnonymousUserCreator,
+	sessionManager *SessionManager,
+	tokenManager *TokenManager,
+) (*AnonymousManager, error) {
+	if store == nil {
+		return nil, errors.New("anonymous account store is required")
+	}
+	if creator == nil {
+		return nil, errors.New("anonymous user creator is required")
+	}
+	if sessionManager == nil {
+		return nil, errors.New("session manager is required")
+	}
+	if tokenManager == nil {
+		return nil, errors.New("token manager is required")
+	}
+
+	// Set defaults
+	if config.RetryDelay == 0 {
+		config.RetryDelay = 6 * time.Hour
+	}
+
+	return &AnonymousManager{
+		config:         config,
+		store:          store,
+		creator:        creator,
+		sessionManager: sessionManager,
+		tokenManager:   tokenManager,
+	}, nil
+}
+
+// TTL returns the inactivity TTL for anonymous tokens (0 = never expire)
+func (m *AnonymousManager) TTL() time.Duration {
+	return m.config.TTL
+}
+
+// Create creates an anonymous user and starts tracking it and its session. The
+// user is only created if its client is under the creation limits.
+func (m *AnonymousManager) Create(ctx context.Context, user *User, sessionID string, client AnonymousClient) error {
+	now := time.Now()
+	account := &AnonymousAccount{
+		UserID:       user.ID,
+		SessionID:    sessionID,
+		LastActiveAt: now,
+		ExpiresAt:    m.expiresAt(now),
+		CreatedAt:    now,
+	}
+	if client.IP != "" {
+		account.IPHash = hashClientValue(client.IP)
+	}
+	if client.DeviceFingerprint != "" {
+		account.DeviceHash = hashClientValue(client.DeviceFingerprint)
+	}
+
+	if err := m.creator.CreateAnonymousUser(ctx, user, account, func(ctx context.Context, store AnonymousAccountStore) error {
+		return m.checkLimit(ctx, store, account)
+	}); err != nil {
+		return err
+	}
+
+	if m.config.TTL > 0 {
+		if _, err := m.sessionManager.RenewAnonymous(ctx, sessionID, m.config.TTL); err != nil {
+			return fmt.Errorf("failed to set anonymous session expiry: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkLimit rejects accounts whose IP or device created too many anonymous accounts recently
+func (m *AnonymousManager) checkLimit(ctx context.Context, store AnonymousAccountStore, account *AnonymousAccount) error {
+	since := time.Now().Add(-m.config.LimitWindow)
+
+	if m.config.MaxPerIP > 0 && account.IPHash != "" {
+		count, err := store.CountAnonymousAccountsByIP(ctx, account.IPHash, since)
+		if err != nil {
+			return fmt.Errorf("failed to count anonymous accounts: %w", err)
+		}
+		if count >= int64(m.config.MaxPerIP) {
+			return rateLimitError()
+		}
+	}
+
+	if m.config.MaxPerDevice > 0 && account.DeviceHash != "" {
+		count, err := store.CountAnonymousAccountsByDevice(ctx, account.DeviceHash, since)
+		if err != nil {
+			return fmt.Errorf("failed to count anonymous accounts: %w", err)
+		}
+		if count >= int64(m.config.MaxPerDevice) {
+			return rateLimitError()
+		}
+	}
+
+	return nil
+}
+
+// Renew slides the expiry of an active anonymous user. Once a token is past half
+// its lifetime a replacement token is returned; otherwise the token is empty.
+func (m *AnonymousManager) Renew(ctx context.Context, claims *Claims) (string, error) {
+	if m.config.TTL <= 0 || !claims.IsAnonymous {
+		return "", nil
+	}
+
+	// Renew at most once per half TTL to keep writes off the hot path
+	now := time.Now()
+	if claims.IssuedAt != nil && now.Sub(claims.IssuedAt.Time) < m.config.TTL/2 {
+		return "", nil
+	}
+
+	// Purged or promoted accounts are no longer tracked and cannot be renewed
+	expiresAt := m.expiresAt(now)
+	if err := m.store.TouchAnonymousAccount(ctx, claims.UserID, now, expiresAt); err != nil {
+		return "", err
+	}
+
+	// Sessions may be lost on restart with the in-memory store; only a present
+	// session is renewed, and a revoked or expired one ends the account's access
+	if _, err := m.sessionManager.RenewAnonymous(ctx, claims.SessionID, m.config.TTL); err != nil {
+		var authErr *AuthError
+		if !errors.As(err, &authErr) || authErr.Code != ErrSessionNotFound {
+			return "", err
+		}
+	}
+
+	renewed := &Claims{
+		UserID:      claims.UserID,
+		SessionID:   claims.SessionID,
+		Username:    claims.Username,
+		Provider:    claims.Provider,
+		IsAnonymous: true,
+		Metadata:    claims.Metadata,
+		AuthTime:    claims.SignedInAt().Unix(),
+	}
+	return m.tokenManager.GenerateToken(renewed, m.config.TTL)
+}
+
+// Cleanup purges expired anonymous users and their content. Users that still
+// hold a live session are kept and their expiry extended to match it. Users
+// that fail to purge are retried after RetryDelay, so they don't hold up the
+// accounts listed after them.
+func (m *AnonymousManager) Cleanup(ctx context.Context) (int, error) {
+	now := time.Now()
+	accounts, err := m.store.ListExpiredAnonymousAccounts(ctx, now, m.config.CleanupBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired anonymous accounts: %w", err)
+	}
+
+	purged := 0
+	for _, account := range accounts {
+		if expiresAt := m.liveSessionExpiry(ctx, account.UserID, now); !expiresAt.IsZero() {
+			if err := m.store.TouchAnonymousAccount(ctx, account.UserID, account.LastActiveAt, expiresAt); err != nil {
+				log.Printf("failed to extend anonymous account %s: %v", account.UserID, err)
+			}
+			continue
+		}
+
+		if err := m.creator.DeleteAnonymousUser(ctx, account.UserID); err != nil {
+			log.Printf("failed to purge anonymous account %s: %v", account.UserID, err)
+			if err := m.store.TouchAnonymousAccount(ctx, account.UserID, account.LastActiveAt, now.Add(m.config.RetryDelay)); err != nil {
+				log.Printf("failed to postpone purge of anonymous account %s: %v", account.UserID, err)
+			}
+			continue
+		}
+		if err := m.sessionManager.RevokeAllForUser(ctx, account.UserID); err != nil {
+			log.Printf("failed to revoke sessions of anonymous account %s: %v", account.UserID, err)
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// Run purges expired anonymous accounts every CleanupInterval until ctx is done
+func (m *AnonymousManager) Run(ctx context.Context) {
+	if m.config.TTL <= 0 || m.config.CleanupInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(m.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := m.Cleanup(ctx)
+			if err != nil {
+				log.Printf("anonymous account cleanup failed: %v", err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("purged %d expired anonymous accounts", purged)
+			}
+		}
+	}
+}
+
+// liveSessionExpiry returns the latest expiry among a user's unexpired sessions,
+// or the zero time if none is live. Sessions without expiry count as live for one TTL.
+func (m *AnonymousManager) liveSessionExpiry(ctx context.Context, userID string, now time.Time) time.Time {
+	sessions, err := m.sessionManager.store.FindByUserID(ctx, userID)
+	if err != nil {
+		return time.Time{}
+	}
+
+	var latest time.Time
+	for _, session := range sessions {
+		expiresAt := m.expiresAt(now)
+		if session.ExpiresAt != nil {
+			expiresAt = *session.ExpiresAt
+		}
+		if expiresAt.After(now) && expiresAt.After(latest) {
+			latest = expiresAt
+		}
+	}
+	return latest
+}
+
+// expiresAt returns the expiry for activity at now
+func (m *AnonymousManager) expiresAt(now time.Time) time.Time {
+	if m.config.TTL <= 0 {
+		// Never expire; keep the column meaningful for ordering
+		return now.AddDate(100, 0, 0)
+	}
+	return now.Add(m.config.TTL)
+}
+
+// hashClientValue hashes client identifiers so raw IPs and fingerprints are not stored
+func hashClientValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// rateLimitError builds the AuthError for clients over the anonymous account limit
+func rateLimitError() *AuthError {
+	return &AuthError{
+		Code:    ErrRateLimited,
+		Message: "too many anonymous accounts created from this device or network; try again later",
+	}
+}
@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// testAnonymousStore keeps anonymous accounts in memory and creates them
+// under one lock, as the database creator does per client
+type testAnonymousStore struct {
+	mu       sync.Mutex
+	accounts map[string]*AnonymousAccount
+	deleted  []string
+}
+
+func newTestAnonymousStore() *testAnonymousStore {
+	return &testAnonymousStore{accounts: make(map[string]*AnonymousAccount)}
+}
+
+func (s *testAnonymousStore) CreateAnonymousAccount(ctx context.Context, account *AnonymousAccount) error {
+	stored := *account
+	s.accounts[account.UserID] = &stored
+	return nil
+}
+
+func (s *testAnonymousStore) TouchAnonymousAccount(ctx context.Context, userID string, lastActiveAt, expiresAt time.Time) error {
+	account, ok := s.accounts[userID]
+	if !ok {
+		return &AuthError{Code: ErrUserNotFound, Message: "anonymous account not found"}
+	}
+	account.LastActiveAt = lastActiveAt
+	account.ExpiresAt = expiresAt
+	return nil
+}
+
+func (s *testAnonymousStore) CountAnonymousAccountsByIP(ctx context.Context, ipHash string, since time.Time) (int64, error) {
+	var count int64
+	for _, account := range s.accounts {
+		if account.IPHash == ipHash && account.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *testAnonymousStore) CountAnonymousAccountsByDevice(ctx context.Context, deviceHash string, since time.Time) (int64, error) {
+	var count int64
+	for _, account := range s.accounts {
+		if account.DeviceHash == deviceHash && account.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (s *testAnonymousStore) ListExpiredAnonymousAccounts(ctx context.Context, before time.Time, limit int) ([]*AnonymousAccount, error) {
+	var expired []*AnonymousAccount
+	for _, account := range s.accounts {
+		if account.ExpiresAt.Before(before) && len(expired) < limit {
+			expired = append(expired, account)
+		}
+	}
+	return expired, nil
+}
+
+func (s *testAnonymousStore) CreateAnonymousUser(ctx context.Context, user *User, account *AnonymousAccount, check func(ctx context.Context, store AnonymousAccountStore) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := check(ctx, s); err != nil {
+		return err
+	}
+	return s.CreateAnonymousAccount(ctx, account)
+}
+
+func (s *testAnonymousStore) DeleteAnonymousUser(ctx context.Context, userID string) error {
+	if _, ok := s.accounts[userID]; !ok {
+		return &AuthError{Code: ErrUserNotFound, Message: "anonymous account not found"}
+	}
+	delete(s.accounts, userID)
+	s.deleted = append(s.deleted, userID)
+	return nil
+}
+
+// anonymousTest is an anonymous manager over in-memory stores
+type anonymousTest struct {
+	manager  *AnonymousManager
+	store    *testAnonymousStore
+	sessions *SessionManager
+	tokens   *TokenManager
+	created  int
+}
+
+func newAnonymousTest(t *testing.T, config AnonymousConfig) *anonymousTest {
+	t.Helper()
+	privateKey, publicKey, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := NewTokenManager(privateKey, publicKey, "alunalun", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := newTestAnonymousStore()
+	sessions := NewSessionManager(NewInMemorySessionStore())
+	manager, err := NewAnonymousManager(config, store, store, sessions, tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &anonymousTest{manager: manager, store: store, sessions: sessions, tokens: tokens}
+}
+
+// create creates an anonymous user with a session from a client
+func (at *anonymousTest) create(t *testing.T, client AnonymousClient) (*User, *Session, error) {
+	t.Helper()
+	at.created++
+	user := &User{
+		ID:       fmt.Sprintf("00000000-0000-4000-8000-%012d", at.created),
+		Username: fmt.Sprintf("anon%d", at.created),
+	}
+	session, err := at.sessions.CreateAnonymous(context.Background(), user.ID, user.Username)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return user, session, at.manager.Create(context.Background(), user, session.ID, client)
+}
+
+// TestAnonymousCreationLimits creates accounts until each client limit is
+// reached: the device limit stops one device, the IP limit stops every
+// device behind the IP, and other IPs are unaffected.
+func TestAnonymousCreationLimits(t *testing.T) {
+	at := newAnonymousTest(t, AnonymousConfig{
+		TTL:          time.Hour,
+		MaxPerIP:     2,
+		MaxPerDevice: 1,
+		LimitWindow:  time.Hour,
+	})
+
+	if _, _, err := at.create(t, AnonymousClient{IP: "203.0.113.1", DeviceFingerprint: "device-a"}); err != nil {
+		t.Fatalf("first account: %v", err)
+	}
+	_, _, err := at.create(t, AnonymousClient{IP: "203.0.113.1", DeviceFingerprint: "device-a"})
+	requireAuthError(t, err, ErrRateLimited, "")
+
+	if _, _, err := at.create(t, AnonymousClient{IP: "203.0.113.1", DeviceFingerprint: "device-b"}); err != nil {
+		t.Fatalf("second device: %v", err)
+	}
+	_, _, err = at.create(t, AnonymousClient{IP: "203.0.113.1", DeviceFingerprint: "device-c"})
+	requireAuthError(t, err, ErrRateLimited, "")
+
+	if _, _, err := at.create(t, AnonymousClient{IP: "198.51.100.7", DeviceFingerprint: "device-c"}); err != nil {
+		t.Fatalf("other IP: %v", err)
+	}
+	if len(at.store.accounts) != 3 {
+		t.Fatalf("expected 3 accounts, got %d", len(at.store.accounts))
+	}
+	for _, account := range at.store.accounts {
+		if account.IPHash == "203.0.113.1" || account.DeviceHash == "device-a" {
+			t.Fatal("expected client identifiers to be stored hashed")
+		}
+	}
+}
+
+// TestAnonymousCreationLimitWindow only counts accounts created within LimitWindow
+func TestAnonymousCreationLimitWindow(t *testing.T) {
+	at := newAnonymousTest(t, AnonymousConfig{TTL: time.Hour, MaxPerDevice: 1, LimitWindow: time.Hour})
+	client := AnonymousClient{IP: "203.0.113.1", DeviceFingerprint: "device-a"}
+
+	user, _, err := at.create(t, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at.store.accounts[user.ID].CreatedAt = time.Now().Add(-2 * time.Hour)
+
+	if _, _, err := at.create(t, client); err != nil {
+		t.Fatalf("expected the old account to no longer count, got %v", err)
+	}
+}
+
+// TestAnonymousRenew slides the account and session expiry once a token is
+// past half its lifetime, and leaves younger tokens alone
+func TestAnonymousRenew(t *testing.T) {
+	ctx := context.Background()
+	at := newAnonymousTest(t, AnonymousConfig{TTL: time.Hour})
+
+	user, session, err := at.create(t, AnonymousClient{IP: "203.0.113.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := &Claims{
+		UserID:      user.ID,
+		SessionID:   session.ID,
+		Username:    user.Username,
+		Provider:    "anonymous",
+		IsAnonymous: true,
+	}
+
+	claims.IssuedAt = jwt.NewNumericDate(time.Now().Add(-10 * time.Minute))
+	before := at.store.accounts[user.ID].ExpiresAt
+	token, err := at.manager.Renew(ctx, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" || !at.store.accounts[user.ID].ExpiresAt.Equal(before) {
+		t.Fatal("expected a young token to be left alone")
+	}
+
+	// Pretend the account was created most of a TTL ago
+	stale := time.Now().Add(-45 * time.Minute)
+	at.store.accounts[user.ID].LastActiveAt = stale
+	at.store.accounts[user.ID].ExpiresAt = stale.Add(time.Hour)
+	claims.IssuedAt = jwt.NewNumericDate(stale)
+
+	token, err = at.manager.Renew(ctx, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == "" {
+		t.Fatal("expected a replacement token")
+	}
+	renewed, err := at.tokens.ValidateToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewed.UserID != user.ID || renewed.SessionID != session.ID || !renewed.IsAnonymous {
+		t.Fatalf("expected the replacement token to keep the user and session, got %+v", renewed)
+	}
+
+	minExpiry := time.Now().Add(59 * time.Minute)
+	if account := at.store.accounts[user.ID]; account.ExpiresAt.Before(minExpiry) {
+		t.Fatalf("expected the account expiry to slide a full TTL, got %v", account.ExpiresAt)
+	}
+	renewedSession, err := at.sessions.Validate(ctx, session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renewedSession.ExpiresAt == nil || renewedSession.ExpiresAt.Before(minExpiry) {
+		t.Fatalf("expected the session expiry to slide a full TTL, got %v", renewedSession.ExpiresAt)
+	}
+}
+
+// TestAnonymousCleanup purges expired accounts without a live session and
+// keeps, extending, those whose session is still live
+func TestAnonymousCleanup(t *testing.T) {
+	ctx := context.Background()
+	at := newAnonymousTest(t, AnonymousConfig{TTL: time.Hour, CleanupBatchSize: 10})
+
+	expired, expiredSession, err := at.create(t, AnonymousClient{IP: "203.0.113.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	live, _, err := at.create(t, AnonymousClient{IP: "203.0.113.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-time.Minute)
+	at.store.accounts[expired.ID].ExpiresAt = past
+	at.store.accounts[live.ID].ExpiresAt = past
+	if err := at.sessions.Revoke(ctx, expiredSession.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	purged, err := at.manager.Cleanup(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if purged != 1 || len(at.store.deleted) != 1 || at.store.deleted[0] != expired.ID {
+		t.Fatalf("expected only the account without a session to be purged, got %v", at.store.deleted)
+	}
+	if account, ok := at.store.accounts[live.ID]; !ok || !account.ExpiresAt.After(time.Now()) {
+		t.Fatal("expected the account with a live session to be kept and extended")
+	}
+}
@@ -0,0 +1,365 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProviderAPIKey is the claims provider for requests signed with an API key
+const ProviderAPIKey = "api_key"
+
+// API key scopes. Keys can only call procedures whose policy names one of their scopes.
+const (
+	ScopePinsRead  = "pins:read"
+	ScopePinsWrite = "pins:write"
+)
+
+// knownScopes lists the scopes a key may be issued with
+var knownScopes = map[string]bool{
+	ScopePinsRead:  true,
+	ScopePinsWrite: true,
+}
+
+// APIKey is a personal API key. The secret itself is only shown once, at creation.
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	Prefix     string // Non-secret start of the key, to tell keys apart
+	KeyHash    []byte
+	Scopes     []string
+	ExpiresAt  *time.Time // nil never expires
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// APIKeyStore persists API keys
+type APIKeyStore interface {
+	// CreateAPIKey stores a new key and fills in its ID and CreatedAt
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+
+	// GetAPIKeyByHash finds a key by its hash. Returns ErrTokenInvalid if there is none.
+	GetAPIKeyByHash(ctx context.Context, hash []byte) (*APIKey, error)
+
+	// ListAPIKeys lists a user's unrevoked keys, newest first
+	ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error)
+
+	// CountActiveAPIKeys counts a user's unrevoked, unexpired keys
+	CountActiveAPIKeys(ctx context.Context, userID string) (int, error)
+
+	// TouchAPIKey records when a key was last used
+	TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error
+
+	// RevokeAPIKey revokes one of a user's keys. Returns ErrNotFound if the user has no such key.
+	RevokeAPIKey(ctx context.Context, userID, keyID string) error
+}
+
+// APIKeyManager issues API keys and turns them into claims
+type APIKeyManager struct {
+	store     APIKeyStore
+	userStore UserStore
+	config    APIKeyConfig
+}
+
+// NewAPIKeyManager creates a new API key manager
+func NewAPIKeyManager(store APIKeyStore, userStore UserStore, config APIKeyConfig) (*APIKeyManager, error) {
+	if store == nil {
+		return nil, errors.New("API key store is required")
+	}
+	if userStore == nil {
+		return nil, errors.New("user store is required")
+	}
+
+	// Set defaults
+	if config.Prefix == "" {
+		config.Prefix = "alun"
+	}
+	if config.MaxPerUser == 0 {
+		config.MaxPerUser = 10
+	}
+	if config.TouchInterval == 0 {
+		config.TouchInterval = time.Minute
+	}
+
+	return &APIKeyManager{
+		store:     store,
+		userStore: userStore,
+		config:    config,
+	}, nil
+}
+
+// Create issues a key for a user and returns its secret, which is not stored.
+// A zero ttl creates a key that never expires.
+func (m *APIKeyManager) Create(ctx context.Context, user *User, name string, scopes []string, ttl time.Duration) (string, *APIKey, error) {
+	if IsAnonymousEmail(user.Email) {
+		return "", nil, &AuthError{Code: ErrInvalidArgument, Message: "anonymous users cannot create API keys"}
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return "", nil, &AuthError{Code: ErrInvalidArgument, Message: "name must be 1-100 characters"}
+	}
+
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if m.config.MaxTTL > 0 && (ttl <= 0 || ttl > m.config.MaxTTL) {
+		return "", nil, &AuthError{
+			Code:    ErrInvalidArgument,
+			Message: fmt.Sprintf("API keys must expire within %s", m.config.MaxTTL),
+		}
+	}
+	if ttl < 0 {
+		return "", nil, &AuthError{Code: ErrInvalidArgument, Message: "expiry must be in the future"}
+	}
+
+	count, err := m.store.CountActiveAPIKeys(ctx, user.ID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to count API keys: %w", err)
+	}
+	if count >= m.config.MaxPerUser {
+		return "", nil, &AuthError{
+			Code:    ErrRateLimited,
+			Message: fmt.Sprintf("users can have at most %d active API keys", m.config.MaxPerUser),
+		}
+	}
+
+	// <prefix>_<43 random chars>; the first 8 random chars are kept in the clear for listings
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(raw)
+	secret := m.config.Prefix + "_" + encoded
+
+	key := &APIKey{
+		UserID:  user.ID,
+		Name:    name,
+		Prefix:  m.config.Prefix + "_" + encoded[:8],
+		KeyHash: hashAPIKey(secret),
+		Scopes:  scopes,
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := m.store.CreateAPIKey(ctx, key); err != nil {
+		return "", nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	return secret, key, nil
+}
+
+// List returns a user's active keys
+func (m *APIKeyManager) List(ctx context.Context, userID string) ([]*APIKey, error) {
+	return m.store.ListAPIKeys(ctx, userID)
+}
+
+// Revoke revokes one of a user's keys. It stops working immediately.
+func (m *APIKeyManager) Revoke(ctx context.Context, userID, keyID string) error {
+	return m.store.RevokeAPIKey(ctx, userID, keyID)
+}
+
+// Authenticate resolves a presented key to claims for its owner. The claims
+// carry the key's scopes and Provider "api_key", and never any roles.
+func (m *APIKeyManager) Authenticate(ctx context.Context, secret string) (*Claims, error) {
+	if !strings.HasPrefix(secret, m.config.Prefix+"_") {
+		return nil, &AuthError{Code: ErrTokenInvalid, Message: "invalid API key"}
+	}
+
+	key, err := m.store.GetAPIKeyByHash(ctx, hashAPIKey(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil {
+		return nil, &AuthError{Code: ErrTokenInvalid, Message: "API key has been revoked"}
+	}
+	if key.ExpiresAt != nil && now.After(*key.ExpiresAt) {
+		return nil, &AuthError{Code: ErrTokenExpired, Message: "API key has expired"}
+	}
+
+	user, err := m.userStore.GetUserByID(ctx, key.UserID)
+	if err != nil {
+		return nil, &AuthError{Code: ErrTokenInvalid, Message: "API key owner not found"}
+	}
+	if user.Status != "active" {
+		return nil, &AuthError{Code: ErrUserDisabled, Message: "user account is disabled"}
+	}
+	// Keys stop working once their owner asks for the account to be deleted,
+	// and work again if signing in cancels the deletion
+	if user.DeletionScheduledAt != nil {
+		return nil, &AuthError{Code: ErrTokenInvalid, Message: "API key owner's account is scheduled for deletion"}
+	}
+
+	// Only write last-used occasionally, so busy scripts don't cost a write per call
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= m.config.TouchInterval {
+		if err := m.store.TouchAPIKey(ctx, key.ID, now); err != nil {
+			log.Printf("failed to record API key use: %v", err)
+		}
+	}
+
+	return &Claims{
+		UserID:   user.ID,
+		Username: user.Username,
+		Email:    user.Email,
+		Provider: ProviderAPIKey,
+		Scopes:   key.Scopes,
+		Metadata: map[string]interface{}{
+			"api_key_id": key.ID,
+		},
+	}, nil
+}
+
+// HasScope reports whether the claims may act within a scope. Only API keys
+// are limited by scope; tokens from a sign-in may do anything their user can.
+func (c *Claims) HasScope(scope string) bool {
+	if c.Provider != ProviderAPIKey {
+		return true
+	}
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeScopes validates, de-duplicates and sorts requested scopes
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, &AuthError{Code: ErrInvalidArgument, Message: "at least one scope is required"}
+	}
+
+	seen := make(map[string]bool, len(scopes))
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !knownScopes[scope] {
+			return nil, &AuthError{Code: ErrInvalidArgument, Message: fmt.Sprintf("unknown scope %q", scope)}
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// hashAPIKey hashes a key for storage. Keys are random, so a fast hash is enough.
+func hashAPIKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+// InMemoryAPIKeyStore is an in-memory implementation of APIKeyStore for single-instance deployments
+type InMemoryAPIKeyStore struct {
+	mu   sync.Mutex
+	keys map[string]*APIKey // key ID -> key
+}
+
+// NewInMemoryAPIKeyStore creates a new in-memory API key store
+func NewInMemoryAPIKeyStore() *InMemoryAPIKeyStore {
+	return &InMemoryAPIKeyStore{
+		keys: make(map[string]*APIKey),
+	}
+}
+
+// CreateAPIKey stores a new key
+func (s *InMemoryAPIKeyStore) CreateAPIKey(ctx context.Context, key *APIKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key.ID = uuid.New().String()
+	key.CreatedAt = time.Now()
+	stored := *key
+	s.keys[key.ID] = &stored
+	return nil
+}
+
+// GetAPIKeyByHash finds a key by its hash
+func (s *InMemoryAPIKeyStore) GetAPIKeyByHash(ctx context.Context, hash []byte) (*APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range s.keys {
+		if string(key.KeyHash) == string(hash) {
+			copied := *key
+			return &copied, nil
+		}
+	}
+	return nil, &AuthError{Code: ErrTokenInvalid, Message: "invalid API key"}
+}
+
+// ListAPIKeys lists a user's unrevoked keys, newest first
+func (s *InMemoryAPIKeyStore) ListAPIKeys(ctx context.Context, userID string) ([]*APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var keys []*APIKey
+	for _, key := range s.keys {
+		if key.UserID == userID && key.RevokedAt == nil {
+			copied := *key
+			keys = append(keys, &copied)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// CountActiveAPIKeys counts a user's unrevoked, unexpired keys
+func (s *InMemoryAPIKeyStore) CountActiveAPIKeys(ctx context.Context, userID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	count := 0
+	for _, key := range s.keys {
+		if key.UserID == userID && key.RevokedAt == nil && (key.ExpiresAt == nil || key.ExpiresAt.After(now)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// TouchAPIKey records when a key was last used
+func (s *InMemoryAPIKeyStore) TouchAPIKey(ctx context.Context, keyID string, usedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, exists := s.keys[keyID]; exists {
+		key.LastUsedAt = &usedAt
+	}
+	return nil
+}
+
+// RevokeAPIKey revokes one of a user's keys
+func (s *InMemoryAPIKeyStore) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, exists := s.keys[keyID]
+	if !exists || key.UserID != userID || key.RevokedAt != nil {
+		return &AuthError{Code: ErrNotFound, Message: "API key not found"}
+	}
+	now := time.Now()
+	key.RevokedAt = &now
+	return nil
+}
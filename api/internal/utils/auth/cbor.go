@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Minimal CBOR (RFC 8949) decoding for WebAuthn attestation objects and COSE keys.
+// Only definite-length items are supported, which is all authenticators emit.
+
+const cborMaxDepth = 16
+
+// cborDecode decodes one CBOR item from data and returns it with the number of bytes read.
+// Integers decode to int64, byte strings to []byte, text to string, arrays to
+// []interface{} and maps to map[interface{}]interface{}.
+func cborDecode(data []byte) (interface{}, int, error) {
+	d := &cborDecoder{data: data}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, 0, err
+	}
+	return v, d.pos, nil
+}
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > cborMaxDepth {
+		return nil, errors.New("cbor: nesting too deep")
+	}
+	if d.pos >= len(d.data) {
+		return nil, errors.New("cbor: unexpected end of data")
+	}
+
+	initial := d.data[d.pos]
+	d.pos++
+	major := initial >> 5
+	info := initial & 0x1f
+
+	// Simple values and floats carry no length
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+		}
+	}
+
+	arg, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		if arg > 1<<63-1 {
+			return nil, errors.New("cbor: integer overflow")
+		}
+		return int64(arg), nil
+	case 1:
+		if arg > 1<<63-1 {
+			return nil, errors.New("cbor: integer overflow")
+		}
+		return -1 - int64(arg), nil
+	case 2, 3:
+		b, err := d.bytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		if major == 3 {
+			return string(b), nil
+		}
+		return b, nil
+	case 4:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errors.New("cbor: array length exceeds data")
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 5:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errors.New("cbor: map length exceeds data")
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, errors.New("cbor: unsupported map key type")
+			}
+			value, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	case 6:
+		// Tags are ignored; return the tagged item
+		return d.decode(depth + 1)
+	}
+	return nil, fmt.Errorf("cbor: unsupported major type %d", major)
+}
+
+// argument reads the length or value that follows an initial byte
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	switch {
+	case info < 24:
+		return uint64(info), nil
+	case info <= 27:
+		size := 1 << (info - 24)
+		b, err := d.bytes(uint64(size))
+		if err != nil {
+			return 0, err
+		}
+		switch size {
+		case 1:
+			return uint64(b[0]), nil
+		case 2:
+			return uint64(binary.BigEndian.Uint16(b)), nil
+		case 4:
+			return uint64(binary.BigEndian.Uint32(b)), nil
+		default:
+			return binary.BigEndian.Uint64(b), nil
+		}
+	default:
+		return 0, errors.New("cbor: indefinite lengths are not supported")
+	}
+}
+
+// bytes reads n raw bytes
+func (d *cborDecoder) bytes(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, errors.New("cbor: unexpected end of data")
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
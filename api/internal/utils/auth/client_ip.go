@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP returns the client IP of a request. X-Forwarded-For is only honored
+// when trustForwardedFor is set, since clients can send it themselves.
+func ClientIP(remoteAddr string, header http.Header, trustForwardedFor bool) string {
+	if trustForwardedFor {
+		if forwarded := header.Get("X-Forwarded-For"); forwarded != "" {
+			// The first entry is the original client
+			if ip := strings.TrimSpace(strings.Split(forwarded, ",")[0]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
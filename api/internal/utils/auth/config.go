@@ -6,7 +6,16 @@ import "time"
 type Config struct {
 	JWT       JWTConfig                  `json:"jwt"`
 	Session   SessionConfig              `json:"session"`
+	Anonymous AnonymousConfig            `json:"anonymous"`
+	Email     EmailProviderConfig        `json:"email"`
+	Lockout   LockoutConfig              `json:"lockout"`
+	MFA       MFAConfig                  `json:"mfa"`
+	Passkey   PasskeyConfig              `json:"passkey"`
+	APIKeys   APIKeyConfig               `json:"api_keys"`
+	Deletion  AccountDeletionConfig      `json:"deletion"`
+	SMTP      SMTPConfig                 `json:"smtp"`
 	Providers map[string]ProviderConfig  `json:"providers"`
+	Redirect  RedirectConfig             `json:"redirect"`
 }
 
 // JWTConfig holds JWT-specific configuration
@@ -36,6 +45,126 @@ type SessionConfig struct {
 	MaxPerUser int `json:"max_per_user" env:"SESSION_MAX_PER_USER" default:"10"`
 }
 
+// AnonymousConfig holds the lifecycle limits for anonymous accounts
+type AnonymousConfig struct {
+	// Inactivity TTL; tokens and accounts are renewed on activity (0 = never expire)
+	TTL time.Duration `json:"ttl" env:"ANONYMOUS_TTL" default:"720h"`
+	
+	// Maximum anonymous accounts created per IP / device fingerprint within LimitWindow (0 = unlimited)
+	MaxPerIP     int           `json:"max_per_ip" env:"ANONYMOUS_MAX_PER_IP" default:"20"`
+	MaxPerDevice int           `json:"max_per_device" env:"ANONYMOUS_MAX_PER_DEVICE" default:"3"`
+	LimitWindow  time.Duration `json:"limit_window" env:"ANONYMOUS_LIMIT_WINDOW" default:"24h"`
+	
+	// Trust X-Forwarded-For for the client IP (only behind a proxy that sets it)
+	TrustForwardedFor bool `json:"trust_forwarded_for" env:"TRUST_FORWARDED_FOR" default:"false"`
+	
+	// How often expired anonymous accounts are purged, and how many per run
+	CleanupInterval  time.Duration `json:"cleanup_interval" env:"ANONYMOUS_CLEANUP_INTERVAL" default:"1h"`
+	CleanupBatchSize int           `json:"cleanup_batch_size" default:"100"`
+	
+	// How long an account whose purge failed waits before it is tried again
+	RetryDelay time.Duration `json:"retry_delay" default:"6h"`
+}
+
+// LockoutConfig holds brute-force protection limits for password sign-in
+type LockoutConfig struct {
+	// Consecutive failures before an account is locked (0 = never lock)
+	MaxAccountFailures int `json:"max_account_failures" env:"LOCKOUT_MAX_ACCOUNT_FAILURES" default:"5"`
+	
+	// Failures from one IP across all accounts before the IP is locked (0 = never lock)
+	MaxIPFailures int `json:"max_ip_failures" env:"LOCKOUT_MAX_IP_FAILURES" default:"50"`
+	
+	// How long a locked account or IP is refused
+	LockoutDuration time.Duration `json:"lockout_duration" env:"LOCKOUT_DURATION" default:"15m"`
+	
+	// Failures older than this are forgotten
+	FailureWindow time.Duration `json:"failure_window" default:"15m"`
+	
+	// Wait after the n-th consecutive account failure: BackoffBase * 2^(n-1), capped at BackoffMax
+	BackoffBase time.Duration `json:"backoff_base" default:"1s"`
+	BackoffMax  time.Duration `json:"backoff_max" default:"1m"`
+}
+
+// MFAConfig holds two-factor authentication settings
+type MFAConfig struct {
+	// Issuer shown in authenticator apps
+	Issuer string `json:"issuer" env:"MFA_ISSUER" default:"Alunalun"`
+	
+	// How long a sign-in waits for the second factor
+	ChallengeTTL time.Duration `json:"challenge_ttl" default:"5m"`
+	
+	// Number of one-time recovery codes issued on enrollment
+	RecoveryCodeCount int `json:"recovery_code_count" default:"10"`
+}
+
+// PasskeyConfig holds WebAuthn relying party settings
+type PasskeyConfig struct {
+	// Relying party ID: the site's registrable domain, without scheme or port
+	RPID   string `json:"rp_id" env:"PASSKEY_RP_ID" default:"localhost"`
+	RPName string `json:"rp_name" default:"Alunalun"`
+	
+	// Origins allowed in client data, e.g. https://alunalun.app
+	Origins []string `json:"origins" env:"PASSKEY_ORIGINS" default:"http://localhost:3000"`
+	
+	// How long a registration or sign-in ceremony may take
+	ChallengeTTL time.Duration `json:"challenge_ttl" default:"5m"`
+	
+	// "required", "preferred" or "discouraged"
+	UserVerification string `json:"user_verification" default:"preferred"`
+}
+
+// APIKeyConfig holds personal API key settings
+type APIKeyConfig struct {
+	// Start of every key, to make leaked keys easy to spot
+	Prefix string `json:"prefix" default:"alun"`
+	
+	// Maximum active keys per user
+	MaxPerUser int `json:"max_per_user" env:"API_KEY_MAX_PER_USER" default:"10"`
+	
+	// Longest allowed lifetime (0 = keys may never expire)
+	MaxTTL time.Duration `json:"max_ttl" env:"API_KEY_MAX_TTL" default:"0"`
+	
+	// Minimum time between last-used updates for a key
+	TouchInterval time.Duration `json:"touch_interval" default:"1m"`
+}
+
+// AccountDeletionConfig holds settings for users deleting their own account
+type AccountDeletionConfig struct {
+	// How long a deletion can be cancelled by signing in again
+	GracePeriod time.Duration `json:"grace_period" env:"ACCOUNT_DELETION_GRACE_PERIOD" default:"720h"`
+	
+	// How recently the user must have signed in to schedule a deletion
+	ReauthWindow time.Duration `json:"reauth_window" default:"10m"`
+	
+	// What happens to the user's posts: "anonymize" keeps them under a placeholder
+	// author, "delete" removes them and redacts the ones other users replied to
+	ContentPolicy string `json:"content_policy" env:"ACCOUNT_DELETION_CONTENT_POLICY" default:"anonymize"`
+	
+	// How often due accounts are erased, and how many per run
+	CleanupInterval  time.Duration `json:"cleanup_interval" default:"1h"`
+	CleanupBatchSize int           `json:"cleanup_batch_size" default:"100"`
+	
+	// How long an account whose erasure failed waits before it is tried again
+	RetryDelay time.Duration `json:"retry_delay" default:"6h"`
+}
+
+// SMTPConfig holds the mail server account emails are sent through
+type SMTPConfig struct {
+	// Mail server; an empty host means no mail server is configured
+	Host string `json:"host" env:"SMTP_HOST"`
+	Port int    `json:"port" env:"SMTP_PORT" default:"587"`
+	
+	// Credentials, sent only over TLS
+	Username string `json:"username" env:"SMTP_USERNAME"`
+	Password string `json:"password" env:"SMTP_PASSWORD"`
+	
+	// Sender address, e.g. "Alunalun <no-reply@alunalun.app>"
+	From string `json:"from" env:"EMAIL_FROM"`
+	
+	// How long sending one email may take
+	Timeout time.Duration `json:"timeout" default:"30s"`
+}
+
 // ProviderConfig holds provider-specific configuration
 type ProviderConfig struct {
 	// Provider type (oauth_google, oauth_apple, email_password, magic_link, anonymous)
@@ -48,6 +177,28 @@ type ProviderConfig struct {
 	Config  map[string]string `json:"config"`
 }
 
+// RedirectConfig holds the allowlist of client redirect URIs for OAuth flows
+type RedirectConfig struct {
+	// Allowed redirects keyed by client (web, ios, android)
+	Clients map[string]RedirectClientConfig `json:"clients"`
+	
+	// How long a one-time login code can be exchanged for a token
+	LoginCodeTTL time.Duration `json:"login_code_ttl" default:"1m"`
+}
+
+// RedirectClientConfig lists the redirect URIs a single client may use
+type RedirectClientConfig struct {
+	// Exact redirect URIs
+	URIs []string `json:"uris"`
+	
+	// Patterns whose host may start with a "*" label matching exactly one subdomain,
+	// e.g. "https://*.alunalun.app/auth/callback"; scheme, port and path must match exactly
+	Patterns []string `json:"patterns"`
+	
+	// How the token is delivered: "fragment" (web) or "code" (one-time code exchange for native apps)
+	TokenDelivery string `json:"token_delivery" default:"fragment"`
+}
+
 // OAuthProviderConfig represents OAuth-specific configuration
 type OAuthProviderConfig struct {
 	ClientID     string   `json:"client_id" env:"OAUTH_CLIENT_ID"`
@@ -69,12 +220,32 @@ type EmailProviderConfig struct {
 	RequireNumbers     bool `json:"require_numbers" default:"true"`
 	RequireSpecialChar bool `json:"require_special_char" default:"false"`
 	
+	// Hash algorithm for new passwords: "bcrypt" or "argon2id". Hashes made with
+	// another algorithm or cost are rehashed on the next successful login.
+	PasswordHashAlgorithm string `json:"password_hash_algorithm" env:"PASSWORD_HASH_ALGORITHM" default:"bcrypt"`
+	
 	// Bcrypt cost factor (10-31)
 	BcryptCost int `json:"bcrypt_cost" default:"12"`
 	
+	// Argon2id parameters (memory in KiB)
+	Argon2Memory      uint32 `json:"argon2_memory" default:"65536"`
+	Argon2Iterations  uint32 `json:"argon2_iterations" default:"3"`
+	Argon2Parallelism uint8  `json:"argon2_parallelism" default:"2"`
+	
 	// Email verification
 	RequireVerification bool          `json:"require_verification" default:"true"`
 	VerificationTTL     time.Duration `json:"verification_ttl" default:"24h"`
+	
+	// Link sent in verification emails; the token is appended as ?token=
+	VerificationURL string `json:"verification_url" env:"EMAIL_VERIFICATION_URL" default:"http://localhost:3000/auth/verify-email"`
+	
+	// Maximum verification emails per user per hour (0 = unlimited)
+	MaxVerificationEmailsPerHour int `json:"max_verification_emails_per_hour" default:"5"`
+	
+	// Password reset links
+	PasswordResetTTL         time.Duration `json:"password_reset_ttl" default:"1h"`
+	PasswordResetURL         string        `json:"password_reset_url" env:"PASSWORD_RESET_URL" default:"http://localhost:3000/auth/reset-password"`
+	MaxPasswordResetsPerHour int           `json:"max_password_resets_per_hour" default:"3"`
 }
 
 // MagicLinkConfig represents magic link provider configuration
@@ -108,6 +279,67 @@ func DefaultConfig() *Config {
 			CleanupInterval: time.Hour,
 			MaxPerUser:      10,
 		},
+		Anonymous: AnonymousConfig{
+			TTL:              30 * 24 * time.Hour,
+			MaxPerIP:         20,
+			MaxPerDevice:     3,
+			LimitWindow:      24 * time.Hour,
+			CleanupInterval:  time.Hour,
+			CleanupBatchSize: 100,
+			RetryDelay:       6 * time.Hour,
+		},
+		Email: EmailProviderConfig{
+			MinLength:                    8,
+			RequireUppercase:             true,
+			RequireLowercase:             true,
+			RequireNumbers:               true,
+			PasswordHashAlgorithm:        PasswordHashBcrypt,
+			BcryptCost:                   12,
+			RequireVerification:          true,
+			VerificationTTL:              24 * time.Hour,
+			VerificationURL:              "http://localhost:3000/auth/verify-email",
+			MaxVerificationEmailsPerHour: 5,
+			PasswordResetTTL:             time.Hour,
+			PasswordResetURL:             "http://localhost:3000/auth/reset-password",
+			MaxPasswordResetsPerHour:     3,
+		},
+		Lockout: LockoutConfig{
+			MaxAccountFailures: 5,
+			MaxIPFailures:      50,
+			LockoutDuration:    15 * time.Minute,
+			FailureWindow:      15 * time.Minute,
+			BackoffBase:        time.Second,
+			BackoffMax:         time.Minute,
+		},
+		MFA: MFAConfig{
+			Issuer:            "Alunalun",
+			ChallengeTTL:      5 * time.Minute,
+			RecoveryCodeCount: 10,
+		},
+		Passkey: PasskeyConfig{
+			RPID:             "localhost",
+			RPName:           "Alunalun",
+			Origins:          []string{"http://localhost:3000"},
+			ChallengeTTL:     5 * time.Minute,
+			UserVerification: "preferred",
+		},
+		APIKeys: APIKeyConfig{
+			Prefix:        "alun",
+			MaxPerUser:    10,
+			TouchInterval: time.Minute,
+		},
+		Deletion: AccountDeletionConfig{
+			GracePeriod:      30 * 24 * time.Hour,
+			ReauthWindow:     10 * time.Minute,
+			ContentPolicy:    DeletionContentAnonymize,
+			CleanupInterval:  time.Hour,
+			CleanupBatchSize: 100,
+			RetryDelay:       6 * time.Hour,
+		},
+		SMTP: SMTPConfig{
+			Port:    587,
+			Timeout: 30 * time.Second,
+		},
 		Providers: map[string]ProviderConfig{
 			"google": {
 				Type:    "oauth",
@@ -136,5 +368,22 @@ func DefaultConfig() *Config {
 				Config:  map[string]string{},
 			},
 		},
+		Redirect: RedirectConfig{
+			Clients: map[string]RedirectClientConfig{
+				"web": {
+					URIs:          []string{"http://localhost:3000/auth/callback"},
+					TokenDelivery: TokenDeliveryFragment,
+				},
+				"ios": {
+					URIs:          []string{"alunalun://auth/callback"},
+					TokenDelivery: TokenDeliveryCode,
+				},
+				"android": {
+					URIs:          []string{"alunalun://auth/callback"},
+					TokenDelivery: TokenDeliveryCode,
+				},
+			},
+			LoginCodeTTL: time.Minute,
+		},
 	}
 }
\ No newline at end of file
@@ -10,6 +10,9 @@ type contextKey string
 const (
 	// claimsKey is the context key for JWT claims
 	claimsKey contextKey = "auth_claims"
+	
+	// clientIPKey is the context key for the caller's IP address
+	clientIPKey contextKey = "auth_client_ip"
 )
 
 // ContextWithClaims adds JWT claims to the context
@@ -48,4 +51,15 @@ func IsAnonymousContext(ctx context.Context) bool {
 		return true
 	}
 	return claims.IsAnonymous
+}
+
+// ContextWithClientIP adds the caller's IP address to the context
+func ContextWithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// ClientIPFromContext retrieves the caller's IP address from the context
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
 }
\ No newline at end of file
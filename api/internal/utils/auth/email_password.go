@@ -5,11 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"regexp"
 	"time"
 	"unicode"
-	
-	"golang.org/x/crypto/bcrypt"
 )
 
 // EmailPasswordProvider implements email/password authentication
@@ -17,6 +16,11 @@ type EmailPasswordProvider struct {
 	userStore  UserStore
 	config     EmailProviderConfig
 	emailRegex *regexp.Regexp
+	verifier   *EmailVerifier      // nil disables verification emails on registration
+	throttle   *LoginThrottle      // nil disables brute-force protection
+	mfa        *MFAManager         // nil when sign-ins have no second factor
+	dummyHash  string              // checked for unknown emails so they take as long as known ones
+	creator    PasswordUserCreator // nil creates the user and password in separate writes
 }
 
 // UserStore defines the interface for user storage operations
@@ -53,6 +57,14 @@ type User struct {
 	LastLoginAt      *time.Time             `json:"last_login_at,omitempty"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 	Status           string                 `json:"status"` // active, disabled, pending
+	DeletionScheduledAt *time.Time          `json:"deletion_scheduled_at,omitempty"`
+	TokensRevokedAt  *time.Time             `json:"-"` // Tokens signed in before this are rejected
+}
+
+// TokenRevoked reports whether a token's sign-in predates the user's last
+// sign-out everywhere
+func (u *User) TokenRevoked(claims *Claims) bool {
+	return u.TokensRevokedAt != nil && claims.SignedInAt().Before(*u.TokensRevokedAt)
 }
 
 // EmailPasswordCredentials represents email/password login credentials
@@ -77,13 +89,41 @@ func NewEmailPasswordProvider(userStore UserStore, config EmailProviderConfig) (
 	
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	
+	// Same algorithm and cost as real hashes, so verifying it costs the same
+	dummyHash, err := HashPassword("not-a-real-password", config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dummy password hash: %w", err)
+	}
+	
 	return &EmailPasswordProvider{
 		userStore:  userStore,
 		config:     config,
 		emailRegex: emailRegex,
+		dummyHash:  dummyHash,
 	}, nil
 }
 
+// SetEmailVerifier enables sending a verification email on registration
+func (p *EmailPasswordProvider) SetEmailVerifier(verifier *EmailVerifier) {
+	p.verifier = verifier
+}
+
+// SetPasswordUserCreator makes registration create the user and password atomically
+func (p *EmailPasswordProvider) SetPasswordUserCreator(creator PasswordUserCreator) {
+	p.creator = creator
+}
+
+// SetLoginThrottle enables backoff and lockout after failed sign-ins
+func (p *EmailPasswordProvider) SetLoginThrottle(throttle *LoginThrottle) {
+	p.throttle = throttle
+}
+
+// SetMFAManager keeps the failure counter of users with two-factor
+// authentication until their code is verified, not just their password
+func (p *EmailPasswordProvider) SetMFAManager(manager *MFAManager) {
+	p.mfa = manager
+}
+
 // Name returns the provider name
 func (p *EmailPasswordProvider) Name() string {
 	return "email"
@@ -113,29 +153,60 @@ func (p *EmailPasswordProvider) Authenticate(ctx context.Context, credential str
 		}
 	}
 	
+	ip := ClientIPFromContext(ctx)
+	
+	// Refuse locked or backing-off emails before checking the password. Emails
+	// without an account are throttled the same, so throttling doesn't reveal them.
+	if err := p.checkThrottle(ctx, creds.Email, ip); err != nil {
+		return nil, err
+	}
+	
 	// Get user by email
 	user, err := p.userStore.GetUserByEmail(ctx, creds.Email)
 	if err != nil {
-		// Don't reveal whether the email exists
+		p.recordFailure(ctx, creds.Email, nil, ip)
+		
+		// Don't reveal whether the email exists, by content or by timing
+		VerifyPassword(p.dummyHash, creds.Password)
+		return nil, &AuthError{
+			Code:    ErrInvalidCredentials,
+			Message: "invalid email or password",
+		}
+	}
+	
+	// Verify password; users who only signed in with other providers have none
+	passwordHash, err := loadPasswordHash(ctx, p.userStore, user)
+	hasPassword := err == nil
+	if !hasPassword {
+		passwordHash = p.dummyHash
+	}
+	if !VerifyPassword(passwordHash, creds.Password) || !hasPassword {
+		p.recordFailure(ctx, creds.Email, user, ip)
 		return nil, &AuthError{
 			Code:    ErrInvalidCredentials,
 			Message: "invalid email or password",
 		}
 	}
 	
-	// Check user status
+	// Only reveal the account status to someone who knows the password
 	if user.Status != "active" {
 		return nil, &AuthError{
 			Code:    ErrUserDisabled,
 			Message: "user account is disabled",
 		}
 	}
+	if p.throttle != nil && !p.secondFactorPending(ctx, user) {
+		if err := p.throttle.Succeed(ctx, creds.Email); err != nil {
+			log.Printf("failed to reset login attempts for user %s: %v", user.ID, err)
+		}
+	}
 	
-	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(creds.Password)); err != nil {
-		return nil, &AuthError{
-			Code:    ErrInvalidCredentials,
-			Message: "invalid email or password",
+	// Upgrade hashes made with an older algorithm or cost
+	if PasswordNeedsRehash(passwordHash, p.config) {
+		if hash, err := HashPassword(creds.Password, p.config); err == nil {
+			if err := savePasswordHash(ctx, p.userStore, user, hash); err != nil {
+				log.Printf("failed to rehash password for user %s: %v", user.ID, err)
+			}
 		}
 	}
 	
@@ -172,13 +243,52 @@ func (p *EmailPasswordProvider) Authenticate(ctx context.Context, credential str
 	}, nil
 }
 
+// checkThrottle refuses the sign-in while the email or IP is throttled
+func (p *EmailPasswordProvider) checkThrottle(ctx context.Context, email, ip string) error {
+	if p.throttle == nil {
+		return nil
+	}
+	return p.throttle.Check(ctx, email, ip)
+}
+
+// secondFactorPending reports whether the user still has to pass TOTP after
+// their password. MFAManager.Verify then resets the failure counter, so
+// alternating correct passwords and wrong codes still adds up to a lockout.
+func (p *EmailPasswordProvider) secondFactorPending(ctx context.Context, user *User) bool {
+	if p.mfa == nil {
+		return false
+	}
+	enabled, err := p.mfa.Enabled(ctx, user.ID)
+	if err != nil {
+		log.Printf("failed to check two-factor status for user %s: %v", user.ID, err)
+		return true
+	}
+	return enabled
+}
+
+// recordFailure counts a failed sign-in; storage errors are logged, not returned
+func (p *EmailPasswordProvider) recordFailure(ctx context.Context, email string, user *User, ip string) {
+	if p.throttle == nil {
+		return
+	}
+	if err := p.throttle.Fail(ctx, email, user, ip); err != nil {
+		log.Printf("failed to record login failure: %v", err)
+	}
+}
+
 // ValidateConfig checks if the provider is properly configured
 func (p *EmailPasswordProvider) ValidateConfig() error {
 	if p.userStore == nil {
 		return errors.New("user store is not configured")
 	}
-	if p.config.BcryptCost < 10 || p.config.BcryptCost > 31 {
-		return errors.New("bcrypt cost must be between 10 and 31")
+	switch p.config.PasswordHashAlgorithm {
+	case PasswordHashBcrypt, "":
+		if p.config.BcryptCost < 10 || p.config.BcryptCost > 31 {
+			return errors.New("bcrypt cost must be between 10 and 31")
+		}
+	case PasswordHashArgon2id:
+	default:
+		return fmt.Errorf("unsupported password hash algorithm: %s", p.config.PasswordHashAlgorithm)
 	}
 	if p.config.MinLength < 6 {
 		return errors.New("minimum password length must be at least 6")
@@ -190,18 +300,27 @@ func (p *EmailPasswordProvider) ValidateConfig() error {
 func (p *EmailPasswordProvider) RegisterUser(ctx context.Context, email, password, username string) (*User, error) {
 	// Validate email
 	if !p.emailRegex.MatchString(email) {
-		return nil, errors.New("invalid email format")
+		return nil, &AuthError{
+			Code:    ErrInvalidCredentials,
+			Message: "invalid email format",
+		}
 	}
 	
 	// Validate password
 	if err := p.validatePassword(password); err != nil {
-		return nil, err
+		return nil, &AuthError{
+			Code:    ErrWeakPassword,
+			Message: err.Error(),
+		}
 	}
 	
 	// Check if email already exists
 	existingUser, _ := p.userStore.GetUserByEmail(ctx, email)
 	if existingUser != nil {
-		return nil, errors.New("email already registered")
+		return nil, &AuthError{
+			Code:    ErrAlreadyExists,
+			Message: "email already registered",
+		}
 	}
 	
 	// Check username availability if provided
@@ -211,7 +330,10 @@ func (p *EmailPasswordProvider) RegisterUser(ctx context.Context, email, passwor
 			return nil, fmt.Errorf("failed to check username: %w", err)
 		}
 		if !available {
-			return nil, errors.New("username not available")
+			return nil, &AuthError{
+				Code:    ErrAlreadyExists,
+				Message: "username not available",
+			}
 		}
 	} else {
 		// Use email as username if not provided
@@ -219,9 +341,9 @@ func (p *EmailPasswordProvider) RegisterUser(ctx context.Context, email, passwor
 	}
 	
 	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), p.config.BcryptCost)
+	hashedPassword, err := HashPassword(password, p.config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
+		return nil, err
 	}
 	
 	// Create user
@@ -229,7 +351,6 @@ func (p *EmailPasswordProvider) RegisterUser(ctx context.Context, email, passwor
 	user := &User{
 		Email:         email,
 		Username:      username,
-		PasswordHash:  string(hashedPassword),
 		EmailVerified: !p.config.RequireVerification, // If verification not required, mark as verified
 		CreatedAt:     now,
 		UpdatedAt:     now,
@@ -241,9 +362,28 @@ func (p *EmailPasswordProvider) RegisterUser(ctx context.Context, email, passwor
 		user.EmailVerifiedAt = &now
 	}
 	
-	// Save user
-	if err := p.userStore.CreateUser(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
+	// Save the user and password hash, together when the store allows it
+	if p.creator != nil {
+		if err := p.creator.CreateUserWithPassword(ctx, user, hashedPassword); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		user.PasswordHash = hashedPassword
+	} else {
+		if err := p.userStore.CreateUser(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		
+		// Store the password hash once the user has an ID
+		if err := savePasswordHash(ctx, p.userStore, user, hashedPassword); err != nil {
+			return nil, fmt.Errorf("failed to save password: %w", err)
+		}
+	}
+	
+	// Send verification email; the user can request another if this fails
+	if p.config.RequireVerification && p.verifier != nil {
+		if err := p.verifier.Send(ctx, user); err != nil {
+			log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+		}
 	}
 	
 	return user, nil
@@ -258,7 +398,8 @@ func (p *EmailPasswordProvider) UpdatePassword(ctx context.Context, userID, oldP
 	}
 	
 	// Verify old password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(oldPassword)); err != nil {
+	passwordHash, err := loadPasswordHash(ctx, p.userStore, user)
+	if err != nil || !VerifyPassword(passwordHash, oldPassword) {
 		return errors.New("invalid old password")
 	}
 	
@@ -268,17 +409,14 @@ func (p *EmailPasswordProvider) UpdatePassword(ctx context.Context, userID, oldP
 	}
 	
 	// Hash new password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), p.config.BcryptCost)
+	hashedPassword, err := HashPassword(newPassword, p.config)
 	if err != nil {
-		return fmt.Errorf("failed to hash password: %w", err)
+		return err
 	}
 	
-	// Update user
-	user.PasswordHash = string(hashedPassword)
-	user.UpdatedAt = time.Now()
-	
-	if err := p.userStore.UpdateUser(ctx, user); err != nil {
-		return fmt.Errorf("failed to update user: %w", err)
+	// Update password
+	if err := savePasswordHash(ctx, p.userStore, user, hashedPassword); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
 	}
 	
 	return nil
@@ -286,8 +424,13 @@ func (p *EmailPasswordProvider) UpdatePassword(ctx context.Context, userID, oldP
 
 // validatePassword checks if a password meets requirements
 func (p *EmailPasswordProvider) validatePassword(password string) error {
-	if len(password) < p.config.MinLength {
-		return fmt.Errorf("password must be at least %d characters long", p.config.MinLength)
+	return validatePasswordPolicy(password, p.config)
+}
+
+// validatePasswordPolicy checks a password against the configured requirements
+func validatePasswordPolicy(password string, config EmailProviderConfig) error {
+	if len(password) < config.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", config.MinLength)
 	}
 	
 	var hasUpper, hasLower, hasNumber, hasSpecial bool
@@ -305,19 +448,19 @@ func (p *EmailPasswordProvider) validatePassword(password string) error {
 		}
 	}
 	
-	if p.config.RequireUppercase && !hasUpper {
+	if config.RequireUppercase && !hasUpper {
 		return errors.New("password must contain at least one uppercase letter")
 	}
 	
-	if p.config.RequireLowercase && !hasLower {
+	if config.RequireLowercase && !hasLower {
 		return errors.New("password must contain at least one lowercase letter")
 	}
 	
-	if p.config.RequireNumbers && !hasNumber {
+	if config.RequireNumbers && !hasNumber {
 		return errors.New("password must contain at least one number")
 	}
 	
-	if p.config.RequireSpecialChar && !hasSpecial {
+	if config.RequireSpecialChar && !hasSpecial {
 		return errors.New("password must contain at least one special character")
 	}
 	
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"log"
+)
+
+// LogEmailSender logs who an email would go to instead of sending it, for
+// development. Links are never logged: they carry bearer tokens.
+type LogEmailSender struct{}
+
+// NewLogEmailSender creates a new log email sender
+func NewLogEmailSender() *LogEmailSender {
+	return &LogEmailSender{}
+}
+
+// SendMagicLink logs that a magic link email would be sent
+func (s *LogEmailSender) SendMagicLink(ctx context.Context, email, token, linkURL string) error {
+	log.Printf("email to %s: magic link", email)
+	return nil
+}
+
+// SendVerificationEmail logs that an email verification email would be sent
+func (s *LogEmailSender) SendVerificationEmail(ctx context.Context, email, token, linkURL string) error {
+	log.Printf("email to %s: email verification", email)
+	return nil
+}
+
+// SendPasswordReset logs that a password reset email would be sent
+func (s *LogEmailSender) SendPasswordReset(ctx context.Context, email, token, linkURL string) error {
+	log.Printf("email to %s: password reset", email)
+	return nil
+}
+
+// SendSecurityAlert logs that a security alert email would be sent
+func (s *LogEmailSender) SendSecurityAlert(ctx context.Context, email, message string) error {
+	log.Printf("email to %s: security alert", email)
+	return nil
+}
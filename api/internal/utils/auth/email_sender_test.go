@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+)
+
+// TestLogEmailSenderKeepsTokensOutOfLogs sends every kind of email through
+// the development sender: the log must name the recipient, never the link.
+func TestLogEmailSenderKeepsTokensOutOfLogs(t *testing.T) {
+	var buf bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&buf)
+
+	ctx := context.Background()
+	sender := NewLogEmailSender()
+	const email, token = "user@alunalun.test", "secret-token"
+	link := "https://alunalun.app/auth/link?token=" + token
+
+	if err := sender.SendMagicLink(ctx, email, token, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.SendVerificationEmail(ctx, email, token, link); err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.SendPasswordReset(ctx, email, token, link); err != nil {
+		t.Fatal(err)
+	}
+
+	logged := buf.String()
+	if strings.Contains(logged, token) {
+		t.Fatalf("expected tokens to stay out of the log, got %q", logged)
+	}
+	if strings.Count(logged, email) != 3 {
+		t.Fatalf("expected each email's recipient to be logged, got %q", logged)
+	}
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Purposes of one-time email tokens
+const (
+	EmailTokenVerifyEmail   = "verify_email"
+	EmailTokenResetPassword = "reset_password"
+)
+
+// EmailToken is a one-time token sent by email. Only its hash is stored.
+type EmailToken struct {
+	Token     string // raw token; empty when loaded from a store
+	UserID    string
+	Purpose   string
+	Email     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// EmailTokenStore persists one-time email tokens
+type EmailTokenStore interface {
+	// SaveEmailToken stores a token by its hash
+	SaveEmailToken(ctx context.Context, token *EmailToken) error
+
+	// ConsumeEmailToken returns and deletes a token; a token can only be consumed once
+	ConsumeEmailToken(ctx context.Context, purpose, token string) (*EmailToken, error)
+
+	// CountRecentEmailTokens counts tokens issued to a user for a purpose since a time
+	CountRecentEmailTokens(ctx context.Context, userID, purpose string, since time.Time) (int64, error)
+
+	// DeleteEmailTokens removes all of a user's tokens for a purpose
+	DeleteEmailTokens(ctx context.Context, userID, purpose string) error
+}
+
+// GenerateEmailToken creates a random one-time email token
+func GenerateEmailToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate email token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashEmailToken returns the stored form of an email token
+func HashEmailToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// InMemoryEmailTokenStore is an in-memory implementation of EmailTokenStore for single-instance deployments
+type InMemoryEmailTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]*EmailToken
+}
+
+// NewInMemoryEmailTokenStore creates a new in-memory email token store
+func NewInMemoryEmailTokenStore() *InMemoryEmailTokenStore {
+	return &InMemoryEmailTokenStore{
+		tokens: make(map[string]*EmailToken),
+	}
+}
+
+// SaveEmailToken stores a token by its hash
+func (s *InMemoryEmailTokenStore) SaveEmailToken(ctx context.Context, token *EmailToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *token
+	stored.Token = ""
+	s.tokens[HashEmailToken(token.Token)] = &stored
+	return nil
+}
+
+// ConsumeEmailToken returns and deletes a token
+func (s *InMemoryEmailTokenStore) ConsumeEmailToken(ctx context.Context, purpose, token string) (*EmailToken, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hash := HashEmailToken(token)
+	t, exists := s.tokens[hash]
+	if !exists || t.Purpose != purpose {
+		return nil, &AuthError{Code: ErrTokenInvalid, Message: "invalid or expired token"}
+	}
+	delete(s.tokens, hash)
+	return t, nil
+}
+
+// CountRecentEmailTokens counts tokens issued to a user for a purpose since a time
+func (s *InMemoryEmailTokenStore) CountRecentEmailTokens(ctx context.Context, userID, purpose string, since time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var count int64
+	for _, t := range s.tokens {
+		if t.UserID == userID && t.Purpose == purpose && t.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// DeleteEmailTokens removes all of a user's tokens for a purpose
+func (s *InMemoryEmailTokenStore) DeleteEmailTokens(ctx context.Context, userID, purpose string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for hash, t := range s.tokens {
+		if t.UserID == userID && t.Purpose == purpose {
+			delete(s.tokens, hash)
+		}
+	}
+	return nil
+}
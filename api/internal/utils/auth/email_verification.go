@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// verificationQueueSize bounds the verification emails waiting to be sent; requests beyond it are dropped
+	verificationQueueSize = 256
+	// verificationSenders is the number of verification emails sent concurrently
+	verificationSenders = 4
+)
+
+// EmailVerifier sends and checks email verification tokens
+type EmailVerifier struct {
+	userStore UserStore
+	tokens    EmailTokenStore
+	sender    EmailSender
+	config    EmailProviderConfig
+	queue     chan *User // users to email a verification link; drained by Run
+}
+
+// NewEmailVerifier creates a new email verifier
+func NewEmailVerifier(userStore UserStore, tokens EmailTokenStore, sender EmailSender, config EmailProviderConfig) (*EmailVerifier, error) {
+	if userStore == nil {
+		return nil, errors.New("user store is required")
+	}
+	if tokens == nil {
+		return nil, errors.New("email token store is required")
+	}
+	if sender == nil {
+		return nil, errors.New("email sender is required")
+	}
+
+	// Set defaults
+	if config.VerificationTTL == 0 {
+		config.VerificationTTL = 24 * time.Hour
+	}
+
+	return &EmailVerifier{
+		userStore: userStore,
+		tokens:    tokens,
+		sender:    sender,
+		config:    config,
+		queue:     make(chan *User, verificationQueueSize),
+	}, nil
+}
+
+// Send emails a verification link to a user
+func (v *EmailVerifier) Send(ctx context.Context, user *User) error {
+	if user.EmailVerified || user.Email == "" || IsAnonymousEmail(user.Email) {
+		return nil
+	}
+
+	// Check rate limiting
+	if v.config.MaxVerificationEmailsPerHour > 0 {
+		count, err := v.tokens.CountRecentEmailTokens(ctx, user.ID, EmailTokenVerifyEmail, time.Now().Add(-time.Hour))
+		if err != nil {
+			return fmt.Errorf("failed to check rate limit: %w", err)
+		}
+		if count >= int64(v.config.MaxVerificationEmailsPerHour) {
+			return &AuthError{
+				Code:    ErrRateLimited,
+				Message: "too many verification emails, please try again later",
+			}
+		}
+	}
+
+	tokenString, err := GenerateEmailToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	token := &EmailToken{
+		Token:     tokenString,
+		UserID:    user.ID,
+		Purpose:   EmailTokenVerifyEmail,
+		Email:     user.Email,
+		ExpiresAt: now.Add(v.config.VerificationTTL),
+		CreatedAt: now,
+	}
+	if err := v.tokens.SaveEmailToken(ctx, token); err != nil {
+		return fmt.Errorf("failed to save verification token: %w", err)
+	}
+
+	if err := v.sender.SendVerificationEmail(ctx, user.Email, tokenString, tokenLink(v.config.VerificationURL, tokenString)); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	return nil
+}
+
+// SendTo emails a verification link to the owner of an address. It returns
+// nil for unknown, already verified and rate-limited addresses, and queues the
+// email for Run, so neither the result nor the response time reveals whether
+// an unverified account exists. When the queue is full the request is dropped
+// rather than waited on.
+func (v *EmailVerifier) SendTo(ctx context.Context, email string) error {
+	user, err := v.userStore.GetUserByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		var authErr *AuthError
+		if errors.As(err, &authErr) && authErr.Code == ErrUserNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.EmailVerified || user.Email == "" || IsAnonymousEmail(user.Email) {
+		return nil
+	}
+
+	select {
+	case v.queue <- user:
+	default:
+		log.Printf("verification email queue is full, dropping request for user %s", user.ID)
+	}
+	return nil
+}
+
+// Run sends queued verification emails until the context is cancelled
+func (v *EmailVerifier) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < verificationSenders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case user := <-v.queue:
+					if err := v.Send(ctx, user); err != nil {
+						log.Printf("failed to send verification email to user %s: %v", user.ID, err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Verify consumes a verification token and marks the user's email as verified
+func (v *EmailVerifier) Verify(ctx context.Context, tokenString string) (*User, error) {
+	invalid := &AuthError{
+		Code:    ErrTokenInvalid,
+		Message: "invalid or expired verification link",
+	}
+
+	token, err := v.tokens.ConsumeEmailToken(ctx, EmailTokenVerifyEmail, tokenString)
+	if err != nil {
+		return nil, invalid
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return nil, &AuthError{
+			Code:    ErrTokenExpired,
+			Message: "verification link has expired",
+		}
+	}
+
+	user, err := v.userStore.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, invalid
+	}
+
+	// The link only verifies the address it was sent to
+	if !strings.EqualFold(user.Email, token.Email) {
+		return nil, invalid
+	}
+
+	if !user.EmailVerified {
+		now := time.Now()
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		user.UpdatedAt = now
+		if err := v.userStore.UpdateUser(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+
+	// Any other outstanding links are now useless
+	if err := v.tokens.DeleteEmailTokens(ctx, user.ID, EmailTokenVerifyEmail); err != nil {
+		return nil, fmt.Errorf("failed to delete verification tokens: %w", err)
+	}
+
+	return user, nil
+}
+
+// tokenLink appends a token to a link as the token query parameter
+func tokenLink(baseURL, token string) string {
+	separator := "?"
+	if strings.Contains(baseURL, "?") {
+		separator = "&"
+	}
+	return baseURL + separator + "token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LoginAttempts tracks recent failed sign-ins for an account or IP
+type LoginAttempts struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+// LoginAttemptStore persists failed sign-in counters
+type LoginAttemptStore interface {
+	// GetLoginAttempts returns the counters for a key, or nil if there are none
+	GetLoginAttempts(ctx context.Context, key string) (*LoginAttempts, error)
+
+	// RecordLoginFailure atomically counts a failure. Counting restarts when
+	// the previous failure is older than windowStart.
+	RecordLoginFailure(ctx context.Context, key string, failedAt, windowStart time.Time) (*LoginAttempts, error)
+
+	// LockLogin refuses sign-ins for a key until a time
+	LockLogin(ctx context.Context, key string, until time.Time) error
+
+	// ResetLoginAttempts clears the counters for a key
+	ResetLoginAttempts(ctx context.Context, key string) error
+}
+
+// LoginThrottle slows down and locks out password guessing per account and per IP
+type LoginThrottle struct {
+	store  LoginAttemptStore
+	sender EmailSender // nil disables security alert emails
+	config LockoutConfig
+}
+
+// NewLoginThrottle creates a new login throttle
+func NewLoginThrottle(store LoginAttemptStore, sender EmailSender, config LockoutConfig) (*LoginThrottle, error) {
+	if store == nil {
+		return nil, errors.New("login attempt store is required")
+	}
+
+	// Set defaults
+	if config.LockoutDuration == 0 {
+		config.LockoutDuration = 15 * time.Minute
+	}
+	if config.FailureWindow == 0 {
+		config.FailureWindow = 15 * time.Minute
+	}
+
+	return &LoginThrottle{
+		store:  store,
+		sender: sender,
+		config: config,
+	}, nil
+}
+
+// Check refuses a sign-in while the account or IP is locked or backing off.
+// account is the email signed in with, or throttleAccount of a known user;
+// it counts whether or not an account has the email. Either may be empty.
+func (t *LoginThrottle) Check(ctx context.Context, account, ip string) error {
+	now := time.Now()
+
+	if ip != "" {
+		attempts, err := t.store.GetLoginAttempts(ctx, ipAttemptKey(ip))
+		if err != nil {
+			return fmt.Errorf("failed to get login attempts: %w", err)
+		}
+		if attempts != nil && attempts.LockedUntil != nil && attempts.LockedUntil.After(now) {
+			return tooManyAttemptsError(*attempts.LockedUntil)
+		}
+	}
+
+	if account != "" {
+		attempts, err := t.store.GetLoginAttempts(ctx, accountAttemptKey(account))
+		if err != nil {
+			return fmt.Errorf("failed to get login attempts: %w", err)
+		}
+		if attempts != nil {
+			if attempts.LockedUntil != nil && attempts.LockedUntil.After(now) {
+				return tooManyAttemptsError(*attempts.LockedUntil)
+			}
+			if retryAt := attempts.LastFailureAt.Add(t.backoff(attempts.Failures)); retryAt.After(now) {
+				return tooManyAttemptsError(retryAt)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Fail records a failed sign-in. user is nil when the email has no account;
+// the email is throttled the same either way. Reaching the account limit
+// locks it and alerts the owner, if there is one, by email.
+func (t *LoginThrottle) Fail(ctx context.Context, account string, user *User, ip string) error {
+	now := time.Now()
+	windowStart := now.Add(-t.config.FailureWindow)
+
+	if ip != "" {
+		attempts, err := t.store.RecordLoginFailure(ctx, ipAttemptKey(ip), now, windowStart)
+		if err != nil {
+			return fmt.Errorf("failed to record login failure: %w", err)
+		}
+		if t.config.MaxIPFailures > 0 && attempts.Failures >= t.config.MaxIPFailures {
+			if err := t.store.LockLogin(ctx, ipAttemptKey(ip), now.Add(t.config.LockoutDuration)); err != nil {
+				return fmt.Errorf("failed to lock IP: %w", err)
+			}
+		}
+	}
+
+	if account == "" {
+		return nil
+	}
+
+	attempts, err := t.store.RecordLoginFailure(ctx, accountAttemptKey(account), now, windowStart)
+	if err != nil {
+		return fmt.Errorf("failed to record login failure: %w", err)
+	}
+	if t.config.MaxAccountFailures <= 0 || attempts.Failures < t.config.MaxAccountFailures {
+		return nil
+	}
+
+	lockedUntil := now.Add(t.config.LockoutDuration)
+	if err := t.store.LockLogin(ctx, accountAttemptKey(account), lockedUntil); err != nil {
+		return fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	// Alert once, when the limit is first reached
+	if attempts.Failures == t.config.MaxAccountFailures && t.sender != nil && user != nil {
+		message := fmt.Sprintf(
+			"We blocked sign-in to your account until %s after %d failed password attempts. If this wasn't you, reset your password.",
+			lockedUntil.UTC().Format(time.RFC1123), attempts.Failures,
+		)
+		if err := t.sender.SendSecurityAlert(ctx, user.Email, message); err != nil {
+			log.Printf("failed to send security alert to user %s: %v", user.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Succeed clears the failure counters of an account after a correct password.
+// IP counters are kept so one valid account cannot be used to reset them.
+func (t *LoginThrottle) Succeed(ctx context.Context, account string) error {
+	return t.store.ResetLoginAttempts(ctx, accountAttemptKey(account))
+}
+
+// backoff returns the wait after a number of consecutive failures
+func (t *LoginThrottle) backoff(failures int) time.Duration {
+	if failures <= 0 || t.config.BackoffBase <= 0 {
+		return 0
+	}
+	delay := float64(t.config.BackoffBase) * math.Pow(2, float64(failures-1))
+	if t.config.BackoffMax > 0 && delay > float64(t.config.BackoffMax) {
+		return t.config.BackoffMax
+	}
+	return time.Duration(delay)
+}
+
+// throttleAccount is what a known user's sign-ins are throttled by: their
+// email, so the counters match sign-ins by email, or the ID of an account
+// without one. IDs have no "@" and can't collide with an email.
+func throttleAccount(user *User) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	return user.ID
+}
+
+// accountAttemptKey is the store key for an account; emails are normalized
+// and stored hashed
+func accountAttemptKey(account string) string {
+	return "account:" + hashClientValue(strings.ToLower(strings.TrimSpace(account)))
+}
+
+// ipAttemptKey is the store key for a client IP; IPs are stored hashed
+func ipAttemptKey(ip string) string {
+	return "ip:" + hashClientValue(ip)
+}
+
+// tooManyAttemptsError builds the AuthError for throttled sign-ins
+func tooManyAttemptsError(retryAt time.Time) *AuthError {
+	return &AuthError{
+		Code:    ErrTooManyAttempts,
+		Message: "too many failed sign-in attempts, please try again later",
+		Details: map[string]interface{}{
+			"retry_after_seconds": int(math.Ceil(time.Until(retryAt).Seconds())),
+		},
+	}
+}
+
+// InMemoryLoginAttemptStore is an in-memory implementation of LoginAttemptStore for single-instance deployments
+type InMemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]*LoginAttempts
+}
+
+// NewInMemoryLoginAttemptStore creates a new in-memory login attempt store
+func NewInMemoryLoginAttemptStore() *InMemoryLoginAttemptStore {
+	return &InMemoryLoginAttemptStore{
+		attempts: make(map[string]*LoginAttempts),
+	}
+}
+
+// GetLoginAttempts returns the counters for a key, or nil if there are none
+func (s *InMemoryLoginAttemptStore) GetLoginAttempts(ctx context.Context, key string) (*LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts, exists := s.attempts[key]
+	if !exists {
+		return nil, nil
+	}
+	copied := *attempts
+	return &copied, nil
+}
+
+// RecordLoginFailure counts a failure, restarting after the window
+func (s *InMemoryLoginAttemptStore) RecordLoginFailure(ctx context.Context, key string, failedAt, windowStart time.Time) (*LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attempts, exists := s.attempts[key]
+	if !exists {
+		attempts = &LoginAttempts{Key: key}
+		s.attempts[key] = attempts
+	}
+	if attempts.LastFailureAt.Before(windowStart) {
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = failedAt
+
+	copied := *attempts
+	return &copied, nil
+}
+
+// LockLogin refuses sign-ins for a key until a time
+func (s *InMemoryLoginAttemptStore) LockLogin(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempts, exists := s.attempts[key]; exists {
+		attempts.LockedUntil = &until
+	}
+	return nil
+}
+
+// ResetLoginAttempts clears the counters for a key
+func (s *InMemoryLoginAttemptStore) ResetLoginAttempts(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+	return nil
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestLoginThrottleLocksAccount fails an account's sign-in up to the limit:
+// it locks on the last failure, alerts the owner once, and a correct
+// password clears it again
+func TestLoginThrottleLocksAccount(t *testing.T) {
+	ctx := context.Background()
+	sender := &testEmailSender{}
+	throttle, err := NewLoginThrottle(NewInMemoryLoginAttemptStore(), sender, LockoutConfig{MaxAccountFailures: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &User{ID: "2b7e1516-28ae-4d2a-a6f7-15884f3c9a10", Email: "user@alunalun.test"}
+
+	for i := 0; i < 2; i++ {
+		if err := throttle.Fail(ctx, user.Email, user, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := throttle.Check(ctx, user.Email, ""); err != nil {
+			t.Fatalf("failure %d: expected no lock below the limit, got %v", i+1, err)
+		}
+	}
+
+	if err := throttle.Fail(ctx, user.Email, user, ""); err != nil {
+		t.Fatal(err)
+	}
+	requireAuthError(t, throttle.Check(ctx, user.Email, ""), ErrTooManyAttempts, "")
+	requireAuthError(t, throttle.Check(ctx, "  USER@alunalun.test ", ""), ErrTooManyAttempts, "")
+
+	if err := throttle.Fail(ctx, user.Email, user, ""); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.alerts) != 1 || sender.alerts[0] != user.Email {
+		t.Fatalf("expected one alert to the owner, got %v", sender.alerts)
+	}
+
+	if err := throttle.Succeed(ctx, user.Email); err != nil {
+		t.Fatal(err)
+	}
+	if err := throttle.Check(ctx, user.Email, ""); err != nil {
+		t.Fatalf("expected a correct password to clear the lock, got %v", err)
+	}
+}
+
+// TestLoginThrottleUnknownEmail locks emails without an account the same
+// way, without alerting anyone
+func TestLoginThrottleUnknownEmail(t *testing.T) {
+	ctx := context.Background()
+	sender := &testEmailSender{}
+	throttle, err := NewLoginThrottle(NewInMemoryLoginAttemptStore(), sender, LockoutConfig{MaxAccountFailures: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := throttle.Fail(ctx, "nobody@alunalun.test", nil, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	requireAuthError(t, throttle.Check(ctx, "nobody@alunalun.test", ""), ErrTooManyAttempts, "")
+	if len(sender.alerts) != 0 {
+		t.Fatalf("expected no alert, got %v", sender.alerts)
+	}
+}
+
+// TestLoginThrottleBackoff waits twice as long after each consecutive
+// failure, up to BackoffMax
+func TestLoginThrottleBackoff(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryLoginAttemptStore()
+	throttle, err := NewLoginThrottle(store, nil, LockoutConfig{
+		BackoffBase:   time.Minute,
+		BackoffMax:    5 * time.Minute,
+		FailureWindow: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const email = "user@alunalun.test"
+	key := accountAttemptKey(email)
+
+	if err := throttle.Fail(ctx, email, nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	err = throttle.Check(ctx, email, "")
+	requireAuthError(t, err, ErrTooManyAttempts, "")
+	if seconds := err.(*AuthError).Details["retry_after_seconds"].(int); seconds < 59 || seconds > 60 {
+		t.Fatalf("expected to wait a minute, got %ds", seconds)
+	}
+
+	store.attempts[key].LastFailureAt = time.Now().Add(-61 * time.Second)
+	if err := throttle.Check(ctx, email, ""); err != nil {
+		t.Fatalf("expected the first backoff to have passed, got %v", err)
+	}
+
+	if err := throttle.Fail(ctx, email, nil, ""); err != nil {
+		t.Fatal(err)
+	}
+	store.attempts[key].LastFailureAt = time.Now().Add(-90 * time.Second)
+	requireAuthError(t, throttle.Check(ctx, email, ""), ErrTooManyAttempts, "")
+
+	if got := throttle.backoff(10); got != 5*time.Minute {
+		t.Fatalf("expected backoff capped at 5m, got %v", got)
+	}
+}
+
+// TestLoginThrottleLocksIP locks an IP after failures across accounts; a
+// correct password for one account doesn't clear it
+func TestLoginThrottleLocksIP(t *testing.T) {
+	ctx := context.Background()
+	throttle, err := NewLoginThrottle(NewInMemoryLoginAttemptStore(), nil, LockoutConfig{MaxIPFailures: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const ip = "203.0.113.1"
+
+	for _, email := range []string{"a@alunalun.test", "b@alunalun.test", "c@alunalun.test"} {
+		if err := throttle.Fail(ctx, email, nil, ip); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := throttle.Succeed(ctx, "a@alunalun.test"); err != nil {
+		t.Fatal(err)
+	}
+
+	requireAuthError(t, throttle.Check(ctx, "d@alunalun.test", ip), ErrTooManyAttempts, "")
+	if err := throttle.Check(ctx, "d@alunalun.test", "198.51.100.7"); err != nil {
+		t.Fatalf("expected other IPs to be unaffected, got %v", err)
+	}
+}
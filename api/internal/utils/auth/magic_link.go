@@ -39,6 +39,9 @@ type MagicLinkTokenStore interface {
 // EmailSender handles sending emails
 type EmailSender interface {
 	SendMagicLink(ctx context.Context, email, token, linkURL string) error
+	SendVerificationEmail(ctx context.Context, email, token, linkURL string) error
+	SendPasswordReset(ctx context.Context, email, token, linkURL string) error
+	SendSecurityAlert(ctx context.Context, email, message string) error
 }
 
 // MagicLinkToken represents a magic link token
@@ -0,0 +1,458 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TOTPEnrollment is a user's authenticator app secret. It only protects sign-in once confirmed.
+type TOTPEnrollment struct {
+	UserID       string
+	Secret       string
+	ConfirmedAt  *time.Time // nil while enrollment is pending
+	LastUsedStep int64      // last accepted time step, to reject code reuse
+	CreatedAt    time.Time
+}
+
+// MFAStore persists TOTP secrets and recovery codes
+type MFAStore interface {
+	// GetTOTP returns a user's enrollment. Returns ErrNotFound if there is none.
+	GetTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error)
+
+	// SaveTOTP starts a pending enrollment, replacing any earlier one
+	SaveTOTP(ctx context.Context, enrollment *TOTPEnrollment) error
+
+	// ConfirmTOTP enables a pending enrollment. Returns ErrNotFound if none is pending.
+	ConfirmTOTP(ctx context.Context, userID string, confirmedAt time.Time, step int64) error
+
+	// UseTOTPStep records an accepted time step. Returns ErrTokenInvalid if it was already used.
+	UseTOTPStep(ctx context.Context, userID string, step int64) error
+
+	// DeleteTOTP removes a user's enrollment and recovery codes
+	DeleteTOTP(ctx context.Context, userID string) error
+
+	// ReplaceRecoveryCodes stores new recovery code hashes, dropping the old ones
+	ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error
+
+	// ConsumeRecoveryCode deletes a matching recovery code. Returns ErrTokenInvalid if none matches.
+	ConsumeRecoveryCode(ctx context.Context, userID, hash string) error
+}
+
+// MFAChallenge is the encrypted state of a sign-in waiting for its second factor
+type MFAChallenge struct {
+	Nonce              string    `json:"n"`
+	UserID             string    `json:"u"`
+	UserInfo           *UserInfo `json:"i"`             // Identity from the first factor, to finish sign-in
+	SessionID          string    `json:"sid,omitempty"` // Anonymous session to migrate
+	UsernameResolution string    `json:"ur,omitempty"`
+	ExpiresAt          int64     `json:"e"`
+}
+
+// MFAManager handles TOTP enrollment, second-factor checks and sign-in challenges
+type MFAManager struct {
+	store        MFAStore
+	stateManager *StateManager
+	throttle     *LoginThrottle // nil disables backoff on wrong codes
+	config       MFAConfig
+}
+
+// NewMFAManager creates a new MFA manager. Challenges are encrypted and made
+// single-use with the state manager's key and replay store.
+func NewMFAManager(store MFAStore, stateManager *StateManager, config MFAConfig) (*MFAManager, error) {
+	if store == nil {
+		return nil, errors.New("MFA store is required")
+	}
+	if stateManager == nil {
+		return nil, errors.New("state manager is required")
+	}
+
+	// Set defaults
+	if config.Issuer == "" {
+		config.Issuer = "Alunalun"
+	}
+	if config.ChallengeTTL == 0 {
+		config.ChallengeTTL = 5 * time.Minute
+	}
+	if config.RecoveryCodeCount == 0 {
+		config.RecoveryCodeCount = 10
+	}
+
+	return &MFAManager{
+		store:        store,
+		stateManager: stateManager,
+		config:       config,
+	}, nil
+}
+
+// SetLoginThrottle counts wrong codes as failed sign-ins for backoff and lockout
+func (m *MFAManager) SetLoginThrottle(throttle *LoginThrottle) {
+	m.throttle = throttle
+}
+
+// Enabled reports whether a user has confirmed TOTP
+func (m *MFAManager) Enabled(ctx context.Context, userID string) (bool, error) {
+	enrollment, err := m.store.GetTOTP(ctx, userID)
+	if err != nil {
+		var authErr *AuthError
+		if errors.As(err, &authErr) && authErr.Code == ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return enrollment.ConfirmedAt != nil, nil
+}
+
+// Enroll starts TOTP enrollment and returns the secret and otpauth URI.
+// It takes effect once confirmed with a code from the authenticator app.
+func (m *MFAManager) Enroll(ctx context.Context, user *User) (string, string, error) {
+	enabled, err := m.Enabled(ctx, user.ID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to check two-factor status: %w", err)
+	}
+	if enabled {
+		return "", "", &AuthError{
+			Code:    ErrAlreadyExists,
+			Message: "two-factor authentication is already enabled",
+		}
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+	if err := m.store.SaveTOTP(ctx, &TOTPEnrollment{
+		UserID:    user.ID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return "", "", fmt.Errorf("failed to save TOTP enrollment: %w", err)
+	}
+
+	account := user.Email
+	if account == "" || IsAnonymousEmail(account) {
+		account = user.Username
+	}
+	return secret, TOTPURI(m.config.Issuer, account, secret), nil
+}
+
+// Confirm enables TOTP with the first code from the authenticator app and
+// returns one-time recovery codes. The codes are only stored hashed.
+func (m *MFAManager) Confirm(ctx context.Context, userID, code string) ([]string, error) {
+	enrollment, err := m.store.GetTOTP(ctx, userID)
+	if err != nil || enrollment.ConfirmedAt != nil {
+		return nil, &AuthError{
+			Code:    ErrTokenInvalid,
+			Message: "no pending two-factor enrollment; enroll first",
+		}
+	}
+
+	step, ok := ValidateTOTP(enrollment.Secret, code, time.Now())
+	if !ok {
+		return nil, invalidMFACodeError()
+	}
+
+	codes, hashes, err := generateRecoveryCodes(m.config.RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to save recovery codes: %w", err)
+	}
+	if err := m.store.ConfirmTOTP(ctx, userID, time.Now(), step); err != nil {
+		return nil, fmt.Errorf("failed to confirm TOTP: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Disable turns off TOTP after checking a current code or recovery code
+func (m *MFAManager) Disable(ctx context.Context, user *User, code string) error {
+	if err := m.Verify(ctx, user, code); err != nil {
+		return err
+	}
+	if err := m.store.DeleteTOTP(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to disable TOTP: %w", err)
+	}
+	return nil
+}
+
+// Verify checks a TOTP code or, failing that, a one-time recovery code.
+// Wrong codes count towards the account's sign-in backoff and lockout.
+func (m *MFAManager) Verify(ctx context.Context, user *User, code string) error {
+	ip := ClientIPFromContext(ctx)
+	if m.throttle != nil {
+		if err := m.throttle.Check(ctx, throttleAccount(user), ip); err != nil {
+			return err
+		}
+	}
+
+	if err := m.verifyCode(ctx, user.ID, code); err != nil {
+		var authErr *AuthError
+		if m.throttle != nil && errors.As(err, &authErr) && authErr.Code == ErrInvalidCredentials {
+			if err := m.throttle.Fail(ctx, throttleAccount(user), user, ip); err != nil {
+				log.Printf("failed to record MFA failure: %v", err)
+			}
+		}
+		return err
+	}
+
+	if m.throttle != nil {
+		if err := m.throttle.Succeed(ctx, throttleAccount(user)); err != nil {
+			log.Printf("failed to reset login attempts for user %s: %v", user.ID, err)
+		}
+	}
+	return nil
+}
+
+// verifyCode accepts an unused TOTP code or a recovery code
+func (m *MFAManager) verifyCode(ctx context.Context, userID, code string) error {
+	enrollment, err := m.store.GetTOTP(ctx, userID)
+	if err != nil || enrollment.ConfirmedAt == nil {
+		return &AuthError{
+			Code:    ErrTokenInvalid,
+			Message: "two-factor authentication is not enabled",
+		}
+	}
+
+	if step, ok := ValidateTOTP(enrollment.Secret, code, time.Now()); ok {
+		if step <= enrollment.LastUsedStep {
+			return invalidMFACodeError()
+		}
+		if err := m.store.UseTOTPStep(ctx, userID, step); err != nil {
+			var authErr *AuthError
+			if errors.As(err, &authErr) && authErr.Code == ErrTokenInvalid {
+				return invalidMFACodeError()
+			}
+			return fmt.Errorf("failed to record TOTP use: %w", err)
+		}
+		return nil
+	}
+
+	if err := m.store.ConsumeRecoveryCode(ctx, userID, hashRecoveryCode(code)); err != nil {
+		var authErr *AuthError
+		if errors.As(err, &authErr) && authErr.Code == ErrTokenInvalid {
+			return invalidMFACodeError()
+		}
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	return nil
+}
+
+// IssueChallenge creates an encrypted, short-lived challenge for a sign-in
+// that passed its first factor
+func (m *MFAManager) IssueChallenge(userID string, info *UserInfo, sessionID, usernameResolution string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	challenge := &MFAChallenge{
+		Nonce:              base64.RawURLEncoding.EncodeToString(nonce),
+		UserID:             userID,
+		UserInfo:           info,
+		SessionID:          sessionID,
+		UsernameResolution: usernameResolution,
+		ExpiresAt:          time.Now().Add(m.config.ChallengeTTL).Unix(),
+	}
+
+	plaintext, err := json.Marshal(challenge)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal challenge: %w", err)
+	}
+	ciphertext, err := m.stateManager.encrypt(plaintext)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt challenge: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// OpenChallenge decrypts and checks the expiry of a challenge. It can be
+// opened again after a wrong code; call ConsumeChallenge once the code is right.
+func (m *MFAManager) OpenChallenge(token string) (*MFAChallenge, error) {
+	invalid := &AuthError{
+		Code:    ErrTokenInvalid,
+		Message: "invalid or expired sign-in challenge",
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	plaintext, err := m.stateManager.decrypt(ciphertext)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var challenge MFAChallenge
+	if err := json.Unmarshal(plaintext, &challenge); err != nil || challenge.UserInfo == nil {
+		return nil, invalid
+	}
+	if time.Now().Unix() > challenge.ExpiresAt {
+		return nil, &AuthError{
+			Code:    ErrTokenExpired,
+			Message: "sign-in challenge has expired, please sign in again",
+		}
+	}
+	return &challenge, nil
+}
+
+// ConsumeChallenge marks a challenge used so it cannot complete a second sign-in
+func (m *MFAManager) ConsumeChallenge(ctx context.Context, challenge *MFAChallenge) error {
+	fresh, err := m.stateManager.replayStore.MarkUsed(ctx, "mfa:"+challenge.Nonce, time.Unix(challenge.ExpiresAt, 0))
+	if err != nil {
+		return fmt.Errorf("failed to check challenge replay: %w", err)
+	}
+	if !fresh {
+		return &AuthError{
+			Code:    ErrTokenInvalid,
+			Message: "sign-in challenge has already been used",
+		}
+	}
+	return nil
+}
+
+// generateRecoveryCodes creates readable one-time codes and their hashes
+func generateRecoveryCodes(count int) ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, 0, count)
+	hashes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		raw := strings.ToLower(encoding.EncodeToString(b)) // 16 characters
+		code := raw[:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode normalizes and hashes a recovery code for storage
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// invalidMFACodeError builds the AuthError for a wrong second-factor code
+func invalidMFACodeError() *AuthError {
+	return &AuthError{
+		Code:    ErrInvalidCredentials,
+		Message: "invalid two-factor code",
+	}
+}
+
+// InMemoryMFAStore is an in-memory implementation of MFAStore for single-instance deployments
+type InMemoryMFAStore struct {
+	mu            sync.Mutex
+	enrollments   map[string]*TOTPEnrollment
+	recoveryCodes map[string]map[string]bool // user ID -> code hashes
+}
+
+// NewInMemoryMFAStore creates a new in-memory MFA store
+func NewInMemoryMFAStore() *InMemoryMFAStore {
+	return &InMemoryMFAStore{
+		enrollments:   make(map[string]*TOTPEnrollment),
+		recoveryCodes: make(map[string]map[string]bool),
+	}
+}
+
+// GetTOTP returns a user's enrollment
+func (s *InMemoryMFAStore) GetTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enrollment, exists := s.enrollments[userID]
+	if !exists {
+		return nil, &AuthError{Code: ErrNotFound, Message: "two-factor enrollment not found"}
+	}
+	copied := *enrollment
+	return &copied, nil
+}
+
+// SaveTOTP starts a pending enrollment
+func (s *InMemoryMFAStore) SaveTOTP(ctx context.Context, enrollment *TOTPEnrollment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := *enrollment
+	stored.ConfirmedAt = nil
+	stored.LastUsedStep = 0
+	s.enrollments[enrollment.UserID] = &stored
+	return nil
+}
+
+// ConfirmTOTP enables a pending enrollment
+func (s *InMemoryMFAStore) ConfirmTOTP(ctx context.Context, userID string, confirmedAt time.Time, step int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enrollment, exists := s.enrollments[userID]
+	if !exists || enrollment.ConfirmedAt != nil {
+		return &AuthError{Code: ErrNotFound, Message: "no pending two-factor enrollment"}
+	}
+	enrollment.ConfirmedAt = &confirmedAt
+	enrollment.LastUsedStep = step
+	return nil
+}
+
+// UseTOTPStep records an accepted time step
+func (s *InMemoryMFAStore) UseTOTPStep(ctx context.Context, userID string, step int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	enrollment, exists := s.enrollments[userID]
+	if !exists || step <= enrollment.LastUsedStep {
+		return &AuthError{Code: ErrTokenInvalid, Message: "code already used"}
+	}
+	enrollment.LastUsedStep = step
+	return nil
+}
+
+// DeleteTOTP removes a user's enrollment and recovery codes
+func (s *InMemoryMFAStore) DeleteTOTP(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.enrollments, userID)
+	delete(s.recoveryCodes, userID)
+	return nil
+}
+
+// ReplaceRecoveryCodes stores new recovery code hashes
+func (s *InMemoryMFAStore) ReplaceRecoveryCodes(ctx context.Context, userID string, hashes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	codes := make(map[string]bool, len(hashes))
+	for _, hash := range hashes {
+		codes[hash] = true
+	}
+	s.recoveryCodes[userID] = codes
+	return nil
+}
+
+// ConsumeRecoveryCode deletes a matching recovery code
+func (s *InMemoryMFAStore) ConsumeRecoveryCode(ctx context.Context, userID, hash string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.recoveryCodes[userID][hash] {
+		return &AuthError{Code: ErrTokenInvalid, Message: "invalid recovery code"}
+	}
+	delete(s.recoveryCodes[userID], hash)
+	return nil
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestMFAFailuresOutlastCorrectPasswords alternates the right password with
+// wrong codes: the password alone must not clear the failures, so the
+// account locks once the wrong codes reach the limit.
+func TestMFAFailuresOutlastCorrectPasswords(t *testing.T) {
+	ctx := context.Background()
+	config := EmailProviderConfig{BcryptCost: 4}
+
+	hash, err := HashPassword("correct horse battery", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &User{
+		ID:            "2b7e1516-28ae-4d2a-a6f7-15884f3c9a10",
+		Email:         "user@alunalun.test",
+		Username:      "user",
+		PasswordHash:  hash,
+		EmailVerified: true,
+		Status:        "active",
+	}
+	users := &testUserStore{users: map[string]*User{user.ID: user}}
+
+	throttle, err := NewLoginThrottle(NewInMemoryLoginAttemptStore(), nil, LockoutConfig{MaxAccountFailures: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stateManager, err := NewStateManager(make([]byte, 32), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfaStore := NewInMemoryMFAStore()
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mfaStore.SaveTOTP(ctx, &TOTPEnrollment{UserID: user.ID, Secret: secret, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mfaStore.ConfirmTOTP(ctx, user.ID, time.Now(), 0); err != nil {
+		t.Fatal(err)
+	}
+	mfa, err := NewMFAManager(mfaStore, stateManager, MFAConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mfa.SetLoginThrottle(throttle)
+
+	provider, err := NewEmailPasswordProvider(users, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.SetLoginThrottle(throttle)
+	provider.SetMFAManager(mfa)
+
+	credential, _ := json.Marshal(EmailPasswordCredentials{Email: user.Email, Password: "correct horse battery"})
+	for i := 0; i < 3; i++ {
+		if _, err := provider.Authenticate(ctx, string(credential)); err != nil {
+			t.Fatalf("password %d: %v", i+1, err)
+		}
+		requireAuthError(t, mfa.Verify(ctx, user, "not-a-code"), ErrInvalidCredentials, "")
+	}
+
+	_, err = provider.Authenticate(ctx, string(credential))
+	requireAuthError(t, err, ErrTooManyAttempts, "")
+}
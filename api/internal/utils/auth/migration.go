@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AnonymousEmailDomain is the domain of placeholder emails given to anonymous users
+const AnonymousEmailDomain = "local.user"
+
+// Username resolutions when an anonymous account is merged into a registered one
+const (
+	// UsernameKeepRegistered keeps the registered account's username
+	UsernameKeepRegistered = "keep_registered"
+
+	// UsernameKeepAnonymous moves the anonymous username to the registered account
+	UsernameKeepAnonymous = "keep_anonymous"
+)
+
+// AccountMigrator moves anonymous accounts onto registered ones.
+// Implementations must apply each operation atomically.
+type AccountMigrator interface {
+	// MergeAnonymousUser re-owns all content of the anonymous user to userID and
+	// deletes the anonymous user. A non-empty username is applied to userID after
+	// the anonymous row (which may hold it) is removed.
+	MergeAnonymousUser(ctx context.Context, anonUserID, userID, username string) error
+
+	// PromoteAnonymousUser turns the anonymous user row into a registered account
+	// in place and records its first provider link
+	PromoteAnonymousUser(ctx context.Context, user *User, link *ProviderLink) error
+}
+
+// AnonymousEmail returns the placeholder email for an anonymous user
+func AnonymousEmail(userID string) string {
+	return fmt.Sprintf("anonymous-%s@%s", userID, AnonymousEmailDomain)
+}
+
+// IsAnonymousEmail reports whether email is an anonymous placeholder
+func IsAnonymousEmail(email string) bool {
+	return strings.HasPrefix(email, "anonymous-") && strings.HasSuffix(email, "@"+AnonymousEmailDomain)
+}
@@ -0,0 +1,558 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WebAuthn ceremony types in client data
+const (
+	passkeyCeremonyCreate = "webauthn.create"
+	passkeyCeremonyGet    = "webauthn.get"
+)
+
+// PasskeyCredential is a WebAuthn credential registered to a user
+type PasskeyCredential struct {
+	ID         string // base64url credential ID
+	UserID     string
+	PublicKey  []byte // COSE_Key
+	SignCount  uint32
+	Transports []string
+	Name       string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// PasskeyStore persists passkey credentials
+type PasskeyStore interface {
+	// CreatePasskey stores a new credential. Returns ErrAlreadyExists if the ID is registered.
+	CreatePasskey(ctx context.Context, credential *PasskeyCredential) error
+
+	// GetPasskey finds a credential by ID. Returns ErrNotFound if there is none.
+	GetPasskey(ctx context.Context, credentialID string) (*PasskeyCredential, error)
+
+	// ListPasskeys lists a user's credentials, newest first
+	ListPasskeys(ctx context.Context, userID string) ([]*PasskeyCredential, error)
+
+	// UpdatePasskeyUse records a sign-in with a credential
+	UpdatePasskeyUse(ctx context.Context, credentialID string, signCount uint32, usedAt time.Time) error
+
+	// DeletePasskey removes a user's credential. Returns ErrNotFound if the user has no such credential.
+	DeletePasskey(ctx context.Context, userID, credentialID string) error
+}
+
+// PasskeyLoginRequest is the credential format for passkey authentication
+type PasskeyLoginRequest struct {
+	ChallengeToken string          `json:"challenge_token"` // From BeginLogin
+	Credential     json.RawMessage `json:"credential"`      // PublicKeyCredential.toJSON() of the assertion
+}
+
+// passkeyChallenge is the encrypted state of a pending ceremony
+type passkeyChallenge struct {
+	Challenge string `json:"c"`
+	Ceremony  string `json:"t"`
+	UserID    string `json:"u,omitempty"` // Registering user
+	ExpiresAt int64  `json:"e"`
+}
+
+// passkeyCredentialJSON is PublicKeyCredential.toJSON() for both ceremonies
+type passkeyCredentialJSON struct {
+	ID       string `json:"id"`
+	RawID    string `json:"rawId"`
+	Type     string `json:"type"`
+	Response struct {
+		ClientDataJSON    string   `json:"clientDataJSON"`
+		AttestationObject string   `json:"attestationObject"`
+		Transports        []string `json:"transports"`
+		AuthenticatorData string   `json:"authenticatorData"`
+		Signature         string   `json:"signature"`
+		UserHandle        string   `json:"userHandle"`
+	} `json:"response"`
+}
+
+// PasskeyProvider implements passwordless sign-in with WebAuthn passkeys
+type PasskeyProvider struct {
+	store        PasskeyStore
+	userStore    UserStore
+	stateManager *StateManager
+	config       PasskeyConfig
+}
+
+// NewPasskeyProvider creates a new passkey provider. Ceremony challenges are
+// encrypted and made single-use with the state manager's key and replay store.
+func NewPasskeyProvider(store PasskeyStore, userStore UserStore, stateManager *StateManager, config PasskeyConfig) (*PasskeyProvider, error) {
+	if store == nil {
+		return nil, errors.New("passkey store is required")
+	}
+	if userStore == nil {
+		return nil, errors.New("user store is required")
+	}
+	if stateManager == nil {
+		return nil, errors.New("state manager is required")
+	}
+
+	// Set defaults
+	if config.RPName == "" {
+		config.RPName = "Alunalun"
+	}
+	if config.ChallengeTTL == 0 {
+		config.ChallengeTTL = 5 * time.Minute
+	}
+	if config.UserVerification == "" {
+		config.UserVerification = "preferred"
+	}
+
+	provider := &PasskeyProvider{
+		store:        store,
+		userStore:    userStore,
+		stateManager: stateManager,
+		config:       config,
+	}
+	if err := provider.ValidateConfig(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// Name returns the provider name
+func (p *PasskeyProvider) Name() string {
+	return "passkey"
+}
+
+// Type returns the provider type
+func (p *PasskeyProvider) Type() string {
+	return "internal"
+}
+
+// ValidateConfig validates the provider configuration
+func (p *PasskeyProvider) ValidateConfig() error {
+	if p.config.RPID == "" {
+		return errors.New("passkey relying party ID is required")
+	}
+	if len(p.config.Origins) == 0 {
+		return errors.New("at least one passkey origin is required")
+	}
+	switch p.config.UserVerification {
+	case "required", "preferred", "discouraged":
+	default:
+		return fmt.Errorf("invalid passkey user verification %q", p.config.UserVerification)
+	}
+	return nil
+}
+
+// BeginRegistration returns PublicKeyCredentialCreationOptions as JSON for
+// navigator.credentials.create, and the challenge token to finish with
+func (p *PasskeyProvider) BeginRegistration(ctx context.Context, user *User) (string, string, error) {
+	existing, err := p.store.ListPasskeys(ctx, user.ID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to list passkeys: %w", err)
+	}
+
+	challenge, token, err := p.newChallenge(passkeyCeremonyCreate, user.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	exclude := make([]map[string]interface{}, 0, len(existing))
+	for _, credential := range existing {
+		exclude = append(exclude, map[string]interface{}{
+			"type":       "public-key",
+			"id":         credential.ID,
+			"transports": credential.Transports,
+		})
+	}
+
+	name := user.Email
+	if name == "" || IsAnonymousEmail(name) {
+		name = user.Username
+	}
+	options := map[string]interface{}{
+		"rp": map[string]string{
+			"id":   p.config.RPID,
+			"name": p.config.RPName,
+		},
+		"user": map[string]string{
+			"id":          base64.RawURLEncoding.EncodeToString([]byte(user.ID)),
+			"name":        name,
+			"displayName": user.Username,
+		},
+		"challenge": challenge,
+		"pubKeyCredParams": []map[string]interface{}{
+			{"type": "public-key", "alg": coseAlgES256},
+			{"type": "public-key", "alg": coseAlgEdDSA},
+			{"type": "public-key", "alg": coseAlgRS256},
+		},
+		"timeout":            p.config.ChallengeTTL.Milliseconds(),
+		"excludeCredentials": exclude,
+		"authenticatorSelection": map[string]interface{}{
+			"residentKey":        "required",
+			"requireResidentKey": true,
+			"userVerification":   p.config.UserVerification,
+		},
+		"attestation": "none",
+	}
+
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal options: %w", err)
+	}
+	return string(optionsJSON), token, nil
+}
+
+// FinishRegistration verifies the authenticator's response and stores the new credential
+func (p *PasskeyProvider) FinishRegistration(ctx context.Context, user *User, challengeToken, credentialJSON, name string) (*PasskeyCredential, error) {
+	state, err := p.openChallenge(ctx, challengeToken, passkeyCeremonyCreate)
+	if err != nil {
+		return nil, err
+	}
+	if state.UserID != user.ID {
+		return nil, invalidPasskeyError("challenge was issued to another user")
+	}
+
+	var credential passkeyCredentialJSON
+	if err := json.Unmarshal([]byte(credentialJSON), &credential); err != nil || credential.Type != "public-key" {
+		return nil, invalidPasskeyError("invalid credential")
+	}
+	clientDataJSON, err := decodeWebAuthnBytes(credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, invalidPasskeyError("invalid client data")
+	}
+	if err := verifyClientData(clientDataJSON, passkeyCeremonyCreate, state.Challenge, p.config.Origins); err != nil {
+		return nil, invalidPasskeyError(err.Error())
+	}
+
+	attestationObject, err := decodeWebAuthnBytes(credential.Response.AttestationObject)
+	if err != nil {
+		return nil, invalidPasskeyError("invalid attestation object")
+	}
+	rawAuthData, err := parseAttestationObject(attestationObject)
+	if err != nil {
+		return nil, invalidPasskeyError(err.Error())
+	}
+	authData, err := parseAuthData(rawAuthData, p.config.RPID, p.config.UserVerification == "required")
+	if err != nil {
+		return nil, invalidPasskeyError(err.Error())
+	}
+	if authData.CredentialID == nil {
+		return nil, invalidPasskeyError("authenticator returned no credential")
+	}
+	if _, _, err := parseCOSEKey(authData.PublicKey); err != nil {
+		return nil, invalidPasskeyError(err.Error())
+	}
+
+	now := time.Now()
+	passkey := &PasskeyCredential{
+		ID:         base64.RawURLEncoding.EncodeToString(authData.CredentialID),
+		UserID:     user.ID,
+		PublicKey:  authData.PublicKey,
+		SignCount:  authData.SignCount,
+		Transports: credential.Response.Transports,
+		Name:       name,
+		CreatedAt:  now,
+	}
+	if passkey.Name == "" {
+		passkey.Name = "Passkey"
+	}
+	if err := p.store.CreatePasskey(ctx, passkey); err != nil {
+		return nil, err
+	}
+	return passkey, nil
+}
+
+// BeginLogin returns PublicKeyCredentialRequestOptions as JSON for
+// navigator.credentials.get, and the challenge token to authenticate with.
+// No credentials are listed, so the authenticator offers its discoverable passkeys.
+func (p *PasskeyProvider) BeginLogin(ctx context.Context) (string, string, error) {
+	challenge, token, err := p.newChallenge(passkeyCeremonyGet, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	options := map[string]interface{}{
+		"challenge":        challenge,
+		"timeout":          p.config.ChallengeTTL.Milliseconds(),
+		"rpId":             p.config.RPID,
+		"allowCredentials": []interface{}{},
+		"userVerification": p.config.UserVerification,
+	}
+
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal options: %w", err)
+	}
+	return string(optionsJSON), token, nil
+}
+
+// Authenticate verifies a passkey assertion. The credential is a JSON PasskeyLoginRequest.
+func (p *PasskeyProvider) Authenticate(ctx context.Context, credential string) (*UserInfo, error) {
+	var req PasskeyLoginRequest
+	if err := json.Unmarshal([]byte(credential), &req); err != nil {
+		return nil, &AuthError{
+			Code:    ErrInvalidCredentials,
+			Message: "invalid passkey credential format",
+		}
+	}
+
+	state, err := p.openChallenge(ctx, req.ChallengeToken, passkeyCeremonyGet)
+	if err != nil {
+		return nil, err
+	}
+
+	var assertion passkeyCredentialJSON
+	if err := json.Unmarshal(req.Credential, &assertion); err != nil || assertion.Type != "public-key" {
+		return nil, invalidPasskeyError("invalid credential")
+	}
+	rawID, err := decodeWebAuthnBytes(assertion.RawID)
+	if err != nil || len(rawID) == 0 {
+		return nil, invalidPasskeyError("invalid credential ID")
+	}
+
+	passkey, err := p.store.GetPasskey(ctx, base64.RawURLEncoding.EncodeToString(rawID))
+	if err != nil {
+		var authErr *AuthError
+		if errors.As(err, &authErr) && authErr.Code == ErrNotFound {
+			return nil, invalidPasskeyError("unknown passkey")
+		}
+		return nil, fmt.Errorf("failed to get passkey: %w", err)
+	}
+
+	// A discoverable credential reports its user; it must be the owner we stored
+	if assertion.Response.UserHandle != "" {
+		userHandle, err := decodeWebAuthnBytes(assertion.Response.UserHandle)
+		if err != nil || string(userHandle) != passkey.UserID {
+			return nil, invalidPasskeyError("user handle mismatch")
+		}
+	}
+
+	clientDataJSON, err := decodeWebAuthnBytes(assertion.Response.ClientDataJSON)
+	if err != nil {
+		return nil, invalidPasskeyError("invalid client data")
+	}
+	if err := verifyClientData(clientDataJSON, passkeyCeremonyGet, state.Challenge, p.config.Origins); err != nil {
+		return nil, invalidPasskeyError(err.Error())
+	}
+
+	rawAuthData, err := decodeWebAuthnBytes(assertion.Response.AuthenticatorData)
+	if err != nil {
+		return nil, invalidPasskeyError("invalid authenticator data")
+	}
+	authData, err := parseAuthData(rawAuthData, p.config.RPID, p.config.UserVerification == "required")
+	if err != nil {
+		return nil, invalidPasskeyError(err.Error())
+	}
+
+	signature, err := decodeWebAuthnBytes(assertion.Response.Signature)
+	if err != nil {
+		return nil, invalidPasskeyError("invalid signature")
+	}
+	if err := verifyAssertionSignature(passkey.PublicKey, rawAuthData, clientDataJSON, signature); err != nil {
+		return nil, invalidPasskeyError(err.Error())
+	}
+
+	// A counter that does not move forward suggests a cloned authenticator.
+	// Synced passkeys always report zero.
+	if (authData.SignCount != 0 || passkey.SignCount != 0) && authData.SignCount <= passkey.SignCount {
+		return nil, invalidPasskeyError("sign counter did not increase; the authenticator may be cloned")
+	}
+	if err := p.store.UpdatePasskeyUse(ctx, passkey.ID, authData.SignCount, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to record passkey use: %w", err)
+	}
+
+	user, err := p.userStore.GetUserByID(ctx, passkey.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status != "active" {
+		return nil, &AuthError{
+			Code:    ErrUserDisabled,
+			Message: "user account is disabled",
+		}
+	}
+
+	return &UserInfo{
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Picture:       user.Picture,
+		Provider:      "passkey",
+		EmailVerified: user.EmailVerified,
+		Metadata: map[string]interface{}{
+			"credential_id": passkey.ID,
+			"user_verified": authData.Flags&webauthnFlagUserVerified != 0,
+		},
+	}, nil
+}
+
+// ListCredentials lists a user's passkeys
+func (p *PasskeyProvider) ListCredentials(ctx context.Context, userID string) ([]*PasskeyCredential, error) {
+	return p.store.ListPasskeys(ctx, userID)
+}
+
+// DeleteCredential removes one of a user's passkeys
+func (p *PasskeyProvider) DeleteCredential(ctx context.Context, userID, credentialID string) error {
+	return p.store.DeletePasskey(ctx, userID, credentialID)
+}
+
+// newChallenge creates a random challenge and its encrypted state token
+func (p *PasskeyProvider) newChallenge(ceremony, userID string) (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	state := &passkeyChallenge{
+		Challenge: base64.RawURLEncoding.EncodeToString(b),
+		Ceremony:  ceremony,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(p.config.ChallengeTTL).Unix(),
+	}
+
+	plaintext, err := json.Marshal(state)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal challenge: %w", err)
+	}
+	ciphertext, err := p.stateManager.encrypt(plaintext)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt challenge: %w", err)
+	}
+	return state.Challenge, base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// openChallenge decrypts a challenge token and consumes it so it cannot be replayed
+func (p *PasskeyProvider) openChallenge(ctx context.Context, token, ceremony string) (*passkeyChallenge, error) {
+	invalid := &AuthError{
+		Code:    ErrTokenInvalid,
+		Message: "invalid or expired passkey challenge",
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	plaintext, err := p.stateManager.decrypt(ciphertext)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var state passkeyChallenge
+	if err := json.Unmarshal(plaintext, &state); err != nil || state.Ceremony != ceremony {
+		return nil, invalid
+	}
+	if time.Now().Unix() > state.ExpiresAt {
+		return nil, &AuthError{
+			Code:    ErrTokenExpired,
+			Message: "passkey challenge has expired",
+		}
+	}
+
+	fresh, err := p.stateManager.replayStore.MarkUsed(ctx, "passkey:"+state.Challenge, time.Unix(state.ExpiresAt, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check challenge replay: %w", err)
+	}
+	if !fresh {
+		return nil, invalid
+	}
+	return &state, nil
+}
+
+// invalidPasskeyError builds the AuthError for a rejected passkey response
+func invalidPasskeyError(reason string) *AuthError {
+	return &AuthError{
+		Code:    ErrInvalidCredentials,
+		Message: "passkey verification failed",
+		Details: map[string]interface{}{"reason": reason},
+	}
+}
+
+// InMemoryPasskeyStore is an in-memory implementation of PasskeyStore for single-instance deployments
+type InMemoryPasskeyStore struct {
+	mu       sync.RWMutex
+	passkeys map[string]*PasskeyCredential
+}
+
+// NewInMemoryPasskeyStore creates a new in-memory passkey store
+func NewInMemoryPasskeyStore() *InMemoryPasskeyStore {
+	return &InMemoryPasskeyStore{
+		passkeys: make(map[string]*PasskeyCredential),
+	}
+}
+
+// CreatePasskey stores a new credential
+func (s *InMemoryPasskeyStore) CreatePasskey(ctx context.Context, credential *PasskeyCredential) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.passkeys[credential.ID]; exists {
+		return &AuthError{Code: ErrAlreadyExists, Message: "passkey is already registered"}
+	}
+	stored := *credential
+	s.passkeys[credential.ID] = &stored
+	return nil
+}
+
+// GetPasskey finds a credential by ID
+func (s *InMemoryPasskeyStore) GetPasskey(ctx context.Context, credentialID string) (*PasskeyCredential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	passkey, exists := s.passkeys[credentialID]
+	if !exists {
+		return nil, &AuthError{Code: ErrNotFound, Message: "passkey not found"}
+	}
+	copied := *passkey
+	return &copied, nil
+}
+
+// ListPasskeys lists a user's credentials, newest first
+func (s *InMemoryPasskeyStore) ListPasskeys(ctx context.Context, userID string) ([]*PasskeyCredential, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var passkeys []*PasskeyCredential
+	for _, passkey := range s.passkeys {
+		if passkey.UserID == userID {
+			copied := *passkey
+			passkeys = append(passkeys, &copied)
+		}
+	}
+	sort.Slice(passkeys, func(i, j int) bool {
+		return passkeys[i].CreatedAt.After(passkeys[j].CreatedAt)
+	})
+	return passkeys, nil
+}
+
+// UpdatePasskeyUse records a sign-in with a credential
+func (s *InMemoryPasskeyStore) UpdatePasskeyUse(ctx context.Context, credentialID string, signCount uint32, usedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	passkey, exists := s.passkeys[credentialID]
+	if !exists {
+		return &AuthError{Code: ErrNotFound, Message: "passkey not found"}
+	}
+	passkey.SignCount = signCount
+	passkey.LastUsedAt = &usedAt
+	return nil
+}
+
+// DeletePasskey removes a user's credential
+func (s *InMemoryPasskeyStore) DeletePasskey(ctx context.Context, userID, credentialID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	passkey, exists := s.passkeys[credentialID]
+	if !exists || passkey.UserID != userID {
+		return &AuthError{Code: ErrNotFound, Message: "passkey not found"}
+	}
+	delete(s.passkeys, credentialID)
+	return nil
+}
@@ -0,0 +1,459 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testRPID   = "alunalun.test"
+	testOrigin = "https://alunalun.test"
+)
+
+// softAuthenticator is a software WebAuthn authenticator holding one ES256 passkey
+type softAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	rpID         string
+	origin       string
+	signCount    uint32
+}
+
+func newSoftAuthenticator(t *testing.T, userID string) *softAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		t.Fatal(err)
+	}
+	return &softAuthenticator{
+		key:          key,
+		credentialID: credentialID,
+		userHandle:   []byte(userID),
+		rpID:         testRPID,
+		origin:       testOrigin,
+	}
+}
+
+// coseKey encodes the public key as a COSE_Key
+func (a *softAuthenticator) coseKey() []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	a.key.PublicKey.X.FillBytes(x)
+	a.key.PublicKey.Y.FillBytes(y)
+	return cborEncode(cborMap{
+		{int64(1), int64(2)},            // kty: EC2
+		{int64(3), int64(coseAlgES256)}, // alg
+		{int64(-1), int64(1)},           // crv: P-256
+		{int64(-2), x},
+		{int64(-3), y},
+	})
+}
+
+// authData builds authenticator data, with the attested credential when registering
+func (a *softAuthenticator) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+
+	flags := byte(webauthnFlagUserPresent | webauthnFlagUserVerified)
+	if attested {
+		flags |= webauthnFlagAttestedData
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+
+	if attested {
+		data = append(data, make([]byte, 16)...) // AAGUID
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func (a *softAuthenticator) clientData(ceremony, challenge string) []byte {
+	clientData, _ := json.Marshal(webauthnClientData{Type: ceremony, Challenge: challenge, Origin: a.origin})
+	return clientData
+}
+
+// register answers navigator.credentials.create with attestation "none"
+func (a *softAuthenticator) register(challenge string) string {
+	attestation := cborEncode(cborMap{
+		{"fmt", "none"},
+		{"attStmt", cborMap{}},
+		{"authData", a.authData(true)},
+	})
+
+	var credential passkeyCredentialJSON
+	credential.ID = encodeWebAuthnBytes(a.credentialID)
+	credential.RawID = credential.ID
+	credential.Type = "public-key"
+	credential.Response.ClientDataJSON = encodeWebAuthnBytes(a.clientData(passkeyCeremonyCreate, challenge))
+	credential.Response.AttestationObject = encodeWebAuthnBytes(attestation)
+	credential.Response.Transports = []string{"internal"}
+
+	out, _ := json.Marshal(credential)
+	return string(out)
+}
+
+// assert answers navigator.credentials.get, bumping the sign counter
+func (a *softAuthenticator) assert(t *testing.T, challenge string) json.RawMessage {
+	t.Helper()
+	a.signCount++
+	authData := a.authData(false)
+	clientData := a.clientData(passkeyCeremonyGet, challenge)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var credential passkeyCredentialJSON
+	credential.ID = encodeWebAuthnBytes(a.credentialID)
+	credential.RawID = credential.ID
+	credential.Type = "public-key"
+	credential.Response.ClientDataJSON = encodeWebAuthnBytes(clientData)
+	credential.Response.AuthenticatorData = encodeWebAuthnBytes(authData)
+	credential.Response.Signature = encodeWebAuthnBytes(signature)
+	credential.Response.UserHandle = encodeWebAuthnBytes(a.userHandle)
+
+	out, _ := json.Marshal(credential)
+	return out
+}
+
+func encodeWebAuthnBytes(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// cborMap is a CBOR map that keeps its key order
+type cborMap [][2]interface{}
+
+// cborEncode encodes the subset of CBOR used by attestation objects and COSE keys
+func cborEncode(v interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n <= 0xff:
+			return []byte{major<<5 | 24, byte(n)}
+		default:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+		}
+	}
+
+	switch v := v.(type) {
+	case int64:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case cborMap:
+		out := head(5, uint64(len(v)))
+		for _, pair := range v {
+			out = append(out, cborEncode(pair[0])...)
+			out = append(out, cborEncode(pair[1])...)
+		}
+		return out
+	}
+	panic("cborEncode: unsupported type")
+}
+
+// testUserStore is a UserStore over a fixed set of users
+type testUserStore struct {
+	users map[string]*User
+}
+
+func (s *testUserStore) CreateUser(ctx context.Context, user *User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *testUserStore) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	for _, user := range s.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, &AuthError{Code: ErrUserNotFound, Message: "user not found"}
+}
+
+func (s *testUserStore) GetUserByID(ctx context.Context, userID string) (*User, error) {
+	if user, ok := s.users[userID]; ok {
+		return user, nil
+	}
+	return nil, &AuthError{Code: ErrUserNotFound, Message: "user not found"}
+}
+
+func (s *testUserStore) UpdateUser(ctx context.Context, user *User) error {
+	s.users[user.ID] = user
+	return nil
+}
+
+func (s *testUserStore) CheckUsernameAvailable(ctx context.Context, username string) (bool, error) {
+	return true, nil
+}
+
+// passkeyTest is a passkey provider with one user who registered the authenticator
+type passkeyTest struct {
+	provider      *PasskeyProvider
+	store         *InMemoryPasskeyStore
+	user          *User
+	authenticator *softAuthenticator
+}
+
+func newPasskeyTest(t *testing.T) *passkeyTest {
+	t.Helper()
+	stateManager, err := NewStateManager(make([]byte, 32), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := &User{ID: "2b7e1516-28ae-4d2a-a6f7-15884f3c9a10", Email: "user@alunalun.test", Username: "user", Status: "active"}
+	other := &User{ID: "5f4dcc3b-5aa7-4c5d-8b2f-9e1a0c6d7e8f", Email: "other@alunalun.test", Username: "other", Status: "active"}
+	users := &testUserStore{users: map[string]*User{user.ID: user, other.ID: other}}
+
+	store := NewInMemoryPasskeyStore()
+	provider, err := NewPasskeyProvider(store, users, stateManager, PasskeyConfig{
+		RPID:             testRPID,
+		Origins:          []string{testOrigin},
+		UserVerification: "required",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &passkeyTest{
+		provider:      provider,
+		store:         store,
+		user:          user,
+		authenticator: newSoftAuthenticator(t, user.ID),
+	}
+}
+
+// register runs a registration ceremony with the test's authenticator
+func (pt *passkeyTest) register(t *testing.T) (*PasskeyCredential, error) {
+	t.Helper()
+	options, token, err := pt.provider.BeginRegistration(context.Background(), pt.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pt.provider.FinishRegistration(context.Background(), pt.user, token, pt.authenticator.register(optionsChallenge(t, options)), "Laptop")
+}
+
+// beginLogin starts a sign-in ceremony, returning its challenge and token
+func (pt *passkeyTest) beginLogin(t *testing.T) (string, string) {
+	t.Helper()
+	options, token, err := pt.provider.BeginLogin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return optionsChallenge(t, options), token
+}
+
+// login signs in with an assertion for a challenge token
+func (pt *passkeyTest) login(token string, assertion json.RawMessage) (*UserInfo, error) {
+	credential, _ := json.Marshal(PasskeyLoginRequest{ChallengeToken: token, Credential: assertion})
+	return pt.provider.Authenticate(context.Background(), string(credential))
+}
+
+func optionsChallenge(t *testing.T, options string) string {
+	t.Helper()
+	var parsed struct {
+		Challenge string `json:"challenge"`
+	}
+	if err := json.Unmarshal([]byte(options), &parsed); err != nil || parsed.Challenge == "" {
+		t.Fatalf("options have no challenge: %s", options)
+	}
+	return parsed.Challenge
+}
+
+// requireAuthError fails unless err is an AuthError with the code, and for
+// rejected passkeys a reason containing the text
+func requireAuthError(t *testing.T, err error, code, reason string) {
+	t.Helper()
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || authErr.Code != code {
+		t.Fatalf("expected %s error, got %v", code, err)
+	}
+	if reason == "" {
+		return
+	}
+	if got, _ := authErr.Details["reason"].(string); !strings.Contains(got, reason) {
+		t.Fatalf("expected reason containing %q, got %q", reason, got)
+	}
+}
+
+func TestPasskeyRegistrationAndLogin(t *testing.T) {
+	pt := newPasskeyTest(t)
+
+	credential, err := pt.register(t)
+	if err != nil {
+		t.Fatalf("registration failed: %v", err)
+	}
+	if credential.ID != encodeWebAuthnBytes(pt.authenticator.credentialID) || credential.UserID != pt.user.ID {
+		t.Fatalf("unexpected credential %+v", credential)
+	}
+
+	challenge, token := pt.beginLogin(t)
+	info, err := pt.login(token, pt.authenticator.assert(t, challenge))
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if info.ID != pt.user.ID || info.Provider != "passkey" {
+		t.Fatalf("unexpected user info %+v", info)
+	}
+	if verified, _ := info.Metadata["user_verified"].(bool); !verified {
+		t.Fatal("expected user verification to be reported")
+	}
+
+	stored, err := pt.store.GetPasskey(context.Background(), credential.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.SignCount != 1 || stored.LastUsedAt == nil {
+		t.Fatalf("sign-in was not recorded: %+v", stored)
+	}
+}
+
+func TestPasskeyRegistrationRejectsMismatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(a *softAuthenticator)
+		reason string
+	}{
+		{"rp ID", func(a *softAuthenticator) { a.rpID = "evil.test" }, "relying party ID mismatch"},
+		{"origin", func(a *softAuthenticator) { a.origin = "https://evil.test" }, "is not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pt := newPasskeyTest(t)
+			tt.modify(pt.authenticator)
+
+			_, err := pt.register(t)
+			requireAuthError(t, err, ErrInvalidCredentials, tt.reason)
+
+			passkeys, _ := pt.store.ListPasskeys(context.Background(), pt.user.ID)
+			if len(passkeys) != 0 {
+				t.Fatal("rejected credential was stored")
+			}
+		})
+	}
+}
+
+func TestPasskeyLoginRejectsMismatches(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(pt *passkeyTest)
+		reason string
+	}{
+		{"rp ID", func(pt *passkeyTest) { pt.authenticator.rpID = "evil.test" }, "relying party ID mismatch"},
+		{"origin", func(pt *passkeyTest) { pt.authenticator.origin = "https://evil.test" }, "is not allowed"},
+		{"user handle", func(pt *passkeyTest) { pt.authenticator.userHandle = []byte("5f4dcc3b-5aa7-4c5d-8b2f-9e1a0c6d7e8f") }, "user handle mismatch"},
+		{"signing key", func(pt *passkeyTest) {
+			key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			pt.authenticator.key = key
+		}, "invalid signature"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pt := newPasskeyTest(t)
+			if _, err := pt.register(t); err != nil {
+				t.Fatalf("registration failed: %v", err)
+			}
+			tt.modify(pt)
+
+			challenge, token := pt.beginLogin(t)
+			_, err := pt.login(token, pt.authenticator.assert(t, challenge))
+			requireAuthError(t, err, ErrInvalidCredentials, tt.reason)
+		})
+	}
+}
+
+func TestPasskeyLoginRejectsChallengeReplay(t *testing.T) {
+	pt := newPasskeyTest(t)
+	if _, err := pt.register(t); err != nil {
+		t.Fatalf("registration failed: %v", err)
+	}
+
+	challenge, token := pt.beginLogin(t)
+	assertion := pt.authenticator.assert(t, challenge)
+	if _, err := pt.login(token, assertion); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	// The same challenge token is single-use
+	_, err := pt.login(token, pt.authenticator.assert(t, challenge))
+	requireAuthError(t, err, ErrTokenInvalid, "")
+
+	// A captured assertion does not answer a new challenge
+	_, freshToken := pt.beginLogin(t)
+	_, err = pt.login(freshToken, assertion)
+	requireAuthError(t, err, ErrInvalidCredentials, "challenge mismatch")
+
+	// Nor does a registration challenge start a sign-in
+	options, registrationToken, err := pt.provider.BeginRegistration(context.Background(), pt.user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = pt.login(registrationToken, pt.authenticator.assert(t, optionsChallenge(t, options)))
+	requireAuthError(t, err, ErrTokenInvalid, "")
+}
+
+func TestPasskeyLoginRejectsSignCounterRegression(t *testing.T) {
+	pt := newPasskeyTest(t)
+	if _, err := pt.register(t); err != nil {
+		t.Fatalf("registration failed: %v", err)
+	}
+
+	pt.authenticator.signCount = 9
+	challenge, token := pt.beginLogin(t)
+	if _, err := pt.login(token, pt.authenticator.assert(t, challenge)); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	// A clone still at an earlier count
+	pt.authenticator.signCount = 4
+	challenge, token = pt.beginLogin(t)
+	_, err := pt.login(token, pt.authenticator.assert(t, challenge))
+	requireAuthError(t, err, ErrInvalidCredentials, "sign counter did not increase")
+
+	// Repeating the last count is rejected too
+	pt.authenticator.signCount = 9
+	challenge, token = pt.beginLogin(t)
+	_, err = pt.login(token, pt.authenticator.assert(t, challenge))
+	requireAuthError(t, err, ErrInvalidCredentials, "sign counter did not increase")
+}
+
+func TestPasskeyLoginRejectsExpiredChallenge(t *testing.T) {
+	pt := newPasskeyTest(t)
+	if _, err := pt.register(t); err != nil {
+		t.Fatalf("registration failed: %v", err)
+	}
+
+	pt.provider.config.ChallengeTTL = -time.Minute
+	challenge, token := pt.beginLogin(t)
+	_, err := pt.login(token, pt.authenticator.assert(t, challenge))
+	requireAuthError(t, err, ErrTokenExpired, "")
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hashing algorithms
+const (
+	PasswordHashBcrypt   = "bcrypt"
+	PasswordHashArgon2id = "argon2id"
+)
+
+// PasswordStore persists password hashes separately from user profiles
+type PasswordStore interface {
+	// GetPasswordHash returns a user's password hash.
+	// Returns ErrUserNotFound if the user has no password.
+	GetPasswordHash(ctx context.Context, userID string) (string, error)
+
+	// SetPasswordHash creates or replaces a user's password hash
+	SetPasswordHash(ctx context.Context, userID, hash string) error
+}
+
+// PasswordUserCreator creates a user together with their password hash, so a
+// failed write can't leave a user without a password holding the email
+type PasswordUserCreator interface {
+	CreateUserWithPassword(ctx context.Context, user *User, hash string) error
+}
+
+// HashPassword hashes a password with the configured algorithm
+func HashPassword(password string, config EmailProviderConfig) (string, error) {
+	switch config.PasswordHashAlgorithm {
+	case PasswordHashArgon2id:
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return "", fmt.Errorf("failed to generate salt: %w", err)
+		}
+		params := argon2ParamsFromConfig(config)
+		key := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, 32)
+		return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+			argon2.Version, params.memory, params.time, params.threads,
+			base64.RawStdEncoding.EncodeToString(salt),
+			base64.RawStdEncoding.EncodeToString(key),
+		), nil
+	case PasswordHashBcrypt, "":
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), config.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash password: %w", err)
+		}
+		return string(hash), nil
+	default:
+		return "", fmt.Errorf("unsupported password hash algorithm: %s", config.PasswordHashAlgorithm)
+	}
+}
+
+// VerifyPassword checks a password against a bcrypt or argon2id hash
+func VerifyPassword(hash, password string) bool {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		params, salt, key, err := decodeArgon2Hash(hash)
+		if err != nil {
+			return false
+		}
+		candidate := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+		return subtle.ConstantTimeCompare(candidate, key) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// PasswordNeedsRehash reports whether a hash was made with a different
+// algorithm or cost than currently configured
+func PasswordNeedsRehash(hash string, config EmailProviderConfig) bool {
+	if strings.HasPrefix(hash, "$argon2id$") {
+		if config.PasswordHashAlgorithm != PasswordHashArgon2id {
+			return true
+		}
+		params, _, _, err := decodeArgon2Hash(hash)
+		return err != nil || params != argon2ParamsFromConfig(config)
+	}
+
+	if config.PasswordHashAlgorithm != PasswordHashBcrypt && config.PasswordHashAlgorithm != "" {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != config.BcryptCost
+}
+
+// argon2Params are the tunable argon2id parameters
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+// argon2ParamsFromConfig returns the configured argon2id parameters with defaults
+func argon2ParamsFromConfig(config EmailProviderConfig) argon2Params {
+	params := argon2Params{
+		memory:  config.Argon2Memory,
+		time:    config.Argon2Iterations,
+		threads: config.Argon2Parallelism,
+	}
+	if params.memory == 0 {
+		params.memory = 64 * 1024
+	}
+	if params.time == 0 {
+		params.time = 3
+	}
+	if params.threads == 0 {
+		params.threads = 2
+	}
+	return params
+}
+
+// decodeArgon2Hash parses a PHC-formatted argon2id hash
+func decodeArgon2Hash(hash string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, errors.New("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, errors.New("unsupported argon2 version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id key: %w", err)
+	}
+	return params, salt, key, nil
+}
+
+// loadPasswordHash returns a user's password hash from the password store,
+// falling back to the hash on the user for stores without one
+func loadPasswordHash(ctx context.Context, userStore UserStore, user *User) (string, error) {
+	if passwords, ok := userStore.(PasswordStore); ok {
+		return passwords.GetPasswordHash(ctx, user.ID)
+	}
+	if user.PasswordHash == "" {
+		return "", &AuthError{Code: ErrUserNotFound, Message: "user has no password"}
+	}
+	return user.PasswordHash, nil
+}
+
+// savePasswordHash stores a user's password hash in the password store,
+// falling back to updating the user for stores without one
+func savePasswordHash(ctx context.Context, userStore UserStore, user *User, hash string) error {
+	user.PasswordHash = hash
+	if passwords, ok := userStore.(PasswordStore); ok {
+		return passwords.SetPasswordHash(ctx, user.ID, hash)
+	}
+	return userStore.UpdateUser(ctx, user)
+}
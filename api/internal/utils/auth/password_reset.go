@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// resetQueueSize bounds the reset emails waiting to be sent; requests beyond it are dropped
+	resetQueueSize = 256
+	// resetSenders is the number of reset emails sent concurrently
+	resetSenders = 4
+)
+
+// PasswordResetter sends password reset links and sets new passwords from them
+type PasswordResetter struct {
+	userStore      UserStore
+	tokens         EmailTokenStore
+	sender         EmailSender
+	sessionManager *SessionManager
+	throttle       *LoginThrottle // nil leaves lockouts in place after a reset
+	config         EmailProviderConfig
+	queue          chan *User // users to email a reset link; drained by Run
+}
+
+// NewPasswordResetter creates a new password resetter
+func NewPasswordResetter(
+	userStore UserStore,
+	tokens EmailTokenStore,
+	sender EmailSender,
+	sessionManager *SessionManager,
+	config EmailProviderConfig,
+) (*PasswordResetter, error) {
+	if userStore == nil {
+		return nil, errors.New("user store is required")
+	}
+	if tokens == nil {
+		return nil, errors.New("email token store is required")
+	}
+	if sender == nil {
+		return nil, errors.New("email sender is required")
+	}
+	if sessionManager == nil {
+		return nil, errors.New("session manager is required")
+	}
+
+	// Set defaults
+	if config.MinLength == 0 {
+		config.MinLength = 8
+	}
+	if config.BcryptCost == 0 {
+		config.BcryptCost = 12
+	}
+	if config.PasswordResetTTL == 0 {
+		config.PasswordResetTTL = time.Hour
+	}
+
+	return &PasswordResetter{
+		userStore:      userStore,
+		tokens:         tokens,
+		sender:         sender,
+		sessionManager: sessionManager,
+		config:         config,
+		queue:          make(chan *User, resetQueueSize),
+	}, nil
+}
+
+// SetLoginThrottle lifts an account lockout once its password is reset
+func (r *PasswordResetter) SetLoginThrottle(throttle *LoginThrottle) {
+	r.throttle = throttle
+}
+
+// Request emails a password reset link to the owner of an address. It returns
+// nil for unknown and rate-limited addresses, and queues the email for Run, so
+// neither the result nor the response time reveals whether an account exists.
+// When the queue is full the request is dropped rather than waited on.
+func (r *PasswordResetter) Request(ctx context.Context, email string) error {
+	user, err := r.userStore.GetUserByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		var authErr *AuthError
+		if errors.As(err, &authErr) && authErr.Code == ErrUserNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Status == "disabled" || IsAnonymousEmail(user.Email) {
+		return nil
+	}
+
+	select {
+	case r.queue <- user:
+	default:
+		log.Printf("password reset queue is full, dropping request for user %s", user.ID)
+	}
+	return nil
+}
+
+// Run sends queued reset emails until the context is cancelled
+func (r *PasswordResetter) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < resetSenders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case user := <-r.queue:
+					if err := r.send(ctx, user); err != nil {
+						log.Printf("failed to send password reset to user %s: %v", user.ID, err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// send issues a reset token for a user and emails the link
+func (r *PasswordResetter) send(ctx context.Context, user *User) error {
+	// Check rate limiting
+	if r.config.MaxPasswordResetsPerHour > 0 {
+		count, err := r.tokens.CountRecentEmailTokens(ctx, user.ID, EmailTokenResetPassword, time.Now().Add(-time.Hour))
+		if err != nil {
+			return fmt.Errorf("failed to check rate limit: %w", err)
+		}
+		if count >= int64(r.config.MaxPasswordResetsPerHour) {
+			return &AuthError{
+				Code:    ErrRateLimited,
+				Message: "too many password reset requests",
+			}
+		}
+	}
+
+	tokenString, err := GenerateEmailToken()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	token := &EmailToken{
+		Token:     tokenString,
+		UserID:    user.ID,
+		Purpose:   EmailTokenResetPassword,
+		Email:     user.Email,
+		ExpiresAt: now.Add(r.config.PasswordResetTTL),
+		CreatedAt: now,
+	}
+	if err := r.tokens.SaveEmailToken(ctx, token); err != nil {
+		return fmt.Errorf("failed to save password reset token: %w", err)
+	}
+
+	if err := r.sender.SendPasswordReset(ctx, user.Email, tokenString, tokenLink(r.config.PasswordResetURL, tokenString)); err != nil {
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+	return nil
+}
+
+// Reset consumes a reset token, sets the new password and revokes all of the
+// user's sessions. The reset also proves ownership of the email address.
+func (r *PasswordResetter) Reset(ctx context.Context, tokenString, newPassword string) (*User, error) {
+	// Check the password first so a weak password doesn't burn the token
+	if err := validatePasswordPolicy(newPassword, r.config); err != nil {
+		return nil, &AuthError{
+			Code:    ErrWeakPassword,
+			Message: err.Error(),
+		}
+	}
+
+	invalid := &AuthError{
+		Code:    ErrTokenInvalid,
+		Message: "invalid or expired password reset link",
+	}
+
+	token, err := r.tokens.ConsumeEmailToken(ctx, EmailTokenResetPassword, tokenString)
+	if err != nil {
+		return nil, invalid
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return nil, &AuthError{
+			Code:    ErrTokenExpired,
+			Message: "password reset link has expired",
+		}
+	}
+
+	user, err := r.userStore.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, invalid
+	}
+	if !strings.EqualFold(user.Email, token.Email) {
+		return nil, invalid
+	}
+
+	hashedPassword, err := HashPassword(newPassword, r.config)
+	if err != nil {
+		return nil, err
+	}
+	if err := savePasswordHash(ctx, r.userStore, user, hashedPassword); err != nil {
+		return nil, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if !user.EmailVerified {
+		now := time.Now()
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		user.UpdatedAt = now
+		if err := r.userStore.UpdateUser(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+	}
+
+	// Other outstanding links must not allow a second reset
+	if err := r.tokens.DeleteEmailTokens(ctx, user.ID, EmailTokenResetPassword); err != nil {
+		log.Printf("failed to delete password reset tokens of user %s: %v", user.ID, err)
+	}
+
+	// The owner proved control of the email; let them sign in right away
+	if r.throttle != nil {
+		if err := r.throttle.Succeed(ctx, throttleAccount(user)); err != nil {
+			log.Printf("failed to reset login attempts for user %s: %v", user.ID, err)
+		}
+	}
+
+	// Sign out everywhere; whoever knew the old password loses access
+	if err := r.sessionManager.RevokeAllForUser(ctx, user.ID); err != nil {
+		return nil, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testEmailSender records the emails it is asked to send
+type testEmailSender struct {
+	mu          sync.Mutex
+	resetTokens []string
+	alerts      []string
+}
+
+func (s *testEmailSender) SendMagicLink(ctx context.Context, email, token, linkURL string) error {
+	return nil
+}
+
+func (s *testEmailSender) SendVerificationEmail(ctx context.Context, email, token, linkURL string) error {
+	return nil
+}
+
+func (s *testEmailSender) SendPasswordReset(ctx context.Context, email, token, linkURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetTokens = append(s.resetTokens, token)
+	return nil
+}
+
+func (s *testEmailSender) SendSecurityAlert(ctx context.Context, email, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = append(s.alerts, email)
+	return nil
+}
+
+// testTokenRevoker records users whose tokens were revoked
+type testTokenRevoker struct {
+	revoked []string
+}
+
+func (r *testTokenRevoker) RevokeTokens(ctx context.Context, userID string, before time.Time) error {
+	r.revoked = append(r.revoked, userID)
+	return nil
+}
+
+// resetTest is a password resetter for one user over in-memory stores
+type resetTest struct {
+	resetter *PasswordResetter
+	tokens   *InMemoryEmailTokenStore
+	sender   *testEmailSender
+	sessions *SessionManager
+	revoker  *testTokenRevoker
+	user     *User
+}
+
+func newResetTest(t *testing.T) *resetTest {
+	t.Helper()
+	config := EmailProviderConfig{BcryptCost: 4, MinLength: 8}
+	hash, err := HashPassword("old password 1", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &User{
+		ID:           "2b7e1516-28ae-4d2a-a6f7-15884f3c9a10",
+		Email:        "user@alunalun.test",
+		Username:     "user",
+		PasswordHash: hash,
+		Status:       "active",
+	}
+	users := &testUserStore{users: map[string]*User{user.ID: user}}
+
+	tokens := NewInMemoryEmailTokenStore()
+	sender := &testEmailSender{}
+	revoker := &testTokenRevoker{}
+	sessions := NewSessionManager(NewInMemorySessionStore())
+	sessions.SetTokenRevoker(revoker)
+
+	resetter, err := NewPasswordResetter(users, tokens, sender, sessions, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &resetTest{resetter: resetter, tokens: tokens, sender: sender, sessions: sessions, revoker: revoker, user: user}
+}
+
+// issue emails a reset link to the user and returns its token
+func (rt *resetTest) issue(t *testing.T) string {
+	t.Helper()
+	if err := rt.resetter.send(context.Background(), rt.user); err != nil {
+		t.Fatal(err)
+	}
+	return rt.sender.resetTokens[len(rt.sender.resetTokens)-1]
+}
+
+// TestPasswordResetTokensAreHashed stores reset tokens by hash only
+func TestPasswordResetTokensAreHashed(t *testing.T) {
+	rt := newResetTest(t)
+	token := rt.issue(t)
+
+	if _, ok := rt.tokens.tokens[token]; ok {
+		t.Fatal("expected the raw token not to be a store key")
+	}
+	stored, ok := rt.tokens.tokens[HashEmailToken(token)]
+	if !ok {
+		t.Fatal("expected the token to be stored by its hash")
+	}
+	if stored.Token != "" {
+		t.Fatal("expected the raw token not to be stored")
+	}
+}
+
+// TestPasswordResetIsSingleUse resets once with a link: the password changes,
+// every session and token is revoked, and neither that link nor any other
+// outstanding one works again.
+func TestPasswordResetIsSingleUse(t *testing.T) {
+	ctx := context.Background()
+	rt := newResetTest(t)
+	session, err := rt.sessions.CreateAuthenticated(ctx, rt.user.ID, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := rt.issue(t)
+	second := rt.issue(t)
+
+	user, err := rt.resetter.Reset(ctx, first, "new password 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyPassword(user.PasswordHash, "new password 2") {
+		t.Fatal("expected the new password to be set")
+	}
+	if !user.EmailVerified || user.EmailVerifiedAt == nil {
+		t.Fatal("expected the reset to verify the email")
+	}
+
+	if _, err := rt.sessions.Validate(ctx, session.ID); err == nil {
+		t.Fatal("expected existing sessions to be revoked")
+	}
+	if len(rt.revoker.revoked) != 1 || rt.revoker.revoked[0] != rt.user.ID {
+		t.Fatalf("expected the user's tokens to be revoked, got %v", rt.revoker.revoked)
+	}
+
+	_, err = rt.resetter.Reset(ctx, first, "new password 3")
+	requireAuthError(t, err, ErrTokenInvalid, "")
+	_, err = rt.resetter.Reset(ctx, second, "new password 3")
+	requireAuthError(t, err, ErrTokenInvalid, "")
+}
+
+// TestPasswordResetWeakPasswordKeepsToken refuses a weak password without
+// using up the link
+func TestPasswordResetWeakPasswordKeepsToken(t *testing.T) {
+	ctx := context.Background()
+	rt := newResetTest(t)
+	token := rt.issue(t)
+
+	_, err := rt.resetter.Reset(ctx, token, "short")
+	requireAuthError(t, err, ErrWeakPassword, "")
+
+	if _, err := rt.resetter.Reset(ctx, token, "new password 2"); err != nil {
+		t.Fatalf("expected the link to still work, got %v", err)
+	}
+}
+
+// TestPasswordResetExpiredToken refuses links past their TTL
+func TestPasswordResetExpiredToken(t *testing.T) {
+	ctx := context.Background()
+	rt := newResetTest(t)
+	token := rt.issue(t)
+	rt.tokens.tokens[HashEmailToken(token)].ExpiresAt = time.Now().Add(-time.Minute)
+
+	_, err := rt.resetter.Reset(ctx, token, "new password 2")
+	requireAuthError(t, err, ErrTokenExpired, "")
+	if !VerifyPassword(rt.user.PasswordHash, "old password 1") {
+		t.Fatal("expected the old password to be kept")
+	}
+}
@@ -79,4 +79,14 @@ const (
 	ErrTokenInvalid       = "TOKEN_INVALID"
 	ErrSessionNotFound    = "SESSION_NOT_FOUND"
 	ErrEmailNotVerified   = "EMAIL_NOT_VERIFIED"
+	ErrInvalidRedirect    = "INVALID_REDIRECT_URI"
+	ErrUsernameConflict   = "USERNAME_CONFLICT"
+	ErrRateLimited        = "RATE_LIMITED"
+	ErrWeakPassword       = "WEAK_PASSWORD"
+	ErrAlreadyExists      = "ALREADY_EXISTS"
+	ErrTooManyAttempts    = "TOO_MANY_ATTEMPTS"
+	ErrReauthRequired     = "REAUTH_REQUIRED"
+	ErrInvalidArgument    = "INVALID_ARGUMENT"
+	ErrNotFound           = "NOT_FOUND"
+	ErrLastLoginMethod    = "LAST_LOGIN_METHOD"
 )
\ No newline at end of file
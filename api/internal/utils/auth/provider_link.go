@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProviderLink is a login provider identity attached to a user
+type ProviderLink struct {
+	ID             string                 `json:"id"`
+	UserID         string                 `json:"user_id"`
+	Provider       string                 `json:"provider"`
+	ProviderUserID string                 `json:"provider_user_id"`
+	Email          string                 `json:"email,omitempty"`
+	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// ProviderLinkStore persists the provider identities linked to each user
+type ProviderLinkStore interface {
+	// CreateProviderLink links a provider identity to a user
+	CreateProviderLink(ctx context.Context, link *ProviderLink) error
+
+	// GetProviderLink finds the link for a provider identity
+	GetProviderLink(ctx context.Context, provider, providerUserID string) (*ProviderLink, error)
+
+	// ListProviderLinks lists all providers linked to a user
+	ListProviderLinks(ctx context.Context, userID string) ([]*ProviderLink, error)
+
+	// DeleteProviderLink removes a user's link for a provider, atomically
+	// refusing to remove their last one. Returns ErrUserNotFound if the provider
+	// is not linked and ErrLastLoginMethod if it is the only link left.
+	DeleteProviderLink(ctx context.Context, userID, provider string) error
+}
+
+// ProviderLinkFromUserInfo builds a link for an authenticated provider identity
+func ProviderLinkFromUserInfo(userID string, info *UserInfo) *ProviderLink {
+	providerUserID := info.ProviderID
+	if providerUserID == "" {
+		// Internal providers (email, magic link) identify users by email
+		providerUserID = info.Email
+	}
+
+	return &ProviderLink{
+		UserID:         userID,
+		Provider:       info.Provider,
+		ProviderUserID: providerUserID,
+		Email:          info.Email,
+		CreatedAt:      time.Now(),
+	}
+}
+
+// InMemoryProviderLinkStore is an in-memory implementation of ProviderLinkStore for single-instance deployments
+type InMemoryProviderLinkStore struct {
+	mu    sync.Mutex
+	links map[string]*ProviderLink // provider + provider user ID -> link
+}
+
+// NewInMemoryProviderLinkStore creates a new in-memory provider link store
+func NewInMemoryProviderLinkStore() *InMemoryProviderLinkStore {
+	return &InMemoryProviderLinkStore{
+		links: make(map[string]*ProviderLink),
+	}
+}
+
+// CreateProviderLink links a provider identity to a user
+func (s *InMemoryProviderLinkStore) CreateProviderLink(ctx context.Context, link *ProviderLink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := providerLinkKey(link.Provider, link.ProviderUserID)
+	if _, exists := s.links[key]; exists {
+		return &AuthError{Code: ErrAlreadyExists, Message: "provider identity is already linked"}
+	}
+	link.ID = uuid.New().String()
+	stored := *link
+	s.links[key] = &stored
+	return nil
+}
+
+// GetProviderLink finds the link for a provider identity
+func (s *InMemoryProviderLinkStore) GetProviderLink(ctx context.Context, provider, providerUserID string) (*ProviderLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link, exists := s.links[providerLinkKey(provider, providerUserID)]
+	if !exists {
+		return nil, &AuthError{Code: ErrUserNotFound, Message: "provider link not found"}
+	}
+	copied := *link
+	return &copied, nil
+}
+
+// ListProviderLinks lists all providers linked to a user, newest first
+func (s *InMemoryProviderLinkStore) ListProviderLinks(ctx context.Context, userID string) ([]*ProviderLink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var links []*ProviderLink
+	for _, link := range s.links {
+		if link.UserID == userID {
+			copied := *link
+			links = append(links, &copied)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].CreatedAt.After(links[j].CreatedAt)
+	})
+	return links, nil
+}
+
+// DeleteProviderLink removes a user's link for a provider unless it is their last
+func (s *InMemoryProviderLinkStore) DeleteProviderLink(ctx context.Context, userID, provider string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var found string
+	count := 0
+	for key, link := range s.links {
+		if link.UserID != userID {
+			continue
+		}
+		count++
+		if link.Provider == provider {
+			found = key
+		}
+	}
+	if found == "" {
+		return &AuthError{Code: ErrUserNotFound, Message: "provider link not found"}
+	}
+	if count <= 1 {
+		return &AuthError{Code: ErrLastLoginMethod, Message: "cannot unlink the last remaining login method"}
+	}
+	delete(s.links, found)
+	return nil
+}
+
+// providerLinkKey identifies a provider identity in InMemoryProviderLinkStore
+func providerLinkKey(provider, providerUserID string) string {
+	return provider + ":" + providerUserID
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Token delivery modes for OAuth redirects
+const (
+	// TokenDeliveryFragment puts the token in the URL fragment, which never reaches servers
+	TokenDeliveryFragment = "fragment"
+
+	// TokenDeliveryCode sends a short-lived one-time code the client exchanges for a token
+	TokenDeliveryCode = "code"
+)
+
+// RedirectMatch describes the allowlisted client a redirect URI belongs to
+type RedirectMatch struct {
+	Client        string
+	TokenDelivery string
+}
+
+// ValidateRedirectURI checks a redirect URI against the configured allowlist
+func (c RedirectConfig) ValidateRedirectURI(redirectURI string) (*RedirectMatch, error) {
+	if redirectURI == "" {
+		return nil, redirectError(redirectURI, "redirect_uri is required")
+	}
+
+	u, err := url.Parse(redirectURI)
+	if err != nil || u.Scheme == "" {
+		return nil, redirectError(redirectURI, "redirect_uri must be an absolute URI")
+	}
+	if u.User != nil {
+		return nil, redirectError(redirectURI, "redirect_uri must not contain credentials")
+	}
+	if u.Fragment != "" || strings.Contains(redirectURI, "#") {
+		return nil, redirectError(redirectURI, "redirect_uri must not contain a fragment")
+	}
+	if u.RawQuery != "" || u.ForceQuery {
+		return nil, redirectError(redirectURI, "redirect_uri must not contain a query string")
+	}
+	if u.Opaque != "" {
+		return nil, redirectError(redirectURI, "redirect_uri must be hierarchical")
+	}
+
+	// Check clients in a stable order so overlapping entries resolve consistently
+	names := make([]string, 0, len(c.Clients))
+	for name := range c.Clients {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		client := c.Clients[name]
+		if !client.allows(redirectURI, u) {
+			continue
+		}
+
+		delivery := client.TokenDelivery
+		if delivery == "" {
+			delivery = defaultTokenDelivery(u)
+		}
+		return &RedirectMatch{Client: name, TokenDelivery: delivery}, nil
+	}
+
+	return nil, redirectError(redirectURI, "redirect_uri is not registered for any client")
+}
+
+// allows reports whether the URI matches an exact entry or a pattern
+func (c RedirectClientConfig) allows(redirectURI string, u *url.URL) bool {
+	for _, uri := range c.URIs {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	for _, pattern := range c.Patterns {
+		if matchRedirectPattern(pattern, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRedirectPattern matches a parsed URI against a pattern such as
+// "https://*.alunalun.app/auth/callback". Scheme, port and path must be equal;
+// only a leading "*" label in the host is a wildcard, and it matches exactly one label.
+func matchRedirectPattern(pattern string, u *url.URL) bool {
+	p, err := url.Parse(pattern)
+	if err != nil || p.User != nil || p.RawQuery != "" || p.Fragment != "" {
+		return false
+	}
+	if p.Scheme != u.Scheme || p.EscapedPath() != u.EscapedPath() {
+		return false
+	}
+
+	host, patternHost := strings.ToLower(u.Host), strings.ToLower(p.Host)
+	suffix, wildcard := strings.CutPrefix(patternHost, "*.")
+	if !wildcard {
+		return host == patternHost
+	}
+	label, ok := strings.CutSuffix(host, "."+suffix)
+	return ok && isHostLabel(label)
+}
+
+// isHostLabel reports whether s is a single DNS label
+func isHostLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultTokenDelivery uses fragments for web URIs and one-time codes for custom schemes
+func defaultTokenDelivery(u *url.URL) string {
+	if u.Scheme == "http" || u.Scheme == "https" {
+		return TokenDeliveryFragment
+	}
+	return TokenDeliveryCode
+}
+
+// redirectError builds an AuthError for a rejected redirect URI
+func redirectError(redirectURI, message string) *AuthError {
+	return &AuthError{
+		Code:    ErrInvalidRedirect,
+		Message: message,
+		Details: map[string]interface{}{
+			"redirect_uri": redirectURI,
+		},
+	}
+}
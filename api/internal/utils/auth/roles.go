@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+)
+
+// Roles, from least to most privileged. Every account is implicitly a user;
+// only elevated roles are stored and carried in claims.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// roleRank orders roles so that a higher role includes the permissions of lower ones
+var roleRank = map[string]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+// RoleStore persists elevated roles
+type RoleStore interface {
+	// ListUserRoles lists a user's elevated roles
+	ListUserRoles(ctx context.Context, userID string) ([]string, error)
+
+	// GrantRole gives a user an elevated role; granting an existing role is a no-op
+	GrantRole(ctx context.Context, userID, role, grantedBy string) error
+
+	// RevokeRole removes an elevated role. Returns ErrUserNotFound if the user does not have it.
+	RevokeRole(ctx context.Context, userID, role string) error
+}
+
+// IsElevatedRole reports whether a role is one that can be granted
+func IsElevatedRole(role string) bool {
+	return role == RoleModerator || role == RoleAdmin
+}
+
+// HasRole reports whether a set of roles includes role or a higher one
+func HasRole(roles []string, role string) bool {
+	required, ok := roleRank[role]
+	if !ok {
+		return false
+	}
+	if required == 0 {
+		return true
+	}
+	for _, r := range roles {
+		if rank, ok := roleRank[r]; ok && rank >= required {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRole reports whether the token holder has role or a higher one.
+// Anonymous users only ever have the user role.
+func (c *Claims) HasRole(role string) bool {
+	if c.IsAnonymous {
+		return role == RoleUser
+	}
+	return HasRole(c.Roles, role)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 )
 
@@ -30,10 +31,25 @@ type SessionStore interface {
 	DeleteExpired(ctx context.Context) error
 }
 
+// TokenRevoker rejects a user's tokens signed in before a time, so signing out
+// everywhere also ends access through tokens whose sessions are gone
+type TokenRevoker interface {
+	RevokeTokens(ctx context.Context, userID string, before time.Time) error
+}
+
+// SessionListener drops what is kept for sessions elsewhere, such as push
+// subscriptions, when they are revoked
+type SessionListener interface {
+	SessionRevoked(ctx context.Context, sessionID string) error
+	UserSessionsRevoked(ctx context.Context, userID string) error
+}
+
 // SessionManager handles session lifecycle
 type SessionManager struct {
-	store SessionStore
-	idGen func() string
+	store    SessionStore
+	idGen    func() string
+	revoker  TokenRevoker    // nil leaves tokens valid until they expire
+	listener SessionListener // nil when nothing is kept per session
 }
 
 // NewSessionManager creates a new session manager
@@ -44,14 +60,25 @@ func NewSessionManager(store SessionStore) *SessionManager {
 	}
 }
 
-// CreateAnonymous creates a new anonymous session
-func (sm *SessionManager) CreateAnonymous(ctx context.Context, username string) (*Session, error) {
+// SetTokenRevoker makes RevokeAllForUser reject the user's outstanding tokens
+func (sm *SessionManager) SetTokenRevoker(revoker TokenRevoker) {
+	sm.revoker = revoker
+}
+
+// SetSessionListener tells a listener about revoked sessions
+func (sm *SessionManager) SetSessionListener(listener SessionListener) {
+	sm.listener = listener
+}
+
+// CreateAnonymous creates a new anonymous session for an anonymous user
+func (sm *SessionManager) CreateAnonymous(ctx context.Context, userID, username string) (*Session, error) {
 	if username == "" {
 		return nil, errors.New("username is required for anonymous session")
 	}
 	
 	session := &Session{
 		ID:          sm.idGen(),
+		UserID:      userID,
 		Username:    username,
 		IsAnonymous: true,
 		CreatedAt:   time.Now(),
@@ -165,13 +192,54 @@ func (sm *SessionManager) Refresh(ctx context.Context, sessionID string, ttl tim
 	return session, nil
 }
 
+// RenewAnonymous slides the expiry of an anonymous session
+func (sm *SessionManager) RenewAnonymous(ctx context.Context, sessionID string, ttl time.Duration) (*Session, error) {
+	session, err := sm.Validate(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if !session.IsAnonymous {
+		return nil, errors.New("session is not anonymous")
+	}
+	
+	// Update expiry
+	expiresAt := time.Now().Add(ttl)
+	session.ExpiresAt = &expiresAt
+	session.UpdatedAt = time.Now()
+	
+	if err := sm.store.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to renew session: %w", err)
+	}
+	
+	return session, nil
+}
+
 // Revoke deletes a session
 func (sm *SessionManager) Revoke(ctx context.Context, sessionID string) error {
+	if sm.listener != nil {
+		if err := sm.listener.SessionRevoked(ctx, sessionID); err != nil {
+			return fmt.Errorf("failed to clean up session: %w", err)
+		}
+	}
 	return sm.store.Delete(ctx, sessionID)
 }
 
-// RevokeAllForUser deletes all sessions for a user
+// RevokeAllForUser deletes all sessions for a user and rejects the tokens
+// issued to them so far
 func (sm *SessionManager) RevokeAllForUser(ctx context.Context, userID string) error {
+	// Tokens carry whole seconds; a sign-in within this second stays valid
+	if sm.revoker != nil {
+		if err := sm.revoker.RevokeTokens(ctx, userID, time.Now().Truncate(time.Second)); err != nil {
+			return fmt.Errorf("failed to revoke user tokens: %w", err)
+		}
+	}
+	if sm.listener != nil {
+		if err := sm.listener.UserSessionsRevoked(ctx, userID); err != nil {
+			return fmt.Errorf("failed to clean up user sessions: %w", err)
+		}
+	}
+
 	sessions, err := sm.store.FindByUserID(ctx, userID)
 	if err != nil {
 		return fmt.Errorf("failed to find user sessions: %w", err)
@@ -187,6 +255,15 @@ func (sm *SessionManager) RevokeAllForUser(ctx context.Context, userID string) e
 	return nil
 }
 
+// ListForUser returns all sessions for a user
+func (sm *SessionManager) ListForUser(ctx context.Context, userID string) ([]*Session, error) {
+	sessions, err := sm.store.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user sessions: %w", err)
+	}
+	return sessions, nil
+}
+
 // CleanupExpired removes all expired sessions
 func (sm *SessionManager) CleanupExpired(ctx context.Context) error {
 	return sm.store.DeleteExpired(ctx)
@@ -204,6 +281,7 @@ func generateSessionID() string {
 
 // InMemorySessionStore provides an in-memory implementation of SessionStore for testing
 type InMemorySessionStore struct {
+	mu       sync.RWMutex
 	sessions map[string]*Session
 }
 
@@ -215,6 +293,9 @@ func NewInMemorySessionStore() *InMemorySessionStore {
 }
 
 func (s *InMemorySessionStore) Create(ctx context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if _, exists := s.sessions[session.ID]; exists {
 		return errors.New("session already exists")
 	}
@@ -223,6 +304,9 @@ func (s *InMemorySessionStore) Create(ctx context.Context, session *Session) err
 }
 
 func (s *InMemorySessionStore) Get(ctx context.Context, sessionID string) (*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	session, exists := s.sessions[sessionID]
 	if !exists {
 		return nil, &AuthError{
@@ -234,6 +318,9 @@ func (s *InMemorySessionStore) Get(ctx context.Context, sessionID string) (*Sess
 }
 
 func (s *InMemorySessionStore) Update(ctx context.Context, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if _, exists := s.sessions[session.ID]; !exists {
 		return errors.New("session not found")
 	}
@@ -242,11 +329,17 @@ func (s *InMemorySessionStore) Update(ctx context.Context, session *Session) err
 }
 
 func (s *InMemorySessionStore) Delete(ctx context.Context, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	delete(s.sessions, sessionID)
 	return nil
 }
 
 func (s *InMemorySessionStore) FindByUserID(ctx context.Context, userID string) ([]*Session, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	var sessions []*Session
 	for _, session := range s.sessions {
 		if session.UserID == userID {
@@ -257,6 +350,9 @@ func (s *InMemorySessionStore) FindByUserID(ctx context.Context, userID string)
 }
 
 func (s *InMemorySessionStore) DeleteExpired(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	now := time.Now()
 	for id, session := range s.sessions {
 		if session.ExpiresAt != nil && session.ExpiresAt.Before(now) {
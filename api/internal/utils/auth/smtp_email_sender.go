@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTPEmailSender sends emails through an SMTP mail server
+type SMTPEmailSender struct {
+	config SMTPConfig
+	from   *mail.Address
+}
+
+// NewSMTPEmailSender creates a new SMTP email sender
+func NewSMTPEmailSender(config SMTPConfig) (*SMTPEmailSender, error) {
+	if config.Host == "" {
+		return nil, errors.New("SMTP host is required")
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	// Set defaults
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &SMTPEmailSender{
+		config: config,
+		from:   from,
+	}, nil
+}
+
+// SendMagicLink emails a sign-in link
+func (s *SMTPEmailSender) SendMagicLink(ctx context.Context, email, token, linkURL string) error {
+	return s.send(ctx, email, "Your sign-in link",
+		"Sign in to Alunalun by opening this link:\n\n"+linkURL+"\n\nIf you didn't ask to sign in, you can ignore this email.\n")
+}
+
+// SendVerificationEmail emails an email verification link
+func (s *SMTPEmailSender) SendVerificationEmail(ctx context.Context, email, token, linkURL string) error {
+	return s.send(ctx, email, "Verify your email address",
+		"Confirm this is your email address by opening this link:\n\n"+linkURL+"\n\nIf you didn't create an Alunalun account, you can ignore this email.\n")
+}
+
+// SendPasswordReset emails a password reset link
+func (s *SMTPEmailSender) SendPasswordReset(ctx context.Context, email, token, linkURL string) error {
+	return s.send(ctx, email, "Reset your password",
+		"Choose a new password by opening this link:\n\n"+linkURL+"\n\nIf you didn't ask to reset your password, you can ignore this email; your password hasn't changed.\n")
+}
+
+// SendSecurityAlert emails a security alert
+func (s *SMTPEmailSender) SendSecurityAlert(ctx context.Context, email, message string) error {
+	return s.send(ctx, email, "Security alert", message+"\n")
+}
+
+// send delivers a plain text email to one recipient
+func (s *SMTPEmailSender) send(ctx context.Context, email, subject, body string) error {
+	// ParseAddress rejects line breaks, so the address can't inject headers
+	to, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address: %w", err)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to mail server: %w", err)
+	}
+	defer client.Close()
+
+	if s.config.Username != "" {
+		// PlainAuth refuses to send credentials over a connection without TLS
+		if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate with mail server: %w", err)
+		}
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return client.Quit()
+}
+
+// dial connects to the mail server: with TLS from the start on port 465,
+// otherwise upgrading with STARTTLS when the server offers it
+func (s *SMTPEmailSender) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+	tlsConfig := &tls.Config{ServerName: s.config.Host}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if s.config.Port == 465 {
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -9,23 +10,36 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
+	
+	"golang.org/x/oauth2"
 )
 
 // OAuthState represents the state data for OAuth flows
 type OAuthState struct {
-	Nonce       string    `json:"n"`   // Random nonce for CSRF protection
-	Provider    string    `json:"p"`   // OAuth provider (google, apple, etc)
-	RedirectURI string    `json:"r"`   // Client redirect URI after auth
-	SessionID   string    `json:"sid,omitempty"` // Optional session ID for migration
-	CreatedAt   int64     `json:"iat"` // Unix timestamp
-	ExpiresAt   int64     `json:"exp"` // Unix timestamp
+	Nonce              string `json:"n"`             // Random nonce for CSRF protection, also sent as the OIDC nonce
+	Provider           string `json:"p"`             // OAuth provider (google, apple, etc)
+	RedirectURI        string `json:"r"`             // Client redirect URI after auth
+	SessionID          string `json:"sid,omitempty"` // Optional session ID for migration
+	UsernameResolution string `json:"ur,omitempty"`  // Username kept when merging an anonymous account
+	CodeVerifier       string `json:"cv"`            // PKCE code verifier (RFC 7636)
+	CodeChallenge      string `json:"cc,omitempty"`  // Client's S256 challenge for the one-time login code
+	CreatedAt          int64  `json:"iat"`           // Unix timestamp
+	ExpiresAt          int64  `json:"exp"`           // Unix timestamp
+}
+
+// StateReplayStore records consumed state nonces so each state is single-use
+type StateReplayStore interface {
+	// MarkUsed records a nonce, returning false if it was already used
+	MarkUsed(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
 }
 
 // StateManager handles stateless OAuth state management
 type StateManager struct {
 	encryptionKey []byte // 32 bytes for AES-256
 	stateTTL      time.Duration
+	replayStore   StateReplayStore
 }
 
 // NewStateManager creates a new state manager with encryption
@@ -41,41 +55,54 @@ func NewStateManager(encryptionKey []byte, stateTTL time.Duration) (*StateManage
 	return &StateManager{
 		encryptionKey: encryptionKey,
 		stateTTL:      stateTTL,
+		replayStore:   NewInMemoryStateReplayStore(),
 	}, nil
 }
 
-// GenerateState creates an encrypted state token for OAuth flow
-func (sm *StateManager) GenerateState(provider, redirectURI, sessionID string) (string, error) {
+// SetReplayStore replaces the default in-memory replay store
+// (use a shared store when running multiple instances)
+func (sm *StateManager) SetReplayStore(store StateReplayStore) {
+	sm.replayStore = store
+}
+
+// GenerateState creates an encrypted state token for OAuth flow.
+// The returned state carries the nonce and PKCE verifier needed to build the auth URL.
+// codeChallenge is the native client's own PKCE challenge, checked when it
+// exchanges the one-time login code.
+func (sm *StateManager) GenerateState(provider, redirectURI, sessionID, usernameResolution, codeChallenge string) (string, *OAuthState, error) {
 	// Generate random nonce
 	nonce := make([]byte, 16)
 	if _, err := rand.Read(nonce); err != nil {
-		return "", fmt.Errorf("failed to generate nonce: %w", err)
+		return "", nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 	
 	now := time.Now()
-	state := OAuthState{
-		Nonce:       base64.URLEncoding.EncodeToString(nonce),
-		Provider:    provider,
-		RedirectURI: redirectURI,
-		SessionID:   sessionID,
-		CreatedAt:   now.Unix(),
-		ExpiresAt:   now.Add(sm.stateTTL).Unix(),
+	state := &OAuthState{
+		Nonce:              base64.RawURLEncoding.EncodeToString(nonce),
+		Provider:           provider,
+		RedirectURI:        redirectURI,
+		SessionID:          sessionID,
+		UsernameResolution: usernameResolution,
+		CodeVerifier:       oauth2.GenerateVerifier(),
+		CodeChallenge:      codeChallenge,
+		CreatedAt:          now.Unix(),
+		ExpiresAt:          now.Add(sm.stateTTL).Unix(),
 	}
 	
 	// Marshal state to JSON
 	plaintext, err := json.Marshal(state)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal state: %w", err)
+		return "", nil, fmt.Errorf("failed to marshal state: %w", err)
 	}
 	
 	// Encrypt the state
 	ciphertext, err := sm.encrypt(plaintext)
 	if err != nil {
-		return "", fmt.Errorf("failed to encrypt state: %w", err)
+		return "", nil, fmt.Errorf("failed to encrypt state: %w", err)
 	}
 	
 	// Encode to URL-safe base64
-	return base64.URLEncoding.EncodeToString(ciphertext), nil
+	return base64.URLEncoding.EncodeToString(ciphertext), state, nil
 }
 
 // ValidateState decrypts and validates an OAuth state token
@@ -106,6 +133,25 @@ func (sm *StateManager) ValidateState(stateToken string) (*OAuthState, error) {
 	return &state, nil
 }
 
+// ConsumeState validates a state token and marks it as used.
+// A state can only be consumed once; replays are rejected.
+func (sm *StateManager) ConsumeState(ctx context.Context, stateToken string) (*OAuthState, error) {
+	state, err := sm.ValidateState(stateToken)
+	if err != nil {
+		return nil, err
+	}
+	
+	fresh, err := sm.replayStore.MarkUsed(ctx, state.Nonce, time.Unix(state.ExpiresAt, 0))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record state: %w", err)
+	}
+	if !fresh {
+		return nil, errors.New("state token already used")
+	}
+	
+	return state, nil
+}
+
 // encrypt performs AES-256-GCM encryption
 func (sm *StateManager) encrypt(plaintext []byte) ([]byte, error) {
 	block, err := aes.NewCipher(sm.encryptionKey)
@@ -174,4 +220,37 @@ func (sm *StateManager) ParseProvider(stateToken string) (string, error) {
 		return "", err
 	}
 	return state.Provider, nil
-}
\ No newline at end of file
+}
+
+// InMemoryStateReplayStore is an in-memory implementation of StateReplayStore for single-instance deployments
+type InMemoryStateReplayStore struct {
+	mu   sync.Mutex
+	used map[string]time.Time // nonce -> expiry
+}
+
+// NewInMemoryStateReplayStore creates a new in-memory state replay store
+func NewInMemoryStateReplayStore() *InMemoryStateReplayStore {
+	return &InMemoryStateReplayStore{
+		used: make(map[string]time.Time),
+	}
+}
+
+// MarkUsed records a nonce, returning false if it was already used
+func (s *InMemoryStateReplayStore) MarkUsed(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	// Prune expired entries; expired states are rejected by ValidateState anyway
+	now := time.Now()
+	for n, exp := range s.used {
+		if now.After(exp) {
+			delete(s.used, n)
+		}
+	}
+	
+	if _, exists := s.used[nonce]; exists {
+		return false, nil
+	}
+	s.used[nonce] = expiresAt
+	return true, nil
+}
@@ -31,7 +31,22 @@ type Claims struct {
 	Email       string                 `json:"email,omitempty"`
 	Provider    string                 `json:"provider"`
 	IsAnonymous bool                   `json:"is_anonymous"`
+	Roles       []string               `json:"roles,omitempty"` // Elevated roles (moderator, admin)
+	Scopes      []string               `json:"scopes,omitempty"` // API key scopes; empty for sign-in tokens
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	AuthTime    int64                  `json:"auth_time,omitempty"` // Unix time of the sign-in; kept across refreshes
+}
+
+// SignedInAt returns when the sign-in behind the token happened. Tokens
+// issued before auth_time existed fall back to their issue time.
+func (c *Claims) SignedInAt() time.Time {
+	if c.AuthTime != 0 {
+		return time.Unix(c.AuthTime, 0)
+	}
+	if c.IssuedAt != nil {
+		return c.IssuedAt.Time
+	}
+	return time.Time{}
 }
 
 // NewTokenManager creates a new token manager
@@ -118,6 +133,9 @@ func (tm *TokenManager) GenerateToken(claims *Claims, expiry time.Duration) (str
 	claims.Audience = []string{tm.audience}
 	claims.IssuedAt = jwt.NewNumericDate(now)
 	claims.NotBefore = jwt.NewNumericDate(now)
+	if claims.AuthTime == 0 {
+		claims.AuthTime = now.Unix()
+	}
 	
 	// Set expiry only if duration is positive (anonymous tokens don't expire)
 	if expiry > 0 {
@@ -215,7 +233,9 @@ func (tm *TokenManager) RefreshToken(expiredToken string, newExpiry time.Duratio
 		Email:       claims.Email,
 		Provider:    claims.Provider,
 		IsAnonymous: claims.IsAnonymous,
+		Roles:       claims.Roles,
 		Metadata:    claims.Metadata,
+		AuthTime:    claims.SignedInAt().Unix(),
 	}
 	
 	return tm.GenerateToken(newClaims, newExpiry)
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// WebAuthn authenticator data flags
+const (
+	webauthnFlagUserPresent  = 0x01
+	webauthnFlagUserVerified = 0x04
+	webauthnFlagAttestedData = 0x40
+)
+
+// COSE algorithms accepted for passkeys, in order of preference
+const (
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+	coseAlgRS256 = -257
+)
+
+// webauthnClientData is the collected client data signed by the authenticator
+type webauthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// webauthnAuthData is parsed authenticator data
+type webauthnAuthData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	CredentialID []byte // Only present during registration
+	PublicKey    []byte // COSE_Key, only present during registration
+}
+
+// verifyClientData checks the ceremony type, challenge and origin of client data
+func verifyClientData(raw []byte, ceremony, challenge string, origins []string) error {
+	var clientData webauthnClientData
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return fmt.Errorf("invalid client data: %w", err)
+	}
+	if clientData.Type != ceremony {
+		return fmt.Errorf("unexpected ceremony type %q", clientData.Type)
+	}
+	if clientData.Challenge != challenge {
+		return errors.New("challenge mismatch")
+	}
+	for _, origin := range origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("origin %q is not allowed", clientData.Origin)
+}
+
+// parseAuthData parses authenticator data and checks the relying party and user flags
+func parseAuthData(raw []byte, rpID string, requireUV bool) (*webauthnAuthData, error) {
+	if len(raw) < 37 {
+		return nil, errors.New("authenticator data too short")
+	}
+
+	authData := &webauthnAuthData{
+		RPIDHash:  raw[:32],
+		Flags:     raw[32],
+		SignCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return nil, errors.New("relying party ID mismatch")
+	}
+	if authData.Flags&webauthnFlagUserPresent == 0 {
+		return nil, errors.New("user presence is required")
+	}
+	if requireUV && authData.Flags&webauthnFlagUserVerified == 0 {
+		return nil, errors.New("user verification is required")
+	}
+
+	if authData.Flags&webauthnFlagAttestedData != 0 {
+		// AAGUID (16 bytes), credential ID length (2 bytes), credential ID, COSE key
+		rest := raw[37:]
+		if len(rest) < 18 {
+			return nil, errors.New("attested credential data too short")
+		}
+		idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLen == 0 || len(rest) < idLen {
+			return nil, errors.New("invalid credential ID")
+		}
+		authData.CredentialID = rest[:idLen]
+
+		_, n, err := cborDecode(rest[idLen:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential public key: %w", err)
+		}
+		authData.PublicKey = rest[idLen : idLen+n]
+	}
+
+	return authData, nil
+}
+
+// parseAttestationObject returns the authenticator data from an attestation object.
+// Passkeys are registered with attestation "none", so attestation statements are not verified.
+func parseAttestationObject(raw []byte) ([]byte, error) {
+	decoded, _, err := cborDecode(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation object: %w", err)
+	}
+	obj, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("invalid attestation object")
+	}
+	authData, ok := obj["authData"].([]byte)
+	if !ok {
+		return nil, errors.New("attestation object has no authenticator data")
+	}
+	return authData, nil
+}
+
+// parseCOSEKey converts a COSE_Key into a public key and its algorithm
+func parseCOSEKey(raw []byte) (crypto.PublicKey, int64, error) {
+	decoded, _, err := cborDecode(raw)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid COSE key: %w", err)
+	}
+	key, ok := decoded.(map[interface{}]interface{})
+	if !ok {
+		return nil, 0, errors.New("invalid COSE key")
+	}
+
+	kty, _ := key[int64(1)].(int64)
+	alg, _ := key[int64(3)].(int64)
+	switch {
+	case kty == 2 && alg == coseAlgES256:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("unsupported EC2 key")
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, 0, errors.New("EC2 key is not on the curve")
+		}
+		return pub, alg, nil
+
+	case kty == 1 && alg == coseAlgEdDSA:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, errors.New("unsupported OKP key")
+		}
+		return ed25519.PublicKey(x), alg, nil
+
+	case kty == 3 && alg == coseAlgRS256:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("unsupported RSA key")
+		}
+		exponent := int(new(big.Int).SetBytes(e).Int64())
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}, alg, nil
+	}
+
+	return nil, 0, fmt.Errorf("unsupported key type %d with algorithm %d", kty, alg)
+}
+
+// verifyAssertionSignature checks an assertion signature over authData || SHA-256(clientDataJSON)
+func verifyAssertionSignature(coseKey, authData, clientDataJSON, signature []byte) error {
+	pub, alg, err := parseCOSEKey(coseKey)
+	if err != nil {
+		return err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authData...), clientDataHash[:]...)
+
+	switch alg {
+	case coseAlgES256:
+		digest := sha256.Sum256(signed)
+		if !ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+	case coseAlgEdDSA:
+		if !ed25519.Verify(pub.(ed25519.PublicKey), signed, signature) {
+			return errors.New("invalid signature")
+		}
+	case coseAlgRS256:
+		digest := sha256.Sum256(signed)
+		if err := rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported algorithm %d", alg)
+	}
+	return nil
+}
+
+// decodeWebAuthnBytes decodes base64url as sent by PublicKeyCredential.toJSON(),
+// tolerating padding and standard base64
+func decodeWebAuthnBytes(s string) ([]byte, error) {
+	if b, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	if b, err := base64.URLEncoding.DecodeString(s); err == nil {
+		return b, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
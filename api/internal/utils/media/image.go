@@ -0,0 +1,109 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+
+	// Decoders for accepted upload formats
+	_ "image/gif"
+	_ "image/png"
+)
+
+// Limits on uploaded images, checked before decoding
+const (
+	MaxImageBytes     = 5 << 20
+	maxImageDimension = 8000
+	maxImagePixels    = 40_000_000
+)
+
+// ErrInvalidImage is returned for uploads that are not a usable JPEG, PNG or GIF
+var ErrInvalidImage = errors.New("image must be a JPEG, PNG or GIF")
+
+// SquareJPEG center-crops an image to a square, shrinks it to at most size
+// pixels per side and re-encodes it as JPEG. Re-encoding also drops metadata
+// such as EXIF location.
+func SquareJPEG(data []byte, size int) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidImage
+	}
+	if len(data) > MaxImageBytes {
+		return nil, fmt.Errorf("image must be at most %d MB", MaxImageBytes>>20)
+	}
+
+	// Check dimensions before decoding, so small files can't expand into huge images
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 ||
+		config.Width > maxImageDimension || config.Height > maxImageDimension ||
+		config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("image must be at most %dx%d pixels", maxImageDimension, maxImageDimension)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	// Center square crop, flattened onto white since JPEG has no transparency
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	origin := image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	)
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(square, square.Bounds(), src, origin, draw.Over)
+
+	out := square
+	if side > size {
+		out = shrink(square, size)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, out, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// shrink downsamples a square image to size x size by averaging the source
+// pixels that fall in each output pixel
+func shrink(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		y0, y1 := y*side/size, (y+1)*side/size
+		for x := 0; x < size; x++ {
+			x0, x1 := x*side/size, (x+1)*side/size
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,131 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Store saves uploaded media and returns public URLs for it
+type Store interface {
+	// Put saves data under key, replacing any earlier object, and returns its public URL
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// KeyFromURL returns the key of an object this store served at url
+	KeyFromURL(url string) (string, bool)
+}
+
+// LocalStore keeps media on the local disk for single-instance deployments.
+// Its Handler serves the files at baseURL.
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStore creates a store rooted at dir, served at baseURL
+func NewLocalStore(dir, baseURL string) (*LocalStore, error) {
+	if dir == "" {
+		return nil, errors.New("media directory is required")
+	}
+	if baseURL == "" {
+		return nil, errors.New("media base URL is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	return &LocalStore{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}, nil
+}
+
+// Put writes data to disk atomically
+func (s *LocalStore) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	file, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	// Write to a temp file first so readers never see a partial image
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create media file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return "", fmt.Errorf("failed to save media file: %w", err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+// Delete removes a file from disk
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	file, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete media file: %w", err)
+	}
+	return nil
+}
+
+// KeyFromURL returns the key of a file served by this store
+func (s *LocalStore) KeyFromURL(url string) (string, bool) {
+	key, ok := strings.CutPrefix(url, s.baseURL+"/")
+	if !ok {
+		return "", false
+	}
+	if _, err := s.path(key); err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// Handler serves stored files. Keys are never reused, so responses are cached for good.
+func (s *LocalStore) Handler() http.Handler {
+	files := http.FileServer(http.Dir(s.dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// No directory listings
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// path maps a key to a file inside the store's directory
+func (s *LocalStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)[1:]
+	if key == "" || cleaned != key || strings.HasPrefix(path.Base(key), ".") {
+		return "", fmt.Errorf("invalid media key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
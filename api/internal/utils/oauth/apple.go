@@ -0,0 +1,410 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/radjathaher/alunalun/api/internal/utils/auth"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// appleIssuer is the issuer of Apple ID tokens and the audience of client secrets
+	appleIssuer = "https://appleid.apple.com"
+
+	// appleClientSecretTTL is how long a generated client secret is valid (Apple allows up to 6 months)
+	appleClientSecretTTL = 24 * time.Hour
+
+	// applePrivateRelayDomain is the domain of Apple's "Hide My Email" addresses
+	applePrivateRelayDomain = "privaterelay.appleid.com"
+)
+
+// AppleProvider implements Sign in with Apple
+type AppleProvider struct {
+	BaseProvider
+	httpClient *http.Client
+	teamID     string
+	keyID      string
+	privateKey *ecdsa.PrivateKey
+	jwks       *JWKSCache
+	audiences  []string // Accepted ID token audiences: the Services ID and native bundle IDs
+	bundleIDs  []string // Native app IDs, the client IDs of codes issued to the apps
+
+	mu            sync.Mutex
+	clientSecrets map[string]appleClientSecret // By client ID
+}
+
+// appleClientSecret is a signed client secret and when it expires
+type appleClientSecret struct {
+	secret    string
+	expiresAt time.Time
+}
+
+// AppleIDTokenClaims represents the claims in an Apple ID token
+type AppleIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Email          string    `json:"email"`
+	EmailVerified  appleBool `json:"email_verified"`
+	IsPrivateEmail appleBool `json:"is_private_email"`
+	Nonce          string    `json:"nonce,omitempty"`
+	NonceSupported bool      `json:"nonce_supported,omitempty"`
+	RealUserStatus int       `json:"real_user_status,omitempty"`
+}
+
+// AppleUser is the user object Apple posts on the first authorization only
+type AppleUser struct {
+	Name struct {
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+	} `json:"name"`
+	Email string `json:"email"`
+}
+
+// appleBool decodes Apple's boolean claims, which are sent as either bools or strings
+type appleBool bool
+
+// UnmarshalJSON accepts true, false, "true" and "false"
+func (b *appleBool) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	*b = appleBool(s == "true")
+	return nil
+}
+
+// NewAppleProvider creates a new Sign in with Apple provider.
+// privateKeyPEM is the contents of the .p8 key downloaded from the Apple developer portal.
+// bundleIDs are the native app IDs whose ID tokens are accepted alongside the web Services ID.
+func NewAppleProvider(clientID, teamID, keyID, privateKeyPEM, redirectURL string, bundleIDs []string) (*AppleProvider, error) {
+	if clientID == "" || teamID == "" || keyID == "" || privateKeyPEM == "" || redirectURL == "" {
+		return nil, errors.New("clientID, teamID, keyID, privateKey, and redirectURL are required")
+	}
+
+	privateKey, err := parseApplePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := getOAuthEndpoint("apple")
+	endpoint.AuthStyle = oauth2.AuthStyleInParams
+
+	config := &oauth2.Config{
+		ClientID:    clientID,
+		RedirectURL: redirectURL,
+		Scopes:      []string{"name", "email"},
+		Endpoint:    endpoint,
+	}
+
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	return &AppleProvider{
+		BaseProvider: BaseProvider{
+			Name:   "apple",
+			Config: config,
+		},
+		httpClient:    httpClient,
+		teamID:        teamID,
+		keyID:         keyID,
+		privateKey:    privateKey,
+		jwks:          NewJWKSCache(getJWKSURL("apple"), httpClient, 24*time.Hour),
+		audiences:     append([]string{clientID}, bundleIDs...),
+		bundleIDs:     bundleIDs,
+		clientSecrets: make(map[string]appleClientSecret),
+	}, nil
+}
+
+// parseApplePrivateKey parses a PKCS#8 encoded ECDSA key in PEM format
+func parseApplePrivateKey(privateKeyPEM string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("failed to parse Apple private key PEM")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Apple private key: %w", err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("Apple private key is not an ECDSA key")
+	}
+
+	return ecKey, nil
+}
+
+// Name returns the provider name
+func (p *AppleProvider) Name() string {
+	return "apple"
+}
+
+// ValidateConfig checks if the Apple provider is properly configured
+func (p *AppleProvider) ValidateConfig() error {
+	if p.Config == nil {
+		return errors.New("OAuth2 config is nil")
+	}
+	if p.Config.ClientID == "" {
+		return errors.New("client ID is required")
+	}
+	if p.teamID == "" || p.keyID == "" || p.privateKey == nil {
+		return errors.New("team ID, key ID, and private key are required")
+	}
+	if p.Config.RedirectURL == "" {
+		return errors.New("redirect URL is required")
+	}
+	return nil
+}
+
+// GetAuthURL generates the authorization URL. Apple requires form_post when
+// requesting name or email scopes, so the callback arrives as a POST.
+func (p *AppleProvider) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.Config.AuthCodeURL(state, append([]oauth2.AuthCodeOption{oauth2.SetAuthURLParam("response_mode", "form_post")}, opts...)...)
+}
+
+// SupportsPKCE returns false; Apple's token endpoint does not document PKCE
+// and relies on the signed client secret instead
+func (p *AppleProvider) SupportsPKCE() bool {
+	return false
+}
+
+// ExchangeCode exchanges an authorization code for tokens using a freshly signed client secret
+func (p *AppleProvider) ExchangeCode(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return p.exchange(ctx, *p.Config, code, opts...)
+}
+
+// exchangeNativeCode exchanges a code a native app received from Apple. Apple
+// issues those to the app's bundle ID and without a redirect URI, so the
+// exchange is made as the app rather than as the web Services ID.
+func (p *AppleProvider) exchangeNativeCode(ctx context.Context, code, bundleID string) (*oauth2.Token, error) {
+	config := *p.Config
+	config.ClientID = bundleID
+	config.RedirectURL = "" // Omits redirect_uri from the request
+	return p.exchange(ctx, config, code)
+}
+
+// exchange redeems a code with a client secret signed for the config's client ID
+func (p *AppleProvider) exchange(ctx context.Context, config oauth2.Config, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	if code == "" {
+		return nil, errors.New("authorization code is required")
+	}
+
+	secret, err := p.getClientSecret(config.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientSecret = secret
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	return config.Exchange(ctx, code, opts...)
+}
+
+// getClientSecret returns a cached ES256 client secret for a client ID,
+// regenerating it shortly before expiry
+func (p *AppleProvider) getClientSecret(clientID string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if cached, ok := p.clientSecrets[clientID]; ok && time.Until(cached.expiresAt) > time.Minute {
+		return cached.secret, nil
+	}
+
+	now := time.Now()
+	exp := now.Add(appleClientSecretTTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.RegisteredClaims{
+		Issuer:    p.teamID,
+		Subject:   clientID,
+		Audience:  jwt.ClaimStrings{appleIssuer},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(exp),
+	})
+	token.Header["kid"] = p.keyID
+
+	secret, err := token.SignedString(p.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign Apple client secret: %w", err)
+	}
+
+	p.clientSecrets[clientID] = appleClientSecret{secret: secret, expiresAt: exp}
+	return secret, nil
+}
+
+// Authenticate handles Sign in with Apple authentication.
+// The credential may be an ID token, an authorization code, or a JSON payload
+// from native clients: {"id_token" | "code": "...", "bundle_id": "...", "user": {...}}.
+// Native codes are exchanged as the app; bundle_id picks which one when
+// several are configured.
+func (p *AppleProvider) Authenticate(ctx context.Context, credential string) (*auth.UserInfo, error) {
+	// Native iOS clients forward the user object alongside the token
+	if strings.HasPrefix(strings.TrimSpace(credential), "{") {
+		var payload struct {
+			IDToken  string          `json:"id_token"`
+			Code     string          `json:"code"`
+			BundleID string          `json:"bundle_id"`
+			User     json.RawMessage `json:"user"`
+		}
+		if err := json.Unmarshal([]byte(credential), &payload); err != nil {
+			return nil, fmt.Errorf("invalid Apple credential: %w", err)
+		}
+
+		var info *auth.UserInfo
+		var err error
+		switch {
+		case payload.IDToken != "":
+			info, err = p.VerifyIDToken(ctx, payload.IDToken)
+		case payload.Code != "":
+			info, err = p.authenticateNativeCode(ctx, payload.Code, payload.BundleID)
+		default:
+			return nil, errors.New("id_token or code is required")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		MergeAppleUser(info, string(payload.User))
+		return info, nil
+	}
+
+	// If it looks like a JWT (has three parts separated by dots), treat as ID token
+	if strings.Count(credential, ".") == 2 {
+		return p.VerifyIDToken(ctx, credential)
+	}
+
+	// Otherwise, treat as authorization code
+	return p.authenticateCode(ctx, credential)
+}
+
+// authenticateCode exchanges a code and verifies the returned ID token
+func (p *AppleProvider) authenticateCode(ctx context.Context, code string) (*auth.UserInfo, error) {
+	token, err := p.ExchangeCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	return p.GetUserInfo(ctx, token)
+}
+
+// authenticateNativeCode exchanges a native app's code as one of the
+// configured bundle IDs and verifies the returned ID token
+func (p *AppleProvider) authenticateNativeCode(ctx context.Context, code, bundleID string) (*auth.UserInfo, error) {
+	switch {
+	case len(p.bundleIDs) == 0:
+		return nil, errors.New("no Apple bundle IDs are configured for native codes")
+	case bundleID == "" && len(p.bundleIDs) == 1:
+		bundleID = p.bundleIDs[0]
+	case bundleID == "":
+		return nil, errors.New("bundle_id is required with several Apple bundle IDs configured")
+	case !slices.Contains(p.bundleIDs, bundleID):
+		return nil, fmt.Errorf("unknown Apple bundle ID: %s", bundleID)
+	}
+
+	token, err := p.exchangeNativeCode(ctx, code, bundleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	return p.GetUserInfo(ctx, token)
+}
+
+// GetUserInfo extracts user info from the ID token returned by the token endpoint
+func (p *AppleProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (*auth.UserInfo, error) {
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return nil, errors.New("token response missing id_token")
+	}
+
+	return p.VerifyIDToken(ctx, idToken)
+}
+
+// VerifyIDToken verifies an Apple ID token against Apple's published signing keys
+func (p *AppleProvider) VerifyIDToken(ctx context.Context, idToken string) (*auth.UserInfo, error) {
+	claims := &AppleIDTokenClaims{}
+
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("ID token missing kid header")
+		}
+		return p.jwks.GetKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(appleIssuer),
+		jwt.WithAudience(p.audiences...), // Any one of them
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Apple ID token: %w", err)
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("Apple ID token missing subject")
+	}
+
+	isPrivateEmail := bool(claims.IsPrivateEmail) || IsApplePrivateRelayEmail(claims.Email)
+
+	// Relay addresses are random and make poor usernames; callers make the name unique
+	username := "apple"
+	if !isPrivateEmail && claims.Email != "" {
+		username, _, _ = strings.Cut(claims.Email, "@")
+	}
+
+	return &auth.UserInfo{
+		ID:            claims.Subject,
+		Email:         claims.Email,
+		Username:      username,
+		Provider:      "apple",
+		ProviderID:    claims.Subject,
+		EmailVerified: bool(claims.EmailVerified),
+		VerifiedAt:    time.Now(),
+		Metadata: map[string]interface{}{
+			"apple_sub":        claims.Subject,
+			"is_private_email": isPrivateEmail,
+			"real_user_status": claims.RealUserStatus,
+			"nonce":            claims.Nonce,
+		},
+	}, nil
+}
+
+// MergeAppleUser merges the user object Apple sends on first authorization into info.
+// Apple only delivers the user's name once, so callers must persist it immediately.
+func MergeAppleUser(info *auth.UserInfo, rawUser string) {
+	if info == nil || rawUser == "" {
+		return
+	}
+
+	var user AppleUser
+	if err := json.Unmarshal([]byte(rawUser), &user); err != nil {
+		return
+	}
+
+	if user.Name.FirstName != "" {
+		info.FirstName = user.Name.FirstName
+	}
+	if user.Name.LastName != "" {
+		info.LastName = user.Name.LastName
+	}
+	if info.FirstName != "" || info.LastName != "" {
+		info.FullName = strings.TrimSpace(info.FirstName + " " + info.LastName)
+	}
+
+	// The ID token is authoritative for email; only fill it in if missing
+	if info.Email == "" && user.Email != "" {
+		info.Email = user.Email
+	}
+}
+
+// IsApplePrivateRelayEmail reports whether email is an Apple "Hide My Email" relay address
+func IsApplePrivateRelayEmail(email string) bool {
+	return strings.HasSuffix(strings.ToLower(email), "@"+applePrivateRelayDomain)
+}
@@ -43,6 +43,11 @@ func getOAuthEndpoint(provider string) oauth2.Endpoint {
 		switch provider {
 		case "google":
 			return google.Endpoint
+		case "apple":
+			return oauth2.Endpoint{
+				AuthURL:  "https://appleid.apple.com/auth/authorize",
+				TokenURL: "https://appleid.apple.com/auth/token",
+			}
 		default:
 			panic("unsupported real OAuth provider: " + provider)
 		}
@@ -91,6 +96,26 @@ func getTokenInfoURL(provider string) string {
 	}
 }
 
+// getJWKSURL returns the appropriate JWKS URL based on configuration
+func getJWKSURL(provider string) string {
+	mode := os.Getenv("OAUTH_MODE")
+	
+	switch mode {
+	case "mock":
+		baseURL := os.Getenv("MOCK_OAUTH_BASE_URL")
+		return baseURL + "/" + provider + "/keys"
+	case "real":
+		switch provider {
+		case "apple":
+			return "https://appleid.apple.com/auth/keys"
+		default:
+			panic("unsupported real OAuth provider: " + provider)
+		}
+	default:
+		panic("OAUTH_MODE must be 'mock' or 'real', got: " + mode)
+	}
+}
+
 // GoogleUserInfo represents the user info returned by Google
 type GoogleUserInfo struct {
 	Sub           string `json:"sub"`           // Unique Google ID
@@ -160,6 +185,16 @@ func (p *GoogleProvider) Authenticate(ctx context.Context, credential string) (*
 	return p.getUserInfo(ctx, token.AccessToken)
 }
 
+// GetUserInfo returns user info for the tokens obtained from a code exchange
+func (p *GoogleProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (*auth.UserInfo, error) {
+	// Prefer the ID token when the token endpoint returned one
+	if idToken, ok := token.Extra("id_token").(string); ok && idToken != "" {
+		return p.VerifyIDToken(ctx, idToken)
+	}
+	
+	return p.getUserInfo(ctx, token.AccessToken)
+}
+
 // VerifyIDToken verifies a Google ID token (for mobile/SPA clients)
 func (p *GoogleProvider) VerifyIDToken(ctx context.Context, idToken string) (*auth.UserInfo, error) {
 	// For production, you should verify the ID token properly
@@ -194,6 +229,7 @@ func (p *GoogleProvider) VerifyIDToken(ctx context.Context, idToken string) (*au
 		Picture       string `json:"picture"`
 		GivenName     string `json:"given_name"`
 		FamilyName    string `json:"family_name"`
+		Nonce         string `json:"nonce"`
 	}
 	
 	if err := json.NewDecoder(resp.Body).Decode(&tokenInfo); err != nil {
@@ -219,6 +255,7 @@ func (p *GoogleProvider) VerifyIDToken(ctx context.Context, idToken string) (*au
 		VerifiedAt:    time.Now(),
 		Metadata: map[string]interface{}{
 			"google_sub": tokenInfo.Sub,
+			"nonce":      tokenInfo.Nonce,
 		},
 	}, nil
 }
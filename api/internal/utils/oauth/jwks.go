@@ -0,0 +1,178 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// JWKS refresh limits. ID tokens come from unauthenticated callers, so an
+// unknown kid must not make every request fetch the key set.
+const (
+	jwksMinRefreshInterval = time.Minute
+	jwksMaxMisses          = 1000
+)
+
+// JWKSCache fetches and caches a provider's JSON Web Key Set
+type JWKSCache struct {
+	url        string
+	httpClient *http.Client
+	ttl        time.Duration
+
+	refreshMu   sync.Mutex // Serializes fetches
+	attemptedAt time.Time  // Last fetch attempt, successful or not
+
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	misses    map[string]bool // Key IDs not in the current set
+	fetchedAt time.Time
+}
+
+// jsonWebKey represents a single RSA key in a JWKS document
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// NewJWKSCache creates a new JWKS cache for the given URL
+func NewJWKSCache(url string, httpClient *http.Client, ttl time.Duration) *JWKSCache {
+	if ttl == 0 {
+		ttl = 24 * time.Hour
+	}
+	return &JWKSCache{
+		url:        url,
+		httpClient: httpClient,
+		ttl:        ttl,
+		keys:       make(map[string]*rsa.PublicKey),
+		misses:     make(map[string]bool),
+	}
+}
+
+// GetKey returns the public key for a key ID, refreshing the set if the key
+// is unknown or the cache is stale (providers rotate keys without notice).
+// Refreshes happen at most once per jwksMinRefreshInterval, and key IDs
+// missing from the current set are remembered until the next refresh.
+func (c *JWKSCache) GetKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	missed := c.misses[kid]
+	fresh := time.Since(c.fetchedAt) < c.ttl
+	c.mu.RUnlock()
+
+	if ok && fresh {
+		return key, nil
+	}
+	if missed && fresh {
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+
+	if err := c.maybeRefresh(ctx); err != nil {
+		// Fall back to a stale key rather than failing every login
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, ok = c.keys[kid]
+	if !ok {
+		if len(c.misses) < jwksMaxMisses {
+			c.misses[kid] = true
+		}
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+	return key, nil
+}
+
+// maybeRefresh refreshes the key set unless a fetch was attempted within jwksMinRefreshInterval
+func (c *JWKSCache) maybeRefresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if time.Since(c.attemptedAt) < jwksMinRefreshInterval {
+		return nil
+	}
+	c.attemptedAt = time.Now()
+
+	return c.refresh(ctx)
+}
+
+// refresh downloads the key set and replaces the cached keys
+func (c *JWKSCache) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to fetch JWKS: %s", string(body))
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		key, err := jwk.rsaPublicKey()
+		if err != nil {
+			return fmt.Errorf("invalid key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("JWKS contains no RSA keys")
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.misses = make(map[string]bool)
+	c.fetchedAt = time.Now()
+	c.mu.Unlock()
+
+	return nil
+}
+
+// rsaPublicKey decodes the modulus and exponent of an RSA JWK
+func (k *jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -3,6 +3,7 @@ package oauth
 import (
 	"context"
 	"errors"
+	"strings"
 	
 	"github.com/radjathaher/alunalun/api/internal/utils/auth"
 	"golang.org/x/oauth2"
@@ -13,10 +14,16 @@ type Provider interface {
 	auth.Provider
 	
 	// GetAuthURL generates the OAuth authorization URL
-	GetAuthURL(state string) string
+	GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string
 	
 	// ExchangeCode exchanges an authorization code for tokens
-	ExchangeCode(ctx context.Context, code string) (*oauth2.Token, error)
+	ExchangeCode(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error)
+	
+	// SupportsPKCE reports whether the provider accepts PKCE code challenges
+	SupportsPKCE() bool
+	
+	// GetUserInfo retrieves user info for tokens obtained from ExchangeCode
+	GetUserInfo(ctx context.Context, token *oauth2.Token) (*auth.UserInfo, error)
 	
 	// VerifyIDToken verifies an ID token (for mobile/SPA clients)
 	VerifyIDToken(ctx context.Context, idToken string) (*auth.UserInfo, error)
@@ -42,16 +49,21 @@ func (p *BaseProvider) Type() string {
 }
 
 // GetAuthURL generates the OAuth authorization URL
-func (p *BaseProvider) GetAuthURL(state string) string {
-	return p.Config.AuthCodeURL(state, oauth2.AccessTypeOffline)
+func (p *BaseProvider) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.Config.AuthCodeURL(state, append([]oauth2.AuthCodeOption{oauth2.AccessTypeOffline}, opts...)...)
 }
 
 // ExchangeCode exchanges an authorization code for tokens
-func (p *BaseProvider) ExchangeCode(ctx context.Context, code string) (*oauth2.Token, error) {
+func (p *BaseProvider) ExchangeCode(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
 	if code == "" {
 		return nil, errors.New("authorization code is required")
 	}
-	return p.Config.Exchange(ctx, code)
+	return p.Config.Exchange(ctx, code, opts...)
+}
+
+// SupportsPKCE returns true; providers that reject PKCE override this
+func (p *BaseProvider) SupportsPKCE() bool {
+	return true
 }
 
 // GetOAuth2Config returns the OAuth2 configuration
@@ -79,6 +91,36 @@ func (p *BaseProvider) ValidateConfig() error {
 	return nil
 }
 
+// NonceOption returns the auth URL option that sets the OIDC nonce
+func NonceOption(nonce string) oauth2.AuthCodeOption {
+	return oauth2.SetAuthURLParam("nonce", nonce)
+}
+
+// VerifyNonce checks that an ID token returned with token carried the expected nonce.
+// Tokens without an ID token (plain OAuth userinfo) have no nonce to check.
+func VerifyNonce(token *oauth2.Token, info *auth.UserInfo, expected string) error {
+	if idToken, _ := token.Extra("id_token").(string); idToken == "" {
+		return nil
+	}
+	
+	nonce, _ := info.Metadata["nonce"].(string)
+	if nonce == "" || nonce != expected {
+		return errors.New("ID token nonce mismatch")
+	}
+	return nil
+}
+
+// bundleIDs splits a comma-separated list of Apple bundle IDs
+func bundleIDs(list string) []string {
+	var ids []string
+	for _, id := range strings.Split(list, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 // ProviderType represents the type of OAuth provider
 type ProviderType string
 
@@ -97,9 +139,16 @@ func NewProvider(providerType ProviderType, config map[string]string) (Provider,
 			config["client_secret"],
 			config["redirect_url"],
 		)
+	case ProviderTypeApple:
+		return NewAppleProvider(
+			config["client_id"],
+			config["team_id"],
+			config["key_id"],
+			config["private_key"],
+			config["redirect_url"],
+			bundleIDs(config["bundle_ids"]),
+		)
 	// Add more providers as needed
-	// case ProviderTypeApple:
-	//     return NewAppleProvider(config)
 	// case ProviderTypeGitHub:
 	//     return NewGitHubProvider(config)
 	default:
@@ -0,0 +1,126 @@
+package push
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// recordSize is the aes128gcm record size; payloads are sent as a single record
+const recordSize = 4096
+
+// MaxPayloadSize is the largest payload that fits in one record
+const MaxPayloadSize = recordSize - 16 - 1 // Less the GCM tag and the padding delimiter
+
+// encrypt encrypts a payload for a subscription with the aes128gcm content
+// encoding of RFC 8291, using a fresh key pair and salt for every message
+func encrypt(payload []byte, p256dh, authSecret string) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, fmt.Errorf("payload of %d bytes exceeds %d", len(payload), MaxPayloadSize)
+	}
+
+	uaPublicBytes, err := decodeKey(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	auth, err := decodeKey(authSecret)
+	if err != nil || len(auth) != 16 {
+		return nil, errors.New("invalid auth secret")
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	asPublicBytes := asPrivate.PublicKey().Bytes()
+	sharedSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	// Combine the shared secret with the subscription's auth secret
+	keyInfo := "WebPush: info\x00" + string(uaPublicBytes) + string(asPublicBytes)
+	prkKey, err := hkdf.Extract(sha256.New, sharedSecret, auth)
+	if err != nil {
+		return nil, err
+	}
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive the content encryption key and nonce
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header: salt, record size, and the sender's public key as the key ID
+	body := make([]byte, 0, 16+4+1+len(asPublicBytes)+len(payload)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublicBytes)))
+	body = append(body, asPublicBytes...)
+
+	// The single record ends with the last-record delimiter
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(body, nonce, plaintext, nil), nil
+}
+
+// ValidateKeys checks a subscription's p256dh public key and auth secret
+func ValidateKeys(p256dh, authSecret string) error {
+	public, err := decodeKey(p256dh)
+	if err != nil {
+		return errors.New("invalid p256dh key")
+	}
+	if _, err := ecdh.P256().NewPublicKey(public); err != nil {
+		return errors.New("invalid p256dh key")
+	}
+	if auth, err := decodeKey(authSecret); err != nil || len(auth) != 16 {
+		return errors.New("invalid auth secret")
+	}
+	return nil
+}
+
+// decodeKey decodes a base64url key as sent by browsers, with or without padding
+func decodeKey(key string) ([]byte, error) {
+	key = strings.TrimRight(key, "=")
+	if decoded, err := base64.RawURLEncoding.DecodeString(key); err == nil {
+		return decoded, nil
+	}
+	return base64.RawStdEncoding.DecodeString(key)
+}
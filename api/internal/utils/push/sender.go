@@ -0,0 +1,246 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// vapidTokenTTL is how long a VAPID token is valid for; push services accept at most 24 hours
+const vapidTokenTTL = 12 * time.Hour
+
+// ErrSubscriptionGone is returned when the push service no longer knows a
+// subscription, e.g. because the user revoked permission. The subscription
+// should be deleted.
+var ErrSubscriptionGone = errors.New("push subscription is no longer valid")
+
+// errPrivateAddress is returned when an endpoint resolves to an address push
+// services never use, such as loopback or the cloud metadata service
+var errPrivateAddress = errors.New("push endpoint is not a public address")
+
+// reservedPrefixes are non-public ranges netip has no predicate for
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"), // Carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64 can reach any IPv4 address
+}
+
+// Subscription is a browser's push subscription, as returned by PushManager.subscribe
+type Subscription struct {
+	Endpoint string
+	P256dh   string // base64url public key of the browser
+	Auth     string // base64url auth secret
+}
+
+// Sender encrypts messages and delivers them to push services, identifying
+// the application with a VAPID key (RFC 8292)
+type Sender struct {
+	key       *ecdsa.PrivateKey
+	publicKey string
+	subject   string
+	client    *http.Client
+}
+
+// NewSender creates a sender from a base64url P-256 private key, as produced
+// by GenerateVAPIDKey. The subject is a mailto: or https: contact URL push
+// services can use to reach the operator.
+func NewSender(privateKey, subject string) (*Sender, error) {
+	raw, err := decodeKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	ecdhKey, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	if subject == "" {
+		return nil, errors.New("VAPID subject is required")
+	}
+
+	// The uncompressed public key is 0x04 || X || Y
+	public := ecdhKey.PublicKey().Bytes()
+	key := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(public[1:33]),
+			Y:     new(big.Int).SetBytes(public[33:]),
+		},
+		D: new(big.Int).SetBytes(raw),
+	}
+
+	return &Sender{
+		key:       key,
+		publicKey: base64.RawURLEncoding.EncodeToString(public),
+		subject:   subject,
+		client:    newClient(),
+	}, nil
+}
+
+// newClient returns the HTTP client for push services. Endpoints come from
+// browsers, so the client refuses to dial non-public addresses whatever a
+// host name resolves to, and does not follow redirects, which could lead
+// there as well.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: refusePrivateAddress,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // A proxy would dial the endpoint for us, past the check
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   10 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// refusePrivateAddress is a net.Dialer Control function that fails connections
+// to non-public addresses. It runs after name resolution, so it also covers
+// host names pointing at internal addresses.
+func refusePrivateAddress(network, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublic(addr) {
+		return errPrivateAddress
+	}
+	return nil
+}
+
+// isPublic reports whether an address may belong to a push service
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateEndpoint checks that a subscription endpoint is an https URL that
+// doesn't name a local host. Host names are checked again when dialing, as
+// they may resolve to anything.
+func ValidateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return errors.New("endpoint must be an https URL")
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errPrivateAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublic(addr) {
+		return errPrivateAddress
+	}
+	return nil
+}
+
+// GenerateVAPIDKey returns a new base64url P-256 private key for NewSender
+func GenerateVAPIDKey() (string, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate VAPID key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// PublicKey returns the base64url public key browsers subscribe with as applicationServerKey
+func (s *Sender) PublicKey() string {
+	return s.publicKey
+}
+
+// Send encrypts a payload and delivers it to a subscription. The push service
+// keeps undelivered messages for ttl. Returns ErrSubscriptionGone if the
+// subscription has expired or was revoked.
+func (s *Sender) Send(ctx context.Context, sub *Subscription, payload []byte, ttl time.Duration) error {
+	// Non-public addresses are refused when dialing
+	endpoint, err := url.Parse(sub.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return fmt.Errorf("invalid push endpoint %q", sub.Endpoint)
+	}
+
+	body, err := encrypt(payload, sub.P256dh, sub.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt payload: %w", err)
+	}
+
+	token, err := s.vapidToken(endpoint.Scheme + "://" + endpoint.Host)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(ttl.Seconds())))
+	req.Header.Set("Authorization", "vapid t="+token+", k="+s.publicKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send push message: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4<<10))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("push service returned %s", resp.Status)
+	}
+	return nil
+}
+
+// vapidToken signs a VAPID JWT for a push service origin
+func (s *Sender) vapidToken(audience string) (string, error) {
+	// Push services expect a single aud string, which RegisteredClaims encodes as an array
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": audience,
+		"exp": time.Now().Add(vapidTokenTTL).Unix(),
+		"sub": s.subject,
+	})
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign VAPID token: %w", err)
+	}
+	return signed, nil
+}
@@ -0,0 +1,298 @@
+package push
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// The receiver's key pair and auth secret from RFC 8291, appendix A
+const (
+	receiverPrivate = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	receiverPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	receiverAuth    = "BTBZMqHH6r4Tts7J_aSIgg"
+
+	testSubject = "mailto:ops@alunalun.test"
+)
+
+// pushRequest is a message received by the stub push service
+type pushRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newStubPushService starts a push service that records messages and answers with status
+func newStubPushService(t *testing.T, status int) (*httptest.Server, chan pushRequest) {
+	t.Helper()
+	received := make(chan pushRequest, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- pushRequest{header: r.Header.Clone(), body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+// newTestSender creates a sender that reaches a local server. Only the dial
+// check is lifted; redirects are still refused.
+func newTestSender(t *testing.T, server *httptest.Server) *Sender {
+	t.Helper()
+	key, err := GenerateVAPIDKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := NewSender(key, testSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.client.Transport = server.Client().Transport
+	return sender
+}
+
+func testSubscription(endpoint string) *Subscription {
+	return &Subscription{Endpoint: endpoint, P256dh: receiverPublic, Auth: receiverAuth}
+}
+
+// decrypt decodes an aes128gcm message (RFC 8188) with the receiver's keys (RFC 8291)
+func decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	if len(body) < 21 {
+		t.Fatalf("message of %d bytes is too short", len(body))
+	}
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		t.Fatalf("record size %d, expected %d", rs, recordSize)
+	}
+	idLength := int(body[20])
+	if len(body) < 21+idLength {
+		t.Fatalf("message of %d bytes is too short for its key ID", len(body))
+	}
+	senderPublic := body[21 : 21+idLength]
+	ciphertext := body[21+idLength:]
+
+	privateBytes, _ := decodeKey(receiverPrivate)
+	private, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public, err := ecdh.P256().NewPublicKey(senderPublic)
+	if err != nil {
+		t.Fatalf("key ID is not a P-256 public key: %v", err)
+	}
+	shared, err := private.ECDH(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth, _ := decodeKey(receiverAuth)
+
+	prkKey, _ := hkdf.Extract(sha256.New, shared, auth)
+	ikm, _ := hkdf.Expand(sha256.New, prkKey, "WebPush: info\x00"+string(private.PublicKey().Bytes())+string(senderPublic), 32)
+	prk, _ := hkdf.Extract(sha256.New, ikm, salt)
+	cek, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("failed to decrypt message: %v", err)
+	}
+
+	// A single record ends with the last-record delimiter and optional zero padding
+	plaintext = bytes.TrimRight(plaintext, "\x00")
+	if len(plaintext) == 0 || plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatal("message lacks the last-record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+// verifyVAPID checks the Authorization header against the sender's key and the push service origin
+func verifyVAPID(t *testing.T, header, publicKey, audience string) {
+	t.Helper()
+	token, key, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	if !ok || !strings.HasPrefix(header, "vapid ") {
+		t.Fatalf("malformed Authorization header %q", header)
+	}
+	if key != publicKey {
+		t.Fatalf("header carries key %q, expected the sender's %q", key, publicKey)
+	}
+
+	raw, err := decodeKey(key)
+	if err != nil || len(raw) != 65 {
+		t.Fatalf("invalid VAPID public key %q", key)
+	}
+	verifier := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(raw[1:33]),
+		Y:     new(big.Int).SetBytes(raw[33:]),
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
+		return verifier, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg()}),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		t.Fatalf("invalid VAPID token: %v", err)
+	}
+
+	// Push services expect a single aud string and refuse tokens valid for over a day
+	if aud, ok := claims["aud"].(string); !ok || aud != audience {
+		t.Fatalf("aud is %v, expected %q", claims["aud"], audience)
+	}
+	exp, _ := claims.GetExpirationTime()
+	if time.Until(exp.Time) > 24*time.Hour {
+		t.Fatalf("token expires at %v, over 24 hours away", exp.Time)
+	}
+	if claims["sub"] != testSubject {
+		t.Fatalf("sub is %v, expected %q", claims["sub"], testSubject)
+	}
+}
+
+func TestSendDeliversEncryptedMessage(t *testing.T) {
+	server, received := newStubPushService(t, http.StatusCreated)
+	sender := newTestSender(t, server)
+	payload := []byte(`{"title":"Alunalun","body":"hello"}`)
+
+	if err := sender.Send(t.Context(), testSubscription(server.URL+"/push/abc"), payload, time.Hour); err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+
+	req := <-received
+	if got := req.header.Get("Content-Encoding"); got != "aes128gcm" {
+		t.Fatalf("Content-Encoding is %q", got)
+	}
+	if got := req.header.Get("TTL"); got != "3600" {
+		t.Fatalf("TTL is %q", got)
+	}
+	verifyVAPID(t, req.header.Get("Authorization"), sender.PublicKey(), server.URL)
+	if got := decrypt(t, req.body); !bytes.Equal(got, payload) {
+		t.Fatalf("decrypted %q, expected %q", got, payload)
+	}
+}
+
+func TestSendReportsGoneSubscription(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+		server, _ := newStubPushService(t, status)
+		sender := newTestSender(t, server)
+
+		err := sender.Send(t.Context(), testSubscription(server.URL), []byte("x"), time.Hour)
+		if !errors.Is(err, ErrSubscriptionGone) {
+			t.Fatalf("status %d: expected ErrSubscriptionGone, got %v", status, err)
+		}
+	}
+
+	// Other failures keep the subscription
+	server, _ := newStubPushService(t, http.StatusInternalServerError)
+	err := newTestSender(t, server).Send(t.Context(), testSubscription(server.URL), []byte("x"), time.Hour)
+	if err == nil || errors.Is(err, ErrSubscriptionGone) {
+		t.Fatalf("expected a transient error, got %v", err)
+	}
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	target, received := newStubPushService(t, http.StatusCreated)
+	server := httptest.NewTLSServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	t.Cleanup(server.Close)
+
+	err := newTestSender(t, server).Send(t.Context(), testSubscription(server.URL), []byte("x"), time.Hour)
+	if err == nil {
+		t.Fatal("expected the redirect to fail the send")
+	}
+	if len(received) != 0 {
+		t.Fatal("redirect was followed")
+	}
+}
+
+func TestSendRefusesPrivateAddresses(t *testing.T) {
+	server, received := newStubPushService(t, http.StatusCreated)
+	key, err := GenerateVAPIDKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := NewSender(key, testSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A host name resolving to loopback passes validation but not the dial check
+	endpoint := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	for _, endpoint := range []string{server.URL, endpoint} {
+		err := sender.Send(t.Context(), testSubscription(endpoint), []byte("x"), time.Hour)
+		if !errors.Is(err, errPrivateAddress) {
+			t.Fatalf("%s: expected the dial to be refused, got %v", endpoint, err)
+		}
+	}
+	if len(received) != 0 {
+		t.Fatal("push service was reached at a loopback address")
+	}
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		valid    bool
+	}{
+		{"https://fcm.googleapis.com/fcm/send/abc", true},
+		{"https://updates.push.services.mozilla.com/wpush/v2/abc", true},
+		{"https://93.184.215.14/push", true},
+		{"http://fcm.googleapis.com/fcm/send/abc", false},
+		{"https:///push", false},
+		{"https://localhost/push", false},
+		{"https://push.localhost./push", false},
+		{"https://127.0.0.1:8080/push", false},
+		{"https://10.0.0.5/push", false},
+		{"https://169.254.169.254/latest/meta-data", false},
+		{"https://[::1]/push", false},
+		{"https://[::ffff:192.168.1.1]/push", false},
+		{"https://[fd00::1]/push", false},
+		{"https://100.64.0.1/push", false},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateEndpoint(tt.endpoint); (err == nil) != tt.valid {
+			t.Errorf("ValidateEndpoint(%q) = %v, expected valid %v", tt.endpoint, err, tt.valid)
+		}
+	}
+}
+
+func TestIsPublic(t *testing.T) {
+	for _, addr := range []string{"0.0.0.0", "127.0.0.53", "10.1.2.3", "172.16.0.1", "192.168.0.1",
+		"169.254.169.254", "100.100.100.200", "224.0.0.1", "::", "::1", "fe80::1", "fc00::1", "64:ff9b::a00:1"} {
+		if isPublic(netip.MustParseAddr(addr)) {
+			t.Errorf("%s is not public", addr)
+		}
+	}
+	for _, addr := range []string{"8.8.8.8", "142.250.74.46", "2a00:1450:4001:830::200a"} {
+		if !isPublic(netip.MustParseAddr(addr)) {
+			t.Errorf("%s is public", addr)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+-- Create anonymous_accounts table to track the lifecycle of anonymous users
+CREATE TABLE anonymous_accounts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    session_id VARCHAR(255) NOT NULL,
+    ip_hash VARCHAR(64) NOT NULL,
+    device_hash VARCHAR(64),
+    last_active_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- Indexes for per-client limits and cleanup
+CREATE INDEX idx_anonymous_accounts_ip_hash ON anonymous_accounts(ip_hash, created_at);
+CREATE INDEX idx_anonymous_accounts_device_hash ON anonymous_accounts(device_hash, created_at) WHERE device_hash IS NOT NULL;
+CREATE INDEX idx_anonymous_accounts_expires_at ON anonymous_accounts(expires_at);
+
+-- Track existing anonymous users, giving them one default TTL from now
+INSERT INTO anonymous_accounts (user_id, session_id, ip_hash, last_active_at, expires_at, created_at)
+SELECT id, '', '', NOW(), NOW() + INTERVAL '30 days', created_at
+FROM users
+WHERE email LIKE 'anonymous-%@local.user';
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- Track when a user's email address was verified (NULL = unverified)
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+-- Create email_tokens table for one-time email links (verification, password reset)
+CREATE TABLE email_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose VARCHAR(32) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- Index for per-user rate limiting and invalidation
+CREATE INDEX idx_email_tokens_user_purpose ON email_tokens(user_id, purpose, created_at);
\ No newline at end of file
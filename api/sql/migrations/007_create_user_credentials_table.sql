@@ -0,0 +1,7 @@
+-- Create user_credentials table for password hashes (bcrypt or argon2id, PHC string format)
+CREATE TABLE user_credentials (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1,8 @@
+-- Create login_attempts table for password brute-force protection
+-- Keys are "account:<sha256 of the email>" for accounts and "ip:<sha256>" for client networks
+CREATE TABLE login_attempts (
+    attempt_key VARCHAR(128) PRIMARY KEY,
+    failures INTEGER DEFAULT 0 NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ
+);
\ No newline at end of file
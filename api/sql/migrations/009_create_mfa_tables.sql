@@ -0,0 +1,18 @@
+-- Create two-factor authentication tables
+-- TOTP secrets only protect sign-in once confirmed_at is set
+CREATE TABLE user_totp (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    last_used_step BIGINT DEFAULT 0 NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- One-time recovery codes, stored as SHA-256 hashes
+CREATE TABLE mfa_recovery_codes (
+    code_hash VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id);
\ No newline at end of file
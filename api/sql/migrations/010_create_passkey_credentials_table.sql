@@ -0,0 +1,14 @@
+-- Create passkey_credentials table for WebAuthn sign-in
+-- credential_id is base64url; public_key is the COSE_Key from registration
+CREATE TABLE passkey_credentials (
+    credential_id VARCHAR(1400) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    public_key BYTEA NOT NULL,
+    sign_count BIGINT DEFAULT 0 NOT NULL,
+    transports TEXT[] DEFAULT '{}' NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    last_used_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_passkey_credentials_user_id ON passkey_credentials(user_id);
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Create user_roles table for elevated permissions
+-- Every account is implicitly a "user"; only elevated roles are stored
+CREATE TABLE user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('moderator', 'admin')),
+    granted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (user_id, role)
+);
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- Create api_keys table for personal API keys used by scripts and integrations
+-- Only the SHA-256 of the key is stored; prefix is the non-secret part shown in listings
+CREATE TABLE api_keys (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    key_hash BYTEA NOT NULL UNIQUE,
+    scopes TEXT[] DEFAULT '{}' NOT NULL,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
\ No newline at end of file
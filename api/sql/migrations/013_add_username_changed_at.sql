@@ -0,0 +1,2 @@
+-- Track username changes so they can be rate limited
+ALTER TABLE users ADD COLUMN username_changed_at TIMESTAMPTZ;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Account status, free-form metadata and last update time for users
+-- status: active, disabled (blocked from signing in and from the API) or pending (not yet activated)
+ALTER TABLE users
+    ADD COLUMN status VARCHAR(20) DEFAULT 'active' NOT NULL CHECK (status IN ('active', 'disabled', 'pending')),
+    ADD COLUMN metadata JSONB DEFAULT '{}' NOT NULL,
+    ADD COLUMN updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL;
+
+-- Existing rows were last changed no later than now; start them at their creation time
+UPDATE users SET updated_at = created_at;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- Create user_follows table for the follow graph
+CREATE TABLE user_follows (
+    follower_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+-- Followers of a user, newest first (following lists use the primary key)
+CREATE INDEX idx_user_follows_followee ON user_follows(followee_id, created_at DESC);
+
+-- Feed lookups: each followed user's pins, newest first
+CREATE INDEX idx_posts_user_pins ON posts(user_id, created_at DESC) WHERE type = 'pin';
+
+-- Followers-only pins are shown to the author's followers; NULL means public
+ALTER TABLE posts ADD CONSTRAINT posts_visibility_check CHECK (visibility IN ('public', 'followers'));
\ No newline at end of file
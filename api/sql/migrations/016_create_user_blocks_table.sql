@@ -0,0 +1,14 @@
+-- Create user_blocks table for blocking and muting users
+-- block: hides the user's posts from the blocker and stops them following or commenting on the blocker's pins
+-- mute: only hides the user's posts from the muter
+CREATE TABLE user_blocks (
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('block', 'mute')),
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+-- Lookups of who blocked a user, for merging anonymous accounts
+CREATE INDEX idx_user_blocks_blocked ON user_blocks(blocked_id);
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Account deletion: users ask to delete their account and are erased after a grace period
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMPTZ;
+
+-- Accounts due for erasure
+CREATE INDEX idx_users_deletion_scheduled ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+-- Placeholder author for the posts of erased accounts, so replies from other users survive.
+-- The username is not a valid username and the account can never sign in.
+INSERT INTO users (id, username, email, status)
+VALUES ('00000000-0000-0000-0000-000000000000', '[deleted]', 'deleted@users.invalid', 'disabled')
+ON CONFLICT (id) DO NOTHING;
\ No newline at end of file
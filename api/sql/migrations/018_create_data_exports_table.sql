@@ -0,0 +1,21 @@
+-- Create data_exports table for users downloading a copy of their data
+-- Exports are built by a background job and the archive is deleted when it expires.
+-- Rows outlive an erased user (user_id set to NULL) so the job can still delete the archive.
+CREATE TABLE data_exports (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'running', 'ready', 'failed')),
+    progress SMALLINT DEFAULT 0 NOT NULL CHECK (progress BETWEEN 0 AND 100),
+    archive_key TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+-- A user's exports, newest first
+CREATE INDEX idx_data_exports_user ON data_exports(user_id, created_at DESC);
+
+-- Queue of exports waiting for the job
+CREATE INDEX idx_data_exports_pending ON data_exports(created_at) WHERE status IN ('pending', 'running');
\ No newline at end of file
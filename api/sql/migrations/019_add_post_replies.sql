@@ -0,0 +1,4 @@
+-- Replies point at the comment they answer; parent_id stays the pin so a thread lists together
+ALTER TABLE posts ADD COLUMN reply_to_id UUID REFERENCES posts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_posts_reply_to ON posts(reply_to_id) WHERE reply_to_id IS NOT NULL;
\ No newline at end of file
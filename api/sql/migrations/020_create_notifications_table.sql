@@ -0,0 +1,25 @@
+-- Create notifications table for telling users about activity on their pins and profile
+-- Notifications are generated by a background job from posts and follows, not by the write path
+CREATE TABLE notifications (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    actor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('comment', 'reply', 'follow')),
+    pin_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES posts(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+-- A user's notifications, newest first
+CREATE INDEX idx_notifications_user ON notifications(user_id, created_at DESC, id DESC);
+
+-- Unread counts
+CREATE INDEX idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- One notification per comment per recipient, and one per follower, so the job can rescan safely
+CREATE UNIQUE INDEX idx_notifications_comment ON notifications(user_id, comment_id) WHERE comment_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_notifications_follow ON notifications(user_id, actor_id) WHERE kind = 'follow';
+
+-- New follows, for the notification job
+CREATE INDEX idx_user_follows_created ON user_follows(created_at);
\ No newline at end of file
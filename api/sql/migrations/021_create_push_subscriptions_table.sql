@@ -0,0 +1,29 @@
+-- Create push_subscriptions table for browser Web Push subscriptions, one per signed-in session
+CREATE TABLE push_subscriptions (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id VARCHAR(255) NOT NULL,
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh TEXT NOT NULL,
+    auth TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX idx_push_subscriptions_user ON push_subscriptions(user_id);
+CREATE INDEX idx_push_subscriptions_session ON push_subscriptions(session_id);
+
+-- Per-user notification settings; kinds without a row are pushed
+CREATE TABLE notification_preferences (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    push BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (user_id, kind)
+);
+
+-- Notifications the push worker has handled; existing ones are not pushed
+ALTER TABLE notifications ADD COLUMN pushed_at TIMESTAMPTZ;
+UPDATE notifications SET pushed_at = created_at;
+
+CREATE INDEX idx_notifications_unpushed ON notifications(created_at) WHERE pushed_at IS NULL;
\ No newline at end of file
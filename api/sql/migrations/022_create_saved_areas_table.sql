@@ -0,0 +1,33 @@
+-- Create saved_areas table for areas users are alerted about new pins in
+-- Circles keep their center and radius, with area set to a slightly larger polygon,
+-- so one GIST index finds candidates for both shapes
+CREATE TABLE saved_areas (
+    id UUID DEFAULT gen_random_uuid() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    area GEOMETRY(POLYGON, 4326) NOT NULL,
+    center GEOMETRY(POINT, 4326),
+    radius_meters INTEGER CHECK (radius_meters > 0),
+    created_at TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+    CHECK ((center IS NULL) = (radius_meters IS NULL)),
+    CHECK (ST_IsValid(area))
+);
+
+-- Spatial index for matching new pins
+CREATE INDEX idx_saved_areas_area ON saved_areas USING GIST(area);
+
+CREATE INDEX idx_saved_areas_user ON saved_areas(user_id, created_at);
+
+-- Notifications of new pins in saved areas
+ALTER TABLE notifications ADD COLUMN area_id UUID REFERENCES saved_areas(id) ON DELETE SET NULL;
+ALTER TABLE notifications DROP CONSTRAINT notifications_kind_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_kind_check CHECK (kind IN ('comment', 'reply', 'follow', 'area'));
+
+-- One notification per pin per recipient, however many of their areas it is in
+CREATE UNIQUE INDEX idx_notifications_area_pin ON notifications(user_id, pin_id) WHERE kind = 'area';
+
+-- Per-user throttle of area notifications
+CREATE INDEX idx_notifications_user_kind ON notifications(user_id, kind, created_at);
+
+-- New pin locations, for the notification job
+CREATE INDEX idx_posts_location_created ON posts_location(created_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Tokens signed in before this time are rejected; set when a password reset or
+-- erasure signs a user out everywhere
+ALTER TABLE users ADD COLUMN tokens_revoked_at TIMESTAMPTZ;
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- name: CreateAnonymousAccount :one
+INSERT INTO anonymous_accounts (user_id, session_id, ip_hash, device_hash, last_active_at, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: TouchAnonymousAccount :execrows
+UPDATE anonymous_accounts
+SET
+    last_active_at = $2,
+    expires_at = $3
+WHERE user_id = $1;
+
+-- name: CountAnonymousAccountsByIP :one
+SELECT COUNT(*) FROM anonymous_accounts
+WHERE ip_hash = $1 AND created_at > $2;
+
+-- name: CountAnonymousAccountsByDevice :one
+SELECT COUNT(*) FROM anonymous_accounts
+WHERE device_hash = $1 AND created_at > $2;
+
+-- name: ListExpiredAnonymousAccounts :many
+SELECT * FROM anonymous_accounts
+WHERE expires_at < $1
+ORDER BY expires_at
+LIMIT $2;
+
+-- name: DeleteAnonymousAccount :exec
+DELETE FROM anonymous_accounts WHERE user_id = $1;
+
+-- name: DeleteAnonymousUser :execrows
+DELETE FROM users
+WHERE id = $1 AND id IN (SELECT user_id FROM anonymous_accounts);
+
+-- name: LockAnonymousAccountClient :exec
+SELECT pg_advisory_xact_lock(hashtextextended(@client_key::text, 0));
@@ -0,0 +1,24 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys WHERE key_hash = $1;
+
+-- name: ListApiKeysByUser :many
+SELECT * FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: CountActiveApiKeys :one
+SELECT COUNT(*) FROM api_keys
+WHERE user_id = $1 AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: TouchApiKey :exec
+UPDATE api_keys SET last_used_at = $2 WHERE id = $1;
+
+-- name: RevokeApiKey :execrows
+UPDATE api_keys SET revoked_at = NOW()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
\ No newline at end of file
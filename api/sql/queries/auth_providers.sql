@@ -10,6 +10,10 @@ SELECT * FROM user_auth_providers WHERE id = $1;
 SELECT * FROM user_auth_providers 
 WHERE provider = $1 AND provider_user_id = $2;
 
+-- name: GetUserAuthProviderByUserAndProvider :one
+SELECT * FROM user_auth_providers
+WHERE user_id = $1 AND provider = $2;
+
 -- name: ListUserAuthProviders :many
 SELECT * FROM user_auth_providers
 WHERE user_id = $1
@@ -18,5 +22,24 @@ ORDER BY created_at DESC;
 -- name: DeleteUserAuthProvider :exec
 DELETE FROM user_auth_providers WHERE id = $1;
 
+-- name: DeleteUserAuthProviderUnlessLast :execrows
+-- Removes a user's link for a provider while another link remains. The count
+-- locks the user's links, so a concurrent unlink waits and recounts without
+-- the link removed here.
+DELETE FROM user_auth_providers d
+WHERE d.user_id = @user_id AND d.provider = @provider
+    AND (
+        SELECT COUNT(*) FROM (
+            SELECT 1 FROM user_auth_providers o
+            WHERE o.user_id = @user_id
+            FOR UPDATE
+        ) locked
+    ) > 1;
+
 -- name: CountUserAuthProviders :one
-SELECT COUNT(*) FROM user_auth_providers WHERE user_id = $1;
\ No newline at end of file
+SELECT COUNT(*) FROM user_auth_providers WHERE user_id = $1;
+
+-- name: ReassignUserAuthProviders :execrows
+UPDATE user_auth_providers
+SET user_id = @new_user_id
+WHERE user_id = @old_user_id;
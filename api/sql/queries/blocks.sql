@@ -0,0 +1,55 @@
+-- name: BlockUser :exec
+-- Blocking ends follows in both directions and replaces a mute
+WITH unfollowed AS (
+    DELETE FROM user_follows
+    WHERE (follower_id = @blocker_id AND followee_id = @blocked_id)
+        OR (follower_id = @blocked_id AND followee_id = @blocker_id)
+)
+INSERT INTO user_blocks (blocker_id, blocked_id, kind)
+VALUES (@blocker_id, @blocked_id, 'block')
+ON CONFLICT (blocker_id, blocked_id) DO UPDATE SET kind = 'block', created_at = NOW();
+
+-- name: MuteUser :exec
+-- Muting a blocked user keeps the block
+INSERT INTO user_blocks (blocker_id, blocked_id, kind)
+VALUES ($1, $2, 'mute')
+ON CONFLICT (blocker_id, blocked_id) DO NOTHING;
+
+-- name: UnblockUser :execrows
+-- Removes a block or a mute
+DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: IsBlocked :one
+-- Reports whether blocker_id blocked (not just muted) blocked_id
+SELECT EXISTS (
+    SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2 AND kind = 'block'
+);
+
+-- name: IsBlockedEitherWay :one
+SELECT EXISTS (
+    SELECT 1 FROM user_blocks
+    WHERE kind = 'block'
+        AND ((blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1))
+);
+
+-- name: IsHidden :one
+-- Reports whether blocker_id blocked or muted blocked_id
+SELECT EXISTS (
+    SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2
+);
+
+-- name: CopyBlocksByUser :exec
+-- Carries an anonymous user's blocks and mutes over to the account it merges into
+INSERT INTO user_blocks (blocker_id, blocked_id, kind, created_at)
+SELECT @new_user_id, ub.blocked_id, ub.kind, ub.created_at
+FROM user_blocks ub
+WHERE ub.blocker_id = @old_user_id AND ub.blocked_id <> @new_user_id
+ON CONFLICT (blocker_id, blocked_id) DO NOTHING;
+
+-- name: CopyBlocksOfUser :exec
+-- Keeps blocks against an anonymous user in force after it merges into an account
+INSERT INTO user_blocks (blocker_id, blocked_id, kind, created_at)
+SELECT ub.blocker_id, @new_user_id, ub.kind, ub.created_at
+FROM user_blocks ub
+WHERE ub.blocked_id = @old_user_id AND ub.blocker_id <> @new_user_id
+ON CONFLICT (blocker_id, blocked_id) DO NOTHING;
@@ -0,0 +1,86 @@
+-- name: CreateDataExport :one
+INSERT INTO data_exports (user_id)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetDataExport :one
+SELECT * FROM data_exports
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetActiveDataExport :one
+-- The user's export that is queued or being built, if any
+SELECT * FROM data_exports
+WHERE user_id = $1 AND status IN ('pending', 'running')
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: CountDataExportsSince :one
+SELECT COUNT(*) FROM data_exports
+WHERE user_id = $1 AND created_at >= $2;
+
+-- name: ClaimDataExport :one
+-- Claims the oldest queued export, or one whose job stopped before $1
+UPDATE data_exports
+SET status = 'running', progress = 0, started_at = NOW()
+WHERE id = (
+    SELECT e.id FROM data_exports e
+    WHERE e.user_id IS NOT NULL
+        AND (e.status = 'pending' OR (e.status = 'running' AND e.started_at < $1))
+    ORDER BY e.created_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: UpdateDataExportProgress :exec
+UPDATE data_exports SET progress = $2
+WHERE id = $1 AND status = 'running';
+
+-- name: CompleteDataExport :exec
+UPDATE data_exports
+SET status = 'ready', progress = 100, archive_key = $2, completed_at = NOW(), expires_at = $3
+WHERE id = $1;
+
+-- name: FailDataExport :exec
+UPDATE data_exports
+SET status = 'failed', error = $2, completed_at = NOW()
+WHERE id = $1;
+
+-- name: ListExpiredDataExports :many
+-- Archives past their expiry, and those of erased users
+SELECT * FROM data_exports
+WHERE status = 'ready' AND archive_key IS NOT NULL
+    AND (expires_at <= $1 OR user_id IS NULL)
+ORDER BY expires_at
+LIMIT $2;
+
+-- name: ExpireDataExport :exec
+UPDATE data_exports SET archive_key = NULL
+WHERE id = $1;
+
+-- name: ListUserPinsForExport :many
+SELECT
+    p.*,
+    ST_X(pl.coordinates) AS longitude,
+    ST_Y(pl.coordinates) AS latitude,
+    pl.geohash
+FROM posts p
+LEFT JOIN posts_location pl ON pl.post_id = p.id
+WHERE p.user_id = $1 AND p.type = 'pin'
+ORDER BY p.created_at;
+
+-- name: ListUserCommentsForExport :many
+SELECT * FROM posts
+WHERE user_id = $1 AND type = 'comment'
+ORDER BY created_at;
+
+-- name: ListFollowsForExport :many
+-- Both directions: rows where the user follows someone and where someone follows the user
+SELECT follower_id, followee_id, created_at FROM user_follows
+WHERE follower_id = $1 OR followee_id = $1
+ORDER BY created_at;
+
+-- name: ListBlocksForExport :many
+SELECT blocked_id, kind, created_at FROM user_blocks
+WHERE blocker_id = $1
+ORDER BY created_at;
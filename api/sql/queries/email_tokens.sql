@@ -0,0 +1,17 @@
+-- name: CreateEmailToken :one
+INSERT INTO email_tokens (token_hash, user_id, purpose, email, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ConsumeEmailToken :one
+DELETE FROM email_tokens
+WHERE token_hash = $1 AND purpose = $2
+RETURNING *;
+
+-- name: CountRecentEmailTokens :one
+SELECT COUNT(*) FROM email_tokens
+WHERE user_id = $1 AND purpose = $2 AND created_at > $3;
+
+-- name: DeleteUserEmailTokens :exec
+DELETE FROM email_tokens
+WHERE user_id = $1 AND purpose = $2;
\ No newline at end of file
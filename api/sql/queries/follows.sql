@@ -0,0 +1,87 @@
+-- name: FollowUser :execrows
+INSERT INTO user_follows (follower_id, followee_id)
+VALUES ($1, $2)
+ON CONFLICT (follower_id, followee_id) DO NOTHING;
+
+-- name: UnfollowUser :execrows
+DELETE FROM user_follows WHERE follower_id = $1 AND followee_id = $2;
+
+-- name: IsFollowing :one
+SELECT EXISTS (
+    SELECT 1 FROM user_follows WHERE follower_id = $1 AND followee_id = $2
+);
+
+-- name: CountFollowers :one
+SELECT COUNT(*) FROM user_follows WHERE followee_id = $1;
+
+-- name: CountFollowing :one
+SELECT COUNT(*) FROM user_follows WHERE follower_id = $1;
+
+-- name: ListFollowers :many
+-- Keyset pagination: pass the last row's followed_at and user ID to get the next page
+SELECT sqlc.embed(u), f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.follower_id
+WHERE f.followee_id = @user_id
+    AND (sqlc.narg(before_created_at)::timestamptz IS NULL
+        OR (f.created_at, f.follower_id) < (sqlc.narg(before_created_at)::timestamptz, sqlc.narg(before_id)::uuid))
+ORDER BY f.created_at DESC, f.follower_id DESC
+LIMIT @page_limit;
+
+-- name: ListFollowing :many
+SELECT sqlc.embed(u), f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.followee_id
+WHERE f.follower_id = @user_id
+    AND (sqlc.narg(before_created_at)::timestamptz IS NULL
+        OR (f.created_at, f.followee_id) < (sqlc.narg(before_created_at)::timestamptz, sqlc.narg(before_id)::uuid))
+ORDER BY f.created_at DESC, f.followee_id DESC
+LIMIT @page_limit;
+
+-- name: ListFeedPins :many
+-- Pins from users the follower follows, anywhere on the map, newest first
+SELECT 
+    p.*,
+    ST_X(pl.coordinates) as longitude,
+    ST_Y(pl.coordinates) as latitude,
+    pl.geohash
+FROM posts p
+JOIN user_follows f ON f.followee_id = p.user_id AND f.follower_id = @follower_id
+JOIN posts_location pl ON p.id = pl.post_id
+WHERE p.type = 'pin'
+    -- Muted users stay followed but out of the feed
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = @follower_id AND b.blocked_id = p.user_id)
+    AND (sqlc.narg(before_created_at)::timestamptz IS NULL
+        OR (p.created_at, p.id) < (sqlc.narg(before_created_at)::timestamptz, sqlc.narg(before_id)::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT @page_limit;
+
+-- name: CopyFollowsByUser :exec
+-- Carries whom an anonymous user follows over to the account it merges into,
+-- except users the account blocked or was blocked by
+INSERT INTO user_follows (follower_id, followee_id, created_at)
+SELECT @new_user_id, f.followee_id, f.created_at
+FROM user_follows f
+WHERE f.follower_id = @old_user_id AND f.followee_id <> @new_user_id
+    AND NOT EXISTS (
+        SELECT 1 FROM user_blocks ub
+        WHERE ub.kind = 'block'
+            AND ((ub.blocker_id = @new_user_id AND ub.blocked_id = f.followee_id)
+                OR (ub.blocker_id = f.followee_id AND ub.blocked_id = @new_user_id))
+    )
+ON CONFLICT (follower_id, followee_id) DO NOTHING;
+
+-- name: CopyFollowersOfUser :exec
+-- Keeps an anonymous user's followers once it merges into an account,
+-- except users the account blocked or was blocked by
+INSERT INTO user_follows (follower_id, followee_id, created_at)
+SELECT f.follower_id, @new_user_id, f.created_at
+FROM user_follows f
+WHERE f.followee_id = @old_user_id AND f.follower_id <> @new_user_id
+    AND NOT EXISTS (
+        SELECT 1 FROM user_blocks ub
+        WHERE ub.kind = 'block'
+            AND ((ub.blocker_id = @new_user_id AND ub.blocked_id = f.follower_id)
+                OR (ub.blocker_id = f.follower_id AND ub.blocked_id = @new_user_id))
+    )
+ON CONFLICT (follower_id, followee_id) DO NOTHING;
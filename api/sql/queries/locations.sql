@@ -83,6 +83,12 @@ JOIN posts_location pl ON p.id = pl.post_id
 WHERE p.type = 'pin' 
     AND p.created_at > NOW() - INTERVAL '24 hours'
     AND pl.geohash LIKE $1 || '%'
+    -- Followers-only pins are visible to their author and the author's followers
+    AND (COALESCE(p.visibility, 'public') = 'public'
+        OR p.user_id = $3
+        OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = $3 AND f.followee_id = p.user_id))
+    -- Hide pins from users the viewer blocked or muted
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = $3 AND b.blocked_id = p.user_id)
 ORDER BY p.created_at DESC
 LIMIT $2;
 
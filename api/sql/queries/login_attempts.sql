@@ -0,0 +1,20 @@
+-- name: GetLoginAttempt :one
+SELECT * FROM login_attempts WHERE attempt_key = $1;
+
+-- name: RecordLoginFailure :one
+-- Failures older than the window are forgotten and counting restarts
+INSERT INTO login_attempts (attempt_key, failures, last_failure_at)
+VALUES (@attempt_key, 1, @failed_at)
+ON CONFLICT (attempt_key) DO UPDATE
+SET failures = CASE
+        WHEN login_attempts.last_failure_at < @window_start THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = EXCLUDED.last_failure_at
+RETURNING *;
+
+-- name: LockLogin :exec
+UPDATE login_attempts SET locked_until = $2 WHERE attempt_key = $1;
+
+-- name: ResetLoginAttempts :exec
+DELETE FROM login_attempts WHERE attempt_key = $1;
\ No newline at end of file
@@ -0,0 +1,35 @@
+-- name: GetUserTOTP :one
+SELECT * FROM user_totp WHERE user_id = $1;
+
+-- name: UpsertUserTOTP :exec
+-- A new secret starts a fresh, unconfirmed enrollment
+INSERT INTO user_totp (user_id, secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+    confirmed_at = NULL,
+    last_used_step = 0,
+    created_at = NOW();
+
+-- name: ConfirmUserTOTP :execrows
+UPDATE user_totp
+SET confirmed_at = @confirmed_at, last_used_step = @last_used_step
+WHERE user_id = @user_id AND confirmed_at IS NULL;
+
+-- name: UseTOTPStep :execrows
+-- Only moves forward, so a code cannot be replayed
+UPDATE user_totp SET last_used_step = @step
+WHERE user_id = @user_id AND last_used_step < @step;
+
+-- name: DeleteUserTOTP :exec
+DELETE FROM user_totp WHERE user_id = $1;
+
+-- name: CreateRecoveryCodes :exec
+INSERT INTO mfa_recovery_codes (code_hash, user_id)
+SELECT unnest(@code_hashes::text[]), @user_id;
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes WHERE user_id = $1;
+
+-- name: ConsumeRecoveryCode :execrows
+DELETE FROM mfa_recovery_codes WHERE user_id = $1 AND code_hash = $2;
\ No newline at end of file
@@ -0,0 +1,164 @@
+-- name: CreateReplyNotifications :many
+-- Notifies comment authors of replies to their comments created since $1
+INSERT INTO notifications (user_id, actor_id, kind, pin_id, comment_id)
+SELECT parent.user_id, c.user_id, 'reply', c.parent_id, c.id
+FROM posts c
+JOIN posts parent ON parent.id = c.reply_to_id
+JOIN users r ON r.id = parent.user_id AND r.status <> 'disabled'
+WHERE c.type = 'comment' AND c.created_at >= $1
+    AND c.user_id <> parent.user_id
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = parent.user_id AND b.blocked_id = c.user_id)
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: CreateCommentNotifications :many
+-- Notifies pin authors of comments on their pins created since $1
+INSERT INTO notifications (user_id, actor_id, kind, pin_id, comment_id)
+SELECT pin.user_id, c.user_id, 'comment', pin.id, c.id
+FROM posts c
+JOIN posts pin ON pin.id = c.parent_id AND pin.type = 'pin'
+JOIN users r ON r.id = pin.user_id AND r.status <> 'disabled'
+WHERE c.type = 'comment' AND c.created_at >= $1
+    AND c.user_id <> pin.user_id
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = pin.user_id AND b.blocked_id = c.user_id)
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: CreateFollowNotifications :many
+-- Notifies users of follows created since $1
+INSERT INTO notifications (user_id, actor_id, kind)
+SELECT f.followee_id, f.follower_id, 'follow'
+FROM user_follows f
+JOIN users r ON r.id = f.followee_id AND r.status <> 'disabled'
+WHERE f.created_at >= $1
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = f.followee_id AND b.blocked_id = f.follower_id)
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: ListNotifications :many
+-- Notifications from users the recipient blocked or muted since are left out
+SELECT sqlc.embed(n), sqlc.embed(u)
+FROM notifications n
+JOIN users u ON u.id = n.actor_id
+WHERE n.user_id = @user_id
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = n.user_id AND b.blocked_id = n.actor_id)
+    AND (NOT @unread_only::boolean OR n.read_at IS NULL)
+    AND (sqlc.narg(before_created_at)::timestamptz IS NULL
+        OR (n.created_at, n.id) < (sqlc.narg(before_created_at)::timestamptz, sqlc.narg(before_id)::uuid))
+ORDER BY n.created_at DESC, n.id DESC
+LIMIT @page_limit;
+
+-- name: ListNotificationsSince :many
+SELECT sqlc.embed(n), sqlc.embed(u)
+FROM notifications n
+JOIN users u ON u.id = n.actor_id
+WHERE n.user_id = $1 AND n.created_at >= $2
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = n.user_id AND b.blocked_id = n.actor_id)
+ORDER BY n.created_at, n.id;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications n
+WHERE n.user_id = $1 AND n.read_at IS NULL
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = n.user_id AND b.blocked_id = n.actor_id);
+
+-- name: MarkNotificationsRead :execrows
+UPDATE notifications SET read_at = NOW()
+WHERE user_id = @user_id AND read_at IS NULL AND id = ANY(@ids::uuid[]);
+
+-- name: MarkAllNotificationsRead :execrows
+UPDATE notifications SET read_at = NOW()
+WHERE user_id = $1 AND read_at IS NULL;
+
+-- name: ClaimUnpushedNotifications :many
+-- Marks a batch of notifications as handled by the push worker, oldest first
+UPDATE notifications n SET pushed_at = NOW()
+WHERE n.id IN (
+    SELECT u.id FROM notifications u
+    WHERE u.pushed_at IS NULL
+    ORDER BY u.created_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING n.*;
+
+-- name: ListNotificationPreferences :many
+SELECT * FROM notification_preferences
+WHERE user_id = $1;
+
+-- name: UpsertNotificationPreference :exec
+INSERT INTO notification_preferences (user_id, kind, push)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, kind) DO UPDATE SET
+    push = EXCLUDED.push,
+    updated_at = NOW();
+
+-- name: IsPushEnabled :one
+-- Kinds without a preference are pushed
+SELECT COALESCE(
+    (SELECT p.push FROM notification_preferences p WHERE p.user_id = $1 AND p.kind = $2),
+    TRUE
+)::boolean AS enabled;
+
+-- name: CreateAreaNotifications :many
+-- Notifies users of pins created since @since inside their saved areas, from
+-- authors they haven't blocked or muted, at most @throttle_limit per user
+-- since @throttle_since. A pin in several of a user's areas is reported once.
+INSERT INTO notifications (user_id, actor_id, kind, pin_id, area_id)
+SELECT ranked.user_id, ranked.author_id, 'area', ranked.pin_id, ranked.area_id
+FROM (
+    SELECT m.*, ROW_NUMBER() OVER (PARTITION BY m.user_id ORDER BY m.created_at, m.pin_id) AS rank
+    FROM (
+        SELECT DISTINCT ON (a.user_id, p.id)
+            a.user_id, p.user_id AS author_id, p.id AS pin_id, a.id AS area_id, p.created_at
+        FROM posts_location pl
+        JOIN posts p ON p.id = pl.post_id AND p.type = 'pin'
+        JOIN saved_areas a ON ST_Contains(a.area, pl.coordinates)
+        JOIN users r ON r.id = a.user_id AND r.status <> 'disabled'
+        WHERE pl.created_at >= @since
+            AND (a.radius_meters IS NULL OR ST_DWithin(a.center::geography, pl.coordinates::geography, a.radius_meters))
+            AND a.user_id <> p.user_id
+            AND (COALESCE(p.visibility, 'public') = 'public'
+                OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = a.user_id AND f.followee_id = p.user_id))
+            AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = a.user_id AND b.blocked_id = p.user_id)
+            AND NOT EXISTS (SELECT 1 FROM notifications e WHERE e.user_id = a.user_id AND e.pin_id = p.id AND e.kind = 'area')
+        ORDER BY a.user_id, p.id, a.created_at
+    ) m
+) ranked
+WHERE ranked.rank + (
+    SELECT COUNT(*) FROM notifications t
+    WHERE t.user_id = ranked.user_id AND t.kind = 'area' AND t.created_at >= @throttle_since::timestamptz
+) <= @throttle_limit::bigint
+ON CONFLICT DO NOTHING
+RETURNING *;
+
+-- name: CopyNotificationsToUser :exec
+-- Carries an anonymous user's notifications over to the account it merges into
+INSERT INTO notifications (user_id, actor_id, kind, pin_id, comment_id, area_id, read_at, pushed_at, created_at)
+SELECT @new_user_id, n.actor_id, n.kind, n.pin_id, n.comment_id, n.area_id, n.read_at, n.pushed_at, n.created_at
+FROM notifications n
+WHERE n.user_id = @old_user_id AND n.actor_id <> @new_user_id
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteDuplicateFollowNotifications :exec
+-- Drops an anonymous user's follow notifications that the account it merges
+-- into already has, so reassigning the actor can't collide with them
+DELETE FROM notifications n
+WHERE n.actor_id = @old_user_id AND n.kind = 'follow'
+    AND EXISTS (
+        SELECT 1 FROM notifications e
+        WHERE e.user_id = n.user_id AND e.actor_id = @new_user_id AND e.kind = 'follow'
+    );
+
+-- name: ReassignNotificationActor :exec
+-- Keeps notifications about an anonymous user's activity once it merges into an account
+UPDATE notifications
+SET actor_id = @new_user_id
+WHERE actor_id = @old_user_id AND user_id <> @new_user_id;
+
+-- name: CopyNotificationPreferences :exec
+-- The account's own preferences win over the anonymous user's
+INSERT INTO notification_preferences (user_id, kind, push, updated_at)
+SELECT @new_user_id, p.kind, p.push, p.updated_at
+FROM notification_preferences p
+WHERE p.user_id = @old_user_id
+ON CONFLICT (user_id, kind) DO NOTHING;
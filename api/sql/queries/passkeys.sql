@@ -0,0 +1,20 @@
+-- name: CreatePasskeyCredential :execrows
+INSERT INTO passkey_credentials (credential_id, user_id, public_key, sign_count, transports, name)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (credential_id) DO NOTHING;
+
+-- name: GetPasskeyCredential :one
+SELECT * FROM passkey_credentials WHERE credential_id = $1;
+
+-- name: ListPasskeyCredentialsByUser :many
+SELECT * FROM passkey_credentials
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdatePasskeyCredentialUse :exec
+UPDATE passkey_credentials
+SET sign_count = $2, last_used_at = $3
+WHERE credential_id = $1;
+
+-- name: DeletePasskeyCredential :execrows
+DELETE FROM passkey_credentials WHERE user_id = $1 AND credential_id = $2;
\ No newline at end of file
@@ -18,10 +18,24 @@ LEFT JOIN users u ON p.user_id = u.id
 WHERE p.id = $1;
 
 -- name: ListPostsByUser :many
-SELECT * FROM posts 
-WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3;
+-- Keyset pagination as in ListFeedPins. Followers-only posts are included
+-- when viewer_id is the author or one of their followers.
+SELECT 
+    p.*,
+    ST_X(pl.coordinates) as longitude,
+    ST_Y(pl.coordinates) as latitude,
+    pl.geohash
+FROM posts p
+LEFT JOIN posts_location pl ON p.id = pl.post_id
+WHERE p.user_id = @user_id
+    AND p.type = @type
+    AND (COALESCE(p.visibility, 'public') = 'public'
+        OR p.user_id = sqlc.narg(viewer_id)
+        OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = sqlc.narg(viewer_id) AND f.followee_id = p.user_id))
+    AND (sqlc.narg(before_created_at)::timestamptz IS NULL
+        OR (p.created_at, p.id) < (sqlc.narg(before_created_at)::timestamptz, sqlc.narg(before_id)::uuid))
+ORDER BY p.created_at DESC, p.id DESC
+LIMIT @page_limit;
 
 -- name: ListPostsByType :many
 SELECT * FROM posts
@@ -30,6 +44,7 @@ ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListCommentsByParent :many
+-- Comments from users the viewer blocked or muted are left out
 SELECT 
     p.*,
     u.username as author_username,
@@ -37,9 +52,10 @@ SELECT
     u.avatar_url as author_avatar_url
 FROM posts p
 LEFT JOIN users u ON p.user_id = u.id
-WHERE p.parent_id = $1 AND p.type = 'comment'
+WHERE p.parent_id = @parent_id AND p.type = 'comment'
+    AND NOT EXISTS (SELECT 1 FROM user_blocks b WHERE b.blocker_id = sqlc.narg(viewer_id) AND b.blocked_id = p.user_id)
 ORDER BY p.created_at ASC
-LIMIT $2 OFFSET $3;
+LIMIT @page_limit OFFSET @page_offset;
 
 -- name: UpdatePost :one
 UPDATE posts
@@ -67,8 +83,35 @@ ORDER BY p.created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: CountPostsByUser :one
-SELECT COUNT(*) FROM posts WHERE user_id = $1;
+-- Counts the posts ListPostsByUser would show viewer_id
+SELECT COUNT(*) FROM posts p
+WHERE p.user_id = @user_id
+    AND p.type = @type
+    AND (COALESCE(p.visibility, 'public') = 'public'
+        OR p.user_id = sqlc.narg(viewer_id)
+        OR EXISTS (SELECT 1 FROM user_follows f WHERE f.follower_id = sqlc.narg(viewer_id) AND f.followee_id = p.user_id));
 
 -- name: CountCommentsByParent :one
 SELECT COUNT(*) FROM posts 
-WHERE parent_id = $1 AND type = 'comment';
\ No newline at end of file
+WHERE parent_id = $1 AND type = 'comment';
+
+-- name: ReassignPostsToUser :execrows
+UPDATE posts
+SET user_id = @new_user_id
+WHERE user_id = @old_user_id;
+
+-- name: DeleteUnrepliedPostsByUser :execrows
+-- Deletes a user's posts that no other user has replied to
+DELETE FROM posts p
+WHERE p.user_id = $1
+    AND NOT EXISTS (SELECT 1 FROM posts r WHERE (r.parent_id = p.id OR r.reply_to_id = p.id) AND r.user_id <> $1);
+
+-- name: RedactPostsByUser :execrows
+UPDATE posts
+SET content = '[deleted]', metadata = '{}'
+WHERE user_id = $1;
+
+-- name: CreateComment :one
+INSERT INTO posts (user_id, type, parent_id, reply_to_id, content)
+VALUES (@user_id, 'comment', @pin_id, sqlc.narg(reply_to_id), @content)
+RETURNING *;
@@ -0,0 +1,49 @@
+-- name: UpsertPushSubscription :one
+-- A browser keeps its endpoint across sign-ins, so the latest session takes it over
+INSERT INTO push_subscriptions (user_id, session_id, endpoint, p256dh, auth)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (endpoint) DO UPDATE SET
+    user_id = EXCLUDED.user_id,
+    session_id = EXCLUDED.session_id,
+    p256dh = EXCLUDED.p256dh,
+    auth = EXCLUDED.auth,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteOtherSessionPushSubscriptions :exec
+-- Drops a session's previous subscription when the browser subscribes again
+DELETE FROM push_subscriptions
+WHERE session_id = $1 AND endpoint <> $2;
+
+-- name: DeletePushSubscription :execrows
+DELETE FROM push_subscriptions
+WHERE user_id = $1 AND endpoint = $2;
+
+-- name: DeletePushSubscriptionsBySession :execrows
+DELETE FROM push_subscriptions
+WHERE user_id = $1 AND session_id = $2;
+
+-- name: DeletePushSubscriptionsBySessionID :exec
+-- Drops a revoked session's subscription
+DELETE FROM push_subscriptions
+WHERE session_id = $1;
+
+-- name: DeletePushSubscriptionsByUser :exec
+-- Drops every subscription of a user signed out everywhere
+DELETE FROM push_subscriptions
+WHERE user_id = $1;
+
+-- name: DeletePushSubscriptionByEndpoint :exec
+DELETE FROM push_subscriptions
+WHERE endpoint = $1;
+
+-- name: ListPushSubscriptionsByUser :many
+SELECT * FROM push_subscriptions
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: ReassignPushSubscriptionsToUser :exec
+-- Keeps an anonymous user's push registrations once it merges into an account
+UPDATE push_subscriptions
+SET user_id = @new_user_id, updated_at = NOW()
+WHERE user_id = @old_user_id;
@@ -0,0 +1,10 @@
+-- name: ListUserRoles :many
+SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role;
+
+-- name: GrantUserRole :exec
+INSERT INTO user_roles (user_id, role, granted_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id, role) DO NOTHING;
+
+-- name: RevokeUserRole :execrows
+DELETE FROM user_roles WHERE user_id = $1 AND role = $2;
\ No newline at end of file
@@ -0,0 +1,87 @@
+-- name: CountSavedAreasByUser :one
+SELECT COUNT(*) FROM saved_areas
+WHERE user_id = $1;
+
+-- name: CreateSavedPolygonArea :one
+INSERT INTO saved_areas (user_id, name, area)
+VALUES (@user_id, @name, ST_SetSRID(ST_GeomFromGeoJSON(@polygon::text), 4326))
+RETURNING
+    id,
+    user_id,
+    name,
+    ST_AsGeoJSON(area)::text AS polygon,
+    ST_X(center) AS longitude,
+    ST_Y(center) AS latitude,
+    radius_meters,
+    created_at;
+
+-- name: CreateSavedCircleArea :one
+-- The polygon is 1% larger than the circle since ST_Buffer inscribes its segments in it.
+-- Circles return that polygon too; clients use the center and radius.
+INSERT INTO saved_areas (user_id, name, area, center, radius_meters)
+VALUES (
+    @user_id,
+    @name,
+    ST_Buffer(ST_SetSRID(ST_MakePoint(@longitude::float8, @latitude::float8), 4326)::geography, 1.01 * @radius_meters::integer)::geometry,
+    ST_SetSRID(ST_MakePoint(@longitude::float8, @latitude::float8), 4326),
+    @radius_meters::integer
+)
+RETURNING
+    id,
+    user_id,
+    name,
+    ST_AsGeoJSON(area)::text AS polygon,
+    ST_X(center) AS longitude,
+    ST_Y(center) AS latitude,
+    radius_meters,
+    created_at;
+
+-- name: GetSavedArea :one
+SELECT
+    id,
+    user_id,
+    name,
+    ST_AsGeoJSON(area)::text AS polygon,
+    ST_X(center) AS longitude,
+    ST_Y(center) AS latitude,
+    radius_meters,
+    created_at
+FROM saved_areas
+WHERE id = $1;
+
+-- name: ListSavedAreasByUser :many
+SELECT
+    id,
+    user_id,
+    name,
+    ST_AsGeoJSON(area)::text AS polygon,
+    ST_X(center) AS longitude,
+    ST_Y(center) AS latitude,
+    radius_meters,
+    created_at
+FROM saved_areas
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: DeleteSavedArea :execrows
+DELETE FROM saved_areas
+WHERE id = $1 AND user_id = $2;
+
+-- name: LockSavedAreasByUser :exec
+-- Serializes changes to a user's saved areas so their limit holds. NO KEY
+-- UPDATE leaves foreign key checks against the user row unblocked.
+SELECT 1 FROM users
+WHERE id = $1
+FOR NO KEY UPDATE;
+
+-- name: ReassignSavedAreasToUser :execrows
+-- Moves the oldest of the old user's areas while the new user stays within
+-- @max_areas; the rest are left behind
+UPDATE saved_areas
+SET user_id = @new_user_id
+WHERE id IN (
+    SELECT a.id FROM saved_areas a
+    WHERE a.user_id = @old_user_id
+    ORDER BY a.created_at
+    LIMIT GREATEST(@max_areas::bigint - (SELECT COUNT(*) FROM saved_areas t WHERE t.user_id = @new_user_id), 0)
+);
@@ -0,0 +1,9 @@
+-- name: GetUserCredential :one
+SELECT * FROM user_credentials WHERE user_id = $1;
+
+-- name: UpsertUserCredential :exec
+INSERT INTO user_credentials (user_id, password_hash)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET password_hash = EXCLUDED.password_hash,
+    updated_at = NOW();
\ No newline at end of file
@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO users (id, username, email, display_name, avatar_url, created_at) 
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO users (id, username, email, display_name, avatar_url, created_at, email_verified_at, status, metadata, updated_at) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $6)
 RETURNING *;
 
 -- name: GetUserByID :one
@@ -13,19 +13,71 @@ SELECT * FROM users WHERE email = $1;
 SELECT * FROM users WHERE username = $1;
 
 -- name: UpdateUser :one
+-- An empty status or NULL metadata keeps the stored value
 UPDATE users 
 SET 
-    username = $2,
-    email = $3,
-    display_name = $4,
-    avatar_url = $5
+    username = @username,
+    email = @email,
+    display_name = @display_name,
+    avatar_url = @avatar_url,
+    email_verified_at = @email_verified_at,
+    status = COALESCE(NULLIF(@status::varchar, ''), status),
+    metadata = COALESCE(sqlc.narg(metadata)::jsonb, metadata),
+    updated_at = NOW()
+WHERE id = @id
+RETURNING *;
+
+-- name: UpdateUserDisplayName :one
+UPDATE users SET display_name = $2, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateUserAvatar :one
+UPDATE users SET avatar_url = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ChangeUsername :one
+-- Only changes the username if the last change was at or before $3
+UPDATE users SET username = $2, username_changed_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND (username_changed_at IS NULL OR username_changed_at <= $3)
+RETURNING *;
+
 -- name: ListUsers :many
 SELECT * FROM users 
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
 -- name: CountUsers :one
-SELECT COUNT(*) FROM users;
\ No newline at end of file
+SELECT COUNT(*) FROM users;
+
+-- name: DeleteUser :exec
+DELETE FROM users WHERE id = $1;
+
+-- name: ScheduleUserDeletion :execrows
+UPDATE users SET deletion_scheduled_at = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CancelUserDeletion :execrows
+UPDATE users SET deletion_scheduled_at = NULL, updated_at = NOW()
+WHERE id = $1 AND deletion_scheduled_at IS NOT NULL;
+
+-- name: PostponeUserDeletion :exec
+-- Only moves a deletion that is still scheduled, so a cancelled one stays cancelled
+UPDATE users SET deletion_scheduled_at = $2
+WHERE id = $1 AND deletion_scheduled_at IS NOT NULL;
+
+-- name: ListUsersDueForDeletion :many
+SELECT id FROM users
+WHERE deletion_scheduled_at <= $1
+ORDER BY deletion_scheduled_at
+LIMIT $2;
+
+-- name: DeleteScheduledUser :execrows
+-- Only deletes the user if the deletion is still scheduled and due, so a sign-in
+-- that cancelled it in the meantime wins
+DELETE FROM users WHERE id = $1 AND deletion_scheduled_at <= NOW();
+
+-- name: RevokeUserTokens :exec
+UPDATE users SET tokens_revoked_at = $2
+WHERE id = $1 AND (tokens_revoked_at IS NULL OR tokens_revoked_at < $2);
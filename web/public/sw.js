@@ -0,0 +1,49 @@
+// Service worker for Web Push notifications. Messages are JSON payloads sent
+// by the API's push deliverer: { id, kind, title, body, pin_id? }.
+
+self.addEventListener("install", () => {
+  self.skipWaiting();
+});
+
+self.addEventListener("activate", (event) => {
+  event.waitUntil(self.clients.claim());
+});
+
+self.addEventListener("push", (event) => {
+  let message = {};
+  try {
+    message = event.data ? event.data.json() : {};
+  } catch {
+    message = { body: event.data ? event.data.text() : "" };
+  }
+
+  event.waitUntil(
+    self.registration.showNotification(message.title || "Alunalun", {
+      body: message.body || "You have a new notification",
+      icon: "/alunalun-favicon.png",
+      badge: "/alunalun-favicon.png",
+      tag: message.id, // Repeated deliveries of one notification replace each other
+      data: { pinId: message.pin_id },
+    }),
+  );
+});
+
+self.addEventListener("notificationclick", (event) => {
+  event.notification.close();
+
+  const pinId = event.notification.data?.pinId;
+  const url = pinId ? `/?pin=${encodeURIComponent(pinId)}` : "/";
+
+  // Focus an open tab if there is one
+  event.waitUntil(
+    self.clients.matchAll({ type: "window", includeUncontrolled: true }).then((windows) => {
+      for (const client of windows) {
+        if ("focus" in client) {
+          client.navigate(url);
+          return client.focus();
+        }
+      }
+      return self.clients.openWindow(url);
+    }),
+  );
+});
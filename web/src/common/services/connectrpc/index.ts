@@ -5,10 +5,15 @@ export { transport } from "./transport";
 export * from "./v1/service/pin_service-PinService_connectquery";
 export * from "./v1/service/auth-AuthService_connectquery";
 export * from "./v1/service/user_service-UserService_connectquery";
+export * from "./v1/service/notification_service-NotificationService_connectquery";
+export * from "./v1/service/push_subscription_service-PushSubscriptionService_connectquery";
 
 // Re-export protobuf types
 export * from "./v1/entities/pin_pb";
 export * from "./v1/entities/user_pb";
+export * from "./v1/entities/notification_pb";
 export * from "./v1/service/pin_service_pb";
 export * from "./v1/service/auth_pb";
-export * from "./v1/service/user_service_pb";
\ No newline at end of file
+export * from "./v1/service/user_service_pb";
+export * from "./v1/service/notification_service_pb";
+export * from "./v1/service/push_subscription_service_pb";
\ No newline at end of file
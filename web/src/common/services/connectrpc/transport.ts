@@ -12,7 +12,15 @@ export const transport = createConnectTransport({
         req.header.set("Authorization", `Bearer ${token}`);
       }
       
-      return await next(req);
+      const res = await next(req);
+      
+      // Anonymous tokens are renewed on activity; keep the replacement
+      const renewedToken = res.header.get("X-Renewed-Token");
+      if (renewedToken) {
+        useAuthStore.setState({ token: renewedToken });
+      }
+      
+      return res;
     },
   ],
 });
\ No newline at end of file
@@ -0,0 +1,181 @@
+// @generated by protoc-gen-es v2.6.3 with parameter "target=ts"
+// @generated from file v1/entities/notification.proto (package api.v1.entities, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Location } from "./pin_pb";
+import { file_v1_entities_pin } from "./pin_pb";
+import type { User } from "./user_pb";
+import { file_v1_entities_user } from "./user_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file v1/entities/notification.proto.
+ */
+export const file_v1_entities_notification: GenFile = /*@__PURE__*/
+  fileDesc("Ch52MS9lbnRpdGllcy9ub3RpZmljYXRpb24ucHJvdG8SD2FwaS52MS5lbnRpdGllcyKMAgoMTm90aWZpY2F0aW9uEgoKAmlkGAEgASgJEi8KBGtpbmQYAiABKA4yIS5hcGkudjEuZW50aXRpZXMuTm90aWZpY2F0aW9uS2luZBIpCgVhY3RvchgDIAEoCzIVLmFwaS52MS5lbnRpdGllcy5Vc2VySACIAQESEwoGcGluX2lkGAQgASgJSAGIAQESFwoKY29tbWVudF9pZBgFIAEoCUgCiAEBEgwKBHJlYWQYBiABKAgSEgoKY3JlYXRlZF9hdBgHIAEoAxIUCgdhcmVhX2lkGAggASgJSAOIAQFCCAoGX2FjdG9yQgkKB19waW5faWRCDQoLX2NvbW1lbnRfaWRCCgoIX2FyZWFfaWQizgEKCVNhdmVkQXJlYRIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEioKB3BvbHlnb24YAyADKAsyGS5hcGkudjEuZW50aXRpZXMuTG9jYXRpb24SLgoGY2VudGVyGAQgASgLMhkuYXBpLnYxLmVudGl0aWVzLkxvY2F0aW9uSACIAQESGgoNcmFkaXVzX21ldGVycxgFIAEoBUgBiAEBEhIKCmNyZWF0ZWRfYXQYBiABKANCCQoHX2NlbnRlckIQCg5fcmFkaXVzX21ldGVycyqrAQoQTm90aWZpY2F0aW9uS2luZBIhCh1OT1RJRklDQVRJT05fS0lORF9VTlNQRUNJRklFRBAAEh0KGU5PVElGSUNBVElPTl9LSU5EX0NPTU1FTlQQARIbChdOT1RJRklDQVRJT05fS0lORF9SRVBMWRACEhwKGE5PVElGSUNBVElPTl9LSU5EX0ZPTExPVxADEhoKFk5PVElGSUNBVElPTl9LSU5EX0FSRUEQBEJPWk1naXRodWIuY29tL3JhZGphdGhhaGVyL2FsdW5hbHVuL2FwaS9pbnRlcm5hbC9wcm90b2NnZW4vdjEvZW50aXRpZXM7ZW50aXRpZXN2MWIGcHJvdG8z", [file_v1_entities_pin, file_v1_entities_user]);
+
+/**
+ * Notification tells a user about activity involving them
+ *
+ * @generated from message api.v1.entities.Notification
+ */
+export type Notification = Message<"api.v1.entities.Notification"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: api.v1.entities.NotificationKind kind = 2;
+   */
+  kind: NotificationKind;
+
+  /**
+   * The user who commented, replied or followed
+   *
+   * @generated from field: optional api.v1.entities.User actor = 3;
+   */
+  actor?: User;
+
+  /**
+   * Set for comments, replies and area alerts
+   *
+   * @generated from field: optional string pin_id = 4;
+   */
+  pinId?: string;
+
+  /**
+   * The new comment, for comments and replies
+   *
+   * @generated from field: optional string comment_id = 5;
+   */
+  commentId?: string;
+
+  /**
+   * @generated from field: bool read = 6;
+   */
+  read: boolean;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 created_at = 7;
+   */
+  createdAt: bigint;
+
+  /**
+   * The saved area the pin is in, for area alerts
+   *
+   * @generated from field: optional string area_id = 8;
+   */
+  areaId?: string;
+};
+
+/**
+ * Describes the message api.v1.entities.Notification.
+ * Use `create(NotificationSchema)` to create a new message.
+ */
+export const NotificationSchema: GenMessage<Notification> = /*@__PURE__*/
+  messageDesc(file_v1_entities_notification, 0);
+
+/**
+ * SavedArea is an area a user is alerted about new pins in: a polygon, or a
+ * circle given by its center and radius
+ *
+ * @generated from message api.v1.entities.SavedArea
+ */
+export type SavedArea = Message<"api.v1.entities.SavedArea"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * Vertices of a polygon area, unclosed
+   *
+   * @generated from field: repeated api.v1.entities.Location polygon = 3;
+   */
+  polygon: Location[];
+
+  /**
+   * Set for circles
+   *
+   * @generated from field: optional api.v1.entities.Location center = 4;
+   */
+  center?: Location;
+
+  /**
+   * Set for circles
+   *
+   * @generated from field: optional int32 radius_meters = 5;
+   */
+  radiusMeters?: number;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 created_at = 6;
+   */
+  createdAt: bigint;
+};
+
+/**
+ * Describes the message api.v1.entities.SavedArea.
+ * Use `create(SavedAreaSchema)` to create a new message.
+ */
+export const SavedAreaSchema: GenMessage<SavedArea> = /*@__PURE__*/
+  messageDesc(file_v1_entities_notification, 1);
+
+/**
+ * NotificationKind is what happened to trigger a notification
+ *
+ * @generated from enum api.v1.entities.NotificationKind
+ */
+export enum NotificationKind {
+  /**
+   * @generated from enum value: NOTIFICATION_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Someone commented on your pin
+   *
+   * @generated from enum value: NOTIFICATION_KIND_COMMENT = 1;
+   */
+  COMMENT = 1,
+
+  /**
+   * Someone replied to your comment
+   *
+   * @generated from enum value: NOTIFICATION_KIND_REPLY = 2;
+   */
+  REPLY = 2,
+
+  /**
+   * Someone followed you
+   *
+   * @generated from enum value: NOTIFICATION_KIND_FOLLOW = 3;
+   */
+  FOLLOW = 3,
+
+  /**
+   * Someone pinned inside one of your saved areas
+   *
+   * @generated from enum value: NOTIFICATION_KIND_AREA = 4;
+   */
+  AREA = 4,
+}
+
+/**
+ * Describes the enum api.v1.entities.NotificationKind.
+ */
+export const NotificationKindSchema: GenEnum<NotificationKind> = /*@__PURE__*/
+  enumDesc(file_v1_entities_notification, 0);
+
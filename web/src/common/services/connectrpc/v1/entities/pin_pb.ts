@@ -2,8 +2,8 @@
 // @generated from file v1/entities/pin.proto (package api.v1.entities, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc } from "@bufbuild/protobuf/codegenv2";
 import type { User } from "./user_pb";
 import { file_v1_entities_user } from "./user_pb";
 import type { Message } from "@bufbuild/protobuf";
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/entities/pin.proto.
  */
 export const file_v1_entities_pin: GenFile = /*@__PURE__*/
-  fileDesc("ChV2MS9lbnRpdGllcy9waW4ucHJvdG8SD2FwaS52MS5lbnRpdGllcyLWAQoDUGluEgoKAmlkGAEgASgJEg8KB3VzZXJfaWQYAiABKAkSDwoHY29udGVudBgDIAEoCRIrCghsb2NhdGlvbhgEIAEoCzIZLmFwaS52MS5lbnRpdGllcy5Mb2NhdGlvbhISCgpjcmVhdGVkX2F0GAUgASgDEhIKCnVwZGF0ZWRfYXQYBiABKAMSKgoGYXV0aG9yGAcgASgLMhUuYXBpLnYxLmVudGl0aWVzLlVzZXJIAIgBARIVCg1jb21tZW50X2NvdW50GAggASgFQgkKB19hdXRob3IidQoITG9jYXRpb24SEAoIbGF0aXR1ZGUYASABKAESEQoJbG9uZ2l0dWRlGAIgASgBEhUKCGFsdGl0dWRlGAMgASgBSACIAQESFAoHZ2VvaGFzaBgEIAEoCUgBiAEBQgsKCV9hbHRpdHVkZUIKCghfZ2VvaGFzaCKoAQoHQ29tbWVudBIKCgJpZBgBIAEoCRIPCgd1c2VyX2lkGAIgASgJEg8KB2NvbnRlbnQYAyABKAkSFgoJcGFyZW50X2lkGAQgASgJSACIAQESEgoKY3JlYXRlZF9hdBgFIAEoAxIqCgZhdXRob3IYBiABKAsyFS5hcGkudjEuZW50aXRpZXMuVXNlckgBiAEBQgwKCl9wYXJlbnRfaWRCCQoHX2F1dGhvckJPWk1naXRodWIuY29tL3JhZGphdGhhaGVyL2FsdW5hbHVuL2FwaS9pbnRlcm5hbC9wcm90b2NnZW4vdjEvZW50aXRpZXM7ZW50aXRpZXN2MWIGcHJvdG8z", [file_v1_entities_user]);
+  fileDesc("ChV2MS9lbnRpdGllcy9waW4ucHJvdG8SD2FwaS52MS5lbnRpdGllcyKKAgoDUGluEgoKAmlkGAEgASgJEg8KB3VzZXJfaWQYAiABKAkSDwoHY29udGVudBgDIAEoCRIrCghsb2NhdGlvbhgEIAEoCzIZLmFwaS52MS5lbnRpdGllcy5Mb2NhdGlvbhISCgpjcmVhdGVkX2F0GAUgASgDEhIKCnVwZGF0ZWRfYXQYBiABKAMSKgoGYXV0aG9yGAcgASgLMhUuYXBpLnYxLmVudGl0aWVzLlVzZXJIAIgBARIVCg1jb21tZW50X2NvdW50GAggASgFEjIKCnZpc2liaWxpdHkYCSABKA4yHi5hcGkudjEuZW50aXRpZXMuUGluVmlzaWJpbGl0eUIJCgdfYXV0aG9yInUKCExvY2F0aW9uEhAKCGxhdGl0dWRlGAEgASgBEhEKCWxvbmdpdHVkZRgCIAEoARIVCghhbHRpdHVkZRgDIAEoAUgAiAEBEhQKB2dlb2hhc2gYBCABKAlIAYgBAUILCglfYWx0aXR1ZGVCCgoIX2dlb2hhc2giqAEKB0NvbW1lbnQSCgoCaWQYASABKAkSDwoHdXNlcl9pZBgCIAEoCRIPCgdjb250ZW50GAMgASgJEhYKCXBhcmVudF9pZBgEIAEoCUgAiAEBEhIKCmNyZWF0ZWRfYXQYBSABKAMSKgoGYXV0aG9yGAYgASgLMhUuYXBpLnYxLmVudGl0aWVzLlVzZXJIAYgBAUIMCgpfcGFyZW50X2lkQgkKB19hdXRob3IqaAoNUGluVmlzaWJpbGl0eRIeChpQSU5fVklTSUJJTElUWV9VTlNQRUNJRklFRBAAEhkKFVBJTl9WSVNJQklMSVRZX1BVQkxJQxABEhwKGFBJTl9WSVNJQklMSVRZX0ZPTExPV0VSUxACQk9aTWdpdGh1Yi5jb20vcmFkamF0aGFoZXIvYWx1bmFsdW4vYXBpL2ludGVybmFsL3Byb3RvY2dlbi92MS9lbnRpdGllcztlbnRpdGllc3YxYgZwcm90bzM", [file_v1_entities_user]);
 
 /**
  * Pin represents a pin on the map (composed from posts + posts_location)
@@ -73,6 +73,11 @@ export type Pin = Message<"api.v1.entities.Pin"> & {
    * @generated from field: int32 comment_count = 8;
    */
   commentCount: number;
+
+  /**
+   * @generated from field: api.v1.entities.PinVisibility visibility = 9;
+   */
+  visibility: PinVisibility;
 };
 
 /**
@@ -174,3 +179,35 @@ export type Comment = Message<"api.v1.entities.Comment"> & {
 export const CommentSchema: GenMessage<Comment> = /*@__PURE__*/
   messageDesc(file_v1_entities_pin, 2);
 
+/**
+ * PinVisibility controls who can see a pin
+ *
+ * @generated from enum api.v1.entities.PinVisibility
+ */
+export enum PinVisibility {
+  /**
+   * Treated as public
+   *
+   * @generated from enum value: PIN_VISIBILITY_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: PIN_VISIBILITY_PUBLIC = 1;
+   */
+  PUBLIC = 1,
+
+  /**
+   * Only the author and their followers
+   *
+   * @generated from enum value: PIN_VISIBILITY_FOLLOWERS = 2;
+   */
+  FOLLOWERS = 2,
+}
+
+/**
+ * Describes the enum api.v1.entities.PinVisibility.
+ */
+export const PinVisibilitySchema: GenEnum<PinVisibility> = /*@__PURE__*/
+  enumDesc(file_v1_entities_pin, 0);
+
@@ -10,7 +10,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/entities/user.proto.
  */
 export const file_v1_entities_user: GenFile = /*@__PURE__*/
-  fileDesc("ChZ2MS9lbnRpdGllcy91c2VyLnByb3RvEg9hcGkudjEuZW50aXRpZXMi0gEKBFVzZXISCgoCaWQYASABKAkSEgoFZW1haWwYAiABKAlIAIgBARIQCgh1c2VybmFtZRgDIAEoCRI1CghtZXRhZGF0YRgEIAMoCzIjLmFwaS52MS5lbnRpdGllcy5Vc2VyLk1ldGFkYXRhRW50cnkSEgoKY3JlYXRlZF9hdBgFIAEoAxISCgp1cGRhdGVkX2F0GAYgASgDGi8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4AUIICgZfZW1haWwqdAoKVXNlclN0YXR1cxIbChdVU0VSX1NUQVRVU19VTlNQRUNJRklFRBAAEhYKElVTRVJfU1RBVFVTX0FDVElWRRABEhgKFFVTRVJfU1RBVFVTX0RJU0FCTEVEEAISFwoTVVNFUl9TVEFUVVNfUEVORElORxADQk9aTWdpdGh1Yi5jb20vcmFkamF0aGFoZXIvYWx1bmFsdW4vYXBpL2ludGVybmFsL3Byb3RvY2dlbi92MS9lbnRpdGllcztlbnRpdGllc3YxYgZwcm90bzM");
+  fileDesc("ChZ2MS9lbnRpdGllcy91c2VyLnByb3RvEg9hcGkudjEuZW50aXRpZXMikQMKBFVzZXISCgoCaWQYASABKAkSEgoFZW1haWwYAiABKAlIAIgBARIQCgh1c2VybmFtZRgDIAEoCRI1CghtZXRhZGF0YRgEIAMoCzIjLmFwaS52MS5lbnRpdGllcy5Vc2VyLk1ldGFkYXRhRW50cnkSEgoKY3JlYXRlZF9hdBgFIAEoAxISCgp1cGRhdGVkX2F0GAYgASgDEhkKDGRpc3BsYXlfbmFtZRgHIAEoCUgBiAEBEhcKCmF2YXRhcl91cmwYCCABKAlIAogBARIrCgZzdGF0dXMYCSABKA4yGy5hcGkudjEuZW50aXRpZXMuVXNlclN0YXR1cxIiChVkZWxldGlvbl9zY2hlZHVsZWRfYXQYCiABKANIA4gBARovCg1NZXRhZGF0YUVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAFCCAoGX2VtYWlsQg8KDV9kaXNwbGF5X25hbWVCDQoLX2F2YXRhcl91cmxCGAoWX2RlbGV0aW9uX3NjaGVkdWxlZF9hdCp0CgpVc2VyU3RhdHVzEhsKF1VTRVJfU1RBVFVTX1VOU1BFQ0lGSUVEEAASFgoSVVNFUl9TVEFUVVNfQUNUSVZFEAESGAoUVVNFUl9TVEFUVVNfRElTQUJMRUQQAhIXChNVU0VSX1NUQVRVU19QRU5ESU5HEANCT1pNZ2l0aHViLmNvbS9yYWRqYXRoYWhlci9hbHVuYWx1bi9hcGkvaW50ZXJuYWwvcHJvdG9jZ2VuL3YxL2VudGl0aWVzO2VudGl0aWVzdjFiBnByb3RvMw");
 
 /**
  * User represents a user in the system (matches database schema)
@@ -57,6 +57,28 @@ export type User = Message<"api.v1.entities.User"> & {
    * @generated from field: int64 updated_at = 6;
    */
   updatedAt: bigint;
+
+  /**
+   * @generated from field: optional string display_name = 7;
+   */
+  displayName?: string;
+
+  /**
+   * @generated from field: optional string avatar_url = 8;
+   */
+  avatarUrl?: string;
+
+  /**
+   * @generated from field: api.v1.entities.UserStatus status = 9;
+   */
+  status: UserStatus;
+
+  /**
+   * Unix timestamp; set while the account is scheduled for deletion
+   *
+   * @generated from field: optional int64 deletion_scheduled_at = 10;
+   */
+  deletionScheduledAt?: bigint;
 };
 
 /**
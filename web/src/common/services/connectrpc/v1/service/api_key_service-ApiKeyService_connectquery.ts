@@ -0,0 +1,26 @@
+// @generated by protoc-gen-connect-query v2.1.1 with parameter "target=ts"
+// @generated from file v1/service/api_key_service.proto (package api.v1.service, syntax proto3)
+/* eslint-disable */
+
+import { ApiKeyService } from "./api_key_service_pb";
+
+/**
+ * Create a key; the key itself is only returned here
+ *
+ * @generated from rpc api.v1.service.ApiKeyService.CreateApiKey
+ */
+export const createApiKey = ApiKeyService.method.createApiKey;
+
+/**
+ * List the caller's active keys
+ *
+ * @generated from rpc api.v1.service.ApiKeyService.ListApiKeys
+ */
+export const listApiKeys = ApiKeyService.method.listApiKeys;
+
+/**
+ * Revoke a key; it stops working immediately
+ *
+ * @generated from rpc api.v1.service.ApiKeyService.RevokeApiKey
+ */
+export const revokeApiKey = ApiKeyService.method.revokeApiKey;
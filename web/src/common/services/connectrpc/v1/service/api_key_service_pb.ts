@@ -0,0 +1,230 @@
+// @generated by protoc-gen-es v2.6.3 with parameter "target=ts"
+// @generated from file v1/service/api_key_service.proto (package api.v1.service, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file v1/service/api_key_service.proto.
+ */
+export const file_v1_service_api_key_service: GenFile = /*@__PURE__*/
+  fileDesc("CiB2MS9zZXJ2aWNlL2FwaV9rZXlfc2VydmljZS5wcm90bxIOYXBpLnYxLnNlcnZpY2UiqgEKBkFwaUtleRIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJEg4KBnByZWZpeBgDIAEoCRIOCgZzY29wZXMYBCADKAkSEgoKY3JlYXRlZF9hdBgFIAEoAxIXCgpleHBpcmVzX2F0GAYgASgDSACIAQESGQoMbGFzdF91c2VkX2F0GAcgASgDSAGIAQFCDQoLX2V4cGlyZXNfYXRCDwoNX2xhc3RfdXNlZF9hdCJPChNDcmVhdGVBcGlLZXlSZXF1ZXN0EgwKBG5hbWUYASABKAkSDgoGc2NvcGVzGAIgAygJEhoKEmV4cGlyZXNfaW5fc2Vjb25kcxgDIAEoAyJMChRDcmVhdGVBcGlLZXlSZXNwb25zZRInCgdhcGlfa2V5GAEgASgLMhYuYXBpLnYxLnNlcnZpY2UuQXBpS2V5EgsKA2tleRgCIAEoCSIUChJMaXN0QXBpS2V5c1JlcXVlc3QiPwoTTGlzdEFwaUtleXNSZXNwb25zZRIoCghhcGlfa2V5cxgBIAMoCzIWLmFwaS52MS5zZXJ2aWNlLkFwaUtleSIpChNSZXZva2VBcGlLZXlSZXF1ZXN0EhIKCmFwaV9rZXlfaWQYASABKAkiJwoUUmV2b2tlQXBpS2V5UmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCDKdAgoNQXBpS2V5U2VydmljZRJZCgxDcmVhdGVBcGlLZXkSIy5hcGkudjEuc2VydmljZS5DcmVhdGVBcGlLZXlSZXF1ZXN0GiQuYXBpLnYxLnNlcnZpY2UuQ3JlYXRlQXBpS2V5UmVzcG9uc2USVgoLTGlzdEFwaUtleXMSIi5hcGkudjEuc2VydmljZS5MaXN0QXBpS2V5c1JlcXVlc3QaIy5hcGkudjEuc2VydmljZS5MaXN0QXBpS2V5c1Jlc3BvbnNlElkKDFJldm9rZUFwaUtleRIjLmFwaS52MS5zZXJ2aWNlLlJldm9rZUFwaUtleVJlcXVlc3QaJC5hcGkudjEuc2VydmljZS5SZXZva2VBcGlLZXlSZXNwb25zZUJNWktnaXRodWIuY29tL3JhZGphdGhhaGVyL2FsdW5hbHVuL2FwaS9pbnRlcm5hbC9wcm90b2NnZW4vdjEvc2VydmljZTtzZXJ2aWNldjFiBnByb3RvMw");
+
+/**
+ * ApiKey describes a key without its secret
+ *
+ * @generated from message api.v1.service.ApiKey
+ */
+export type ApiKey = Message<"api.v1.service.ApiKey"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * Start of the key, e.g. "alun_Xk3v9QaB"
+   *
+   * @generated from field: string prefix = 3;
+   */
+  prefix: string;
+
+  /**
+   * "pins:read", "pins:write"
+   *
+   * @generated from field: repeated string scopes = 4;
+   */
+  scopes: string[];
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 created_at = 5;
+   */
+  createdAt: bigint;
+
+  /**
+   * Unix timestamp; unset never expires
+   *
+   * @generated from field: optional int64 expires_at = 6;
+   */
+  expiresAt?: bigint;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: optional int64 last_used_at = 7;
+   */
+  lastUsedAt?: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.ApiKey.
+ * Use `create(ApiKeySchema)` to create a new message.
+ */
+export const ApiKeySchema: GenMessage<ApiKey> = /*@__PURE__*/
+  messageDesc(file_v1_service_api_key_service, 0);
+
+/**
+ * @generated from message api.v1.service.CreateApiKeyRequest
+ */
+export type CreateApiKeyRequest = Message<"api.v1.service.CreateApiKeyRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: repeated string scopes = 2;
+   */
+  scopes: string[];
+
+  /**
+   * 0 = never expires, if the server allows it
+   *
+   * @generated from field: int64 expires_in_seconds = 3;
+   */
+  expiresInSeconds: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.CreateApiKeyRequest.
+ * Use `create(CreateApiKeyRequestSchema)` to create a new message.
+ */
+export const CreateApiKeyRequestSchema: GenMessage<CreateApiKeyRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_api_key_service, 1);
+
+/**
+ * @generated from message api.v1.service.CreateApiKeyResponse
+ */
+export type CreateApiKeyResponse = Message<"api.v1.service.CreateApiKeyResponse"> & {
+  /**
+   * @generated from field: api.v1.service.ApiKey api_key = 1;
+   */
+  apiKey?: ApiKey;
+
+  /**
+   * Full key; store it now, it cannot be shown again
+   *
+   * @generated from field: string key = 2;
+   */
+  key: string;
+};
+
+/**
+ * Describes the message api.v1.service.CreateApiKeyResponse.
+ * Use `create(CreateApiKeyResponseSchema)` to create a new message.
+ */
+export const CreateApiKeyResponseSchema: GenMessage<CreateApiKeyResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_api_key_service, 2);
+
+/**
+ * @generated from message api.v1.service.ListApiKeysRequest
+ */
+export type ListApiKeysRequest = Message<"api.v1.service.ListApiKeysRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.ListApiKeysRequest.
+ * Use `create(ListApiKeysRequestSchema)` to create a new message.
+ */
+export const ListApiKeysRequestSchema: GenMessage<ListApiKeysRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_api_key_service, 3);
+
+/**
+ * @generated from message api.v1.service.ListApiKeysResponse
+ */
+export type ListApiKeysResponse = Message<"api.v1.service.ListApiKeysResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.service.ApiKey api_keys = 1;
+   */
+  apiKeys: ApiKey[];
+};
+
+/**
+ * Describes the message api.v1.service.ListApiKeysResponse.
+ * Use `create(ListApiKeysResponseSchema)` to create a new message.
+ */
+export const ListApiKeysResponseSchema: GenMessage<ListApiKeysResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_api_key_service, 4);
+
+/**
+ * @generated from message api.v1.service.RevokeApiKeyRequest
+ */
+export type RevokeApiKeyRequest = Message<"api.v1.service.RevokeApiKeyRequest"> & {
+  /**
+   * @generated from field: string api_key_id = 1;
+   */
+  apiKeyId: string;
+};
+
+/**
+ * Describes the message api.v1.service.RevokeApiKeyRequest.
+ * Use `create(RevokeApiKeyRequestSchema)` to create a new message.
+ */
+export const RevokeApiKeyRequestSchema: GenMessage<RevokeApiKeyRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_api_key_service, 5);
+
+/**
+ * @generated from message api.v1.service.RevokeApiKeyResponse
+ */
+export type RevokeApiKeyResponse = Message<"api.v1.service.RevokeApiKeyResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.RevokeApiKeyResponse.
+ * Use `create(RevokeApiKeyResponseSchema)` to create a new message.
+ */
+export const RevokeApiKeyResponseSchema: GenMessage<RevokeApiKeyResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_api_key_service, 6);
+
+/**
+ * ApiKeyService manages personal API keys for scripts and integrations.
+ * Keys are sent as "Authorization: ApiKey <key>".
+ *
+ * @generated from service api.v1.service.ApiKeyService
+ */
+export const ApiKeyService: GenService<{
+  /**
+   * Create a key; the key itself is only returned here
+   *
+   * @generated from rpc api.v1.service.ApiKeyService.CreateApiKey
+   */
+  createApiKey: {
+    methodKind: "unary";
+    input: typeof CreateApiKeyRequestSchema;
+    output: typeof CreateApiKeyResponseSchema;
+  },
+  /**
+   * List the caller's active keys
+   *
+   * @generated from rpc api.v1.service.ApiKeyService.ListApiKeys
+   */
+  listApiKeys: {
+    methodKind: "unary";
+    input: typeof ListApiKeysRequestSchema;
+    output: typeof ListApiKeysResponseSchema;
+  },
+  /**
+   * Revoke a key; it stops working immediately
+   *
+   * @generated from rpc api.v1.service.ApiKeyService.RevokeApiKey
+   */
+  revokeApiKey: {
+    methodKind: "unary";
+    input: typeof RevokeApiKeyRequestSchema;
+    output: typeof RevokeApiKeyResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_v1_service_api_key_service, 0);
+
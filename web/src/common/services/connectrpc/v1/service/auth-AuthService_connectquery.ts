@@ -31,3 +31,129 @@ export const authenticate = AuthService.method.authenticate;
  * @generated from rpc api.v1.service.auth.AuthService.RefreshToken
  */
 export const refreshToken = AuthService.method.refreshToken;
+
+/**
+ * Link another login provider to the authenticated user
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.LinkProvider
+ */
+export const linkProvider = AuthService.method.linkProvider;
+
+/**
+ * Unlink a login provider (the last remaining one cannot be removed)
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.UnlinkProvider
+ */
+export const unlinkProvider = AuthService.method.unlinkProvider;
+
+/**
+ * List login providers linked to the authenticated user
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.ListLinkedProviders
+ */
+export const listLinkedProviders = AuthService.method.listLinkedProviders;
+
+/**
+ * Send an email verification link (always succeeds, to avoid revealing accounts)
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.SendVerificationEmail
+ */
+export const sendVerificationEmail = AuthService.method.sendVerificationEmail;
+
+/**
+ * Verify an email address with the token from a verification link
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.VerifyEmail
+ */
+export const verifyEmail = AuthService.method.verifyEmail;
+
+/**
+ * Send a password reset link (always succeeds, to avoid revealing accounts)
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.RequestPasswordReset
+ */
+export const requestPasswordReset = AuthService.method.requestPasswordReset;
+
+/**
+ * Set a new password with the token from a reset link; signs out all sessions
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.ResetPassword
+ */
+export const resetPassword = AuthService.method.resetPassword;
+
+/**
+ * Start TOTP enrollment; returns a secret and otpauth URI for an authenticator app
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.EnrollTOTP
+ */
+export const enrollTOTP = AuthService.method.enrollTOTP;
+
+/**
+ * Enable TOTP with the first code from the app; returns one-time recovery codes
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.ConfirmTOTP
+ */
+export const confirmTOTP = AuthService.method.confirmTOTP;
+
+/**
+ * Turn off TOTP with a current code or recovery code
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.DisableTOTP
+ */
+export const disableTOTP = AuthService.method.disableTOTP;
+
+/**
+ * Finish a sign-in that returned mfa_required with a TOTP or recovery code
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.CompleteMFA
+ */
+export const completeMFA = AuthService.method.completeMFA;
+
+/**
+ * Start registering a passkey for the authenticated user
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.BeginPasskeyRegistration
+ */
+export const beginPasskeyRegistration = AuthService.method.beginPasskeyRegistration;
+
+/**
+ * Verify the authenticator's response and store the passkey
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.FinishPasskeyRegistration
+ */
+export const finishPasskeyRegistration = AuthService.method.finishPasskeyRegistration;
+
+/**
+ * Start a passkey sign-in; finish with Authenticate(provider = "passkey")
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.BeginPasskeyLogin
+ */
+export const beginPasskeyLogin = AuthService.method.beginPasskeyLogin;
+
+/**
+ * List the authenticated user's passkeys
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.ListPasskeys
+ */
+export const listPasskeys = AuthService.method.listPasskeys;
+
+/**
+ * Remove one of the authenticated user's passkeys
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.DeletePasskey
+ */
+export const deletePasskey = AuthService.method.deletePasskey;
+
+/**
+ * Give a user an elevated role (admin only; moderators and admins need 2FA)
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.GrantRole
+ */
+export const grantRole = AuthService.method.grantRole;
+
+/**
+ * Remove an elevated role from a user (admin only)
+ *
+ * @generated from rpc api.v1.service.auth.AuthService.RevokeRole
+ */
+export const revokeRole = AuthService.method.revokeRole;
@@ -2,8 +2,8 @@
 // @generated from file v1/service/auth.proto (package api.v1.service.auth, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { User } from "../entities/user_pb";
 import { file_v1_entities_user } from "../entities/user_pb";
 import { file_google_protobuf_field_mask } from "@bufbuild/protobuf/wkt";
@@ -13,7 +13,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/service/auth.proto.
  */
 export const file_v1_service_auth: GenFile = /*@__PURE__*/
-  fileDesc("ChV2MS9zZXJ2aWNlL2F1dGgucHJvdG8SE2FwaS52MS5zZXJ2aWNlLmF1dGgiKAoUQ2hlY2tVc2VybmFtZVJlcXVlc3QSEAoIdXNlcm5hbWUYASABKAkiOwoVQ2hlY2tVc2VybmFtZVJlc3BvbnNlEhEKCWF2YWlsYWJsZRgBIAEoCBIPCgdtZXNzYWdlGAIgASgJIigKFEluaXRBbm9ueW1vdXNSZXF1ZXN0EhAKCHVzZXJuYW1lGAEgASgJIkwKFUluaXRBbm9ueW1vdXNSZXNwb25zZRINCgV0b2tlbhgBIAEoCRISCgpzZXNzaW9uX2lkGAIgASgJEhAKCHVzZXJuYW1lGAMgASgJImMKE0F1dGhlbnRpY2F0ZVJlcXVlc3QSEAoIcHJvdmlkZXIYASABKAkSEgoKY3JlZGVudGlhbBgCIAEoCRIXCgpzZXNzaW9uX2lkGAMgASgJSACIAQFCDQoLX3Nlc3Npb25faWQiZAoUQXV0aGVudGljYXRlUmVzcG9uc2USDQoFdG9rZW4YASABKAkSIwoEdXNlchgCIAEoCzIVLmFwaS52MS5lbnRpdGllcy5Vc2VyEhgKEHNlc3Npb25fbWlncmF0ZWQYAyABKAgiLAoTUmVmcmVzaFRva2VuUmVxdWVzdBIVCg1leHBpcmVkX3Rva2VuGAEgASgJIiUKFFJlZnJlc2hUb2tlblJlc3BvbnNlEg0KBXRva2VuGAEgASgJMqcDCgtBdXRoU2VydmljZRJmCg1DaGVja1VzZXJuYW1lEikuYXBpLnYxLnNlcnZpY2UuYXV0aC5DaGVja1VzZXJuYW1lUmVxdWVzdBoqLmFwaS52MS5zZXJ2aWNlLmF1dGguQ2hlY2tVc2VybmFtZVJlc3BvbnNlEmYKDUluaXRBbm9ueW1vdXMSKS5hcGkudjEuc2VydmljZS5hdXRoLkluaXRBbm9ueW1vdXNSZXF1ZXN0GiouYXBpLnYxLnNlcnZpY2UuYXV0aC5Jbml0QW5vbnltb3VzUmVzcG9uc2USYwoMQXV0aGVudGljYXRlEiguYXBpLnYxLnNlcnZpY2UuYXV0aC5BdXRoZW50aWNhdGVSZXF1ZXN0GikuYXBpLnYxLnNlcnZpY2UuYXV0aC5BdXRoZW50aWNhdGVSZXNwb25zZRJjCgxSZWZyZXNoVG9rZW4SKC5hcGkudjEuc2VydmljZS5hdXRoLlJlZnJlc2hUb2tlblJlcXVlc3QaKS5hcGkudjEuc2VydmljZS5hdXRoLlJlZnJlc2hUb2tlblJlc3BvbnNlQldaVWdpdGh1Yi5jb20vcmFkamF0aGFoZXIvYWx1bmFsdW4vYXBpL2ludGVybmFsL3Byb3RvY2dlbi92MS9hdXRoX3NlcnZpY2U7YXV0aF9zZXJ2aWNldjFiBnByb3RvMw", [file_v1_entities_user, file_google_protobuf_field_mask]);
+  fileDesc("ChV2MS9zZXJ2aWNlL2F1dGgucHJvdG8SE2FwaS52MS5zZXJ2aWNlLmF1dGgiKAoUQ2hlY2tVc2VybmFtZVJlcXVlc3QSEAoIdXNlcm5hbWUYASABKAkiOwoVQ2hlY2tVc2VybmFtZVJlc3BvbnNlEhEKCWF2YWlsYWJsZRgBIAEoCBIPCgdtZXNzYWdlGAIgASgJImAKFEluaXRBbm9ueW1vdXNSZXF1ZXN0EhAKCHVzZXJuYW1lGAEgASgJEh8KEmRldmljZV9maW5nZXJwcmludBgCIAEoCUgAiAEBQhUKE19kZXZpY2VfZmluZ2VycHJpbnQiTAoVSW5pdEFub255bW91c1Jlc3BvbnNlEg0KBXRva2VuGAEgASgJEhIKCnNlc3Npb25faWQYAiABKAkSEAoIdXNlcm5hbWUYAyABKAkiqQEKE0F1dGhlbnRpY2F0ZVJlcXVlc3QSEAoIcHJvdmlkZXIYASABKAkSEgoKY3JlZGVudGlhbBgCIAEoCRIXCgpzZXNzaW9uX2lkGAMgASgJSACIAQESRAoTdXNlcm5hbWVfcmVzb2x1dGlvbhgEIAEoDjInLmFwaS52MS5zZXJ2aWNlLmF1dGguVXNlcm5hbWVSZXNvbHV0aW9uQg0KC19zZXNzaW9uX2lkIpEBChRBdXRoZW50aWNhdGVSZXNwb25zZRINCgV0b2tlbhgBIAEoCRIjCgR1c2VyGAIgASgLMhUuYXBpLnYxLmVudGl0aWVzLlVzZXISGAoQc2Vzc2lvbl9taWdyYXRlZBgDIAEoCBIUCgxtZmFfcmVxdWlyZWQYBCABKAgSFQoNbWZhX2NoYWxsZW5nZRgFIAEoCSIsChNSZWZyZXNoVG9rZW5SZXF1ZXN0EhUKDWV4cGlyZWRfdG9rZW4YASABKAkiJQoUUmVmcmVzaFRva2VuUmVzcG9uc2USDQoFdG9rZW4YASABKAkibQoOTGlua2VkUHJvdmlkZXISEAoIcHJvdmlkZXIYASABKAkSGAoQcHJvdmlkZXJfdXNlcl9pZBgCIAEoCRISCgVlbWFpbBgDIAEoCUgAiAEBEhEKCWxpbmtlZF9hdBgEIAEoA0IICgZfZW1haWwiOwoTTGlua1Byb3ZpZGVyUmVxdWVzdBIQCghwcm92aWRlchgBIAEoCRISCgpjcmVkZW50aWFsGAIgASgJIk0KFExpbmtQcm92aWRlclJlc3BvbnNlEjUKCHByb3ZpZGVyGAEgASgLMiMuYXBpLnYxLnNlcnZpY2UuYXV0aC5MaW5rZWRQcm92aWRlciIpChVVbmxpbmtQcm92aWRlclJlcXVlc3QSEAoIcHJvdmlkZXIYASABKAkiGAoWVW5saW5rUHJvdmlkZXJSZXNwb25zZSIcChpMaXN0TGlua2VkUHJvdmlkZXJzUmVxdWVzdCJVChtMaXN0TGlua2VkUHJvdmlkZXJzUmVzcG9uc2USNgoJcHJvdmlkZXJzGAEgAygLMiMuYXBpLnYxLnNlcnZpY2UuYXV0aC5MaW5rZWRQcm92aWRlciItChxTZW5kVmVyaWZpY2F0aW9uRW1haWxSZXF1ZXN0Eg0KBWVtYWlsGAEgASgJIh8KHVNlbmRWZXJpZmljYXRpb25FbWFpbFJlc3BvbnNlIiMKElZlcmlmeUVtYWlsUmVxdWVzdBINCgV0b2tlbhgBIAEoCSI6ChNWZXJpZnlFbWFpbFJlc3BvbnNlEiMKBHVzZXIYASABKAsyFS5hcGkudjEuZW50aXRpZXMuVXNlciIsChtSZXF1ZXN0UGFzc3dvcmRSZXNldFJlcXVlc3QSDQoFZW1haWwYASABKAkiHgocUmVxdWVzdFBhc3N3b3JkUmVzZXRSZXNwb25zZSI7ChRSZXNldFBhc3N3b3JkUmVxdWVzdBINCgV0b2tlbhgBIAEoCRIUCgxuZXdfcGFzc3dvcmQYAiABKAkiFwoVUmVzZXRQYXNzd29yZFJlc3BvbnNlIhMKEUVucm9sbFRPVFBSZXF1ZXN0IjkKEkVucm9sbFRPVFBSZXNwb25zZRIOCgZzZWNyZXQYASABKAkSEwoLb3RwYXV0aF91cmkYAiABKAkiIgoSQ29uZmlybVRPVFBSZXF1ZXN0EgwKBGNvZGUYASABKAkiLQoTQ29uZmlybVRPVFBSZXNwb25zZRIWCg5yZWNvdmVyeV9jb2RlcxgBIAMoCSIiChJEaXNhYmxlVE9UUFJlcXVlc3QSDAoEY29kZRgBIAEoCSIVChNEaXNhYmxlVE9UUFJlc3BvbnNlIjkKEkNvbXBsZXRlTUZBUmVxdWVzdBIVCg1tZmFfY2hhbGxlbmdlGAEgASgJEgwKBGNvZGUYAiABKAkiYwoTQ29tcGxldGVNRkFSZXNwb25zZRINCgV0b2tlbhgBIAEoCRIjCgR1c2VyGAIgASgLMhUuYXBpLnYxLmVudGl0aWVzLlVzZXISGAoQc2Vzc2lvbl9taWdyYXRlZBgDIAEoCCJ3CgdQYXNza2V5EgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSEgoKdHJhbnNwb3J0cxgDIAMoCRISCgpjcmVhdGVkX2F0GAQgASgDEhkKDGxhc3RfdXNlZF9hdBgFIAEoA0gAiAEBQg8KDV9sYXN0X3VzZWRfYXQiIQofQmVnaW5QYXNza2V5UmVnaXN0cmF0aW9uUmVxdWVzdCJRCiBCZWdpblBhc3NrZXlSZWdpc3RyYXRpb25SZXNwb25zZRIUCgxvcHRpb25zX2pzb24YASABKAkSFwoPY2hhbGxlbmdlX3Rva2VuGAIgASgJImIKIEZpbmlzaFBhc3NrZXlSZWdpc3RyYXRpb25SZXF1ZXN0EhcKD2NoYWxsZW5nZV90b2tlbhgBIAEoCRIXCg9jcmVkZW50aWFsX2pzb24YAiABKAkSDAoEbmFtZRgDIAEoCSJSCiFGaW5pc2hQYXNza2V5UmVnaXN0cmF0aW9uUmVzcG9uc2USLQoHcGFzc2tleRgBIAEoCzIcLmFwaS52MS5zZXJ2aWNlLmF1dGguUGFzc2tleSIaChhCZWdpblBhc3NrZXlMb2dpblJlcXVlc3QiSgoZQmVnaW5QYXNza2V5TG9naW5SZXNwb25zZRIUCgxvcHRpb25zX2pzb24YASABKAkSFwoPY2hhbGxlbmdlX3Rva2VuGAIgASgJIhUKE0xpc3RQYXNza2V5c1JlcXVlc3QiRgoUTGlzdFBhc3NrZXlzUmVzcG9uc2USLgoIcGFzc2tleXMYASADKAsyHC5hcGkudjEuc2VydmljZS5hdXRoLlBhc3NrZXkiIgoURGVsZXRlUGFzc2tleVJlcXVlc3QSCgoCaWQYASABKAkiFwoVRGVsZXRlUGFzc2tleVJlc3BvbnNlIjEKEEdyYW50Um9sZVJlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCRIMCgRyb2xlGAIgASgJIiIKEUdyYW50Um9sZVJlc3BvbnNlEg0KBXJvbGVzGAEgAygJIjIKEVJldm9rZVJvbGVSZXF1ZXN0Eg8KB3VzZXJfaWQYASABKAkSDAoEcm9sZRgCIAEoCSIjChJSZXZva2VSb2xlUmVzcG9uc2USDQoFcm9sZXMYASADKAkqigEKElVzZXJuYW1lUmVzb2x1dGlvbhIjCh9VU0VSTkFNRV9SRVNPTFVUSU9OX1VOU1BFQ0lGSUVEEAASJwojVVNFUk5BTUVfUkVTT0xVVElPTl9LRUVQX1JFR0lTVEVSRUQQARImCiJVU0VSTkFNRV9SRVNPTFVUSU9OX0tFRVBfQU5PTllNT1VTEAIy0BIKC0F1dGhTZXJ2aWNlEmYKDUNoZWNrVXNlcm5hbWUSKS5hcGkudjEuc2VydmljZS5hdXRoLkNoZWNrVXNlcm5hbWVSZXF1ZXN0GiouYXBpLnYxLnNlcnZpY2UuYXV0aC5DaGVja1VzZXJuYW1lUmVzcG9uc2USZgoNSW5pdEFub255bW91cxIpLmFwaS52MS5zZXJ2aWNlLmF1dGguSW5pdEFub255bW91c1JlcXVlc3QaKi5hcGkudjEuc2VydmljZS5hdXRoLkluaXRBbm9ueW1vdXNSZXNwb25zZRJjCgxBdXRoZW50aWNhdGUSKC5hcGkudjEuc2VydmljZS5hdXRoLkF1dGhlbnRpY2F0ZVJlcXVlc3QaKS5hcGkudjEuc2VydmljZS5hdXRoLkF1dGhlbnRpY2F0ZVJlc3BvbnNlEmMKDFJlZnJlc2hUb2tlbhIoLmFwaS52MS5zZXJ2aWNlLmF1dGguUmVmcmVzaFRva2VuUmVxdWVzdBopLmFwaS52MS5zZXJ2aWNlLmF1dGguUmVmcmVzaFRva2VuUmVzcG9uc2USYwoMTGlua1Byb3ZpZGVyEiguYXBpLnYxLnNlcnZpY2UuYXV0aC5MaW5rUHJvdmlkZXJSZXF1ZXN0GikuYXBpLnYxLnNlcnZpY2UuYXV0aC5MaW5rUHJvdmlkZXJSZXNwb25zZRJpCg5VbmxpbmtQcm92aWRlchIqLmFwaS52MS5zZXJ2aWNlLmF1dGguVW5saW5rUHJvdmlkZXJSZXF1ZXN0GisuYXBpLnYxLnNlcnZpY2UuYXV0aC5VbmxpbmtQcm92aWRlclJlc3BvbnNlEngKE0xpc3RMaW5rZWRQcm92aWRlcnMSLy5hcGkudjEuc2VydmljZS5hdXRoLkxpc3RMaW5rZWRQcm92aWRlcnNSZXF1ZXN0GjAuYXBpLnYxLnNlcnZpY2UuYXV0aC5MaXN0TGlua2VkUHJvdmlkZXJzUmVzcG9uc2USfgoVU2VuZFZlcmlmaWNhdGlvbkVtYWlsEjEuYXBpLnYxLnNlcnZpY2UuYXV0aC5TZW5kVmVyaWZpY2F0aW9uRW1haWxSZXF1ZXN0GjIuYXBpLnYxLnNlcnZpY2UuYXV0aC5TZW5kVmVyaWZpY2F0aW9uRW1haWxSZXNwb25zZRJgCgtWZXJpZnlFbWFpbBInLmFwaS52MS5zZXJ2aWNlLmF1dGguVmVyaWZ5RW1haWxSZXF1ZXN0GiguYXBpLnYxLnNlcnZpY2UuYXV0aC5WZXJpZnlFbWFpbFJlc3BvbnNlEnsKFFJlcXVlc3RQYXNzd29yZFJlc2V0EjAuYXBpLnYxLnNlcnZpY2UuYXV0aC5SZXF1ZXN0UGFzc3dvcmRSZXNldFJlcXVlc3QaMS5hcGkudjEuc2VydmljZS5hdXRoLlJlcXVlc3RQYXNzd29yZFJlc2V0UmVzcG9uc2USZgoNUmVzZXRQYXNzd29yZBIpLmFwaS52MS5zZXJ2aWNlLmF1dGguUmVzZXRQYXNzd29yZFJlcXVlc3QaKi5hcGkudjEuc2VydmljZS5hdXRoLlJlc2V0UGFzc3dvcmRSZXNwb25zZRJdCgpFbnJvbGxUT1RQEiYuYXBpLnYxLnNlcnZpY2UuYXV0aC5FbnJvbGxUT1RQUmVxdWVzdBonLmFwaS52MS5zZXJ2aWNlLmF1dGguRW5yb2xsVE9UUFJlc3BvbnNlEmAKC0NvbmZpcm1UT1RQEicuYXBpLnYxLnNlcnZpY2UuYXV0aC5Db25maXJtVE9UUFJlcXVlc3QaKC5hcGkudjEuc2VydmljZS5hdXRoLkNvbmZpcm1UT1RQUmVzcG9uc2USYAoLRGlzYWJsZVRPVFASJy5hcGkudjEuc2VydmljZS5hdXRoLkRpc2FibGVUT1RQUmVxdWVzdBooLmFwaS52MS5zZXJ2aWNlLmF1dGguRGlzYWJsZVRPVFBSZXNwb25zZRJgCgtDb21wbGV0ZU1GQRInLmFwaS52MS5zZXJ2aWNlLmF1dGguQ29tcGxldGVNRkFSZXF1ZXN0GiguYXBpLnYxLnNlcnZpY2UuYXV0aC5Db21wbGV0ZU1GQVJlc3BvbnNlEocBChhCZWdpblBhc3NrZXlSZWdpc3RyYXRpb24SNC5hcGkudjEuc2VydmljZS5hdXRoLkJlZ2luUGFzc2tleVJlZ2lzdHJhdGlvblJlcXVlc3QaNS5hcGkudjEuc2VydmljZS5hdXRoLkJlZ2luUGFzc2tleVJlZ2lzdHJhdGlvblJlc3BvbnNlEooBChlGaW5pc2hQYXNza2V5UmVnaXN0cmF0aW9uEjUuYXBpLnYxLnNlcnZpY2UuYXV0aC5GaW5pc2hQYXNza2V5UmVnaXN0cmF0aW9uUmVxdWVzdBo2LmFwaS52MS5zZXJ2aWNlLmF1dGguRmluaXNoUGFzc2tleVJlZ2lzdHJhdGlvblJlc3BvbnNlEnIKEUJlZ2luUGFzc2tleUxvZ2luEi0uYXBpLnYxLnNlcnZpY2UuYXV0aC5CZWdpblBhc3NrZXlMb2dpblJlcXVlc3QaLi5hcGkudjEuc2VydmljZS5hdXRoLkJlZ2luUGFzc2tleUxvZ2luUmVzcG9uc2USYwoMTGlzdFBhc3NrZXlzEiguYXBpLnYxLnNlcnZpY2UuYXV0aC5MaXN0UGFzc2tleXNSZXF1ZXN0GikuYXBpLnYxLnNlcnZpY2UuYXV0aC5MaXN0UGFzc2tleXNSZXNwb25zZRJmCg1EZWxldGVQYXNza2V5EikuYXBpLnYxLnNlcnZpY2UuYXV0aC5EZWxldGVQYXNza2V5UmVxdWVzdBoqLmFwaS52MS5zZXJ2aWNlLmF1dGguRGVsZXRlUGFzc2tleVJlc3BvbnNlEloKCUdyYW50Um9sZRIlLmFwaS52MS5zZXJ2aWNlLmF1dGguR3JhbnRSb2xlUmVxdWVzdBomLmFwaS52MS5zZXJ2aWNlLmF1dGguR3JhbnRSb2xlUmVzcG9uc2USXQoKUmV2b2tlUm9sZRImLmFwaS52MS5zZXJ2aWNlLmF1dGguUmV2b2tlUm9sZVJlcXVlc3QaJy5hcGkudjEuc2VydmljZS5hdXRoLlJldm9rZVJvbGVSZXNwb25zZUJXWlVnaXRodWIuY29tL3JhZGphdGhhaGVyL2FsdW5hbHVuL2FwaS9pbnRlcm5hbC9wcm90b2NnZW4vdjEvYXV0aF9zZXJ2aWNlO2F1dGhfc2VydmljZXYxYgZwcm90bzM", [file_v1_entities_user, file_google_protobuf_field_mask]);
 
 /**
  * CheckUsernameRequest checks availability
@@ -70,6 +70,13 @@ export type InitAnonymousRequest = Message<"api.v1.service.auth.InitAnonymousReq
    * @generated from field: string username = 1;
    */
   username: string;
+
+  /**
+   * Stable device identifier, used to cap anonymous accounts per device
+   *
+   * @generated from field: optional string device_fingerprint = 2;
+   */
+  deviceFingerprint?: string;
 };
 
 /**
@@ -86,7 +93,7 @@ export const InitAnonymousRequestSchema: GenMessage<InitAnonymousRequest> = /*@_
  */
 export type InitAnonymousResponse = Message<"api.v1.service.auth.InitAnonymousResponse"> & {
   /**
-   * JWT with no expiration
+   * JWT renewed on activity (see x-renewed-token)
    *
    * @generated from field: string token = 1;
    */
@@ -121,14 +128,14 @@ export const InitAnonymousResponseSchema: GenMessage<InitAnonymousResponse> = /*
  */
 export type AuthenticateRequest = Message<"api.v1.service.auth.AuthenticateRequest"> & {
   /**
-   * "google", "magic_link", etc
+   * "google", "magic_link", "passkey", etc
    *
    * @generated from field: string provider = 1;
    */
   provider: string;
 
   /**
-   * ID token, magic token, etc
+   * ID token, magic token, passkey assertion JSON, etc
    *
    * @generated from field: string credential = 2;
    */
@@ -140,6 +147,13 @@ export type AuthenticateRequest = Message<"api.v1.service.auth.AuthenticateReque
    * @generated from field: optional string session_id = 3;
    */
   sessionId?: string;
+
+  /**
+   * Required when merging into an account with a different username
+   *
+   * @generated from field: api.v1.service.auth.UsernameResolution username_resolution = 4;
+   */
+  usernameResolution: UsernameResolution;
 };
 
 /**
@@ -173,6 +187,20 @@ export type AuthenticateResponse = Message<"api.v1.service.auth.AuthenticateResp
    * @generated from field: bool session_migrated = 3;
    */
   sessionMigrated: boolean;
+
+  /**
+   * Token and user are empty; call CompleteMFA
+   *
+   * @generated from field: bool mfa_required = 4;
+   */
+  mfaRequired: boolean;
+
+  /**
+   * Short-lived challenge for CompleteMFA
+   *
+   * @generated from field: string mfa_challenge = 5;
+   */
+  mfaChallenge: string;
 };
 
 /**
@@ -225,50 +253,1089 @@ export const RefreshTokenResponseSchema: GenMessage<RefreshTokenResponse> = /*@_
   messageDesc(file_v1_service_auth, 7);
 
 /**
- * AuthService handles authentication flows
+ * LinkedProvider is a login method attached to a user
  *
- * @generated from service api.v1.service.auth.AuthService
+ * @generated from message api.v1.service.auth.LinkedProvider
  */
-export const AuthService: GenService<{
+export type LinkedProvider = Message<"api.v1.service.auth.LinkedProvider"> & {
   /**
-   * Check if username is available
+   * "google", "apple", "email", etc
    *
-   * @generated from rpc api.v1.service.auth.AuthService.CheckUsername
+   * @generated from field: string provider = 1;
    */
-  checkUsername: {
-    methodKind: "unary";
-    input: typeof CheckUsernameRequestSchema;
-    output: typeof CheckUsernameResponseSchema;
-  },
+  provider: string;
+
   /**
-   * Initialize anonymous session (generates session_id + token)
+   * Subject at the provider
    *
-   * @generated from rpc api.v1.service.auth.AuthService.InitAnonymous
+   * @generated from field: string provider_user_id = 2;
    */
-  initAnonymous: {
-    methodKind: "unary";
-    input: typeof InitAnonymousRequestSchema;
-    output: typeof InitAnonymousResponseSchema;
-  },
+  providerUserId: string;
+
   /**
-   * Authenticate with provider (Google, magic link, etc)
+   * Email reported by the provider
    *
-   * @generated from rpc api.v1.service.auth.AuthService.Authenticate
+   * @generated from field: optional string email = 3;
    */
-  authenticate: {
-    methodKind: "unary";
-    input: typeof AuthenticateRequestSchema;
-    output: typeof AuthenticateResponseSchema;
-  },
+  email?: string;
+
   /**
-   * Refresh authenticated tokens (anonymous tokens never expire)
+   * Unix timestamp
    *
-   * @generated from rpc api.v1.service.auth.AuthService.RefreshToken
+   * @generated from field: int64 linked_at = 4;
    */
-  refreshToken: {
-    methodKind: "unary";
-    input: typeof RefreshTokenRequestSchema;
-    output: typeof RefreshTokenResponseSchema;
+  linkedAt: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.auth.LinkedProvider.
+ * Use `create(LinkedProviderSchema)` to create a new message.
+ */
+export const LinkedProviderSchema: GenMessage<LinkedProvider> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 8);
+
+/**
+ * LinkProviderRequest links a provider using a fresh authentication
+ *
+ * @generated from message api.v1.service.auth.LinkProviderRequest
+ */
+export type LinkProviderRequest = Message<"api.v1.service.auth.LinkProviderRequest"> & {
+  /**
+   * Provider to link
+   *
+   * @generated from field: string provider = 1;
+   */
+  provider: string;
+
+  /**
+   * Fresh credential for that provider (ID token, code, etc)
+   *
+   * @generated from field: string credential = 2;
+   */
+  credential: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.LinkProviderRequest.
+ * Use `create(LinkProviderRequestSchema)` to create a new message.
+ */
+export const LinkProviderRequestSchema: GenMessage<LinkProviderRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 9);
+
+/**
+ * LinkProviderResponse returns the new link
+ *
+ * @generated from message api.v1.service.auth.LinkProviderResponse
+ */
+export type LinkProviderResponse = Message<"api.v1.service.auth.LinkProviderResponse"> & {
+  /**
+   * @generated from field: api.v1.service.auth.LinkedProvider provider = 1;
+   */
+  provider?: LinkedProvider;
+};
+
+/**
+ * Describes the message api.v1.service.auth.LinkProviderResponse.
+ * Use `create(LinkProviderResponseSchema)` to create a new message.
+ */
+export const LinkProviderResponseSchema: GenMessage<LinkProviderResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 10);
+
+/**
+ * UnlinkProviderRequest removes a provider
+ *
+ * @generated from message api.v1.service.auth.UnlinkProviderRequest
+ */
+export type UnlinkProviderRequest = Message<"api.v1.service.auth.UnlinkProviderRequest"> & {
+  /**
+   * @generated from field: string provider = 1;
+   */
+  provider: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.UnlinkProviderRequest.
+ * Use `create(UnlinkProviderRequestSchema)` to create a new message.
+ */
+export const UnlinkProviderRequestSchema: GenMessage<UnlinkProviderRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 11);
+
+/**
+ * UnlinkProviderResponse is empty on success
+ *
+ * @generated from message api.v1.service.auth.UnlinkProviderResponse
+ */
+export type UnlinkProviderResponse = Message<"api.v1.service.auth.UnlinkProviderResponse"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.UnlinkProviderResponse.
+ * Use `create(UnlinkProviderResponseSchema)` to create a new message.
+ */
+export const UnlinkProviderResponseSchema: GenMessage<UnlinkProviderResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 12);
+
+/**
+ * ListLinkedProvidersRequest lists the caller's providers
+ *
+ * @generated from message api.v1.service.auth.ListLinkedProvidersRequest
+ */
+export type ListLinkedProvidersRequest = Message<"api.v1.service.auth.ListLinkedProvidersRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.ListLinkedProvidersRequest.
+ * Use `create(ListLinkedProvidersRequestSchema)` to create a new message.
+ */
+export const ListLinkedProvidersRequestSchema: GenMessage<ListLinkedProvidersRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 13);
+
+/**
+ * ListLinkedProvidersResponse returns linked providers
+ *
+ * @generated from message api.v1.service.auth.ListLinkedProvidersResponse
+ */
+export type ListLinkedProvidersResponse = Message<"api.v1.service.auth.ListLinkedProvidersResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.service.auth.LinkedProvider providers = 1;
+   */
+  providers: LinkedProvider[];
+};
+
+/**
+ * Describes the message api.v1.service.auth.ListLinkedProvidersResponse.
+ * Use `create(ListLinkedProvidersResponseSchema)` to create a new message.
+ */
+export const ListLinkedProvidersResponseSchema: GenMessage<ListLinkedProvidersResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 14);
+
+/**
+ * SendVerificationEmailRequest requests a verification link
+ *
+ * @generated from message api.v1.service.auth.SendVerificationEmailRequest
+ */
+export type SendVerificationEmailRequest = Message<"api.v1.service.auth.SendVerificationEmailRequest"> & {
+  /**
+   * Optional when authenticated; defaults to the caller's email
+   *
+   * @generated from field: string email = 1;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.SendVerificationEmailRequest.
+ * Use `create(SendVerificationEmailRequestSchema)` to create a new message.
+ */
+export const SendVerificationEmailRequestSchema: GenMessage<SendVerificationEmailRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 15);
+
+/**
+ * SendVerificationEmailResponse is empty on success
+ *
+ * @generated from message api.v1.service.auth.SendVerificationEmailResponse
+ */
+export type SendVerificationEmailResponse = Message<"api.v1.service.auth.SendVerificationEmailResponse"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.SendVerificationEmailResponse.
+ * Use `create(SendVerificationEmailResponseSchema)` to create a new message.
+ */
+export const SendVerificationEmailResponseSchema: GenMessage<SendVerificationEmailResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 16);
+
+/**
+ * VerifyEmailRequest verifies an email address
+ *
+ * @generated from message api.v1.service.auth.VerifyEmailRequest
+ */
+export type VerifyEmailRequest = Message<"api.v1.service.auth.VerifyEmailRequest"> & {
+  /**
+   * Token from the verification link
+   *
+   * @generated from field: string token = 1;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.VerifyEmailRequest.
+ * Use `create(VerifyEmailRequestSchema)` to create a new message.
+ */
+export const VerifyEmailRequestSchema: GenMessage<VerifyEmailRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 17);
+
+/**
+ * VerifyEmailResponse returns the verified user
+ *
+ * @generated from message api.v1.service.auth.VerifyEmailResponse
+ */
+export type VerifyEmailResponse = Message<"api.v1.service.auth.VerifyEmailResponse"> & {
+  /**
+   * @generated from field: api.v1.entities.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message api.v1.service.auth.VerifyEmailResponse.
+ * Use `create(VerifyEmailResponseSchema)` to create a new message.
+ */
+export const VerifyEmailResponseSchema: GenMessage<VerifyEmailResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 18);
+
+/**
+ * RequestPasswordResetRequest requests a password reset link
+ *
+ * @generated from message api.v1.service.auth.RequestPasswordResetRequest
+ */
+export type RequestPasswordResetRequest = Message<"api.v1.service.auth.RequestPasswordResetRequest"> & {
+  /**
+   * @generated from field: string email = 1;
+   */
+  email: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.RequestPasswordResetRequest.
+ * Use `create(RequestPasswordResetRequestSchema)` to create a new message.
+ */
+export const RequestPasswordResetRequestSchema: GenMessage<RequestPasswordResetRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 19);
+
+/**
+ * RequestPasswordResetResponse is empty; it is the same whether or not the email exists
+ *
+ * @generated from message api.v1.service.auth.RequestPasswordResetResponse
+ */
+export type RequestPasswordResetResponse = Message<"api.v1.service.auth.RequestPasswordResetResponse"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.RequestPasswordResetResponse.
+ * Use `create(RequestPasswordResetResponseSchema)` to create a new message.
+ */
+export const RequestPasswordResetResponseSchema: GenMessage<RequestPasswordResetResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 20);
+
+/**
+ * ResetPasswordRequest sets a new password
+ *
+ * @generated from message api.v1.service.auth.ResetPasswordRequest
+ */
+export type ResetPasswordRequest = Message<"api.v1.service.auth.ResetPasswordRequest"> & {
+  /**
+   * Token from the reset link
+   *
+   * @generated from field: string token = 1;
+   */
+  token: string;
+
+  /**
+   * @generated from field: string new_password = 2;
+   */
+  newPassword: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.ResetPasswordRequest.
+ * Use `create(ResetPasswordRequestSchema)` to create a new message.
+ */
+export const ResetPasswordRequestSchema: GenMessage<ResetPasswordRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 21);
+
+/**
+ * ResetPasswordResponse is empty on success
+ *
+ * @generated from message api.v1.service.auth.ResetPasswordResponse
+ */
+export type ResetPasswordResponse = Message<"api.v1.service.auth.ResetPasswordResponse"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.ResetPasswordResponse.
+ * Use `create(ResetPasswordResponseSchema)` to create a new message.
+ */
+export const ResetPasswordResponseSchema: GenMessage<ResetPasswordResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 22);
+
+/**
+ * EnrollTOTPRequest starts TOTP enrollment for the caller
+ *
+ * @generated from message api.v1.service.auth.EnrollTOTPRequest
+ */
+export type EnrollTOTPRequest = Message<"api.v1.service.auth.EnrollTOTPRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.EnrollTOTPRequest.
+ * Use `create(EnrollTOTPRequestSchema)` to create a new message.
+ */
+export const EnrollTOTPRequestSchema: GenMessage<EnrollTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 23);
+
+/**
+ * EnrollTOTPResponse returns the new secret
+ *
+ * @generated from message api.v1.service.auth.EnrollTOTPResponse
+ */
+export type EnrollTOTPResponse = Message<"api.v1.service.auth.EnrollTOTPResponse"> & {
+  /**
+   * Base32 secret for manual entry
+   *
+   * @generated from field: string secret = 1;
+   */
+  secret: string;
+
+  /**
+   * otpauth:// URI for QR codes
+   *
+   * @generated from field: string otpauth_uri = 2;
+   */
+  otpauthUri: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.EnrollTOTPResponse.
+ * Use `create(EnrollTOTPResponseSchema)` to create a new message.
+ */
+export const EnrollTOTPResponseSchema: GenMessage<EnrollTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 24);
+
+/**
+ * ConfirmTOTPRequest confirms enrollment
+ *
+ * @generated from message api.v1.service.auth.ConfirmTOTPRequest
+ */
+export type ConfirmTOTPRequest = Message<"api.v1.service.auth.ConfirmTOTPRequest"> & {
+  /**
+   * Current code from the authenticator app
+   *
+   * @generated from field: string code = 1;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.ConfirmTOTPRequest.
+ * Use `create(ConfirmTOTPRequestSchema)` to create a new message.
+ */
+export const ConfirmTOTPRequestSchema: GenMessage<ConfirmTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 25);
+
+/**
+ * ConfirmTOTPResponse returns recovery codes; they are shown only once
+ *
+ * @generated from message api.v1.service.auth.ConfirmTOTPResponse
+ */
+export type ConfirmTOTPResponse = Message<"api.v1.service.auth.ConfirmTOTPResponse"> & {
+  /**
+   * @generated from field: repeated string recovery_codes = 1;
+   */
+  recoveryCodes: string[];
+};
+
+/**
+ * Describes the message api.v1.service.auth.ConfirmTOTPResponse.
+ * Use `create(ConfirmTOTPResponseSchema)` to create a new message.
+ */
+export const ConfirmTOTPResponseSchema: GenMessage<ConfirmTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 26);
+
+/**
+ * DisableTOTPRequest turns off TOTP
+ *
+ * @generated from message api.v1.service.auth.DisableTOTPRequest
+ */
+export type DisableTOTPRequest = Message<"api.v1.service.auth.DisableTOTPRequest"> & {
+  /**
+   * Current TOTP code or a recovery code
+   *
+   * @generated from field: string code = 1;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.DisableTOTPRequest.
+ * Use `create(DisableTOTPRequestSchema)` to create a new message.
+ */
+export const DisableTOTPRequestSchema: GenMessage<DisableTOTPRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 27);
+
+/**
+ * DisableTOTPResponse is empty on success
+ *
+ * @generated from message api.v1.service.auth.DisableTOTPResponse
+ */
+export type DisableTOTPResponse = Message<"api.v1.service.auth.DisableTOTPResponse"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.DisableTOTPResponse.
+ * Use `create(DisableTOTPResponseSchema)` to create a new message.
+ */
+export const DisableTOTPResponseSchema: GenMessage<DisableTOTPResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 28);
+
+/**
+ * CompleteMFARequest finishes a two-factor sign-in
+ *
+ * @generated from message api.v1.service.auth.CompleteMFARequest
+ */
+export type CompleteMFARequest = Message<"api.v1.service.auth.CompleteMFARequest"> & {
+  /**
+   * From AuthenticateResponse
+   *
+   * @generated from field: string mfa_challenge = 1;
+   */
+  mfaChallenge: string;
+
+  /**
+   * TOTP code or a recovery code
+   *
+   * @generated from field: string code = 2;
+   */
+  code: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.CompleteMFARequest.
+ * Use `create(CompleteMFARequestSchema)` to create a new message.
+ */
+export const CompleteMFARequestSchema: GenMessage<CompleteMFARequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 29);
+
+/**
+ * CompleteMFAResponse returns the auth token
+ *
+ * @generated from message api.v1.service.auth.CompleteMFAResponse
+ */
+export type CompleteMFAResponse = Message<"api.v1.service.auth.CompleteMFAResponse"> & {
+  /**
+   * @generated from field: string token = 1;
+   */
+  token: string;
+
+  /**
+   * @generated from field: api.v1.entities.User user = 2;
+   */
+  user?: User;
+
+  /**
+   * @generated from field: bool session_migrated = 3;
+   */
+  sessionMigrated: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.auth.CompleteMFAResponse.
+ * Use `create(CompleteMFAResponseSchema)` to create a new message.
+ */
+export const CompleteMFAResponseSchema: GenMessage<CompleteMFAResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 30);
+
+/**
+ * Passkey is a WebAuthn credential registered to a user
+ *
+ * @generated from message api.v1.service.auth.Passkey
+ */
+export type Passkey = Message<"api.v1.service.auth.Passkey"> & {
+  /**
+   * base64url credential ID
+   *
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * "internal", "hybrid", "usb", etc
+   *
+   * @generated from field: repeated string transports = 3;
+   */
+  transports: string[];
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 created_at = 4;
+   */
+  createdAt: bigint;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: optional int64 last_used_at = 5;
+   */
+  lastUsedAt?: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.auth.Passkey.
+ * Use `create(PasskeySchema)` to create a new message.
+ */
+export const PasskeySchema: GenMessage<Passkey> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 31);
+
+/**
+ * BeginPasskeyRegistrationRequest starts passkey registration
+ *
+ * @generated from message api.v1.service.auth.BeginPasskeyRegistrationRequest
+ */
+export type BeginPasskeyRegistrationRequest = Message<"api.v1.service.auth.BeginPasskeyRegistrationRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.BeginPasskeyRegistrationRequest.
+ * Use `create(BeginPasskeyRegistrationRequestSchema)` to create a new message.
+ */
+export const BeginPasskeyRegistrationRequestSchema: GenMessage<BeginPasskeyRegistrationRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 32);
+
+/**
+ * BeginPasskeyRegistrationResponse returns creation options
+ *
+ * @generated from message api.v1.service.auth.BeginPasskeyRegistrationResponse
+ */
+export type BeginPasskeyRegistrationResponse = Message<"api.v1.service.auth.BeginPasskeyRegistrationResponse"> & {
+  /**
+   * PublicKeyCredentialCreationOptionsJSON for navigator.credentials.create
+   *
+   * @generated from field: string options_json = 1;
+   */
+  optionsJson: string;
+
+  /**
+   * Pass back to FinishPasskeyRegistration
+   *
+   * @generated from field: string challenge_token = 2;
+   */
+  challengeToken: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.BeginPasskeyRegistrationResponse.
+ * Use `create(BeginPasskeyRegistrationResponseSchema)` to create a new message.
+ */
+export const BeginPasskeyRegistrationResponseSchema: GenMessage<BeginPasskeyRegistrationResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 33);
+
+/**
+ * FinishPasskeyRegistrationRequest completes passkey registration
+ *
+ * @generated from message api.v1.service.auth.FinishPasskeyRegistrationRequest
+ */
+export type FinishPasskeyRegistrationRequest = Message<"api.v1.service.auth.FinishPasskeyRegistrationRequest"> & {
+  /**
+   * @generated from field: string challenge_token = 1;
+   */
+  challengeToken: string;
+
+  /**
+   * PublicKeyCredential.toJSON() of the new credential
+   *
+   * @generated from field: string credential_json = 2;
+   */
+  credentialJson: string;
+
+  /**
+   * Optional label, e.g. "iPhone"
+   *
+   * @generated from field: string name = 3;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.FinishPasskeyRegistrationRequest.
+ * Use `create(FinishPasskeyRegistrationRequestSchema)` to create a new message.
+ */
+export const FinishPasskeyRegistrationRequestSchema: GenMessage<FinishPasskeyRegistrationRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 34);
+
+/**
+ * FinishPasskeyRegistrationResponse returns the stored passkey
+ *
+ * @generated from message api.v1.service.auth.FinishPasskeyRegistrationResponse
+ */
+export type FinishPasskeyRegistrationResponse = Message<"api.v1.service.auth.FinishPasskeyRegistrationResponse"> & {
+  /**
+   * @generated from field: api.v1.service.auth.Passkey passkey = 1;
+   */
+  passkey?: Passkey;
+};
+
+/**
+ * Describes the message api.v1.service.auth.FinishPasskeyRegistrationResponse.
+ * Use `create(FinishPasskeyRegistrationResponseSchema)` to create a new message.
+ */
+export const FinishPasskeyRegistrationResponseSchema: GenMessage<FinishPasskeyRegistrationResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 35);
+
+/**
+ * BeginPasskeyLoginRequest starts a passkey sign-in
+ *
+ * @generated from message api.v1.service.auth.BeginPasskeyLoginRequest
+ */
+export type BeginPasskeyLoginRequest = Message<"api.v1.service.auth.BeginPasskeyLoginRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.BeginPasskeyLoginRequest.
+ * Use `create(BeginPasskeyLoginRequestSchema)` to create a new message.
+ */
+export const BeginPasskeyLoginRequestSchema: GenMessage<BeginPasskeyLoginRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 36);
+
+/**
+ * BeginPasskeyLoginResponse returns request options. Authenticate with provider
+ * "passkey" and credential {"challenge_token": ..., "credential": PublicKeyCredential.toJSON()}
+ *
+ * @generated from message api.v1.service.auth.BeginPasskeyLoginResponse
+ */
+export type BeginPasskeyLoginResponse = Message<"api.v1.service.auth.BeginPasskeyLoginResponse"> & {
+  /**
+   * PublicKeyCredentialRequestOptionsJSON for navigator.credentials.get
+   *
+   * @generated from field: string options_json = 1;
+   */
+  optionsJson: string;
+
+  /**
+   * @generated from field: string challenge_token = 2;
+   */
+  challengeToken: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.BeginPasskeyLoginResponse.
+ * Use `create(BeginPasskeyLoginResponseSchema)` to create a new message.
+ */
+export const BeginPasskeyLoginResponseSchema: GenMessage<BeginPasskeyLoginResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 37);
+
+/**
+ * ListPasskeysRequest lists the caller's passkeys
+ *
+ * @generated from message api.v1.service.auth.ListPasskeysRequest
+ */
+export type ListPasskeysRequest = Message<"api.v1.service.auth.ListPasskeysRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.ListPasskeysRequest.
+ * Use `create(ListPasskeysRequestSchema)` to create a new message.
+ */
+export const ListPasskeysRequestSchema: GenMessage<ListPasskeysRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 38);
+
+/**
+ * ListPasskeysResponse returns passkeys, newest first
+ *
+ * @generated from message api.v1.service.auth.ListPasskeysResponse
+ */
+export type ListPasskeysResponse = Message<"api.v1.service.auth.ListPasskeysResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.service.auth.Passkey passkeys = 1;
+   */
+  passkeys: Passkey[];
+};
+
+/**
+ * Describes the message api.v1.service.auth.ListPasskeysResponse.
+ * Use `create(ListPasskeysResponseSchema)` to create a new message.
+ */
+export const ListPasskeysResponseSchema: GenMessage<ListPasskeysResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 39);
+
+/**
+ * DeletePasskeyRequest removes a passkey
+ *
+ * @generated from message api.v1.service.auth.DeletePasskeyRequest
+ */
+export type DeletePasskeyRequest = Message<"api.v1.service.auth.DeletePasskeyRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.DeletePasskeyRequest.
+ * Use `create(DeletePasskeyRequestSchema)` to create a new message.
+ */
+export const DeletePasskeyRequestSchema: GenMessage<DeletePasskeyRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 40);
+
+/**
+ * DeletePasskeyResponse is empty on success
+ *
+ * @generated from message api.v1.service.auth.DeletePasskeyResponse
+ */
+export type DeletePasskeyResponse = Message<"api.v1.service.auth.DeletePasskeyResponse"> & {
+};
+
+/**
+ * Describes the message api.v1.service.auth.DeletePasskeyResponse.
+ * Use `create(DeletePasskeyResponseSchema)` to create a new message.
+ */
+export const DeletePasskeyResponseSchema: GenMessage<DeletePasskeyResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 41);
+
+/**
+ * GrantRoleRequest grants an elevated role
+ *
+ * @generated from message api.v1.service.auth.GrantRoleRequest
+ */
+export type GrantRoleRequest = Message<"api.v1.service.auth.GrantRoleRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * "moderator" or "admin"
+   *
+   * @generated from field: string role = 2;
+   */
+  role: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.GrantRoleRequest.
+ * Use `create(GrantRoleRequestSchema)` to create a new message.
+ */
+export const GrantRoleRequestSchema: GenMessage<GrantRoleRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 42);
+
+/**
+ * GrantRoleResponse returns the user's elevated roles
+ *
+ * @generated from message api.v1.service.auth.GrantRoleResponse
+ */
+export type GrantRoleResponse = Message<"api.v1.service.auth.GrantRoleResponse"> & {
+  /**
+   * @generated from field: repeated string roles = 1;
+   */
+  roles: string[];
+};
+
+/**
+ * Describes the message api.v1.service.auth.GrantRoleResponse.
+ * Use `create(GrantRoleResponseSchema)` to create a new message.
+ */
+export const GrantRoleResponseSchema: GenMessage<GrantRoleResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 43);
+
+/**
+ * RevokeRoleRequest revokes an elevated role
+ *
+ * @generated from message api.v1.service.auth.RevokeRoleRequest
+ */
+export type RevokeRoleRequest = Message<"api.v1.service.auth.RevokeRoleRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * @generated from field: string role = 2;
+   */
+  role: string;
+};
+
+/**
+ * Describes the message api.v1.service.auth.RevokeRoleRequest.
+ * Use `create(RevokeRoleRequestSchema)` to create a new message.
+ */
+export const RevokeRoleRequestSchema: GenMessage<RevokeRoleRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 44);
+
+/**
+ * RevokeRoleResponse returns the user's remaining elevated roles
+ *
+ * @generated from message api.v1.service.auth.RevokeRoleResponse
+ */
+export type RevokeRoleResponse = Message<"api.v1.service.auth.RevokeRoleResponse"> & {
+  /**
+   * @generated from field: repeated string roles = 1;
+   */
+  roles: string[];
+};
+
+/**
+ * Describes the message api.v1.service.auth.RevokeRoleResponse.
+ * Use `create(RevokeRoleResponseSchema)` to create a new message.
+ */
+export const RevokeRoleResponseSchema: GenMessage<RevokeRoleResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_auth, 45);
+
+/**
+ * UsernameResolution picks the username kept when an anonymous account
+ * is merged into an existing registered account
+ *
+ * @generated from enum api.v1.service.auth.UsernameResolution
+ */
+export enum UsernameResolution {
+  /**
+   * Fail if the usernames differ
+   *
+   * @generated from enum value: USERNAME_RESOLUTION_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Keep the registered account's username
+   *
+   * @generated from enum value: USERNAME_RESOLUTION_KEEP_REGISTERED = 1;
+   */
+  KEEP_REGISTERED = 1,
+
+  /**
+   * Move the anonymous username to the registered account
+   *
+   * @generated from enum value: USERNAME_RESOLUTION_KEEP_ANONYMOUS = 2;
+   */
+  KEEP_ANONYMOUS = 2,
+}
+
+/**
+ * Describes the enum api.v1.service.auth.UsernameResolution.
+ */
+export const UsernameResolutionSchema: GenEnum<UsernameResolution> = /*@__PURE__*/
+  enumDesc(file_v1_service_auth, 0);
+
+/**
+ * AuthService handles authentication flows
+ *
+ * @generated from service api.v1.service.auth.AuthService
+ */
+export const AuthService: GenService<{
+  /**
+   * Check if username is available
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.CheckUsername
+   */
+  checkUsername: {
+    methodKind: "unary";
+    input: typeof CheckUsernameRequestSchema;
+    output: typeof CheckUsernameResponseSchema;
+  },
+  /**
+   * Initialize anonymous session (generates session_id + token)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.InitAnonymous
+   */
+  initAnonymous: {
+    methodKind: "unary";
+    input: typeof InitAnonymousRequestSchema;
+    output: typeof InitAnonymousResponseSchema;
+  },
+  /**
+   * Authenticate with provider (Google, magic link, etc)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.Authenticate
+   */
+  authenticate: {
+    methodKind: "unary";
+    input: typeof AuthenticateRequestSchema;
+    output: typeof AuthenticateResponseSchema;
+  },
+  /**
+   * Refresh authenticated tokens (anonymous tokens never expire)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.RefreshToken
+   */
+  refreshToken: {
+    methodKind: "unary";
+    input: typeof RefreshTokenRequestSchema;
+    output: typeof RefreshTokenResponseSchema;
+  },
+  /**
+   * Link another login provider to the authenticated user
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.LinkProvider
+   */
+  linkProvider: {
+    methodKind: "unary";
+    input: typeof LinkProviderRequestSchema;
+    output: typeof LinkProviderResponseSchema;
+  },
+  /**
+   * Unlink a login provider (the last remaining one cannot be removed)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.UnlinkProvider
+   */
+  unlinkProvider: {
+    methodKind: "unary";
+    input: typeof UnlinkProviderRequestSchema;
+    output: typeof UnlinkProviderResponseSchema;
+  },
+  /**
+   * List login providers linked to the authenticated user
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.ListLinkedProviders
+   */
+  listLinkedProviders: {
+    methodKind: "unary";
+    input: typeof ListLinkedProvidersRequestSchema;
+    output: typeof ListLinkedProvidersResponseSchema;
+  },
+  /**
+   * Send an email verification link (always succeeds, to avoid revealing accounts)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.SendVerificationEmail
+   */
+  sendVerificationEmail: {
+    methodKind: "unary";
+    input: typeof SendVerificationEmailRequestSchema;
+    output: typeof SendVerificationEmailResponseSchema;
+  },
+  /**
+   * Verify an email address with the token from a verification link
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.VerifyEmail
+   */
+  verifyEmail: {
+    methodKind: "unary";
+    input: typeof VerifyEmailRequestSchema;
+    output: typeof VerifyEmailResponseSchema;
+  },
+  /**
+   * Send a password reset link (always succeeds, to avoid revealing accounts)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.RequestPasswordReset
+   */
+  requestPasswordReset: {
+    methodKind: "unary";
+    input: typeof RequestPasswordResetRequestSchema;
+    output: typeof RequestPasswordResetResponseSchema;
+  },
+  /**
+   * Set a new password with the token from a reset link; signs out all sessions
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.ResetPassword
+   */
+  resetPassword: {
+    methodKind: "unary";
+    input: typeof ResetPasswordRequestSchema;
+    output: typeof ResetPasswordResponseSchema;
+  },
+  /**
+   * Start TOTP enrollment; returns a secret and otpauth URI for an authenticator app
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.EnrollTOTP
+   */
+  enrollTOTP: {
+    methodKind: "unary";
+    input: typeof EnrollTOTPRequestSchema;
+    output: typeof EnrollTOTPResponseSchema;
+  },
+  /**
+   * Enable TOTP with the first code from the app; returns one-time recovery codes
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.ConfirmTOTP
+   */
+  confirmTOTP: {
+    methodKind: "unary";
+    input: typeof ConfirmTOTPRequestSchema;
+    output: typeof ConfirmTOTPResponseSchema;
+  },
+  /**
+   * Turn off TOTP with a current code or recovery code
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.DisableTOTP
+   */
+  disableTOTP: {
+    methodKind: "unary";
+    input: typeof DisableTOTPRequestSchema;
+    output: typeof DisableTOTPResponseSchema;
+  },
+  /**
+   * Finish a sign-in that returned mfa_required with a TOTP or recovery code
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.CompleteMFA
+   */
+  completeMFA: {
+    methodKind: "unary";
+    input: typeof CompleteMFARequestSchema;
+    output: typeof CompleteMFAResponseSchema;
+  },
+  /**
+   * Start registering a passkey for the authenticated user
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.BeginPasskeyRegistration
+   */
+  beginPasskeyRegistration: {
+    methodKind: "unary";
+    input: typeof BeginPasskeyRegistrationRequestSchema;
+    output: typeof BeginPasskeyRegistrationResponseSchema;
+  },
+  /**
+   * Verify the authenticator's response and store the passkey
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.FinishPasskeyRegistration
+   */
+  finishPasskeyRegistration: {
+    methodKind: "unary";
+    input: typeof FinishPasskeyRegistrationRequestSchema;
+    output: typeof FinishPasskeyRegistrationResponseSchema;
+  },
+  /**
+   * Start a passkey sign-in; finish with Authenticate(provider = "passkey")
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.BeginPasskeyLogin
+   */
+  beginPasskeyLogin: {
+    methodKind: "unary";
+    input: typeof BeginPasskeyLoginRequestSchema;
+    output: typeof BeginPasskeyLoginResponseSchema;
+  },
+  /**
+   * List the authenticated user's passkeys
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.ListPasskeys
+   */
+  listPasskeys: {
+    methodKind: "unary";
+    input: typeof ListPasskeysRequestSchema;
+    output: typeof ListPasskeysResponseSchema;
+  },
+  /**
+   * Remove one of the authenticated user's passkeys
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.DeletePasskey
+   */
+  deletePasskey: {
+    methodKind: "unary";
+    input: typeof DeletePasskeyRequestSchema;
+    output: typeof DeletePasskeyResponseSchema;
+  },
+  /**
+   * Give a user an elevated role (admin only; moderators and admins need 2FA)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.GrantRole
+   */
+  grantRole: {
+    methodKind: "unary";
+    input: typeof GrantRoleRequestSchema;
+    output: typeof GrantRoleResponseSchema;
+  },
+  /**
+   * Remove an elevated role from a user (admin only)
+   *
+   * @generated from rpc api.v1.service.auth.AuthService.RevokeRole
+   */
+  revokeRole: {
+    methodKind: "unary";
+    input: typeof RevokeRoleRequestSchema;
+    output: typeof RevokeRoleResponseSchema;
   },
 }> = /*@__PURE__*/
   serviceDesc(file_v1_service_auth, 0);
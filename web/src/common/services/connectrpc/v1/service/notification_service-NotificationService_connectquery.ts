@@ -0,0 +1,61 @@
+// @generated by protoc-gen-connect-query v2.1.1 with parameter "target=ts"
+// @generated from file v1/service/notification_service.proto (package api.v1.service, syntax proto3)
+/* eslint-disable */
+
+import { NotificationService } from "./notification_service_pb";
+
+/**
+ * List notifications, newest first
+ *
+ * @generated from rpc api.v1.service.NotificationService.ListNotifications
+ */
+export const listNotifications = NotificationService.method.listNotifications;
+
+/**
+ * Mark notifications as read
+ *
+ * @generated from rpc api.v1.service.NotificationService.MarkRead
+ */
+export const markRead = NotificationService.method.markRead;
+
+/**
+ * Count unread notifications
+ *
+ * @generated from rpc api.v1.service.NotificationService.GetUnreadCount
+ */
+export const getUnreadCount = NotificationService.method.getUnreadCount;
+
+/**
+ * Get which kinds of notifications are pushed to the caller's devices
+ *
+ * @generated from rpc api.v1.service.NotificationService.GetPreferences
+ */
+export const getPreferences = NotificationService.method.getPreferences;
+
+/**
+ * Change which kinds of notifications are pushed
+ *
+ * @generated from rpc api.v1.service.NotificationService.UpdatePreferences
+ */
+export const updatePreferences = NotificationService.method.updatePreferences;
+
+/**
+ * Save an area to be notified about new pins in
+ *
+ * @generated from rpc api.v1.service.NotificationService.SaveArea
+ */
+export const saveArea = NotificationService.method.saveArea;
+
+/**
+ * List the caller's saved areas
+ *
+ * @generated from rpc api.v1.service.NotificationService.ListSavedAreas
+ */
+export const listSavedAreas = NotificationService.method.listSavedAreas;
+
+/**
+ * Delete a saved area
+ *
+ * @generated from rpc api.v1.service.NotificationService.DeleteSavedArea
+ */
+export const deleteSavedArea = NotificationService.method.deleteSavedArea;
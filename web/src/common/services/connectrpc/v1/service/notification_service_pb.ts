@@ -0,0 +1,495 @@
+// @generated by protoc-gen-es v2.6.3 with parameter "target=ts"
+// @generated from file v1/service/notification_service.proto (package api.v1.service, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Notification, NotificationKind, SavedArea } from "../entities/notification_pb";
+import { file_v1_entities_notification } from "../entities/notification_pb";
+import type { Location } from "../entities/pin_pb";
+import { file_v1_entities_pin } from "../entities/pin_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file v1/service/notification_service.proto.
+ */
+export const file_v1_service_notification_service: GenFile = /*@__PURE__*/
+  fileDesc("CiV2MS9zZXJ2aWNlL25vdGlmaWNhdGlvbl9zZXJ2aWNlLnByb3RvEg5hcGkudjEuc2VydmljZSJtChhMaXN0Tm90aWZpY2F0aW9uc1JlcXVlc3QSEgoFbGltaXQYASABKAVIAIgBARITCgZjdXJzb3IYAiABKAlIAYgBARITCgt1bnJlYWRfb25seRgDIAEoCEIICgZfbGltaXRCCQoHX2N1cnNvciJ7ChlMaXN0Tm90aWZpY2F0aW9uc1Jlc3BvbnNlEjQKDW5vdGlmaWNhdGlvbnMYASADKAsyHS5hcGkudjEuZW50aXRpZXMuTm90aWZpY2F0aW9uEhgKC25leHRfY3Vyc29yGAIgASgJSACIAQFCDgoMX25leHRfY3Vyc29yIjgKD01hcmtSZWFkUmVxdWVzdBIYChBub3RpZmljYXRpb25faWRzGAEgAygJEgsKA2FsbBgCIAEoCCIoChBNYXJrUmVhZFJlc3BvbnNlEhQKDHVucmVhZF9jb3VudBgBIAEoAyIXChVHZXRVbnJlYWRDb3VudFJlcXVlc3QiLgoWR2V0VW5yZWFkQ291bnRSZXNwb25zZRIUCgx1bnJlYWRfY291bnQYASABKAMiGwoZV2F0Y2hOb3RpZmljYXRpb25zUmVxdWVzdCJnChpXYXRjaE5vdGlmaWNhdGlvbnNSZXNwb25zZRIzCgxub3RpZmljYXRpb24YASABKAsyHS5hcGkudjEuZW50aXRpZXMuTm90aWZpY2F0aW9uEhQKDHVucmVhZF9jb3VudBgCIAEoAyJXChZOb3RpZmljYXRpb25QcmVmZXJlbmNlEi8KBGtpbmQYASABKA4yIS5hcGkudjEuZW50aXRpZXMuTm90aWZpY2F0aW9uS2luZBIMCgRwdXNoGAIgASgIIhcKFUdldFByZWZlcmVuY2VzUmVxdWVzdCJVChZHZXRQcmVmZXJlbmNlc1Jlc3BvbnNlEjsKC3ByZWZlcmVuY2VzGAEgAygLMiYuYXBpLnYxLnNlcnZpY2UuTm90aWZpY2F0aW9uUHJlZmVyZW5jZSJXChhVcGRhdGVQcmVmZXJlbmNlc1JlcXVlc3QSOwoLcHJlZmVyZW5jZXMYASADKAsyJi5hcGkudjEuc2VydmljZS5Ob3RpZmljYXRpb25QcmVmZXJlbmNlIlgKGVVwZGF0ZVByZWZlcmVuY2VzUmVzcG9uc2USOwoLcHJlZmVyZW5jZXMYASADKAsyJi5hcGkudjEuc2VydmljZS5Ob3RpZmljYXRpb25QcmVmZXJlbmNlIrQBCg9TYXZlQXJlYVJlcXVlc3QSDAoEbmFtZRgBIAEoCRIqCgdwb2x5Z29uGAIgAygLMhkuYXBpLnYxLmVudGl0aWVzLkxvY2F0aW9uEi4KBmNlbnRlchgDIAEoCzIZLmFwaS52MS5lbnRpdGllcy5Mb2NhdGlvbkgAiAEBEhoKDXJhZGl1c19tZXRlcnMYBCABKAVIAYgBAUIJCgdfY2VudGVyQhAKDl9yYWRpdXNfbWV0ZXJzIjwKEFNhdmVBcmVhUmVzcG9uc2USKAoEYXJlYRgBIAEoCzIaLmFwaS52MS5lbnRpdGllcy5TYXZlZEFyZWEiFwoVTGlzdFNhdmVkQXJlYXNSZXF1ZXN0IkMKFkxpc3RTYXZlZEFyZWFzUmVzcG9uc2USKQoFYXJlYXMYASADKAsyGi5hcGkudjEuZW50aXRpZXMuU2F2ZWRBcmVhIikKFkRlbGV0ZVNhdmVkQXJlYVJlcXVlc3QSDwoHYXJlYV9pZBgBIAEoCSIqChdEZWxldGVTYXZlZEFyZWFSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIMv0GChNOb3RpZmljYXRpb25TZXJ2aWNlEmgKEUxpc3ROb3RpZmljYXRpb25zEiguYXBpLnYxLnNlcnZpY2UuTGlzdE5vdGlmaWNhdGlvbnNSZXF1ZXN0GikuYXBpLnYxLnNlcnZpY2UuTGlzdE5vdGlmaWNhdGlvbnNSZXNwb25zZRJNCghNYXJrUmVhZBIfLmFwaS52MS5zZXJ2aWNlLk1hcmtSZWFkUmVxdWVzdBogLmFwaS52MS5zZXJ2aWNlLk1hcmtSZWFkUmVzcG9uc2USXwoOR2V0VW5yZWFkQ291bnQSJS5hcGkudjEuc2VydmljZS5HZXRVbnJlYWRDb3VudFJlcXVlc3QaJi5hcGkudjEuc2VydmljZS5HZXRVbnJlYWRDb3VudFJlc3BvbnNlEm0KEldhdGNoTm90aWZpY2F0aW9ucxIpLmFwaS52MS5zZXJ2aWNlLldhdGNoTm90aWZpY2F0aW9uc1JlcXVlc3QaKi5hcGkudjEuc2VydmljZS5XYXRjaE5vdGlmaWNhdGlvbnNSZXNwb25zZTABEl8KDkdldFByZWZlcmVuY2VzEiUuYXBpLnYxLnNlcnZpY2UuR2V0UHJlZmVyZW5jZXNSZXF1ZXN0GiYuYXBpLnYxLnNlcnZpY2UuR2V0UHJlZmVyZW5jZXNSZXNwb25zZRJoChFVcGRhdGVQcmVmZXJlbmNlcxIoLmFwaS52MS5zZXJ2aWNlLlVwZGF0ZVByZWZlcmVuY2VzUmVxdWVzdBopLmFwaS52MS5zZXJ2aWNlLlVwZGF0ZVByZWZlcmVuY2VzUmVzcG9uc2USTQoIU2F2ZUFyZWESHy5hcGkudjEuc2VydmljZS5TYXZlQXJlYVJlcXVlc3QaIC5hcGkudjEuc2VydmljZS5TYXZlQXJlYVJlc3BvbnNlEl8KDkxpc3RTYXZlZEFyZWFzEiUuYXBpLnYxLnNlcnZpY2UuTGlzdFNhdmVkQXJlYXNSZXF1ZXN0GiYuYXBpLnYxLnNlcnZpY2UuTGlzdFNhdmVkQXJlYXNSZXNwb25zZRJiCg9EZWxldGVTYXZlZEFyZWESJi5hcGkudjEuc2VydmljZS5EZWxldGVTYXZlZEFyZWFSZXF1ZXN0GicuYXBpLnYxLnNlcnZpY2UuRGVsZXRlU2F2ZWRBcmVhUmVzcG9uc2VCTVpLZ2l0aHViLmNvbS9yYWRqYXRoYWhlci9hbHVuYWx1bi9hcGkvaW50ZXJuYWwvcHJvdG9jZ2VuL3YxL3NlcnZpY2U7c2VydmljZXYxYgZwcm90bzM", [file_v1_entities_notification, file_v1_entities_pin]);
+
+/**
+ * @generated from message api.v1.service.ListNotificationsRequest
+ */
+export type ListNotificationsRequest = Message<"api.v1.service.ListNotificationsRequest"> & {
+  /**
+   * Defaults to 20, at most 100
+   *
+   * @generated from field: optional int32 limit = 1;
+   */
+  limit?: number;
+
+  /**
+   * next_cursor from the previous page
+   *
+   * @generated from field: optional string cursor = 2;
+   */
+  cursor?: string;
+
+  /**
+   * @generated from field: bool unread_only = 3;
+   */
+  unreadOnly: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.ListNotificationsRequest.
+ * Use `create(ListNotificationsRequestSchema)` to create a new message.
+ */
+export const ListNotificationsRequestSchema: GenMessage<ListNotificationsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 0);
+
+/**
+ * @generated from message api.v1.service.ListNotificationsResponse
+ */
+export type ListNotificationsResponse = Message<"api.v1.service.ListNotificationsResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.entities.Notification notifications = 1;
+   */
+  notifications: Notification[];
+
+  /**
+   * Unset on the last page
+   *
+   * @generated from field: optional string next_cursor = 2;
+   */
+  nextCursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListNotificationsResponse.
+ * Use `create(ListNotificationsResponseSchema)` to create a new message.
+ */
+export const ListNotificationsResponseSchema: GenMessage<ListNotificationsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 1);
+
+/**
+ * @generated from message api.v1.service.MarkReadRequest
+ */
+export type MarkReadRequest = Message<"api.v1.service.MarkReadRequest"> & {
+  /**
+   * @generated from field: repeated string notification_ids = 1;
+   */
+  notificationIds: string[];
+
+  /**
+   * Mark every notification read; notification_ids is ignored
+   *
+   * @generated from field: bool all = 2;
+   */
+  all: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.MarkReadRequest.
+ * Use `create(MarkReadRequestSchema)` to create a new message.
+ */
+export const MarkReadRequestSchema: GenMessage<MarkReadRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 2);
+
+/**
+ * @generated from message api.v1.service.MarkReadResponse
+ */
+export type MarkReadResponse = Message<"api.v1.service.MarkReadResponse"> & {
+  /**
+   * @generated from field: int64 unread_count = 1;
+   */
+  unreadCount: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.MarkReadResponse.
+ * Use `create(MarkReadResponseSchema)` to create a new message.
+ */
+export const MarkReadResponseSchema: GenMessage<MarkReadResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 3);
+
+/**
+ * @generated from message api.v1.service.GetUnreadCountRequest
+ */
+export type GetUnreadCountRequest = Message<"api.v1.service.GetUnreadCountRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.GetUnreadCountRequest.
+ * Use `create(GetUnreadCountRequestSchema)` to create a new message.
+ */
+export const GetUnreadCountRequestSchema: GenMessage<GetUnreadCountRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 4);
+
+/**
+ * @generated from message api.v1.service.GetUnreadCountResponse
+ */
+export type GetUnreadCountResponse = Message<"api.v1.service.GetUnreadCountResponse"> & {
+  /**
+   * @generated from field: int64 unread_count = 1;
+   */
+  unreadCount: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.GetUnreadCountResponse.
+ * Use `create(GetUnreadCountResponseSchema)` to create a new message.
+ */
+export const GetUnreadCountResponseSchema: GenMessage<GetUnreadCountResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 5);
+
+/**
+ * @generated from message api.v1.service.WatchNotificationsRequest
+ */
+export type WatchNotificationsRequest = Message<"api.v1.service.WatchNotificationsRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.WatchNotificationsRequest.
+ * Use `create(WatchNotificationsRequestSchema)` to create a new message.
+ */
+export const WatchNotificationsRequestSchema: GenMessage<WatchNotificationsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 6);
+
+/**
+ * @generated from message api.v1.service.WatchNotificationsResponse
+ */
+export type WatchNotificationsResponse = Message<"api.v1.service.WatchNotificationsResponse"> & {
+  /**
+   * @generated from field: api.v1.entities.Notification notification = 1;
+   */
+  notification?: Notification;
+
+  /**
+   * Current unread count
+   *
+   * @generated from field: int64 unread_count = 2;
+   */
+  unreadCount: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.WatchNotificationsResponse.
+ * Use `create(WatchNotificationsResponseSchema)` to create a new message.
+ */
+export const WatchNotificationsResponseSchema: GenMessage<WatchNotificationsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 7);
+
+/**
+ * NotificationPreference is the caller's setting for one kind of notification
+ *
+ * @generated from message api.v1.service.NotificationPreference
+ */
+export type NotificationPreference = Message<"api.v1.service.NotificationPreference"> & {
+  /**
+   * @generated from field: api.v1.entities.NotificationKind kind = 1;
+   */
+  kind: NotificationKind;
+
+  /**
+   * Send Web Push messages; notifications are listed either way
+   *
+   * @generated from field: bool push = 2;
+   */
+  push: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.NotificationPreference.
+ * Use `create(NotificationPreferenceSchema)` to create a new message.
+ */
+export const NotificationPreferenceSchema: GenMessage<NotificationPreference> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 8);
+
+/**
+ * @generated from message api.v1.service.GetPreferencesRequest
+ */
+export type GetPreferencesRequest = Message<"api.v1.service.GetPreferencesRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.GetPreferencesRequest.
+ * Use `create(GetPreferencesRequestSchema)` to create a new message.
+ */
+export const GetPreferencesRequestSchema: GenMessage<GetPreferencesRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 9);
+
+/**
+ * @generated from message api.v1.service.GetPreferencesResponse
+ */
+export type GetPreferencesResponse = Message<"api.v1.service.GetPreferencesResponse"> & {
+  /**
+   * One per kind
+   *
+   * @generated from field: repeated api.v1.service.NotificationPreference preferences = 1;
+   */
+  preferences: NotificationPreference[];
+};
+
+/**
+ * Describes the message api.v1.service.GetPreferencesResponse.
+ * Use `create(GetPreferencesResponseSchema)` to create a new message.
+ */
+export const GetPreferencesResponseSchema: GenMessage<GetPreferencesResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 10);
+
+/**
+ * @generated from message api.v1.service.UpdatePreferencesRequest
+ */
+export type UpdatePreferencesRequest = Message<"api.v1.service.UpdatePreferencesRequest"> & {
+  /**
+   * Kinds not listed are unchanged
+   *
+   * @generated from field: repeated api.v1.service.NotificationPreference preferences = 1;
+   */
+  preferences: NotificationPreference[];
+};
+
+/**
+ * Describes the message api.v1.service.UpdatePreferencesRequest.
+ * Use `create(UpdatePreferencesRequestSchema)` to create a new message.
+ */
+export const UpdatePreferencesRequestSchema: GenMessage<UpdatePreferencesRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 11);
+
+/**
+ * @generated from message api.v1.service.UpdatePreferencesResponse
+ */
+export type UpdatePreferencesResponse = Message<"api.v1.service.UpdatePreferencesResponse"> & {
+  /**
+   * One per kind
+   *
+   * @generated from field: repeated api.v1.service.NotificationPreference preferences = 1;
+   */
+  preferences: NotificationPreference[];
+};
+
+/**
+ * Describes the message api.v1.service.UpdatePreferencesResponse.
+ * Use `create(UpdatePreferencesResponseSchema)` to create a new message.
+ */
+export const UpdatePreferencesResponseSchema: GenMessage<UpdatePreferencesResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 12);
+
+/**
+ * SaveAreaRequest describes a polygon, or a circle with center and radius_meters
+ *
+ * @generated from message api.v1.service.SaveAreaRequest
+ */
+export type SaveAreaRequest = Message<"api.v1.service.SaveAreaRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * 3 to 100 vertices; the ring is closed automatically
+   *
+   * @generated from field: repeated api.v1.entities.Location polygon = 2;
+   */
+  polygon: Location[];
+
+  /**
+   * @generated from field: optional api.v1.entities.Location center = 3;
+   */
+  center?: Location;
+
+  /**
+   * 100 to 50000
+   *
+   * @generated from field: optional int32 radius_meters = 4;
+   */
+  radiusMeters?: number;
+};
+
+/**
+ * Describes the message api.v1.service.SaveAreaRequest.
+ * Use `create(SaveAreaRequestSchema)` to create a new message.
+ */
+export const SaveAreaRequestSchema: GenMessage<SaveAreaRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 13);
+
+/**
+ * @generated from message api.v1.service.SaveAreaResponse
+ */
+export type SaveAreaResponse = Message<"api.v1.service.SaveAreaResponse"> & {
+  /**
+   * @generated from field: api.v1.entities.SavedArea area = 1;
+   */
+  area?: SavedArea;
+};
+
+/**
+ * Describes the message api.v1.service.SaveAreaResponse.
+ * Use `create(SaveAreaResponseSchema)` to create a new message.
+ */
+export const SaveAreaResponseSchema: GenMessage<SaveAreaResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 14);
+
+/**
+ * @generated from message api.v1.service.ListSavedAreasRequest
+ */
+export type ListSavedAreasRequest = Message<"api.v1.service.ListSavedAreasRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.ListSavedAreasRequest.
+ * Use `create(ListSavedAreasRequestSchema)` to create a new message.
+ */
+export const ListSavedAreasRequestSchema: GenMessage<ListSavedAreasRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 15);
+
+/**
+ * @generated from message api.v1.service.ListSavedAreasResponse
+ */
+export type ListSavedAreasResponse = Message<"api.v1.service.ListSavedAreasResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.entities.SavedArea areas = 1;
+   */
+  areas: SavedArea[];
+};
+
+/**
+ * Describes the message api.v1.service.ListSavedAreasResponse.
+ * Use `create(ListSavedAreasResponseSchema)` to create a new message.
+ */
+export const ListSavedAreasResponseSchema: GenMessage<ListSavedAreasResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 16);
+
+/**
+ * @generated from message api.v1.service.DeleteSavedAreaRequest
+ */
+export type DeleteSavedAreaRequest = Message<"api.v1.service.DeleteSavedAreaRequest"> & {
+  /**
+   * @generated from field: string area_id = 1;
+   */
+  areaId: string;
+};
+
+/**
+ * Describes the message api.v1.service.DeleteSavedAreaRequest.
+ * Use `create(DeleteSavedAreaRequestSchema)` to create a new message.
+ */
+export const DeleteSavedAreaRequestSchema: GenMessage<DeleteSavedAreaRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 17);
+
+/**
+ * @generated from message api.v1.service.DeleteSavedAreaResponse
+ */
+export type DeleteSavedAreaResponse = Message<"api.v1.service.DeleteSavedAreaResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.DeleteSavedAreaResponse.
+ * Use `create(DeleteSavedAreaResponseSchema)` to create a new message.
+ */
+export const DeleteSavedAreaResponseSchema: GenMessage<DeleteSavedAreaResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_notification_service, 18);
+
+/**
+ * NotificationService lists and watches the caller's notifications (requires authentication)
+ *
+ * @generated from service api.v1.service.NotificationService
+ */
+export const NotificationService: GenService<{
+  /**
+   * List notifications, newest first
+   *
+   * @generated from rpc api.v1.service.NotificationService.ListNotifications
+   */
+  listNotifications: {
+    methodKind: "unary";
+    input: typeof ListNotificationsRequestSchema;
+    output: typeof ListNotificationsResponseSchema;
+  },
+  /**
+   * Mark notifications as read
+   *
+   * @generated from rpc api.v1.service.NotificationService.MarkRead
+   */
+  markRead: {
+    methodKind: "unary";
+    input: typeof MarkReadRequestSchema;
+    output: typeof MarkReadResponseSchema;
+  },
+  /**
+   * Count unread notifications
+   *
+   * @generated from rpc api.v1.service.NotificationService.GetUnreadCount
+   */
+  getUnreadCount: {
+    methodKind: "unary";
+    input: typeof GetUnreadCountRequestSchema;
+    output: typeof GetUnreadCountResponseSchema;
+  },
+  /**
+   * Stream new notifications as they are created
+   *
+   * @generated from rpc api.v1.service.NotificationService.WatchNotifications
+   */
+  watchNotifications: {
+    methodKind: "server_streaming";
+    input: typeof WatchNotificationsRequestSchema;
+    output: typeof WatchNotificationsResponseSchema;
+  },
+  /**
+   * Get which kinds of notifications are pushed to the caller's devices
+   *
+   * @generated from rpc api.v1.service.NotificationService.GetPreferences
+   */
+  getPreferences: {
+    methodKind: "unary";
+    input: typeof GetPreferencesRequestSchema;
+    output: typeof GetPreferencesResponseSchema;
+  },
+  /**
+   * Change which kinds of notifications are pushed
+   *
+   * @generated from rpc api.v1.service.NotificationService.UpdatePreferences
+   */
+  updatePreferences: {
+    methodKind: "unary";
+    input: typeof UpdatePreferencesRequestSchema;
+    output: typeof UpdatePreferencesResponseSchema;
+  },
+  /**
+   * Save an area to be notified about new pins in
+   *
+   * @generated from rpc api.v1.service.NotificationService.SaveArea
+   */
+  saveArea: {
+    methodKind: "unary";
+    input: typeof SaveAreaRequestSchema;
+    output: typeof SaveAreaResponseSchema;
+  },
+  /**
+   * List the caller's saved areas
+   *
+   * @generated from rpc api.v1.service.NotificationService.ListSavedAreas
+   */
+  listSavedAreas: {
+    methodKind: "unary";
+    input: typeof ListSavedAreasRequestSchema;
+    output: typeof ListSavedAreasResponseSchema;
+  },
+  /**
+   * Delete a saved area
+   *
+   * @generated from rpc api.v1.service.NotificationService.DeleteSavedArea
+   */
+  deleteSavedArea: {
+    methodKind: "unary";
+    input: typeof DeleteSavedAreaRequestSchema;
+    output: typeof DeleteSavedAreaResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_v1_service_notification_service, 0);
+
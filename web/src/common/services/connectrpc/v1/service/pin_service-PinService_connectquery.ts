@@ -38,3 +38,10 @@ export const addComment = PinService.method.addComment;
  * @generated from rpc api.v1.service.PinService.DeletePin
  */
 export const deletePin = PinService.method.deletePin;
+
+/**
+ * Get recent pins from followed users, anywhere on the map
+ *
+ * @generated from rpc api.v1.service.PinService.ListFeed
+ */
+export const listFeed = PinService.method.listFeed;
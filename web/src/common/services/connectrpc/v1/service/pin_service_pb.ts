@@ -4,7 +4,7 @@
 
 import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { Comment, Location, Pin } from "../entities/pin_pb";
+import type { Comment, Location, Pin, PinVisibility } from "../entities/pin_pb";
 import { file_v1_entities_pin } from "../entities/pin_pb";
 import type { Message } from "@bufbuild/protobuf";
 
@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/service/pin_service.proto.
  */
 export const file_v1_service_pin_service: GenFile = /*@__PURE__*/
-  fileDesc("Chx2MS9zZXJ2aWNlL3Bpbl9zZXJ2aWNlLnByb3RvEg5hcGkudjEuc2VydmljZSJQChBDcmVhdGVQaW5SZXF1ZXN0Eg8KB2NvbnRlbnQYASABKAkSKwoIbG9jYXRpb24YAiABKAsyGS5hcGkudjEuZW50aXRpZXMuTG9jYXRpb24iNgoRQ3JlYXRlUGluUmVzcG9uc2USIQoDcGluGAEgASgLMhQuYXBpLnYxLmVudGl0aWVzLlBpbiKCAQoPTGlzdFBpbnNSZXF1ZXN0EhAKCGxhdGl0dWRlGAEgASgBEhEKCWxvbmdpdHVkZRgCIAEoARIMCgR6b29tGAMgASgFEhIKBWxpbWl0GAQgASgFSACIAQESEwoGY3Vyc29yGAUgASgJSAGIAQFCCAoGX2xpbWl0QgkKB19jdXJzb3IiYAoQTGlzdFBpbnNSZXNwb25zZRIiCgRwaW5zGAEgAygLMhQuYXBpLnYxLmVudGl0aWVzLlBpbhIYCgtuZXh0X2N1cnNvchgCIAEoCUgAiAEBQg4KDF9uZXh0X2N1cnNvciIfCg1HZXRQaW5SZXF1ZXN0Eg4KBnBpbl9pZBgBIAEoCSJfCg5HZXRQaW5SZXNwb25zZRIhCgNwaW4YASABKAsyFC5hcGkudjEuZW50aXRpZXMuUGluEioKCGNvbW1lbnRzGAIgAygLMhguYXBpLnYxLmVudGl0aWVzLkNvbW1lbnQiWgoRQWRkQ29tbWVudFJlcXVlc3QSDgoGcGluX2lkGAEgASgJEg8KB2NvbnRlbnQYAiABKAkSFgoJcGFyZW50X2lkGAMgASgJSACIAQFCDAoKX3BhcmVudF9pZCI/ChJBZGRDb21tZW50UmVzcG9uc2USKQoHY29tbWVudBgBIAEoCzIYLmFwaS52MS5lbnRpdGllcy5Db21tZW50IiIKEERlbGV0ZVBpblJlcXVlc3QSDgoGcGluX2lkGAEgASgJIiQKEURlbGV0ZVBpblJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgynQMKClBpblNlcnZpY2USUAoJQ3JlYXRlUGluEiAuYXBpLnYxLnNlcnZpY2UuQ3JlYXRlUGluUmVxdWVzdBohLmFwaS52MS5zZXJ2aWNlLkNyZWF0ZVBpblJlc3BvbnNlEk0KCExpc3RQaW5zEh8uYXBpLnYxLnNlcnZpY2UuTGlzdFBpbnNSZXF1ZXN0GiAuYXBpLnYxLnNlcnZpY2UuTGlzdFBpbnNSZXNwb25zZRJHCgZHZXRQaW4SHS5hcGkudjEuc2VydmljZS5HZXRQaW5SZXF1ZXN0Gh4uYXBpLnYxLnNlcnZpY2UuR2V0UGluUmVzcG9uc2USUwoKQWRkQ29tbWVudBIhLmFwaS52MS5zZXJ2aWNlLkFkZENvbW1lbnRSZXF1ZXN0GiIuYXBpLnYxLnNlcnZpY2UuQWRkQ29tbWVudFJlc3BvbnNlElAKCURlbGV0ZVBpbhIgLmFwaS52MS5zZXJ2aWNlLkRlbGV0ZVBpblJlcXVlc3QaIS5hcGkudjEuc2VydmljZS5EZWxldGVQaW5SZXNwb25zZUJNWktnaXRodWIuY29tL3JhZGphdGhhaGVyL2FsdW5hbHVuL2FwaS9pbnRlcm5hbC9wcm90b2NnZW4vdjEvc2VydmljZTtzZXJ2aWNldjFiBnByb3RvMw", [file_v1_entities_pin]);
+  fileDesc("Chx2MS9zZXJ2aWNlL3Bpbl9zZXJ2aWNlLnByb3RvEg5hcGkudjEuc2VydmljZSKEAQoQQ3JlYXRlUGluUmVxdWVzdBIPCgdjb250ZW50GAEgASgJEisKCGxvY2F0aW9uGAIgASgLMhkuYXBpLnYxLmVudGl0aWVzLkxvY2F0aW9uEjIKCnZpc2liaWxpdHkYAyABKA4yHi5hcGkudjEuZW50aXRpZXMuUGluVmlzaWJpbGl0eSI2ChFDcmVhdGVQaW5SZXNwb25zZRIhCgNwaW4YASABKAsyFC5hcGkudjEuZW50aXRpZXMuUGluIoIBCg9MaXN0UGluc1JlcXVlc3QSEAoIbGF0aXR1ZGUYASABKAESEQoJbG9uZ2l0dWRlGAIgASgBEgwKBHpvb20YAyABKAUSEgoFbGltaXQYBCABKAVIAIgBARITCgZjdXJzb3IYBSABKAlIAYgBAUIICgZfbGltaXRCCQoHX2N1cnNvciJgChBMaXN0UGluc1Jlc3BvbnNlEiIKBHBpbnMYASADKAsyFC5hcGkudjEuZW50aXRpZXMuUGluEhgKC25leHRfY3Vyc29yGAIgASgJSACIAQFCDgoMX25leHRfY3Vyc29yIh8KDUdldFBpblJlcXVlc3QSDgoGcGluX2lkGAEgASgJIl8KDkdldFBpblJlc3BvbnNlEiEKA3BpbhgBIAEoCzIULmFwaS52MS5lbnRpdGllcy5QaW4SKgoIY29tbWVudHMYAiADKAsyGC5hcGkudjEuZW50aXRpZXMuQ29tbWVudCJaChFBZGRDb21tZW50UmVxdWVzdBIOCgZwaW5faWQYASABKAkSDwoHY29udGVudBgCIAEoCRIWCglwYXJlbnRfaWQYAyABKAlIAIgBAUIMCgpfcGFyZW50X2lkIj8KEkFkZENvbW1lbnRSZXNwb25zZRIpCgdjb21tZW50GAEgASgLMhguYXBpLnYxLmVudGl0aWVzLkNvbW1lbnQiIgoQRGVsZXRlUGluUmVxdWVzdBIOCgZwaW5faWQYASABKAkiJAoRRGVsZXRlUGluUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCJPCg9MaXN0RmVlZFJlcXVlc3QSEgoFbGltaXQYASABKAVIAIgBARITCgZjdXJzb3IYAiABKAlIAYgBAUIICgZfbGltaXRCCQoHX2N1cnNvciJgChBMaXN0RmVlZFJlc3BvbnNlEiIKBHBpbnMYASADKAsyFC5hcGkudjEuZW50aXRpZXMuUGluEhgKC25leHRfY3Vyc29yGAIgASgJSACIAQFCDgoMX25leHRfY3Vyc29yMuwDCgpQaW5TZXJ2aWNlElAKCUNyZWF0ZVBpbhIgLmFwaS52MS5zZXJ2aWNlLkNyZWF0ZVBpblJlcXVlc3QaIS5hcGkudjEuc2VydmljZS5DcmVhdGVQaW5SZXNwb25zZRJNCghMaXN0UGlucxIfLmFwaS52MS5zZXJ2aWNlLkxpc3RQaW5zUmVxdWVzdBogLmFwaS52MS5zZXJ2aWNlLkxpc3RQaW5zUmVzcG9uc2USRwoGR2V0UGluEh0uYXBpLnYxLnNlcnZpY2UuR2V0UGluUmVxdWVzdBoeLmFwaS52MS5zZXJ2aWNlLkdldFBpblJlc3BvbnNlElMKCkFkZENvbW1lbnQSIS5hcGkudjEuc2VydmljZS5BZGRDb21tZW50UmVxdWVzdBoiLmFwaS52MS5zZXJ2aWNlLkFkZENvbW1lbnRSZXNwb25zZRJQCglEZWxldGVQaW4SIC5hcGkudjEuc2VydmljZS5EZWxldGVQaW5SZXF1ZXN0GiEuYXBpLnYxLnNlcnZpY2UuRGVsZXRlUGluUmVzcG9uc2USTQoITGlzdEZlZWQSHy5hcGkudjEuc2VydmljZS5MaXN0RmVlZFJlcXVlc3QaIC5hcGkudjEuc2VydmljZS5MaXN0RmVlZFJlc3BvbnNlQk1aS2dpdGh1Yi5jb20vcmFkamF0aGFoZXIvYWx1bmFsdW4vYXBpL2ludGVybmFsL3Byb3RvY2dlbi92MS9zZXJ2aWNlO3NlcnZpY2V2MWIGcHJvdG8z", [file_v1_entities_pin]);
 
 /**
  * @generated from message api.v1.service.CreatePinRequest
@@ -31,6 +31,13 @@ export type CreatePinRequest = Message<"api.v1.service.CreatePinRequest"> & {
    * @generated from field: api.v1.entities.Location location = 2;
    */
   location?: Location;
+
+  /**
+   * Defaults to public
+   *
+   * @generated from field: api.v1.entities.PinVisibility visibility = 3;
+   */
+  visibility: PinVisibility;
 };
 
 /**
@@ -255,6 +262,56 @@ export type DeletePinResponse = Message<"api.v1.service.DeletePinResponse"> & {
 export const DeletePinResponseSchema: GenMessage<DeletePinResponse> = /*@__PURE__*/
   messageDesc(file_v1_service_pin_service, 9);
 
+/**
+ * @generated from message api.v1.service.ListFeedRequest
+ */
+export type ListFeedRequest = Message<"api.v1.service.ListFeedRequest"> & {
+  /**
+   * Defaults to 20, at most 100
+   *
+   * @generated from field: optional int32 limit = 1;
+   */
+  limit?: number;
+
+  /**
+   * next_cursor from the previous page
+   *
+   * @generated from field: optional string cursor = 2;
+   */
+  cursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListFeedRequest.
+ * Use `create(ListFeedRequestSchema)` to create a new message.
+ */
+export const ListFeedRequestSchema: GenMessage<ListFeedRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_pin_service, 10);
+
+/**
+ * @generated from message api.v1.service.ListFeedResponse
+ */
+export type ListFeedResponse = Message<"api.v1.service.ListFeedResponse"> & {
+  /**
+   * @generated from field: repeated api.v1.entities.Pin pins = 1;
+   */
+  pins: Pin[];
+
+  /**
+   * Unset on the last page
+   *
+   * @generated from field: optional string next_cursor = 2;
+   */
+  nextCursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListFeedResponse.
+ * Use `create(ListFeedResponseSchema)` to create a new message.
+ */
+export const ListFeedResponseSchema: GenMessage<ListFeedResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_pin_service, 11);
+
 /**
  * PinService handles pin and comment operations
  *
@@ -311,6 +368,16 @@ export const PinService: GenService<{
     input: typeof DeletePinRequestSchema;
     output: typeof DeletePinResponseSchema;
   },
+  /**
+   * Get recent pins from followed users, anywhere on the map
+   *
+   * @generated from rpc api.v1.service.PinService.ListFeed
+   */
+  listFeed: {
+    methodKind: "unary";
+    input: typeof ListFeedRequestSchema;
+    output: typeof ListFeedResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_v1_service_pin_service, 0);
 
@@ -0,0 +1,26 @@
+// @generated by protoc-gen-connect-query v2.1.1 with parameter "target=ts"
+// @generated from file v1/service/push_subscription_service.proto (package api.v1.service, syntax proto3)
+/* eslint-disable */
+
+import { PushSubscriptionService } from "./push_subscription_service_pb";
+
+/**
+ * Get the key browsers subscribe with (public)
+ *
+ * @generated from rpc api.v1.service.PushSubscriptionService.GetVapidPublicKey
+ */
+export const getVapidPublicKey = PushSubscriptionService.method.getVapidPublicKey;
+
+/**
+ * Register the browser's push subscription for the caller's session
+ *
+ * @generated from rpc api.v1.service.PushSubscriptionService.Subscribe
+ */
+export const subscribe = PushSubscriptionService.method.subscribe;
+
+/**
+ * Remove a push subscription, e.g. before signing out
+ *
+ * @generated from rpc api.v1.service.PushSubscriptionService.Unsubscribe
+ */
+export const unsubscribe = PushSubscriptionService.method.unsubscribe;
@@ -0,0 +1,173 @@
+// @generated by protoc-gen-es v2.6.3 with parameter "target=ts"
+// @generated from file v1/service/push_subscription_service.proto (package api.v1.service, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file v1/service/push_subscription_service.proto.
+ */
+export const file_v1_service_push_subscription_service: GenFile = /*@__PURE__*/
+  fileDesc("Cip2MS9zZXJ2aWNlL3B1c2hfc3Vic2NyaXB0aW9uX3NlcnZpY2UucHJvdG8SDmFwaS52MS5zZXJ2aWNlIhoKGEdldFZhcGlkUHVibGljS2V5UmVxdWVzdCIvChlHZXRWYXBpZFB1YmxpY0tleVJlc3BvbnNlEhIKCnB1YmxpY19rZXkYASABKAkiQgoQU3Vic2NyaWJlUmVxdWVzdBIQCghlbmRwb2ludBgBIAEoCRIOCgZwMjU2ZGgYAiABKAkSDAoEYXV0aBgDIAEoCSIkChFTdWJzY3JpYmVSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIjgKElVuc3Vic2NyaWJlUmVxdWVzdBIVCghlbmRwb2ludBgBIAEoCUgAiAEBQgsKCV9lbmRwb2ludCImChNVbnN1YnNjcmliZVJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgyrQIKF1B1c2hTdWJzY3JpcHRpb25TZXJ2aWNlEmgKEUdldFZhcGlkUHVibGljS2V5EiguYXBpLnYxLnNlcnZpY2UuR2V0VmFwaWRQdWJsaWNLZXlSZXF1ZXN0GikuYXBpLnYxLnNlcnZpY2UuR2V0VmFwaWRQdWJsaWNLZXlSZXNwb25zZRJQCglTdWJzY3JpYmUSIC5hcGkudjEuc2VydmljZS5TdWJzY3JpYmVSZXF1ZXN0GiEuYXBpLnYxLnNlcnZpY2UuU3Vic2NyaWJlUmVzcG9uc2USVgoLVW5zdWJzY3JpYmUSIi5hcGkudjEuc2VydmljZS5VbnN1YnNjcmliZVJlcXVlc3QaIy5hcGkudjEuc2VydmljZS5VbnN1YnNjcmliZVJlc3BvbnNlQk1aS2dpdGh1Yi5jb20vcmFkamF0aGFoZXIvYWx1bmFsdW4vYXBpL2ludGVybmFsL3Byb3RvY2dlbi92MS9zZXJ2aWNlO3NlcnZpY2V2MWIGcHJvdG8z");
+
+/**
+ * @generated from message api.v1.service.GetVapidPublicKeyRequest
+ */
+export type GetVapidPublicKeyRequest = Message<"api.v1.service.GetVapidPublicKeyRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.GetVapidPublicKeyRequest.
+ * Use `create(GetVapidPublicKeyRequestSchema)` to create a new message.
+ */
+export const GetVapidPublicKeyRequestSchema: GenMessage<GetVapidPublicKeyRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_push_subscription_service, 0);
+
+/**
+ * @generated from message api.v1.service.GetVapidPublicKeyResponse
+ */
+export type GetVapidPublicKeyResponse = Message<"api.v1.service.GetVapidPublicKeyResponse"> & {
+  /**
+   * base64url, the applicationServerKey for PushManager.subscribe
+   *
+   * @generated from field: string public_key = 1;
+   */
+  publicKey: string;
+};
+
+/**
+ * Describes the message api.v1.service.GetVapidPublicKeyResponse.
+ * Use `create(GetVapidPublicKeyResponseSchema)` to create a new message.
+ */
+export const GetVapidPublicKeyResponseSchema: GenMessage<GetVapidPublicKeyResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_push_subscription_service, 1);
+
+/**
+ * SubscribeRequest carries the fields of the browser's PushSubscription
+ *
+ * @generated from message api.v1.service.SubscribeRequest
+ */
+export type SubscribeRequest = Message<"api.v1.service.SubscribeRequest"> & {
+  /**
+   * @generated from field: string endpoint = 1;
+   */
+  endpoint: string;
+
+  /**
+   * base64url, from PushSubscription.getKey("p256dh")
+   *
+   * @generated from field: string p256dh = 2;
+   */
+  p256dh: string;
+
+  /**
+   * base64url, from PushSubscription.getKey("auth")
+   *
+   * @generated from field: string auth = 3;
+   */
+  auth: string;
+};
+
+/**
+ * Describes the message api.v1.service.SubscribeRequest.
+ * Use `create(SubscribeRequestSchema)` to create a new message.
+ */
+export const SubscribeRequestSchema: GenMessage<SubscribeRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_push_subscription_service, 2);
+
+/**
+ * @generated from message api.v1.service.SubscribeResponse
+ */
+export type SubscribeResponse = Message<"api.v1.service.SubscribeResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.SubscribeResponse.
+ * Use `create(SubscribeResponseSchema)` to create a new message.
+ */
+export const SubscribeResponseSchema: GenMessage<SubscribeResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_push_subscription_service, 3);
+
+/**
+ * @generated from message api.v1.service.UnsubscribeRequest
+ */
+export type UnsubscribeRequest = Message<"api.v1.service.UnsubscribeRequest"> & {
+  /**
+   * Unset removes every subscription of the caller's session
+   *
+   * @generated from field: optional string endpoint = 1;
+   */
+  endpoint?: string;
+};
+
+/**
+ * Describes the message api.v1.service.UnsubscribeRequest.
+ * Use `create(UnsubscribeRequestSchema)` to create a new message.
+ */
+export const UnsubscribeRequestSchema: GenMessage<UnsubscribeRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_push_subscription_service, 4);
+
+/**
+ * @generated from message api.v1.service.UnsubscribeResponse
+ */
+export type UnsubscribeResponse = Message<"api.v1.service.UnsubscribeResponse"> & {
+  /**
+   * Also true if there was no subscription
+   *
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.UnsubscribeResponse.
+ * Use `create(UnsubscribeResponseSchema)` to create a new message.
+ */
+export const UnsubscribeResponseSchema: GenMessage<UnsubscribeResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_push_subscription_service, 5);
+
+/**
+ * PushSubscriptionService registers browsers for Web Push notifications
+ *
+ * @generated from service api.v1.service.PushSubscriptionService
+ */
+export const PushSubscriptionService: GenService<{
+  /**
+   * Get the key browsers subscribe with (public)
+   *
+   * @generated from rpc api.v1.service.PushSubscriptionService.GetVapidPublicKey
+   */
+  getVapidPublicKey: {
+    methodKind: "unary";
+    input: typeof GetVapidPublicKeyRequestSchema;
+    output: typeof GetVapidPublicKeyResponseSchema;
+  },
+  /**
+   * Register the browser's push subscription for the caller's session
+   *
+   * @generated from rpc api.v1.service.PushSubscriptionService.Subscribe
+   */
+  subscribe: {
+    methodKind: "unary";
+    input: typeof SubscribeRequestSchema;
+    output: typeof SubscribeResponseSchema;
+  },
+  /**
+   * Remove a push subscription, e.g. before signing out
+   *
+   * @generated from rpc api.v1.service.PushSubscriptionService.Unsubscribe
+   */
+  unsubscribe: {
+    methodKind: "unary";
+    input: typeof UnsubscribeRequestSchema;
+    output: typeof UnsubscribeResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_v1_service_push_subscription_service, 0);
+
@@ -24,3 +24,102 @@ export const registerUser = UserService.method.registerUser;
  * @generated from rpc api.v1.service.UserService.GetUser
  */
 export const getUser = UserService.method.getUser;
+
+/**
+ * Update the caller's profile; only fields named in update_mask change
+ *
+ * @generated from rpc api.v1.service.UserService.UpdateProfile
+ */
+export const updateProfile = UserService.method.updateProfile;
+
+/**
+ * Replace the caller's avatar; the image is cropped and resized server-side
+ *
+ * @generated from rpc api.v1.service.UserService.UploadAvatar
+ */
+export const uploadAvatar = UserService.method.uploadAvatar;
+
+/**
+ * Follow a user to see their pins in the feed
+ *
+ * @generated from rpc api.v1.service.UserService.Follow
+ */
+export const follow = UserService.method.follow;
+
+/**
+ * Stop following a user
+ *
+ * @generated from rpc api.v1.service.UserService.Unfollow
+ */
+export const unfollow = UserService.method.unfollow;
+
+/**
+ * List the users following a user, most recent first
+ *
+ * @generated from rpc api.v1.service.UserService.ListFollowers
+ */
+export const listFollowers = UserService.method.listFollowers;
+
+/**
+ * List the users a user follows, most recent first
+ *
+ * @generated from rpc api.v1.service.UserService.ListFollowing
+ */
+export const listFollowing = UserService.method.listFollowing;
+
+/**
+ * List a user's pins, newest first, for their profile page
+ *
+ * @generated from rpc api.v1.service.UserService.ListUserPins
+ */
+export const listUserPins = UserService.method.listUserPins;
+
+/**
+ * Get the counts shown on a user's profile page
+ *
+ * @generated from rpc api.v1.service.UserService.GetUserStats
+ */
+export const getUserStats = UserService.method.getUserStats;
+
+/**
+ * Block a user: hide their pins and comments, end follows both ways and
+ * stop them following or commenting on the caller's pins
+ *
+ * @generated from rpc api.v1.service.UserService.BlockUser
+ */
+export const blockUser = UserService.method.blockUser;
+
+/**
+ * Remove a block or mute
+ *
+ * @generated from rpc api.v1.service.UserService.UnblockUser
+ */
+export const unblockUser = UserService.method.unblockUser;
+
+/**
+ * Mute a user: hide their pins and comments from the caller only
+ *
+ * @generated from rpc api.v1.service.UserService.MuteUser
+ */
+export const muteUser = UserService.method.muteUser;
+
+/**
+ * Schedule the caller's account for deletion; signing in again before then cancels it
+ *
+ * @generated from rpc api.v1.service.UserService.DeleteAccount
+ */
+export const deleteAccount = UserService.method.deleteAccount;
+
+/**
+ * Start building an archive of the caller's data; poll GetExportStatus for the download link
+ *
+ * @generated from rpc api.v1.service.UserService.ExportMyData
+ */
+export const exportMyData = UserService.method.exportMyData;
+
+/**
+ * Get the progress of a data export, with a short-lived download link once it is ready
+ *
+ * @generated from rpc api.v1.service.UserService.GetExportStatus
+ */
+export const getExportStatus = UserService.method.getExportStatus;
@@ -2,8 +2,12 @@
 // @generated from file v1/service/user_service.proto (package api.v1.service, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { FieldMask } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_field_mask } from "@bufbuild/protobuf/wkt";
+import type { Pin } from "../entities/pin_pb";
+import { file_v1_entities_pin } from "../entities/pin_pb";
 import type { User } from "../entities/user_pb";
 import { file_v1_entities_user } from "../entities/user_pb";
 import type { Message } from "@bufbuild/protobuf";
@@ -12,7 +16,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file v1/service/user_service.proto.
  */
 export const file_v1_service_user_service: GenFile = /*@__PURE__*/
-  fileDesc("Ch12MS9zZXJ2aWNlL3VzZXJfc2VydmljZS5wcm90bxIOYXBpLnYxLnNlcnZpY2UiFwoVR2V0Q3VycmVudFVzZXJSZXF1ZXN0IlMKFkdldEN1cnJlbnRVc2VyUmVzcG9uc2USIwoEdXNlchgBIAEoCzIVLmFwaS52MS5lbnRpdGllcy5Vc2VyEhQKDGFjY2Vzc190b2tlbhgCIAEoCSJIChNSZWdpc3RlclVzZXJSZXF1ZXN0Eg0KBWVtYWlsGAEgASgJEhAKCHVzZXJuYW1lGAIgASgJEhAKCHBhc3N3b3JkGAMgASgJIlEKFFJlZ2lzdGVyVXNlclJlc3BvbnNlEiMKBHVzZXIYASABKAsyFS5hcGkudjEuZW50aXRpZXMuVXNlchIUCgxhY2Nlc3NfdG9rZW4YAiABKAkiIQoOR2V0VXNlclJlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCSI2Cg9HZXRVc2VyUmVzcG9uc2USIwoEdXNlchgBIAEoCzIVLmFwaS52MS5lbnRpdGllcy5Vc2VyMpUCCgtVc2VyU2VydmljZRJfCg5HZXRDdXJyZW50VXNlchIlLmFwaS52MS5zZXJ2aWNlLkdldEN1cnJlbnRVc2VyUmVxdWVzdBomLmFwaS52MS5zZXJ2aWNlLkdldEN1cnJlbnRVc2VyUmVzcG9uc2USWQoMUmVnaXN0ZXJVc2VyEiMuYXBpLnYxLnNlcnZpY2UuUmVnaXN0ZXJVc2VyUmVxdWVzdBokLmFwaS52MS5zZXJ2aWNlLlJlZ2lzdGVyVXNlclJlc3BvbnNlEkoKB0dldFVzZXISHi5hcGkudjEuc2VydmljZS5HZXRVc2VyUmVxdWVzdBofLmFwaS52MS5zZXJ2aWNlLkdldFVzZXJSZXNwb25zZUJNWktnaXRodWIuY29tL3JhZGphdGhhaGVyL2FsdW5hbHVuL2FwaS9pbnRlcm5hbC9wcm90b2NnZW4vdjEvc2VydmljZTtzZXJ2aWNldjFiBnByb3RvMw", [file_v1_entities_user]);
+  fileDesc("Ch12MS9zZXJ2aWNlL3VzZXJfc2VydmljZS5wcm90bxIOYXBpLnYxLnNlcnZpY2UiFwoVR2V0Q3VycmVudFVzZXJSZXF1ZXN0IlMKFkdldEN1cnJlbnRVc2VyUmVzcG9uc2USIwoEdXNlchgBIAEoCzIVLmFwaS52MS5lbnRpdGllcy5Vc2VyEhQKDGFjY2Vzc190b2tlbhgCIAEoCSJIChNSZWdpc3RlclVzZXJSZXF1ZXN0Eg0KBWVtYWlsGAEgASgJEhAKCHVzZXJuYW1lGAIgASgJEhAKCHBhc3N3b3JkGAMgASgJIlEKFFJlZ2lzdGVyVXNlclJlc3BvbnNlEiMKBHVzZXIYASABKAsyFS5hcGkudjEuZW50aXRpZXMuVXNlchIUCgxhY2Nlc3NfdG9rZW4YAiABKAkiIQoOR2V0VXNlclJlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCSI2Cg9HZXRVc2VyUmVzcG9uc2USIwoEdXNlchgBIAEoCzIVLmFwaS52MS5lbnRpdGllcy5Vc2VyIoMBChRVcGRhdGVQcm9maWxlUmVxdWVzdBIUCgxkaXNwbGF5X25hbWUYASABKAkSEAoIdXNlcm5hbWUYAiABKAkSEgoKYXZhdGFyX3VybBgDIAEoCRIvCgt1cGRhdGVfbWFzaxgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5GaWVsZE1hc2siUgoVVXBkYXRlUHJvZmlsZVJlc3BvbnNlEiMKBHVzZXIYASABKAsyFS5hcGkudjEuZW50aXRpZXMuVXNlchIUCgxhY2Nlc3NfdG9rZW4YAiABKAkiJAoTVXBsb2FkQXZhdGFyUmVxdWVzdBINCgVpbWFnZRgBIAEoDCI7ChRVcGxvYWRBdmF0YXJSZXNwb25zZRIjCgR1c2VyGAEgASgLMhUuYXBpLnYxLmVudGl0aWVzLlVzZXIiIAoNRm9sbG93UmVxdWVzdBIPCgd1c2VyX2lkGAEgASgJIiEKDkZvbGxvd1Jlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiIgoPVW5mb2xsb3dSZXF1ZXN0Eg8KB3VzZXJfaWQYASABKAkiIwoQVW5mb2xsb3dSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIImUKFExpc3RGb2xsb3dlcnNSZXF1ZXN0Eg8KB3VzZXJfaWQYASABKAkSEgoFbGltaXQYAiABKAVIAIgBARITCgZjdXJzb3IYAyABKAlIAYgBAUIICgZfbGltaXRCCQoHX2N1cnNvciJnChVMaXN0Rm9sbG93ZXJzUmVzcG9uc2USJAoFdXNlcnMYASADKAsyFS5hcGkudjEuZW50aXRpZXMuVXNlchIYCgtuZXh0X2N1cnNvchgCIAEoCUgAiAEBQg4KDF9uZXh0X2N1cnNvciJlChRMaXN0Rm9sbG93aW5nUmVxdWVzdBIPCgd1c2VyX2lkGAEgASgJEhIKBWxpbWl0GAIgASgFSACIAQESEwoGY3Vyc29yGAMgASgJSAGIAQFCCAoGX2xpbWl0QgkKB19jdXJzb3IiZwoVTGlzdEZvbGxvd2luZ1Jlc3BvbnNlEiQKBXVzZXJzGAEgAygLMhUuYXBpLnYxLmVudGl0aWVzLlVzZXISGAoLbmV4dF9jdXJzb3IYAiABKAlIAIgBAUIOCgxfbmV4dF9jdXJzb3IiZAoTTGlzdFVzZXJQaW5zUmVxdWVzdBIPCgd1c2VyX2lkGAEgASgJEhIKBWxpbWl0GAIgASgFSACIAQESEwoGY3Vyc29yGAMgASgJSAGIAQFCCAoGX2xpbWl0QgkKB19jdXJzb3IiZAoUTGlzdFVzZXJQaW5zUmVzcG9uc2USIgoEcGlucxgBIAMoCzIULmFwaS52MS5lbnRpdGllcy5QaW4SGAoLbmV4dF9jdXJzb3IYAiABKAlIAIgBAUIOCgxfbmV4dF9jdXJzb3IiJgoTR2V0VXNlclN0YXRzUmVxdWVzdBIPCgd1c2VyX2lkGAEgASgJInwKCVVzZXJTdGF0cxIRCglwaW5fY291bnQYASABKAMSFQoNY29tbWVudF9jb3VudBgCIAEoAxIWCg5mb2xsb3dlcl9jb3VudBgDIAEoAxIXCg9mb2xsb3dpbmdfY291bnQYBCABKAMSFAoMbWVtYmVyX3NpbmNlGAUgASgDIkAKFEdldFVzZXJTdGF0c1Jlc3BvbnNlEigKBXN0YXRzGAEgASgLMhkuYXBpLnYxLnNlcnZpY2UuVXNlclN0YXRzIiMKEEJsb2NrVXNlclJlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCSIkChFCbG9ja1VzZXJSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIIiUKElVuYmxvY2tVc2VyUmVxdWVzdBIPCgd1c2VyX2lkGAEgASgJIiYKE1VuYmxvY2tVc2VyUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCIiCg9NdXRlVXNlclJlcXVlc3QSDwoHdXNlcl9pZBgBIAEoCSIjChBNdXRlVXNlclJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiFgoURGVsZXRlQWNjb3VudFJlcXVlc3QiNgoVRGVsZXRlQWNjb3VudFJlc3BvbnNlEh0KFWRlbGV0aW9uX3NjaGVkdWxlZF9hdBgBIAEoAyKyAgoKRGF0YUV4cG9ydBIKCgJpZBgBIAEoCRIwCgZzdGF0dXMYAiABKA4yIC5hcGkudjEuc2VydmljZS5EYXRhRXhwb3J0U3RhdHVzEhAKCHByb2dyZXNzGAMgASgFEhIKCmNyZWF0ZWRfYXQYBCABKAMSGQoMY29tcGxldGVkX2F0GAUgASgDSACIAQESFwoKZXhwaXJlc19hdBgGIAEoA0gBiAEBEhkKDGRvd25sb2FkX3VybBgHIAEoCUgCiAEBEiQKF2Rvd25sb2FkX3VybF9leHBpcmVzX2F0GAggASgDSAOIAQFCDwoNX2NvbXBsZXRlZF9hdEINCgtfZXhwaXJlc19hdEIPCg1fZG93bmxvYWRfdXJsQhoKGF9kb3dubG9hZF91cmxfZXhwaXJlc19hdCIVChNFeHBvcnRNeURhdGFSZXF1ZXN0IkIKFEV4cG9ydE15RGF0YVJlc3BvbnNlEioKBmV4cG9ydBgBIAEoCzIaLmFwaS52MS5zZXJ2aWNlLkRhdGFFeHBvcnQiKwoWR2V0RXhwb3J0U3RhdHVzUmVxdWVzdBIRCglleHBvcnRfaWQYASABKAkiRQoXR2V0RXhwb3J0U3RhdHVzUmVzcG9uc2USKgoGZXhwb3J0GAEgASgLMhouYXBpLnYxLnNlcnZpY2UuRGF0YUV4cG9ydCrTAQoQRGF0YUV4cG9ydFN0YXR1cxIiCh5EQVRBX0VYUE9SVF9TVEFUVVNfVU5TUEVDSUZJRUQQABIeChpEQVRBX0VYUE9SVF9TVEFUVVNfUEVORElORxABEh4KGkRBVEFfRVhQT1JUX1NUQVRVU19SVU5OSU5HEAISHAoYREFUQV9FWFBPUlRfU1RBVFVTX1JFQURZEAMSHQoZREFUQV9FWFBPUlRfU1RBVFVTX0ZBSUxFRBAEEh4KGkRBVEFfRVhQT1JUX1NUQVRVU19FWFBJUkVEEAUy7gsKC1VzZXJTZXJ2aWNlEl8KDkdldEN1cnJlbnRVc2VyEiUuYXBpLnYxLnNlcnZpY2UuR2V0Q3VycmVudFVzZXJSZXF1ZXN0GiYuYXBpLnYxLnNlcnZpY2UuR2V0Q3VycmVudFVzZXJSZXNwb25zZRJZCgxSZWdpc3RlclVzZXISIy5hcGkudjEuc2VydmljZS5SZWdpc3RlclVzZXJSZXF1ZXN0GiQuYXBpLnYxLnNlcnZpY2UuUmVnaXN0ZXJVc2VyUmVzcG9uc2USSgoHR2V0VXNlchIeLmFwaS52MS5zZXJ2aWNlLkdldFVzZXJSZXF1ZXN0Gh8uYXBpLnYxLnNlcnZpY2UuR2V0VXNlclJlc3BvbnNlElwKDVVwZGF0ZVByb2ZpbGUSJC5hcGkudjEuc2VydmljZS5VcGRhdGVQcm9maWxlUmVxdWVzdBolLmFwaS52MS5zZXJ2aWNlLlVwZGF0ZVByb2ZpbGVSZXNwb25zZRJZCgxVcGxvYWRBdmF0YXISIy5hcGkudjEuc2VydmljZS5VcGxvYWRBdmF0YXJSZXF1ZXN0GiQuYXBpLnYxLnNlcnZpY2UuVXBsb2FkQXZhdGFyUmVzcG9uc2USRwoGRm9sbG93Eh0uYXBpLnYxLnNlcnZpY2UuRm9sbG93UmVxdWVzdBoeLmFwaS52MS5zZXJ2aWNlLkZvbGxvd1Jlc3BvbnNlEk0KCFVuZm9sbG93Eh8uYXBpLnYxLnNlcnZpY2UuVW5mb2xsb3dSZXF1ZXN0GiAuYXBpLnYxLnNlcnZpY2UuVW5mb2xsb3dSZXNwb25zZRJcCg1MaXN0Rm9sbG93ZXJzEiQuYXBpLnYxLnNlcnZpY2UuTGlzdEZvbGxvd2Vyc1JlcXVlc3QaJS5hcGkudjEuc2VydmljZS5MaXN0Rm9sbG93ZXJzUmVzcG9uc2USXAoNTGlzdEZvbGxvd2luZxIkLmFwaS52MS5zZXJ2aWNlLkxpc3RGb2xsb3dpbmdSZXF1ZXN0GiUuYXBpLnYxLnNlcnZpY2UuTGlzdEZvbGxvd2luZ1Jlc3BvbnNlElkKDExpc3RVc2VyUGlucxIjLmFwaS52MS5zZXJ2aWNlLkxpc3RVc2VyUGluc1JlcXVlc3QaJC5hcGkudjEuc2VydmljZS5MaXN0VXNlclBpbnNSZXNwb25zZRJZCgxHZXRVc2VyU3RhdHMSIy5hcGkudjEuc2VydmljZS5HZXRVc2VyU3RhdHNSZXF1ZXN0GiQuYXBpLnYxLnNlcnZpY2UuR2V0VXNlclN0YXRzUmVzcG9uc2USUAoJQmxvY2tVc2VyEiAuYXBpLnYxLnNlcnZpY2UuQmxvY2tVc2VyUmVxdWVzdBohLmFwaS52MS5zZXJ2aWNlLkJsb2NrVXNlclJlc3BvbnNlElYKC1VuYmxvY2tVc2VyEiIuYXBpLnYxLnNlcnZpY2UuVW5ibG9ja1VzZXJSZXF1ZXN0GiMuYXBpLnYxLnNlcnZpY2UuVW5ibG9ja1VzZXJSZXNwb25zZRJNCghNdXRlVXNlchIfLmFwaS52MS5zZXJ2aWNlLk11dGVVc2VyUmVxdWVzdBogLmFwaS52MS5zZXJ2aWNlLk11dGVVc2VyUmVzcG9uc2USXAoNRGVsZXRlQWNjb3VudBIkLmFwaS52MS5zZXJ2aWNlLkRlbGV0ZUFjY291bnRSZXF1ZXN0GiUuYXBpLnYxLnNlcnZpY2UuRGVsZXRlQWNjb3VudFJlc3BvbnNlElkKDEV4cG9ydE15RGF0YRIjLmFwaS52MS5zZXJ2aWNlLkV4cG9ydE15RGF0YVJlcXVlc3QaJC5hcGkudjEuc2VydmljZS5FeHBvcnRNeURhdGFSZXNwb25zZRJiCg9HZXRFeHBvcnRTdGF0dXMSJi5hcGkudjEuc2VydmljZS5HZXRFeHBvcnRTdGF0dXNSZXF1ZXN0GicuYXBpLnYxLnNlcnZpY2UuR2V0RXhwb3J0U3RhdHVzUmVzcG9uc2VCTVpLZ2l0aHViLmNvbS9yYWRqYXRoYWhlci9hbHVuYWx1bi9hcGkvaW50ZXJuYWwvcHJvdG9jZ2VuL3YxL3NlcnZpY2U7c2VydmljZXYxYgZwcm90bzM", [file_google_protobuf_field_mask, file_v1_entities_pin, file_v1_entities_user]);
 
 /**
  * GetCurrentUserRequest - empty, uses session/JWT context
@@ -140,6 +144,743 @@ export type GetUserResponse = Message<"api.v1.service.GetUserResponse"> & {
 export const GetUserResponseSchema: GenMessage<GetUserResponse> = /*@__PURE__*/
   messageDesc(file_v1_service_user_service, 5);
 
+/**
+ * @generated from message api.v1.service.UpdateProfileRequest
+ */
+export type UpdateProfileRequest = Message<"api.v1.service.UpdateProfileRequest"> & {
+  /**
+   * Empty clears it
+   *
+   * @generated from field: string display_name = 1;
+   */
+  displayName: string;
+
+  /**
+   * Can only change once per cooldown period
+   *
+   * @generated from field: string username = 2;
+   */
+  username: string;
+
+  /**
+   * Only "" is accepted, to remove the avatar; use UploadAvatar to set one
+   *
+   * @generated from field: string avatar_url = 3;
+   */
+  avatarUrl: string;
+
+  /**
+   * "display_name", "username", "avatar_url"
+   *
+   * @generated from field: google.protobuf.FieldMask update_mask = 4;
+   */
+  updateMask?: FieldMask;
+};
+
+/**
+ * Describes the message api.v1.service.UpdateProfileRequest.
+ * Use `create(UpdateProfileRequestSchema)` to create a new message.
+ */
+export const UpdateProfileRequestSchema: GenMessage<UpdateProfileRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 6);
+
+/**
+ * @generated from message api.v1.service.UpdateProfileResponse
+ */
+export type UpdateProfileResponse = Message<"api.v1.service.UpdateProfileResponse"> & {
+  /**
+   * @generated from field: api.v1.entities.User user = 1;
+   */
+  user?: User;
+
+  /**
+   * New JWT when the username changed
+   *
+   * @generated from field: string access_token = 2;
+   */
+  accessToken: string;
+};
+
+/**
+ * Describes the message api.v1.service.UpdateProfileResponse.
+ * Use `create(UpdateProfileResponseSchema)` to create a new message.
+ */
+export const UpdateProfileResponseSchema: GenMessage<UpdateProfileResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 7);
+
+/**
+ * @generated from message api.v1.service.UploadAvatarRequest
+ */
+export type UploadAvatarRequest = Message<"api.v1.service.UploadAvatarRequest"> & {
+  /**
+   * JPEG, PNG or GIF, up to 5 MB
+   *
+   * @generated from field: bytes image = 1;
+   */
+  image: Uint8Array;
+};
+
+/**
+ * Describes the message api.v1.service.UploadAvatarRequest.
+ * Use `create(UploadAvatarRequestSchema)` to create a new message.
+ */
+export const UploadAvatarRequestSchema: GenMessage<UploadAvatarRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 8);
+
+/**
+ * @generated from message api.v1.service.UploadAvatarResponse
+ */
+export type UploadAvatarResponse = Message<"api.v1.service.UploadAvatarResponse"> & {
+  /**
+   * @generated from field: api.v1.entities.User user = 1;
+   */
+  user?: User;
+};
+
+/**
+ * Describes the message api.v1.service.UploadAvatarResponse.
+ * Use `create(UploadAvatarResponseSchema)` to create a new message.
+ */
+export const UploadAvatarResponseSchema: GenMessage<UploadAvatarResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 9);
+
+/**
+ * @generated from message api.v1.service.FollowRequest
+ */
+export type FollowRequest = Message<"api.v1.service.FollowRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message api.v1.service.FollowRequest.
+ * Use `create(FollowRequestSchema)` to create a new message.
+ */
+export const FollowRequestSchema: GenMessage<FollowRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 10);
+
+/**
+ * @generated from message api.v1.service.FollowResponse
+ */
+export type FollowResponse = Message<"api.v1.service.FollowResponse"> & {
+  /**
+   * Also true if already following
+   *
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.FollowResponse.
+ * Use `create(FollowResponseSchema)` to create a new message.
+ */
+export const FollowResponseSchema: GenMessage<FollowResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 11);
+
+/**
+ * @generated from message api.v1.service.UnfollowRequest
+ */
+export type UnfollowRequest = Message<"api.v1.service.UnfollowRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message api.v1.service.UnfollowRequest.
+ * Use `create(UnfollowRequestSchema)` to create a new message.
+ */
+export const UnfollowRequestSchema: GenMessage<UnfollowRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 12);
+
+/**
+ * @generated from message api.v1.service.UnfollowResponse
+ */
+export type UnfollowResponse = Message<"api.v1.service.UnfollowResponse"> & {
+  /**
+   * Also true if not following
+   *
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.UnfollowResponse.
+ * Use `create(UnfollowResponseSchema)` to create a new message.
+ */
+export const UnfollowResponseSchema: GenMessage<UnfollowResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 13);
+
+/**
+ * @generated from message api.v1.service.ListFollowersRequest
+ */
+export type ListFollowersRequest = Message<"api.v1.service.ListFollowersRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * Defaults to 20, at most 100
+   *
+   * @generated from field: optional int32 limit = 2;
+   */
+  limit?: number;
+
+  /**
+   * next_cursor from the previous page
+   *
+   * @generated from field: optional string cursor = 3;
+   */
+  cursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListFollowersRequest.
+ * Use `create(ListFollowersRequestSchema)` to create a new message.
+ */
+export const ListFollowersRequestSchema: GenMessage<ListFollowersRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 14);
+
+/**
+ * @generated from message api.v1.service.ListFollowersResponse
+ */
+export type ListFollowersResponse = Message<"api.v1.service.ListFollowersResponse"> & {
+  /**
+   * Public profiles
+   *
+   * @generated from field: repeated api.v1.entities.User users = 1;
+   */
+  users: User[];
+
+  /**
+   * Unset on the last page
+   *
+   * @generated from field: optional string next_cursor = 2;
+   */
+  nextCursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListFollowersResponse.
+ * Use `create(ListFollowersResponseSchema)` to create a new message.
+ */
+export const ListFollowersResponseSchema: GenMessage<ListFollowersResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 15);
+
+/**
+ * @generated from message api.v1.service.ListFollowingRequest
+ */
+export type ListFollowingRequest = Message<"api.v1.service.ListFollowingRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * Defaults to 20, at most 100
+   *
+   * @generated from field: optional int32 limit = 2;
+   */
+  limit?: number;
+
+  /**
+   * next_cursor from the previous page
+   *
+   * @generated from field: optional string cursor = 3;
+   */
+  cursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListFollowingRequest.
+ * Use `create(ListFollowingRequestSchema)` to create a new message.
+ */
+export const ListFollowingRequestSchema: GenMessage<ListFollowingRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 16);
+
+/**
+ * @generated from message api.v1.service.ListFollowingResponse
+ */
+export type ListFollowingResponse = Message<"api.v1.service.ListFollowingResponse"> & {
+  /**
+   * Public profiles
+   *
+   * @generated from field: repeated api.v1.entities.User users = 1;
+   */
+  users: User[];
+
+  /**
+   * Unset on the last page
+   *
+   * @generated from field: optional string next_cursor = 2;
+   */
+  nextCursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListFollowingResponse.
+ * Use `create(ListFollowingResponseSchema)` to create a new message.
+ */
+export const ListFollowingResponseSchema: GenMessage<ListFollowingResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 17);
+
+/**
+ * @generated from message api.v1.service.ListUserPinsRequest
+ */
+export type ListUserPinsRequest = Message<"api.v1.service.ListUserPinsRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * Defaults to 20, at most 100
+   *
+   * @generated from field: optional int32 limit = 2;
+   */
+  limit?: number;
+
+  /**
+   * next_cursor from the previous page
+   *
+   * @generated from field: optional string cursor = 3;
+   */
+  cursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListUserPinsRequest.
+ * Use `create(ListUserPinsRequestSchema)` to create a new message.
+ */
+export const ListUserPinsRequestSchema: GenMessage<ListUserPinsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 18);
+
+/**
+ * @generated from message api.v1.service.ListUserPinsResponse
+ */
+export type ListUserPinsResponse = Message<"api.v1.service.ListUserPinsResponse"> & {
+  /**
+   * Only pins the caller may see
+   *
+   * @generated from field: repeated api.v1.entities.Pin pins = 1;
+   */
+  pins: Pin[];
+
+  /**
+   * Unset on the last page
+   *
+   * @generated from field: optional string next_cursor = 2;
+   */
+  nextCursor?: string;
+};
+
+/**
+ * Describes the message api.v1.service.ListUserPinsResponse.
+ * Use `create(ListUserPinsResponseSchema)` to create a new message.
+ */
+export const ListUserPinsResponseSchema: GenMessage<ListUserPinsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 19);
+
+/**
+ * @generated from message api.v1.service.GetUserStatsRequest
+ */
+export type GetUserStatsRequest = Message<"api.v1.service.GetUserStatsRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message api.v1.service.GetUserStatsRequest.
+ * Use `create(GetUserStatsRequestSchema)` to create a new message.
+ */
+export const GetUserStatsRequestSchema: GenMessage<GetUserStatsRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 20);
+
+/**
+ * UserStats holds the counts shown on a profile page
+ *
+ * @generated from message api.v1.service.UserStats
+ */
+export type UserStats = Message<"api.v1.service.UserStats"> & {
+  /**
+   * Pins the caller may see
+   *
+   * @generated from field: int64 pin_count = 1;
+   */
+  pinCount: bigint;
+
+  /**
+   * Comments the user has written
+   *
+   * @generated from field: int64 comment_count = 2;
+   */
+  commentCount: bigint;
+
+  /**
+   * @generated from field: int64 follower_count = 3;
+   */
+  followerCount: bigint;
+
+  /**
+   * @generated from field: int64 following_count = 4;
+   */
+  followingCount: bigint;
+
+  /**
+   * Unix timestamp of sign-up
+   *
+   * @generated from field: int64 member_since = 5;
+   */
+  memberSince: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.UserStats.
+ * Use `create(UserStatsSchema)` to create a new message.
+ */
+export const UserStatsSchema: GenMessage<UserStats> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 21);
+
+/**
+ * @generated from message api.v1.service.GetUserStatsResponse
+ */
+export type GetUserStatsResponse = Message<"api.v1.service.GetUserStatsResponse"> & {
+  /**
+   * @generated from field: api.v1.service.UserStats stats = 1;
+   */
+  stats?: UserStats;
+};
+
+/**
+ * Describes the message api.v1.service.GetUserStatsResponse.
+ * Use `create(GetUserStatsResponseSchema)` to create a new message.
+ */
+export const GetUserStatsResponseSchema: GenMessage<GetUserStatsResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 22);
+
+/**
+ * @generated from message api.v1.service.BlockUserRequest
+ */
+export type BlockUserRequest = Message<"api.v1.service.BlockUserRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message api.v1.service.BlockUserRequest.
+ * Use `create(BlockUserRequestSchema)` to create a new message.
+ */
+export const BlockUserRequestSchema: GenMessage<BlockUserRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 23);
+
+/**
+ * @generated from message api.v1.service.BlockUserResponse
+ */
+export type BlockUserResponse = Message<"api.v1.service.BlockUserResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.BlockUserResponse.
+ * Use `create(BlockUserResponseSchema)` to create a new message.
+ */
+export const BlockUserResponseSchema: GenMessage<BlockUserResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 24);
+
+/**
+ * @generated from message api.v1.service.UnblockUserRequest
+ */
+export type UnblockUserRequest = Message<"api.v1.service.UnblockUserRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message api.v1.service.UnblockUserRequest.
+ * Use `create(UnblockUserRequestSchema)` to create a new message.
+ */
+export const UnblockUserRequestSchema: GenMessage<UnblockUserRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 25);
+
+/**
+ * @generated from message api.v1.service.UnblockUserResponse
+ */
+export type UnblockUserResponse = Message<"api.v1.service.UnblockUserResponse"> & {
+  /**
+   * Also true if the user was not blocked
+   *
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.UnblockUserResponse.
+ * Use `create(UnblockUserResponseSchema)` to create a new message.
+ */
+export const UnblockUserResponseSchema: GenMessage<UnblockUserResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 26);
+
+/**
+ * @generated from message api.v1.service.MuteUserRequest
+ */
+export type MuteUserRequest = Message<"api.v1.service.MuteUserRequest"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+};
+
+/**
+ * Describes the message api.v1.service.MuteUserRequest.
+ * Use `create(MuteUserRequestSchema)` to create a new message.
+ */
+export const MuteUserRequestSchema: GenMessage<MuteUserRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 27);
+
+/**
+ * @generated from message api.v1.service.MuteUserResponse
+ */
+export type MuteUserResponse = Message<"api.v1.service.MuteUserResponse"> & {
+  /**
+   * Also true if the user is already muted or blocked
+   *
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message api.v1.service.MuteUserResponse.
+ * Use `create(MuteUserResponseSchema)` to create a new message.
+ */
+export const MuteUserResponseSchema: GenMessage<MuteUserResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 28);
+
+/**
+ * @generated from message api.v1.service.DeleteAccountRequest
+ */
+export type DeleteAccountRequest = Message<"api.v1.service.DeleteAccountRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.DeleteAccountRequest.
+ * Use `create(DeleteAccountRequestSchema)` to create a new message.
+ */
+export const DeleteAccountRequestSchema: GenMessage<DeleteAccountRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 29);
+
+/**
+ * @generated from message api.v1.service.DeleteAccountResponse
+ */
+export type DeleteAccountResponse = Message<"api.v1.service.DeleteAccountResponse"> & {
+  /**
+   * Unix timestamp the account will be erased at
+   *
+   * @generated from field: int64 deletion_scheduled_at = 1;
+   */
+  deletionScheduledAt: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.DeleteAccountResponse.
+ * Use `create(DeleteAccountResponseSchema)` to create a new message.
+ */
+export const DeleteAccountResponseSchema: GenMessage<DeleteAccountResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 30);
+
+/**
+ * DataExport is a zip archive of a user's data as JSON, with pins as GeoJSON
+ *
+ * @generated from message api.v1.service.DataExport
+ */
+export type DataExport = Message<"api.v1.service.DataExport"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: api.v1.service.DataExportStatus status = 2;
+   */
+  status: DataExportStatus;
+
+  /**
+   * Percent complete
+   *
+   * @generated from field: int32 progress = 3;
+   */
+  progress: number;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: int64 created_at = 4;
+   */
+  createdAt: bigint;
+
+  /**
+   * Unix timestamp
+   *
+   * @generated from field: optional int64 completed_at = 5;
+   */
+  completedAt?: bigint;
+
+  /**
+   * Unix timestamp the archive is deleted at
+   *
+   * @generated from field: optional int64 expires_at = 6;
+   */
+  expiresAt?: bigint;
+
+  /**
+   * Signed link, only while ready
+   *
+   * @generated from field: optional string download_url = 7;
+   */
+  downloadUrl?: string;
+
+  /**
+   * Unix timestamp; poll again for a fresh link
+   *
+   * @generated from field: optional int64 download_url_expires_at = 8;
+   */
+  downloadUrlExpiresAt?: bigint;
+};
+
+/**
+ * Describes the message api.v1.service.DataExport.
+ * Use `create(DataExportSchema)` to create a new message.
+ */
+export const DataExportSchema: GenMessage<DataExport> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 31);
+
+/**
+ * @generated from message api.v1.service.ExportMyDataRequest
+ */
+export type ExportMyDataRequest = Message<"api.v1.service.ExportMyDataRequest"> & {
+};
+
+/**
+ * Describes the message api.v1.service.ExportMyDataRequest.
+ * Use `create(ExportMyDataRequestSchema)` to create a new message.
+ */
+export const ExportMyDataRequestSchema: GenMessage<ExportMyDataRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 32);
+
+/**
+ * @generated from message api.v1.service.ExportMyDataResponse
+ */
+export type ExportMyDataResponse = Message<"api.v1.service.ExportMyDataResponse"> & {
+  /**
+   * An export already in progress is returned instead of starting another
+   *
+   * @generated from field: api.v1.service.DataExport export = 1;
+   */
+  export?: DataExport;
+};
+
+/**
+ * Describes the message api.v1.service.ExportMyDataResponse.
+ * Use `create(ExportMyDataResponseSchema)` to create a new message.
+ */
+export const ExportMyDataResponseSchema: GenMessage<ExportMyDataResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 33);
+
+/**
+ * @generated from message api.v1.service.GetExportStatusRequest
+ */
+export type GetExportStatusRequest = Message<"api.v1.service.GetExportStatusRequest"> & {
+  /**
+   * @generated from field: string export_id = 1;
+   */
+  exportId: string;
+};
+
+/**
+ * Describes the message api.v1.service.GetExportStatusRequest.
+ * Use `create(GetExportStatusRequestSchema)` to create a new message.
+ */
+export const GetExportStatusRequestSchema: GenMessage<GetExportStatusRequest> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 34);
+
+/**
+ * @generated from message api.v1.service.GetExportStatusResponse
+ */
+export type GetExportStatusResponse = Message<"api.v1.service.GetExportStatusResponse"> & {
+  /**
+   * @generated from field: api.v1.service.DataExport export = 1;
+   */
+  export?: DataExport;
+};
+
+/**
+ * Describes the message api.v1.service.GetExportStatusResponse.
+ * Use `create(GetExportStatusResponseSchema)` to create a new message.
+ */
+export const GetExportStatusResponseSchema: GenMessage<GetExportStatusResponse> = /*@__PURE__*/
+  messageDesc(file_v1_service_user_service, 35);
+
+/**
+ * DataExportStatus is the state of a data export job
+ *
+ * @generated from enum api.v1.service.DataExportStatus
+ */
+export enum DataExportStatus {
+  /**
+   * @generated from enum value: DATA_EXPORT_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: DATA_EXPORT_STATUS_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: DATA_EXPORT_STATUS_RUNNING = 2;
+   */
+  RUNNING = 2,
+
+  /**
+   * @generated from enum value: DATA_EXPORT_STATUS_READY = 3;
+   */
+  READY = 3,
+
+  /**
+   * @generated from enum value: DATA_EXPORT_STATUS_FAILED = 4;
+   */
+  FAILED = 4,
+
+  /**
+   * The archive was deleted; start a new export
+   *
+   * @generated from enum value: DATA_EXPORT_STATUS_EXPIRED = 5;
+   */
+  EXPIRED = 5,
+}
+
+/**
+ * Describes the enum api.v1.service.DataExportStatus.
+ */
+export const DataExportStatusSchema: GenEnum<DataExportStatus> = /*@__PURE__*/
+  enumDesc(file_v1_service_user_service, 0);
+
 /**
  * UserService handles user-related operations
  *
@@ -176,6 +917,147 @@ export const UserService: GenService<{
     input: typeof GetUserRequestSchema;
     output: typeof GetUserResponseSchema;
   },
+  /**
+   * Update the caller's profile; only fields named in update_mask change
+   *
+   * @generated from rpc api.v1.service.UserService.UpdateProfile
+   */
+  updateProfile: {
+    methodKind: "unary";
+    input: typeof UpdateProfileRequestSchema;
+    output: typeof UpdateProfileResponseSchema;
+  },
+  /**
+   * Replace the caller's avatar; the image is cropped and resized server-side
+   *
+   * @generated from rpc api.v1.service.UserService.UploadAvatar
+   */
+  uploadAvatar: {
+    methodKind: "unary";
+    input: typeof UploadAvatarRequestSchema;
+    output: typeof UploadAvatarResponseSchema;
+  },
+  /**
+   * Follow a user to see their pins in the feed
+   *
+   * @generated from rpc api.v1.service.UserService.Follow
+   */
+  follow: {
+    methodKind: "unary";
+    input: typeof FollowRequestSchema;
+    output: typeof FollowResponseSchema;
+  },
+  /**
+   * Stop following a user
+   *
+   * @generated from rpc api.v1.service.UserService.Unfollow
+   */
+  unfollow: {
+    methodKind: "unary";
+    input: typeof UnfollowRequestSchema;
+    output: typeof UnfollowResponseSchema;
+  },
+  /**
+   * List the users following a user, most recent first
+   *
+   * @generated from rpc api.v1.service.UserService.ListFollowers
+   */
+  listFollowers: {
+    methodKind: "unary";
+    input: typeof ListFollowersRequestSchema;
+    output: typeof ListFollowersResponseSchema;
+  },
+  /**
+   * List the users a user follows, most recent first
+   *
+   * @generated from rpc api.v1.service.UserService.ListFollowing
+   */
+  listFollowing: {
+    methodKind: "unary";
+    input: typeof ListFollowingRequestSchema;
+    output: typeof ListFollowingResponseSchema;
+  },
+  /**
+   * List a user's pins, newest first, for their profile page
+   *
+   * @generated from rpc api.v1.service.UserService.ListUserPins
+   */
+  listUserPins: {
+    methodKind: "unary";
+    input: typeof ListUserPinsRequestSchema;
+    output: typeof ListUserPinsResponseSchema;
+  },
+  /**
+   * Get the counts shown on a user's profile page
+   *
+   * @generated from rpc api.v1.service.UserService.GetUserStats
+   */
+  getUserStats: {
+    methodKind: "unary";
+    input: typeof GetUserStatsRequestSchema;
+    output: typeof GetUserStatsResponseSchema;
+  },
+  /**
+   * Block a user: hide their pins and comments, end follows both ways and
+   * stop them following or commenting on the caller's pins
+   *
+   * @generated from rpc api.v1.service.UserService.BlockUser
+   */
+  blockUser: {
+    methodKind: "unary";
+    input: typeof BlockUserRequestSchema;
+    output: typeof BlockUserResponseSchema;
+  },
+  /**
+   * Remove a block or mute
+   *
+   * @generated from rpc api.v1.service.UserService.UnblockUser
+   */
+  unblockUser: {
+    methodKind: "unary";
+    input: typeof UnblockUserRequestSchema;
+    output: typeof UnblockUserResponseSchema;
+  },
+  /**
+   * Mute a user: hide their pins and comments from the caller only
+   *
+   * @generated from rpc api.v1.service.UserService.MuteUser
+   */
+  muteUser: {
+    methodKind: "unary";
+    input: typeof MuteUserRequestSchema;
+    output: typeof MuteUserResponseSchema;
+  },
+  /**
+   * Schedule the caller's account for deletion; signing in again before then cancels it
+   *
+   * @generated from rpc api.v1.service.UserService.DeleteAccount
+   */
+  deleteAccount: {
+    methodKind: "unary";
+    input: typeof DeleteAccountRequestSchema;
+    output: typeof DeleteAccountResponseSchema;
+  },
+  /**
+   * Start building an archive of the caller's data; poll GetExportStatus for the download link
+   *
+   * @generated from rpc api.v1.service.UserService.ExportMyData
+   */
+  exportMyData: {
+    methodKind: "unary";
+    input: typeof ExportMyDataRequestSchema;
+    output: typeof ExportMyDataResponseSchema;
+  },
+  /**
+   * Get the progress of a data export, with a short-lived download link once it is ready
+   *
+   * @generated from rpc api.v1.service.UserService.GetExportStatus
+   */
+  getExportStatus: {
+    methodKind: "unary";
+    input: typeof GetExportStatusRequestSchema;
+    output: typeof GetExportStatusResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_v1_service_user_service, 0);
 
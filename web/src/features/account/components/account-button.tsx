@@ -1,4 +1,4 @@
-import { LogIn } from "lucide-react";
+import { Bell, BellOff, LogIn } from "lucide-react";
 import {
   Popover,
   PopoverContent,
@@ -7,11 +7,24 @@ import {
 import { cn } from "@/common/lib/utils";
 import { useAuthModal } from "@/features/auth/hooks/useAuthModal";
 import { useAuthStore } from "@/features/auth/store/authStore";
+import {
+  disablePushNotifications,
+  usePushNotifications,
+} from "@/features/notifications";
 
 export function AccountButton() {
   const { user, isAuthenticated } = useAuthStore();
   const { openAuthModal } = useAuthModal();
   const { logout } = useAuthStore();
+  const push = usePushNotifications();
+
+  // Stop pushes to this browser before the session ends
+  const handleLogout = async () => {
+    if (push.isSubscribed) {
+      await disablePushNotifications().catch(() => {});
+    }
+    logout();
+  };
 
   if (!isAuthenticated) {
     return (
@@ -59,9 +72,24 @@ export function AccountButton() {
             @{user?.username}
           </div>
           <div className="h-px bg-gray-200" />
+          {push.isSupported && push.permission !== "denied" && (
+            <button
+              type="button"
+              onClick={() => (push.isSubscribed ? push.disable() : push.enable())}
+              disabled={push.isLoading}
+              className="flex items-center gap-2 rounded-md px-2 py-1.5 text-left text-sm transition-colors hover:bg-gray-100 disabled:opacity-50"
+            >
+              {push.isSubscribed ? (
+                <BellOff className="h-4 w-4" />
+              ) : (
+                <Bell className="h-4 w-4" />
+              )}
+              {push.isSubscribed ? "Turn off notifications" : "Turn on notifications"}
+            </button>
+          )}
           <button
             type="button"
-            onClick={() => logout()}
+            onClick={() => handleLogout()}
             className="rounded-md px-2 py-1.5 text-left text-sm transition-colors hover:bg-gray-100"
           >
             Logout
@@ -0,0 +1,116 @@
+import { useCallback, useEffect, useState } from "react";
+import { createClient } from "@connectrpc/connect";
+import { transport } from "@/common/services/connectrpc/transport";
+import { PushSubscriptionService } from "@/common/services/connectrpc/v1/service/push_subscription_service_pb";
+
+const pushClient = createClient(PushSubscriptionService, transport);
+
+const SERVICE_WORKER_URL = "/sw.js";
+
+// Whether this browser can receive Web Push messages
+export const isPushSupported =
+  typeof window !== "undefined" &&
+  "serviceWorker" in navigator &&
+  "PushManager" in window &&
+  "Notification" in window;
+
+// Decodes the base64url VAPID key into the bytes PushManager.subscribe expects
+function decodeBase64Url(value: string): Uint8Array {
+  const base64 = value.replace(/-/g, "+").replace(/_/g, "/");
+  const padded = base64 + "=".repeat((4 - (base64.length % 4)) % 4);
+  const binary = atob(padded);
+  return Uint8Array.from(binary, (char) => char.charCodeAt(0));
+}
+
+// Encodes a subscription key as base64url
+function encodeBase64Url(buffer: ArrayBuffer | null): string {
+  if (!buffer) return "";
+  const binary = String.fromCharCode(...new Uint8Array(buffer));
+  return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+async function getRegistration() {
+  return navigator.serviceWorker.register(SERVICE_WORKER_URL);
+}
+
+// Removes this browser's subscription, on the server first so the session stops getting pushes
+export async function disablePushNotifications() {
+  if (!isPushSupported) return;
+
+  const registration = await navigator.serviceWorker.getRegistration(SERVICE_WORKER_URL);
+  const subscription = await registration?.pushManager.getSubscription();
+  try {
+    await pushClient.unsubscribe({ endpoint: subscription?.endpoint });
+  } finally {
+    await subscription?.unsubscribe();
+  }
+}
+
+export function usePushNotifications() {
+  const [isSubscribed, setIsSubscribed] = useState(false);
+  const [isLoading, setIsLoading] = useState(false);
+  const [permission, setPermission] = useState<NotificationPermission>(
+    isPushSupported ? Notification.permission : "denied",
+  );
+
+  // Pick up a subscription made in an earlier visit
+  useEffect(() => {
+    if (!isPushSupported) return;
+    navigator.serviceWorker
+      .getRegistration(SERVICE_WORKER_URL)
+      .then((registration) => registration?.pushManager.getSubscription())
+      .then((subscription) => setIsSubscribed(!!subscription))
+      .catch(() => setIsSubscribed(false));
+  }, []);
+
+  const enable = useCallback(async () => {
+    if (!isPushSupported) return;
+    setIsLoading(true);
+    try {
+      const result = await Notification.requestPermission();
+      setPermission(result);
+      if (result !== "granted") return;
+
+      const { publicKey } = await pushClient.getVapidPublicKey({});
+      const registration = await getRegistration();
+      await navigator.serviceWorker.ready;
+
+      const subscription = await registration.pushManager.subscribe({
+        userVisibleOnly: true,
+        applicationServerKey: decodeBase64Url(publicKey),
+      });
+
+      await pushClient.subscribe({
+        endpoint: subscription.endpoint,
+        p256dh: encodeBase64Url(subscription.getKey("p256dh")),
+        auth: encodeBase64Url(subscription.getKey("auth")),
+      });
+      setIsSubscribed(true);
+    } catch (error) {
+      console.error("Failed to enable push notifications:", error);
+    } finally {
+      setIsLoading(false);
+    }
+  }, []);
+
+  const disable = useCallback(async () => {
+    setIsLoading(true);
+    try {
+      await disablePushNotifications();
+      setIsSubscribed(false);
+    } catch (error) {
+      console.error("Failed to disable push notifications:", error);
+    } finally {
+      setIsLoading(false);
+    }
+  }, []);
+
+  return {
+    isSupported: isPushSupported,
+    isSubscribed,
+    isLoading,
+    permission,
+    enable,
+    disable,
+  };
+}
@@ -0,0 +1,6 @@
+// Public API for notifications feature
+export {
+  usePushNotifications,
+  disablePushNotifications,
+  isPushSupported,
+} from "./hooks/usePushNotifications";
\ No newline at end of file